@@ -2,17 +2,42 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"expvar"
 	"fmt"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
 
 	"voconsteroid/internal/config"
+	"voconsteroid/internal/domain/dailyword"
+	"voconsteroid/internal/domain/event"
+	"voconsteroid/internal/domain/gamification"
+	"voconsteroid/internal/domain/language"
+	"voconsteroid/internal/domain/quiz"
+	"voconsteroid/internal/domain/refresh"
+	"voconsteroid/internal/domain/review"
+	"voconsteroid/internal/domain/savedword"
+	"voconsteroid/internal/domain/snapshot"
+	"voconsteroid/internal/domain/stats"
+	"voconsteroid/internal/domain/tag"
 	"voconsteroid/internal/domain/word"
+	"voconsteroid/internal/domain/wordlist"
+	"voconsteroid/internal/infrastructure/blobstore"
+	"voconsteroid/internal/infrastructure/cache"
+	"voconsteroid/internal/infrastructure/coalesce"
 	"voconsteroid/internal/infrastructure/dictionary"
+	"voconsteroid/internal/infrastructure/frequency"
+	"voconsteroid/internal/infrastructure/httpclient"
+	"voconsteroid/internal/infrastructure/ratelimit"
 	"voconsteroid/internal/infrastructure/repository"
 	"voconsteroid/internal/server"
 	"voconsteroid/pkg/logger"
@@ -25,6 +50,14 @@ func main() {
 		log.Printf("No .env file found: %v", err)
 	}
 
+	// Subcommands run instead of the API
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:]); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		return
+	}
+
 	if err := run(); err != nil {
 		log.Fatalf("Application failed: %v", err)
 	}
@@ -37,6 +70,12 @@ func run() error {
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
+	if err := cfg.ValidateAuth(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	if err := cfg.ValidateScraper(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
 
 	// Initialize logger
 	logConfig := logger.DefaultConfig()
@@ -52,17 +91,173 @@ func run() error {
 	}
 	defer dbpool.Close()
 
+	// Bring the schema up to date, one replica at a time
+	if cfg.MigrateOnStart {
+		runner, err := newMigrationRunner(dbpool, log)
+		if err != nil {
+			return err
+		}
+		applied, err := runner.Up(context.Background(), 0)
+		if err != nil {
+			return fmt.Errorf("failed to migrate database: %w", err)
+		}
+		log.Info().Int("applied", applied).Msg("Database schema up to date")
+	}
+
 	// Initialize repositories
 	wordRepo := repository.NewWordRepository(dbpool, log)
+	savedWordRepo := repository.NewSavedWordRepository(dbpool, log)
+	wordListRepo := repository.NewWordListRepository(dbpool, log)
+	tagRepo := repository.NewTagRepository(dbpool, log)
+	reviewRepo := repository.NewReviewRepository(dbpool, log)
+	quizRepo := repository.NewQuizRepository(dbpool, log)
+	dailyWordRepo := repository.NewDailyWordRepository(dbpool, log)
+	gamificationRepo := repository.NewGamificationRepository(dbpool, log)
+	statsRepo := repository.NewStatsRepository(dbpool, log)
+	outboxRepo := repository.NewOutboxRepository(dbpool, log)
+
+	// Domain events are delivered to asynchronous subscribers through the outbox
+	eventBus := event.NewBus(outboxRepo, time.Now, log)
+
+	// Load word frequency lists used to score difficulty
+	frequencyIndex, err := frequency.Load(cfg.FrequencyDir, language.Default.Codes(), log)
+	if err != nil {
+		return fmt.Errorf("failed to load frequency lists: %w", err)
+	}
+
+	// Initialize external APIs, sharing one rate-limited client
+	scraperConfig := httpclient.DefaultConfig()
+	scraperConfig.UserAgent = httpclient.UserAgent(cfg.ScraperUserAgent, cfg.ScraperContact)
+	scraperConfig.Rate = cfg.ScraperRate
+	wiktionaryAPI := dictionary.NewWiktionaryAPI(httpclient.New(scraperConfig, log), log)
+
+	// Keep the raw pages fetched from the dictionary, to parse them again offline
+	if cfg.SnapshotDir != "" {
+		archive, err := newSnapshotArchive(cfg, dbpool, log)
+		if err != nil {
+			return fmt.Errorf("failed to initialize page snapshots: %w", err)
+		}
+		wiktionaryAPI.SetRecorder(archive)
+	}
 
-	// Initialize external APIs
-	wiktionaryAPI := dictionary.NewWiktionaryAPI(log)
+	// Redis is shared by the cache, the coalescing lock and the rate limiter
+	redisClient, err := newRedisClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+	if redisClient != nil {
+		defer redisClient.Close()
+	}
+
+	// Cache word lookups and dictionary fetches
+	var wordLookupRepo word.Repository = wordRepo
+	var difficultyRepo word.DifficultyRepository = wordRepo
+	var dictionaryAPI word.DictionaryAPI = wiktionaryAPI
+	cacheStore, err := newCacheStore(cfg, redisClient, log)
+	if err != nil {
+		return fmt.Errorf("failed to initialize cache: %w", err)
+	}
+	if cacheStore != nil {
+		cachedWordRepo := cache.NewDifficultyRepository(wordRepo, cacheStore, cache.DefaultTTLs(), log)
+		wordLookupRepo = cachedWordRepo
+		difficultyRepo = cachedWordRepo
+		dictionaryAPI = cache.NewDictionaryAPI(wiktionaryAPI, cacheStore, cache.DefaultTTLs(), log)
+	}
+
+	// Score words stored before their frequency list was available, through
+	// the cache-evicting repository so that cached words get their difficulty
+	backfillCtx, cancelBackfill := context.WithCancel(context.Background())
+	defer cancelBackfill()
+	go func() {
+		backfiller := word.NewDifficultyBackfiller(difficultyRepo, frequencyIndex, log)
+		if _, err := backfiller.Run(backfillCtx, 500); err != nil && !errors.Is(err, context.Canceled) {
+			log.Error().Err(err).Msg("Word difficulty backfill failed")
+		}
+	}()
+
+	// Coalesce concurrent fetches of the same word, in front of the cache so
+	// that callers waiting for another replica are answered by it
+	var locker coalesce.Locker
+	if cfg.CoalesceLock {
+		locker = coalesce.NewRedisLocker(redisClient, "voconsteroid:lock:")
+	}
+	fetches := coalesce.NewGroup(locker, coalesce.DefaultConfig(), log)
+	expvar.Publish("dictionary_fetches", expvar.Func(func() any { return fetches.Stats() }))
+	dictionaryAPI = coalesce.NewDictionaryAPI(dictionaryAPI, fetches)
 
 	// Initialize services
-	wordService := word.NewService(wordRepo, wiktionaryAPI, log)
+	wordService := word.NewService(wordLookupRepo, dictionaryAPI, frequencyIndex, eventBus, log)
+	savedWordService := savedword.NewService(savedWordRepo, wordRepo, eventBus, log)
+	wordListService := wordlist.NewService(wordListRepo, savedWordRepo, wordRepo, eventBus, log)
+	tagService := tag.NewService(tagRepo, log)
+
+	scheduler, err := review.NewScheduler(cfg.ReviewScheduler)
+	if err != nil {
+		return fmt.Errorf("failed to initialize review scheduler: %w", err)
+	}
+	reviewService := review.NewService(reviewRepo, savedWordRepo, wordRepo, scheduler, time.Now, eventBus, log)
+	quizService := quiz.NewService(quizRepo, savedWordRepo, wordListRepo, wordRepo, reviewService, quiz.NewGenerator(nil), time.Now, eventBus, log)
+	dailyWordService := dailyword.NewService(dailyWordRepo, time.Now, log)
+
+	gamificationRules, err := gamification.LoadRules(cfg.GamificationRules)
+	if err != nil {
+		return fmt.Errorf("failed to load gamification rules: %w", err)
+	}
+	gamificationService := gamification.NewService(gamificationRepo, gamificationRules, time.Now, log)
+	if err := gamification.Subscribe(eventBus, gamificationService); err != nil {
+		return fmt.Errorf("failed to subscribe gamification to events: %w", err)
+	}
+	statsService := stats.NewService(statsRepo, time.Now, log)
+
+	// Deliver queued events until shutdown
+	dispatchCtx, stopDispatcher := context.WithCancel(context.Background())
+	defer stopDispatcher()
+	dispatcher := event.NewDispatcher(eventBus, outboxRepo, event.DefaultDispatcherConfig(), time.Now, log)
+	go func() {
+		if err := dispatcher.Run(dispatchCtx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Error().Err(err).Msg("Event dispatcher stopped")
+		}
+	}()
+
+	// Fetch stale, incomplete and outdated words again until shutdown,
+	// through the shared scraper client and the cache-evicting repository
+	refreshCtx, stopRefresh := context.WithCancel(context.Background())
+	defer stopRefresh()
+	if cfg.RefreshInterval > 0 {
+		refreshWorker := refresh.NewWorker(
+			repository.NewRefreshRepository(dbpool, log), wordLookupRepo, wiktionaryAPI,
+			frequencyIndex, refreshConfig(cfg), time.Now, log,
+		)
+		go func() {
+			if err := refreshWorker.Run(refreshCtx); err != nil && !errors.Is(err, context.Canceled) {
+				log.Error().Err(err).Msg("Refresh worker stopped")
+			}
+		}()
+	}
 
 	// Create and start server
-	srv := server.NewServer(cfg, log, wordService)
+	srv := server.NewServer(cfg, log, server.Services{
+		Word:         wordService,
+		SavedWord:    savedWordService,
+		WordList:     wordListService,
+		Tag:          tagService,
+		Review:       reviewService,
+		Quiz:         quizService,
+		DailyWord:    dailyWordService,
+		Gamification: gamificationService,
+		Stats:        statsService,
+	})
+	rateLimiter, err := newRateLimiter(cfg, redisClient)
+	if err != nil {
+		return fmt.Errorf("failed to initialize rate limiter: %w", err)
+	}
+	if rateLimiter != nil {
+		rateLimitRules, err := ratelimit.LoadRules(cfg.RateLimitRules)
+		if err != nil {
+			return fmt.Errorf("failed to load rate limit rules: %w", err)
+		}
+		srv.SetRateLimiter(rateLimiter, rateLimitRules)
+	}
 	if err := srv.Run(); err != nil {
 		return fmt.Errorf("failed to start server: %w", err)
 	}
@@ -82,3 +277,69 @@ func run() error {
 	log.Info().Msg("Application stopped")
 	return nil
 }
+
+// refreshConfig returns the refresh worker settings of the configuration
+func refreshConfig(cfg *config.Config) refresh.Config {
+	refreshCfg := refresh.DefaultConfig()
+	refreshCfg.Interval = cfg.RefreshInterval
+	refreshCfg.BatchSize = cfg.RefreshBatchSize
+	refreshCfg.Concurrency = cfg.RefreshConcurrency
+	refreshCfg.StaleAfter = cfg.RefreshStaleAfter
+	refreshCfg.ParserVersion = dictionary.ParserVersion
+	return refreshCfg
+}
+
+// newSnapshotArchive creates the archive of raw dictionary pages, kept in
+// SnapshotDir
+func newSnapshotArchive(cfg *config.Config, dbpool *pgxpool.Pool, log zerolog.Logger) (*snapshot.Archive, error) {
+	blobs, err := blobstore.NewFS(cfg.SnapshotDir)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.NewArchive(repository.NewSnapshotRepository(dbpool, log), blobs, log), nil
+}
+
+// newRedisClient connects to Redis when a feature is configured to use it;
+// the client is nil otherwise
+func newRedisClient(cfg *config.Config) (redis.UniversalClient, error) {
+	if cfg.CacheBackend != "redis" && cfg.RateLimitBackend != "redis" && !cfg.CoalesceLock {
+		return nil, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return cache.NewRedisClient(ctx, cfg.RedisURL)
+}
+
+// newCacheStore creates the cache backend selected by the configuration;
+// the store is nil when caching is disabled
+func newCacheStore(cfg *config.Config, redisClient redis.UniversalClient, log zerolog.Logger) (cache.Store, error) {
+	switch cfg.CacheBackend {
+	case "redis":
+		log.Info().Msg("Caching word lookups in Redis")
+		return cache.NewRedisStore(redisClient, "voconsteroid:"), nil
+	case "memory":
+		store, err := cache.NewMemoryStore(cfg.CacheSize, time.Now)
+		if err != nil {
+			return nil, err
+		}
+		log.Info().Int("size", cfg.CacheSize).Msg("Caching word lookups in memory")
+		return store, nil
+	case "none", "":
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unknown cache backend %q", cfg.CacheBackend)
+}
+
+// newRateLimiter creates the rate limiter selected by the configuration;
+// the limiter is nil when rate limiting is disabled
+func newRateLimiter(cfg *config.Config, redisClient redis.UniversalClient) (ratelimit.Limiter, error) {
+	switch cfg.RateLimitBackend {
+	case "redis":
+		return ratelimit.NewRedisLimiter(redisClient, "voconsteroid:ratelimit:"), nil
+	case "memory":
+		return ratelimit.NewMemoryLimiter(time.Now), nil
+	case "none", "":
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unknown rate limit backend %q", cfg.RateLimitBackend)
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/config"
+	"voconsteroid/internal/infrastructure/migrations"
+	"voconsteroid/pkg/logger"
+)
+
+const migrateUsage = `usage: api migrate <command>
+
+commands:
+  up [N]         apply the next N pending migrations, all by default
+  down [N|all]   revert the last N applied migrations, 1 by default
+  status         print the database version and the pending migrations
+  force V        record version V as applied, 0 for none, without running it`
+
+// runMigrate runs a migrate subcommand against the configured database
+func runMigrate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing migrate command\n%s", migrateUsage)
+	}
+	command, arg := args[0], ""
+	if len(args) > 1 {
+		arg = args[1]
+	}
+	switch command {
+	case "up", "down", "status", "force":
+	default:
+		return fmt.Errorf("unknown migrate command %q\n%s", command, migrateUsage)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	logConfig := logger.DefaultConfig()
+	logConfig.Level = cfg.LogLevel
+	log := logger.NewWithConfig(logConfig)
+
+	dbpool, err := config.InitDatabase(cfg, log)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer dbpool.Close()
+
+	runner, err := newMigrationRunner(dbpool, log)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	switch command {
+	case "up":
+		steps, err := parseSteps(arg, 0)
+		if err != nil {
+			return err
+		}
+		applied, err := runner.Up(ctx, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Applied %d migrations\n", applied)
+	case "down":
+		steps, err := parseSteps(arg, 1)
+		if err != nil {
+			return err
+		}
+		reverted, err := runner.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Reverted %d migrations\n", reverted)
+	case "status":
+		status, err := runner.Status(ctx)
+		if err != nil {
+			return err
+		}
+		dirty := ""
+		if status.Dirty {
+			dirty = " (dirty)"
+		}
+		fmt.Printf("Version %d%s, latest %d\n", status.Version, dirty, status.Latest)
+		for _, m := range status.Pending {
+			fmt.Printf("Pending %06d_%s\n", m.Version, m.Name)
+		}
+	case "force":
+		version, err := strconv.ParseUint(arg, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid version %q\n%s", arg, migrateUsage)
+		}
+		if err := runner.Force(ctx, uint(version)); err != nil {
+			return err
+		}
+		fmt.Printf("Forced version %d\n", version)
+	}
+	return nil
+}
+
+// newMigrationRunner creates a runner of the migrations built into the binary
+func newMigrationRunner(dbpool *pgxpool.Pool, log zerolog.Logger) (*migrations.Runner, error) {
+	embedded, err := migrations.Embedded()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
+	}
+	return migrations.NewRunner(dbpool, embedded, log), nil
+}
+
+// parseSteps parses a number of migrations, "all" being 0
+func parseSteps(arg string, fallback int) (int, error) {
+	switch arg {
+	case "":
+		return fallback, nil
+	case "all":
+		return 0, nil
+	}
+	steps, err := strconv.Atoi(arg)
+	if err != nil || steps <= 0 {
+		return 0, fmt.Errorf("invalid number of migrations %q\n%s", arg, migrateUsage)
+	}
+	return steps, nil
+}
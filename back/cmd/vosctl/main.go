@@ -0,0 +1,567 @@
+// Package main is the entry point of vosctl, the command line tool
+// maintaining the stored dictionary.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/joho/godotenv"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/config"
+	"voconsteroid/internal/domain/language"
+	"voconsteroid/internal/domain/refresh"
+	"voconsteroid/internal/domain/snapshot"
+	"voconsteroid/internal/domain/word"
+	"voconsteroid/internal/infrastructure/blobstore"
+	"voconsteroid/internal/infrastructure/cache"
+	"voconsteroid/internal/infrastructure/dictionary"
+	"voconsteroid/internal/infrastructure/frequency"
+	"voconsteroid/internal/infrastructure/httpclient"
+	"voconsteroid/internal/infrastructure/repository"
+	"voconsteroid/pkg/logger"
+)
+
+const usage = `usage: vosctl <command> [flags] [arguments]
+
+commands:
+  fetch [-lang LANG] [-refresh] WORD... fetch words missing from the database and store them
+  rescrape -before DATE [-batch N]      fetch again the words last updated before DATE (YYYY-MM-DD)
+  show [-lang LANG] [-json] ID|WORD     print a stored word with the source and parser it was fetched with
+  delete ID...                          delete words, with the saved and daily words pointing to them
+  merge SOURCE_ID TARGET_ID             fold a duplicate word into another and delete it
+  relemmatize [-batch N]                set lemmas and rebuild search terms of every word
+  validate [-batch N]                   check every stored definition against its language rules
+  stats [-json]                         print statistics of the words table
+  reparse [-lang LANG] [-dry-run]       parse the recorded pages again with the current parsers, offline
+  page [-lang LANG] WORD                print the last recorded page of a word, to use as a test fixture
+  refresh run|pause|resume|status       run a refresh job now, pause or resume the refresh worker,
+                                        or print its state and recent jobs
+
+Run vosctl <command> -h for the flags of a command.`
+
+// app holds what the commands work with
+type app struct {
+	words      word.Service
+	maintainer *word.Maintainer
+	refresher  *refresh.Worker
+	archive    *snapshot.Archive  // Nil unless SNAPSHOT_DIR is set
+	reparser   *snapshot.Reparser // Nil unless SNAPSHOT_DIR is set
+	out        io.Writer
+}
+
+// command runs a subcommand with its arguments
+type command func(ctx context.Context, a *app, args []string) error
+
+var commands = map[string]command{
+	"fetch":       runFetch,
+	"rescrape":    runRescrape,
+	"show":        runShow,
+	"delete":      runDelete,
+	"merge":       runMerge,
+	"relemmatize": runRelemmatize,
+	"validate":    runValidate,
+	"stats":       runStats,
+	"reparse":     runReparse,
+	"page":        runPage,
+	"refresh":     runRefresh,
+}
+
+func main() {
+	// Load .env file if it exists
+	_ = godotenv.Load()
+
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n%s\n", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	err := run(cmd, os.Args[2:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("vosctl %s failed: %v", os.Args[1], err)
+	}
+}
+
+// run wires the dependencies of the commands and runs cmd until it
+// returns or is interrupted
+func run(cmd command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	logConfig := logger.DefaultConfig()
+	logConfig.Level = cfg.LogLevel
+	log := logger.NewWithConfig(logConfig)
+
+	dbpool, err := config.InitDatabase(cfg, log)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer dbpool.Close()
+
+	redisClient, err := newRedisClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+	if redisClient != nil {
+		defer redisClient.Close()
+	}
+
+	a, err := newApp(cfg, dbpool, redisClient, log)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	return cmd(ctx, a, args)
+}
+
+// newApp creates the word service and maintainer on the database, as the
+// API does. Words go through the Redis cache of the API when there is one,
+// so that the words changed here are not served stale from it.
+func newApp(cfg *config.Config, dbpool *pgxpool.Pool, redisClient redis.UniversalClient, log zerolog.Logger) (*app, error) {
+	frequencyIndex, err := frequency.Load(cfg.FrequencyDir, language.Default.Codes(), log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load frequency lists: %w", err)
+	}
+	lemmatizer, err := dictionary.NewLemmatizer()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.ValidateScraper(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	scraperConfig := httpclient.DefaultConfig()
+	scraperConfig.UserAgent = httpclient.UserAgent(cfg.ScraperUserAgent, cfg.ScraperContact)
+	scraperConfig.Rate = cfg.ScraperRate
+	wiktionaryAPI := dictionary.NewWiktionaryAPI(httpclient.New(scraperConfig, log), log)
+
+	refreshConfig := refresh.DefaultConfig()
+	refreshConfig.BatchSize = cfg.RefreshBatchSize
+	refreshConfig.Concurrency = cfg.RefreshConcurrency
+	refreshConfig.StaleAfter = cfg.RefreshStaleAfter
+	refreshConfig.ParserVersion = dictionary.ParserVersion
+
+	var wordRepo word.MaintenanceRepository = repository.NewWordRepository(dbpool, log)
+	if redisClient != nil {
+		wordRepo = cache.NewMaintenanceRepository(wordRepo, cache.NewRedisStore(redisClient, "voconsteroid:"), cache.DefaultTTLs(), log)
+	}
+	refreshRepo := repository.NewRefreshRepository(dbpool, log)
+	a := &app{
+		words:      word.NewService(wordRepo, wiktionaryAPI, frequencyIndex, nil, log),
+		maintainer: word.NewMaintainer(wordRepo, wiktionaryAPI, frequencyIndex, lemmatizer, log),
+		refresher:  refresh.NewWorker(refreshRepo, wordRepo, wiktionaryAPI, frequencyIndex, refreshConfig, nil, log),
+		out:        os.Stdout,
+	}
+
+	// Record fetched pages and parse them again, as the API does
+	if cfg.SnapshotDir != "" {
+		blobs, err := blobstore.NewFS(cfg.SnapshotDir)
+		if err != nil {
+			return nil, err
+		}
+		a.archive = snapshot.NewArchive(repository.NewSnapshotRepository(dbpool, log), blobs, log)
+		a.reparser = snapshot.NewReparser(a.archive, wiktionaryAPI, wordRepo, frequencyIndex, log)
+		wiktionaryAPI.SetRecorder(a.archive)
+	}
+	return a, nil
+}
+
+// newRedisClient connects to Redis when the API caches words there; the
+// client is nil otherwise, a memory cache being out of reach
+func newRedisClient(cfg *config.Config) (redis.UniversalClient, error) {
+	if cfg.CacheBackend != "redis" {
+		return nil, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return cache.NewRedisClient(ctx, cfg.RedisURL)
+}
+
+// errNoSnapshots is returned by the commands needing recorded pages when
+// they are not kept
+var errNoSnapshots = errors.New("pages are not recorded: set SNAPSHOT_DIR")
+
+// newFlagSet creates the flag set of a command
+func newFlagSet(name, arguments string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "usage: vosctl %s [flags] %s\n", name, arguments)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// languageFlag defines the -lang flag, defaulting to the primary language
+func languageFlag(flags *flag.FlagSet, usage string) *string {
+	return flags.String("lang", language.Default.Primary().Code, usage)
+}
+
+// runFetch fetches and stores words, through the word service unless
+// refreshing stored words
+func runFetch(ctx context.Context, a *app, args []string) error {
+	flags := newFlagSet("fetch", "WORD...")
+	language := languageFlag(flags, "language of the words")
+	refresh := flags.Bool("refresh", false, "fetch stored words again")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return errors.New("missing word")
+	}
+
+	failed := 0
+	for _, text := range flags.Args() {
+		var w *word.Word
+		var err error
+		if *refresh {
+			w, err = a.maintainer.Refresh(ctx, text, *language)
+		} else {
+			w, err = a.words.Search(ctx, "", text, *language)
+		}
+		if err != nil {
+			fmt.Fprintf(a.out, "%s\terror: %v\n", text, err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(a.out, "%s\t%s\t%d definitions\n", w.Text, w.ID, len(w.Definitions))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d words failed", failed, flags.NArg())
+	}
+	return nil
+}
+
+// runRescrape fetches again the words last updated before a date
+func runRescrape(ctx context.Context, a *app, args []string) error {
+	flags := newFlagSet("rescrape", "")
+	before := flags.String("before", "", "date (YYYY-MM-DD) before which words are fetched again")
+	batch := flags.Int("batch", 100, "number of words read at once")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	date, err := time.Parse(time.DateOnly, *before)
+	if err != nil {
+		flags.Usage()
+		return fmt.Errorf("invalid date %q", *before)
+	}
+
+	report, err := a.maintainer.RescrapeBefore(ctx, date, *batch)
+	fmt.Fprintf(a.out, "Refreshed %d words, %d no longer found, %d failed\n", report.Refreshed, report.NotFound, report.Failed)
+	return err
+}
+
+// runShow prints a stored word and its provenance
+func runShow(ctx context.Context, a *app, args []string) error {
+	flags := newFlagSet("show", "ID|WORD")
+	language := languageFlag(flags, "language of the word")
+	asJSON := flags.Bool("json", false, "print the word as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errors.New("expected a word ID or text")
+	}
+
+	found, err := a.maintainer.Find(ctx, flags.Arg(0), *language)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		encoder := json.NewEncoder(a.out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(found)
+	}
+
+	w := tabwriter.NewWriter(a.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "ID\t%s\n", found.ID)
+	fmt.Fprintf(w, "Text\t%s (%s)\n", found.Text, found.Language)
+	fmt.Fprintf(w, "Definitions\t%d\n", len(found.Definitions))
+	fmt.Fprintf(w, "Updated\t%s\n", found.UpdatedAt.Format(time.DateTime))
+	if p := found.Provenance; p != nil {
+		fmt.Fprintf(w, "Source\t%s\n", p.Source)
+		fmt.Fprintf(w, "Source URL\t%s\n", p.SourceURL)
+		fmt.Fprintf(w, "Revision\t%d\n", p.RevisionID)
+		fmt.Fprintf(w, "Parser version\t%d\n", p.ParserVersion)
+		if !p.FetchedAt.IsZero() {
+			fmt.Fprintf(w, "Fetched\t%s\n", p.FetchedAt.Format(time.DateTime))
+		}
+	} else {
+		fmt.Fprintln(w, "Source\tunknown")
+	}
+	return w.Flush()
+}
+
+// runDelete deletes words by ID
+func runDelete(ctx context.Context, a *app, args []string) error {
+	flags := newFlagSet("delete", "ID...")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return errors.New("missing word ID")
+	}
+
+	for _, id := range flags.Args() {
+		if err := a.maintainer.Delete(ctx, id); err != nil {
+			return fmt.Errorf("word %s: %w", id, err)
+		}
+		fmt.Fprintf(a.out, "Deleted %s\n", id)
+	}
+	return nil
+}
+
+// runMerge folds a word into another
+func runMerge(ctx context.Context, a *app, args []string) error {
+	flags := newFlagSet("merge", "SOURCE_ID TARGET_ID")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return errors.New("expected a source and a target word ID")
+	}
+
+	target, err := a.maintainer.Merge(ctx, flags.Arg(0), flags.Arg(1))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(a.out, "Merged %s into %s (%s)\n", flags.Arg(0), target.ID, target.Text)
+	return nil
+}
+
+// runRelemmatize sets lemmas and search terms again
+func runRelemmatize(ctx context.Context, a *app, args []string) error {
+	flags := newFlagSet("relemmatize", "")
+	batch := flags.Int("batch", 100, "number of words read at once")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	updated, err := a.maintainer.Relemmatize(ctx, *batch)
+	fmt.Fprintf(a.out, "Updated %d words\n", updated)
+	return err
+}
+
+// runValidate prints the stored definitions breaking their language rules
+func runValidate(ctx context.Context, a *app, args []string) error {
+	flags := newFlagSet("validate", "")
+	batch := flags.Int("batch", 100, "number of words read at once")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	issues, err := a.maintainer.Validate(ctx, *batch)
+	if err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		fmt.Fprintf(a.out, "%s\t%s\t%s\tdefinition %d: %v\n", issue.WordID, issue.Language, issue.Text, issue.Index, issue.Err)
+	}
+	if len(issues) > 0 {
+		return fmt.Errorf("%d invalid definitions", len(issues))
+	}
+	fmt.Fprintln(a.out, "All definitions are valid")
+	return nil
+}
+
+// runStats prints statistics of the words table
+func runStats(ctx context.Context, a *app, args []string) error {
+	flags := newFlagSet("stats", "")
+	asJSON := flags.Bool("json", false, "print the statistics as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	stats, err := a.maintainer.Stats(ctx)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		encoder := json.NewEncoder(a.out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(stats)
+	}
+
+	w := tabwriter.NewWriter(a.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Words\t%d\n", stats.Total)
+	fmt.Fprintf(w, "Without definitions\t%d\n", stats.WithoutDefinitions)
+	fmt.Fprintf(w, "Without lemma\t%d\n", stats.WithoutLemma)
+	if stats.Total > 0 {
+		fmt.Fprintf(w, "Oldest update\t%s\n", stats.OldestUpdate.Format(time.DateTime))
+		fmt.Fprintf(w, "Newest update\t%s\n", stats.NewestUpdate.Format(time.DateTime))
+	}
+	for _, language := range sortedKeys(stats.ByLanguage) {
+		fmt.Fprintf(w, "Language %s\t%d\n", language, stats.ByLanguage[language])
+	}
+	for _, difficulty := range sortedKeys(stats.ByDifficulty) {
+		label := difficulty
+		if label == "" {
+			label = "unscored"
+		}
+		fmt.Fprintf(w, "Difficulty %s\t%d\n", label, stats.ByDifficulty[difficulty])
+	}
+	for _, source := range sortedKeys(stats.BySource) {
+		label := source
+		if label == "" {
+			label = "unknown"
+		}
+		fmt.Fprintf(w, "Source %s\t%d\n", label, stats.BySource[source])
+	}
+	for _, version := range sortedKeys(stats.ByParserVersion) {
+		fmt.Fprintf(w, "Parser version %s\t%d\n", version, stats.ByParserVersion[version])
+	}
+	return w.Flush()
+}
+
+// runReparse parses the recorded pages again and saves the words that changed
+func runReparse(ctx context.Context, a *app, args []string) error {
+	flags := newFlagSet("reparse", "")
+	language := flags.String("lang", "", "language of the pages, all when empty")
+	batch := flags.Int("batch", 100, "number of pages read at once")
+	dryRun := flags.Bool("dry-run", false, "report the words that would change without saving them")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if a.reparser == nil {
+		return errNoSnapshots
+	}
+
+	report, err := a.reparser.Reparse(ctx, *language, *batch, *dryRun)
+	verb := "Updated"
+	if *dryRun {
+		verb = "Would update"
+	}
+	fmt.Fprintf(a.out, "Parsed %d pages: %s %d words, %d unchanged, %d failed\n",
+		report.Parsed, verb, report.Updated, report.Unchanged, report.Failed)
+	return err
+}
+
+// runPage prints the last recorded page of a word
+func runPage(ctx context.Context, a *app, args []string) error {
+	flags := newFlagSet("page", "WORD")
+	language := languageFlag(flags, "language of the word")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errors.New("expected a word")
+	}
+	if a.archive == nil {
+		return errNoSnapshots
+	}
+
+	_, page, err := a.archive.Page(ctx, flags.Arg(0), *language)
+	if err != nil {
+		return err
+	}
+	_, err = a.out.Write(page)
+	return err
+}
+
+// runRefresh controls the refresh worker
+func runRefresh(ctx context.Context, a *app, args []string) error {
+	flags := newFlagSet("refresh", "run|pause|resume|status")
+	limit := flags.Int("n", 10, "number of jobs printed by status")
+	if len(args) == 0 {
+		flags.Usage()
+		return errors.New("missing refresh action")
+	}
+	action := args[0]
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	switch action {
+	case "run":
+		job, err := a.refresher.RunOnce(ctx)
+		if err != nil {
+			return err
+		}
+		printJobs(a.out, []*refresh.Job{job})
+		return nil
+	case "pause":
+		if err := a.refresher.Pause(ctx); err != nil {
+			return err
+		}
+		fmt.Fprintln(a.out, "Refresh paused")
+		return nil
+	case "resume":
+		if err := a.refresher.Resume(ctx); err != nil {
+			return err
+		}
+		fmt.Fprintln(a.out, "Refresh resumed")
+		return nil
+	case "status":
+		paused, err := a.refresher.Paused(ctx)
+		if err != nil {
+			return err
+		}
+		jobs, err := a.refresher.History(ctx, *limit)
+		if err != nil {
+			return err
+		}
+		state := "active"
+		if paused {
+			state = "paused"
+		}
+		fmt.Fprintf(a.out, "Refresh %s\n", state)
+		printJobs(a.out, jobs)
+		return nil
+	default:
+		flags.Usage()
+		return fmt.Errorf("unknown refresh action %q", action)
+	}
+}
+
+// printJobs prints refresh jobs as a table
+func printJobs(out io.Writer, jobs []*refresh.Job) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STARTED\tSTATUS\tSELECTED\tREFRESHED\tUNCHANGED\tFAILED\tERROR")
+	for _, job := range jobs {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%d\t%s\n",
+			job.StartedAt.Format(time.DateTime), job.Status, job.Selected,
+			job.Refreshed, job.Unchanged, job.Failed, job.Error)
+	}
+	w.Flush()
+}
+
+// sortedKeys returns the keys of counts in order
+func sortedKeys(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"testing"
+	"time"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -36,8 +38,50 @@ func TestLoadConfig(t *testing.T) {
 		if cfg.RedisURL != "redis://localhost:6379" {
 			t.Errorf("Expected RedisURL to be 'redis://localhost:6379', got %s", cfg.RedisURL)
 		}
-		if cfg.JWTSecret != "secret" {
-			t.Errorf("Expected JWTSecret to be 'secret', got %s", cfg.JWTSecret)
+		if cfg.JWTSecret != "" {
+			t.Errorf("Expected JWTSecret to be empty, got %s", cfg.JWTSecret)
+		}
+		if cfg.MigrateOnStart {
+			t.Errorf("Expected MigrateOnStart to be false")
+		}
+		if cfg.ReviewScheduler != "fsrs" {
+			t.Errorf("Expected ReviewScheduler to be 'fsrs', got %s", cfg.ReviewScheduler)
+		}
+		if cfg.FrequencyDir != "" {
+			t.Errorf("Expected FrequencyDir to be empty, got %s", cfg.FrequencyDir)
+		}
+		if cfg.CacheBackend != "memory" {
+			t.Errorf("Expected CacheBackend to be 'memory', got %s", cfg.CacheBackend)
+		}
+		if cfg.CacheSize != 10000 {
+			t.Errorf("Expected CacheSize to be 10000, got %d", cfg.CacheSize)
+		}
+		if cfg.CoalesceLock {
+			t.Errorf("Expected CoalesceLock to be false")
+		}
+		if cfg.ScraperUserAgent != "voconsteroid/1.0" {
+			t.Errorf("Expected ScraperUserAgent to be 'voconsteroid/1.0', got %s", cfg.ScraperUserAgent)
+		}
+		if cfg.ScraperRate != 5 {
+			t.Errorf("Expected ScraperRate to be 5, got %v", cfg.ScraperRate)
+		}
+		if cfg.SnapshotDir != "" {
+			t.Errorf("Expected SnapshotDir to be empty, got %s", cfg.SnapshotDir)
+		}
+		if cfg.RefreshInterval != time.Hour {
+			t.Errorf("Expected RefreshInterval to be 1h, got %v", cfg.RefreshInterval)
+		}
+		if cfg.RefreshBatchSize != 50 {
+			t.Errorf("Expected RefreshBatchSize to be 50, got %d", cfg.RefreshBatchSize)
+		}
+		if cfg.RefreshConcurrency != 2 {
+			t.Errorf("Expected RefreshConcurrency to be 2, got %d", cfg.RefreshConcurrency)
+		}
+		if cfg.RefreshStaleAfter != 90*24*time.Hour {
+			t.Errorf("Expected RefreshStaleAfter to be 2160h, got %v", cfg.RefreshStaleAfter)
+		}
+		if cfg.RateLimitBackend != "memory" {
+			t.Errorf("Expected RateLimitBackend to be 'memory', got %s", cfg.RateLimitBackend)
 		}
 	})
 
@@ -84,3 +128,53 @@ func TestLoadConfig(t *testing.T) {
 		os.Unsetenv("JWT_SECRET")
 	})
 }
+
+func TestConfig_ValidateAuth(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		expectErr bool
+	}{
+		{name: "empty secret", secret: "", expectErr: true},
+		{name: "former default secret", secret: "secret", expectErr: true},
+		{name: "configured secret", secret: "s3cr3t-for-tests", expectErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{JWTSecret: tt.secret}
+			err := cfg.ValidateAuth()
+			if tt.expectErr && !errors.Is(err, ErrInsecureJWTSecret) {
+				t.Errorf("Expected ErrInsecureJWTSecret, got %v", err)
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateScraper(t *testing.T) {
+	tests := []struct {
+		name      string
+		contact   string
+		expectErr bool
+	}{
+		{name: "no contact", contact: "", expectErr: true},
+		{name: "blank contact", contact: "  ", expectErr: true},
+		{name: "email contact", contact: "admin@example.org", expectErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{ScraperContact: tt.contact}
+			err := cfg.ValidateScraper()
+			if tt.expectErr && !errors.Is(err, ErrMissingScraperContact) {
+				t.Errorf("Expected ErrMissingScraperContact, got %v", err)
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package dailyword
+
+import (
+	"time"
+
+	"voconsteroid/internal/domain/language"
+	"voconsteroid/internal/domain/word"
+)
+
+// DateLayout is the format of daily word dates
+const DateLayout = "2006-01-02"
+
+// DefaultLanguage is used when no language is given and none can be inferred:
+// the primary language of the registry
+var DefaultLanguage = language.Default.Primary().Code
+
+// RepeatWindowDays is the number of days during which a word of the day is
+// not picked again for the same language and user
+const RepeatWindowDays = 365
+
+// BackfillDays is the number of past days a missing word of the day is
+// still picked for. Older dates only return the words picked at the time.
+const BackfillDays = 7
+
+// MaxHistory is the number of past words of the day returned at most
+const MaxHistory = 60
+
+// Criteria are the quality requirements a word must meet to be picked
+type Criteria struct {
+	MinDefinitions   int
+	RequireEtymology bool
+	RequireExamples  bool
+	MinDifficulty    word.Difficulty // Optional, inclusive
+	MaxDifficulty    word.Difficulty // Optional, inclusive
+}
+
+// WithLevel returns the criteria restricted to the learner's level and the
+// band above it, so personal picks stretch the learner a little
+func (c Criteria) WithLevel(level word.Difficulty) Criteria {
+	c.MinDifficulty = level
+	c.MaxDifficulty = level.Next()
+	return c
+}
+
+// Quality criteria, tried in order until a word qualifies
+var (
+	DefaultCriteria = Criteria{MinDefinitions: 2, RequireEtymology: true, RequireExamples: true}
+	RelaxedCriteria = Criteria{MinDefinitions: 1}
+)
+
+// DailyWord is the word picked for a date, either for everyone learning a
+// language or for a single user
+type DailyWord struct {
+	Date      string     `json:"date"` // Formatted with DateLayout
+	Language  string     `json:"language"`
+	UserID    string     `json:"user_id,omitempty"` // Empty for the global word of the day
+	WordID    string     `json:"word_id"`
+	Word      *word.Word `json:"word,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// IsPersonal reports whether the word was picked for a single user
+func (d *DailyWord) IsPersonal() bool {
+	return d.UserID != ""
+}
+
+// Seed returns the value ordering the candidate words of a date. The same
+// date, language and user always give the same order, so every server picks
+// the same word.
+func Seed(date, language, userID string) string {
+	return date + "/" + language + "/" + userID
+}
@@ -0,0 +1,10 @@
+package dailyword
+
+import "errors"
+
+// Domain errors
+var (
+	ErrDailyWordNotFound = errors.New("daily word not found")
+	ErrNoCandidate       = errors.New("no word meets the daily word criteria")
+	ErrInvalidDate       = errors.New("daily word date is in the future")
+)
@@ -0,0 +1,31 @@
+package dailyword
+
+import (
+	"context"
+
+	"voconsteroid/internal/domain/word"
+)
+
+// Repository defines the interface for daily word data access
+type Repository interface {
+	// FindByDate retrieves the word of the day recorded for a date, with its word
+	FindByDate(ctx context.Context, date, language, userID string) (*DailyWord, error)
+
+	// Pick retrieves the first word of the language meeting the criteria in
+	// the order given by seed. Words picked for the same language and user
+	// since the given date, and words the user saved, are skipped.
+	Pick(ctx context.Context, language, userID, seed string, criteria Criteria, since string) (*word.Word, error)
+
+	// Save records a word of the day; a word already recorded for the date is kept
+	Save(ctx context.Context, daily *DailyWord) error
+
+	// ListHistory retrieves the words of the day up to a date, most recent first
+	ListHistory(ctx context.Context, language, userID, until string, limit int) ([]*DailyWord, error)
+
+	// PreferredLanguage returns the language of most of the user's saved words, or "" if none
+	PreferredLanguage(ctx context.Context, userID string) (string, error)
+
+	// Level returns the median difficulty of the user's scored saved words in
+	// the language, or "" if none is scored
+	Level(ctx context.Context, userID, language string) (word.Difficulty, error)
+}
@@ -0,0 +1,17 @@
+package dailyword
+
+import (
+	"context"
+	"time"
+)
+
+// Service defines the interface for daily word business logic
+type Service interface {
+	// GetDailyWord returns the word of the day for a date, picking and
+	// recording it on first request. An empty userID selects the global word
+	// of the language; an empty language falls back to the user's preferred one.
+	GetDailyWord(ctx context.Context, userID, language string, date time.Time) (*DailyWord, error)
+
+	// GetHistory returns the previous words of the day, most recent first
+	GetHistory(ctx context.Context, userID, language string, limit int) ([]*DailyWord, error)
+}
@@ -0,0 +1,175 @@
+package dailyword
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/word"
+)
+
+// Ensure service implements Service interface
+var _ Service = (*service)(nil)
+
+// service implements the Service interface
+type service struct {
+	repo   Repository
+	clock  func() time.Time
+	logger zerolog.Logger
+}
+
+// NewService creates a new daily word service
+func NewService(repo Repository, clock func() time.Time, logger zerolog.Logger) Service {
+	if clock == nil {
+		clock = time.Now
+	}
+	return &service{
+		repo:   repo,
+		clock:  clock,
+		logger: logger.With().Str("component", "daily_word_service").Logger(),
+	}
+}
+
+// GetDailyWord returns the word of the day for a date, picking it unless
+// the date is more than BackfillDays old
+func (s *service) GetDailyWord(ctx context.Context, userID, language string, date time.Time) (*DailyWord, error) {
+	s.logger.Debug().Str("userID", userID).Str("language", language).Time("date", date).Msg("Getting daily word")
+
+	// A day of margin lets clients in timezones ahead of UTC ask for their today
+	day := date.Format(DateLayout)
+	now := s.clock().UTC()
+	if day > now.AddDate(0, 0, 1).Format(DateLayout) {
+		return nil, ErrInvalidDate
+	}
+
+	language, err := s.resolveLanguage(ctx, userID, language)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.FindByDate(ctx, day, language, userID)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, ErrDailyWordNotFound) {
+		s.logger.Error().Err(err).Str("date", day).Msg("Failed to find daily word")
+		return nil, fmt.Errorf("failed to find daily word: %w", err)
+	}
+
+	// Picking a word for any past date would let clients fill the history
+	if day < now.AddDate(0, 0, -BackfillDays).Format(DateLayout) {
+		return nil, ErrDailyWordNotFound
+	}
+
+	w, err := s.pick(ctx, day, language, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	daily := &DailyWord{
+		Date:      day,
+		Language:  language,
+		UserID:    userID,
+		WordID:    w.ID,
+		Word:      w,
+		CreatedAt: s.clock(),
+	}
+	if err := s.repo.Save(ctx, daily); err != nil {
+		s.logger.Error().Err(err).Str("date", day).Msg("Failed to save daily word")
+		return nil, fmt.Errorf("failed to save daily word: %w", err)
+	}
+
+	// A concurrent request may have recorded its pick first; the recorded word wins
+	stored, err := s.repo.FindByDate(ctx, day, language, userID)
+	if err != nil {
+		s.logger.Error().Err(err).Str("date", day).Msg("Failed to find saved daily word")
+		return nil, fmt.Errorf("failed to find daily word: %w", err)
+	}
+
+	return stored, nil
+}
+
+// GetHistory returns the previous words of the day, most recent first
+func (s *service) GetHistory(ctx context.Context, userID, language string, limit int) ([]*DailyWord, error) {
+	s.logger.Debug().Str("userID", userID).Str("language", language).Int("limit", limit).Msg("Getting daily word history")
+
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > MaxHistory {
+		limit = MaxHistory
+	}
+
+	language, err := s.resolveLanguage(ctx, userID, language)
+	if err != nil {
+		return nil, err
+	}
+
+	today := s.clock().UTC().Format(DateLayout)
+	history, err := s.repo.ListHistory(ctx, language, userID, today, limit)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to list daily word history")
+		return nil, fmt.Errorf("failed to list daily word history: %w", err)
+	}
+
+	return history, nil
+}
+
+// pick selects the word of the day. Personal picks first target the user's
+// level; the quality criteria are then relaxed until a word qualifies.
+func (s *service) pick(ctx context.Context, day, language, userID string) (*word.Word, error) {
+	date, err := time.Parse(DateLayout, day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse daily word date: %w", err)
+	}
+	since := date.AddDate(0, 0, -RepeatWindowDays).Format(DateLayout)
+	seed := Seed(day, language, userID)
+
+	attempts := []Criteria{DefaultCriteria, RelaxedCriteria}
+	if userID != "" {
+		level, err := s.repo.Level(ctx, userID, language)
+		if err != nil {
+			s.logger.Error().Err(err).Str("userID", userID).Msg("Failed to find user level")
+			return nil, fmt.Errorf("failed to find user level: %w", err)
+		}
+		if level.IsValid() {
+			attempts = append([]Criteria{DefaultCriteria.WithLevel(level), RelaxedCriteria.WithLevel(level)}, attempts...)
+		}
+	}
+
+	for _, criteria := range attempts {
+		w, err := s.repo.Pick(ctx, language, userID, seed, criteria, since)
+		if err == nil {
+			return w, nil
+		}
+		if !errors.Is(err, word.ErrWordNotFound) {
+			s.logger.Error().Err(err).Str("date", day).Msg("Failed to pick daily word")
+			return nil, fmt.Errorf("failed to pick daily word: %w", err)
+		}
+	}
+
+	return nil, ErrNoCandidate
+}
+
+// resolveLanguage defaults the language to the one the user saves most words in
+func (s *service) resolveLanguage(ctx context.Context, userID, language string) (string, error) {
+	if language != "" {
+		return language, nil
+	}
+
+	if userID != "" {
+		preferred, err := s.repo.PreferredLanguage(ctx, userID)
+		if err != nil {
+			s.logger.Error().Err(err).Str("userID", userID).Msg("Failed to find preferred language")
+			return "", fmt.Errorf("failed to find preferred language: %w", err)
+		}
+		if preferred != "" {
+			return preferred, nil
+		}
+	}
+
+	return DefaultLanguage, nil
+}
@@ -0,0 +1,250 @@
+package dailyword
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"voconsteroid/internal/domain/word"
+)
+
+var testNow = time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+// MockRepository is a mock implementation of the Repository interface
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) FindByDate(ctx context.Context, date, language, userID string) (*DailyWord, error) {
+	args := m.Called(ctx, date, language, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*DailyWord), args.Error(1)
+}
+
+func (m *MockRepository) Pick(ctx context.Context, language, userID, seed string, criteria Criteria, since string) (*word.Word, error) {
+	args := m.Called(ctx, language, userID, seed, criteria, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.Word), args.Error(1)
+}
+
+func (m *MockRepository) Save(ctx context.Context, daily *DailyWord) error {
+	args := m.Called(ctx, daily)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListHistory(ctx context.Context, language, userID, until string, limit int) ([]*DailyWord, error) {
+	args := m.Called(ctx, language, userID, until, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*DailyWord), args.Error(1)
+}
+
+func (m *MockRepository) PreferredLanguage(ctx context.Context, userID string) (string, error) {
+	args := m.Called(ctx, userID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockRepository) Level(ctx context.Context, userID, language string) (word.Difficulty, error) {
+	args := m.Called(ctx, userID, language)
+	return args.Get(0).(word.Difficulty), args.Error(1)
+}
+
+// setupTestService creates a service with a mock repository for testing
+func setupTestService(t *testing.T) (*MockRepository, Service) {
+	repo := new(MockRepository)
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	clock := func() time.Time { return testNow }
+	return repo, NewService(repo, clock, logger)
+}
+
+func TestGetDailyWord(t *testing.T) {
+	ctx := context.Background()
+	w := &word.Word{ID: "word-1", Text: "flâner", Language: "fr"}
+	since := "2023-03-02"
+
+	t.Run("returns the recorded word", func(t *testing.T) {
+		// Setup
+		repo, svc := setupTestService(t)
+		existing := &DailyWord{Date: "2024-03-01", Language: "fr", WordID: w.ID, Word: w}
+		repo.On("FindByDate", ctx, "2024-03-01", "fr", "").Return(existing, nil)
+
+		// Execute
+		daily, err := svc.GetDailyWord(ctx, "", "fr", testNow)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, existing, daily)
+		repo.AssertNotCalled(t, "Pick", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("picks and records a new word", func(t *testing.T) {
+		// Setup
+		repo, svc := setupTestService(t)
+		stored := &DailyWord{Date: "2024-03-01", Language: "fr", UserID: "user-1", WordID: w.ID, Word: w}
+		repo.On("PreferredLanguage", ctx, "user-1").Return("fr", nil)
+		repo.On("FindByDate", ctx, "2024-03-01", "fr", "user-1").Return(nil, ErrDailyWordNotFound).Once()
+		repo.On("Level", ctx, "user-1", "fr").Return(word.Difficulty(""), nil)
+		repo.On("Pick", ctx, "fr", "user-1", Seed("2024-03-01", "fr", "user-1"), DefaultCriteria, since).Return(w, nil)
+		repo.On("Save", ctx, mock.MatchedBy(func(d *DailyWord) bool {
+			return d.Date == "2024-03-01" && d.UserID == "user-1" && d.WordID == w.ID && d.IsPersonal()
+		})).Return(nil)
+		repo.On("FindByDate", ctx, "2024-03-01", "fr", "user-1").Return(stored, nil).Once()
+
+		// Execute
+		daily, err := svc.GetDailyWord(ctx, "user-1", "", testNow)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, stored, daily)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("relaxes the criteria when no word qualifies", func(t *testing.T) {
+		// Setup
+		repo, svc := setupTestService(t)
+		stored := &DailyWord{Date: "2024-03-01", Language: "en", WordID: w.ID, Word: w}
+		repo.On("FindByDate", ctx, "2024-03-01", "en", "").Return(nil, ErrDailyWordNotFound).Once()
+		repo.On("Pick", ctx, "en", "", mock.Anything, DefaultCriteria, since).Return(nil, word.ErrWordNotFound)
+		repo.On("Pick", ctx, "en", "", mock.Anything, RelaxedCriteria, since).Return(w, nil)
+		repo.On("Save", ctx, mock.Anything).Return(nil)
+		repo.On("FindByDate", ctx, "2024-03-01", "en", "").Return(stored, nil).Once()
+
+		// Execute
+		daily, err := svc.GetDailyWord(ctx, "", "en", testNow)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, stored, daily)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("targets the user level", func(t *testing.T) {
+		// Setup
+		repo, svc := setupTestService(t)
+		stored := &DailyWord{Date: "2024-03-01", Language: "fr", UserID: "user-1", WordID: w.ID, Word: w}
+		leveled := DefaultCriteria
+		leveled.MinDifficulty, leveled.MaxDifficulty = word.DifficultyB1, word.DifficultyB2
+		relaxedLeveled := RelaxedCriteria
+		relaxedLeveled.MinDifficulty, relaxedLeveled.MaxDifficulty = word.DifficultyB1, word.DifficultyB2
+		repo.On("FindByDate", ctx, "2024-03-01", "fr", "user-1").Return(nil, ErrDailyWordNotFound).Once()
+		repo.On("Level", ctx, "user-1", "fr").Return(word.DifficultyB1, nil)
+		repo.On("Pick", ctx, "fr", "user-1", mock.Anything, leveled, since).Return(nil, word.ErrWordNotFound)
+		repo.On("Pick", ctx, "fr", "user-1", mock.Anything, relaxedLeveled, since).Return(w, nil)
+		repo.On("Save", ctx, mock.Anything).Return(nil)
+		repo.On("FindByDate", ctx, "2024-03-01", "fr", "user-1").Return(stored, nil).Once()
+
+		// Execute
+		daily, err := svc.GetDailyWord(ctx, "user-1", "fr", testNow)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, stored, daily)
+		repo.AssertExpectations(t)
+		repo.AssertNotCalled(t, "Pick", ctx, "fr", "user-1", mock.Anything, DefaultCriteria, since)
+	})
+
+	t.Run("no candidate", func(t *testing.T) {
+		// Setup
+		repo, svc := setupTestService(t)
+		repo.On("FindByDate", ctx, "2024-03-01", "fr", "").Return(nil, ErrDailyWordNotFound)
+		repo.On("Pick", ctx, "fr", "", mock.Anything, mock.Anything, since).Return(nil, word.ErrWordNotFound)
+
+		// Execute
+		daily, err := svc.GetDailyWord(ctx, "", "", testNow)
+
+		// Assert
+		assert.Nil(t, daily)
+		assert.ErrorIs(t, err, ErrNoCandidate)
+		repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects future dates", func(t *testing.T) {
+		// Setup
+		repo, svc := setupTestService(t)
+
+		// Execute
+		daily, err := svc.GetDailyWord(ctx, "", "fr", testNow.AddDate(0, 0, 2))
+
+		// Assert
+		assert.Nil(t, daily)
+		assert.ErrorIs(t, err, ErrInvalidDate)
+		repo.AssertNotCalled(t, "FindByDate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("does not pick for old dates", func(t *testing.T) {
+		// Setup
+		repo, svc := setupTestService(t)
+		repo.On("FindByDate", ctx, "2024-02-01", "fr", "").Return(nil, ErrDailyWordNotFound)
+
+		// Execute
+		daily, err := svc.GetDailyWord(ctx, "", "fr", testNow.AddDate(0, -1, 0))
+
+		// Assert
+		assert.Nil(t, daily)
+		assert.ErrorIs(t, err, ErrDailyWordNotFound)
+		repo.AssertNotCalled(t, "Pick", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		// Setup
+		repo, svc := setupTestService(t)
+		repo.On("FindByDate", ctx, "2024-03-01", "fr", "").Return(nil, errors.New("db down"))
+
+		// Execute
+		daily, err := svc.GetDailyWord(ctx, "", "fr", testNow)
+
+		// Assert
+		assert.Nil(t, daily)
+		assert.Error(t, err)
+	})
+}
+
+func TestGetHistory(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		userID        string
+		language      string
+		limit         int
+		preferred     string
+		expectedLang  string
+		expectedLimit int
+	}{
+		{name: "default limit", language: "fr", limit: 0, expectedLang: "fr", expectedLimit: 20},
+		{name: "capped limit", language: "en", limit: 500, expectedLang: "en", expectedLimit: MaxHistory},
+		{name: "preferred language", userID: "user-1", limit: 5, preferred: "en", expectedLang: "en", expectedLimit: 5},
+		{name: "default language", userID: "user-1", limit: 5, preferred: "", expectedLang: DefaultLanguage, expectedLimit: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			repo, svc := setupTestService(t)
+			history := []*DailyWord{{Date: "2024-02-29", Language: tt.expectedLang, WordID: "word-1"}}
+			if tt.language == "" {
+				repo.On("PreferredLanguage", ctx, tt.userID).Return(tt.preferred, nil)
+			}
+			repo.On("ListHistory", ctx, tt.expectedLang, tt.userID, "2024-03-01", tt.expectedLimit).Return(history, nil)
+
+			// Execute
+			result, err := svc.GetHistory(ctx, tt.userID, tt.language, tt.limit)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, history, result)
+			repo.AssertExpectations(t)
+		})
+	}
+}
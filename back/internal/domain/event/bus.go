@@ -0,0 +1,195 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// Ensure Bus implements Publisher
+var _ Publisher = (*Bus)(nil)
+
+// Bus delivers domain events to typed subscribers.
+//
+// Synchronous subscribers run inside Publish, in the publisher's goroutine,
+// and their errors are returned to it. Asynchronous subscribers are
+// delivered through the outbox by a Dispatcher, at least once and with
+// retries, so they must be idempotent. Without an outbox, asynchronous
+// subscribers run once in their own goroutine and failures are only logged.
+type Bus struct {
+	mu       sync.RWMutex
+	sync     map[string][]func(context.Context, Event) error
+	async    map[string][]string                                     // Event name to subscriber names
+	handlers map[string]func(context.Context, json.RawMessage) error // Subscriber name to handler
+	outbox   Outbox
+	clock    func() time.Time
+	logger   zerolog.Logger
+}
+
+// NewBus creates a new event bus queuing asynchronous deliveries in the
+// outbox; a nil outbox delivers them in memory
+func NewBus(outbox Outbox, clock func() time.Time, logger zerolog.Logger) *Bus {
+	if clock == nil {
+		clock = time.Now
+	}
+	return &Bus{
+		sync:     make(map[string][]func(context.Context, Event) error),
+		async:    make(map[string][]string),
+		handlers: make(map[string]func(context.Context, json.RawMessage) error),
+		outbox:   outbox,
+		clock:    clock,
+		logger:   logger.With().Str("component", "event_bus").Logger(),
+	}
+}
+
+// Subscribe registers a synchronous subscriber for events of type T
+func Subscribe[T Event](b *Bus, handler func(context.Context, T) error) {
+	var zero T
+	name := zero.EventName()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.sync[name] = append(b.sync[name], func(ctx context.Context, e Event) error {
+		typed, ok := e.(T)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for event %s", e, name)
+		}
+		return handler(ctx, typed)
+	})
+}
+
+// SubscribeAsync registers an asynchronous subscriber for events of type T.
+// The name identifies the subscriber in the outbox and must be stable
+// across releases, or pending deliveries are lost.
+func SubscribeAsync[T Event](b *Bus, name string, handler func(context.Context, T) error) error {
+	var zero T
+	eventName := zero.EventName()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, exists := b.handlers[name]; exists {
+		return fmt.Errorf("%w: %q", ErrDuplicateSubscriber, name)
+	}
+
+	b.handlers[name] = func(ctx context.Context, payload json.RawMessage) error {
+		var e T
+		if err := json.Unmarshal(payload, &e); err != nil {
+			return fmt.Errorf("failed to decode %s event: %w", eventName, err)
+		}
+		return handler(ctx, e)
+	}
+	b.async[eventName] = append(b.async[eventName], name)
+
+	return nil
+}
+
+// Publish runs the synchronous subscribers of the events and queues them
+// for the asynchronous ones. Every subscriber runs even if another fails.
+func (b *Bus) Publish(ctx context.Context, events ...Event) error {
+	return b.publish(ctx, b.outbox, events)
+}
+
+// Emit returns the publication of events caused by a database write. The
+// synchronous subscribers run before the write commits, and the
+// asynchronous deliveries are appended to the outbox of its transaction; a
+// bus without an outbox delivers them in memory instead.
+func (b *Bus) Emit(events ...Event) Emit {
+	return func(ctx context.Context, outbox Outbox) error {
+		if b.outbox == nil {
+			outbox = nil
+		}
+		return b.publish(ctx, outbox, events)
+	}
+}
+
+// publish runs the synchronous subscribers of the events and queues them for
+// the asynchronous ones in the outbox
+func (b *Bus) publish(ctx context.Context, outbox Outbox, events []Event) error {
+	var errs []error
+	var records []*Record
+	now := b.clock()
+
+	for _, e := range events {
+		name := e.EventName()
+		b.logger.Debug().Str("event", name).Msg("Publishing event")
+
+		b.mu.RLock()
+		handlers := b.sync[name]
+		subscribers := b.async[name]
+		b.mu.RUnlock()
+
+		for _, handle := range handlers {
+			if err := handle(ctx, e); err != nil {
+				errs = append(errs, fmt.Errorf("subscriber of %s failed: %w", name, err))
+			}
+		}
+
+		if len(subscribers) == 0 {
+			continue
+		}
+		payload, err := json.Marshal(e)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to encode %s event: %w", name, err))
+			continue
+		}
+		for _, subscriber := range subscribers {
+			records = append(records, &Record{
+				ID:         uuid.New().String(),
+				Event:      name,
+				Subscriber: subscriber,
+				Payload:    payload,
+				OccurredAt: now,
+			})
+		}
+	}
+
+	if len(records) > 0 {
+		if err := b.enqueue(ctx, outbox, records); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// enqueue stores records in the outbox, or delivers them in the background
+// when there is none
+func (b *Bus) enqueue(ctx context.Context, outbox Outbox, records []*Record) error {
+	if outbox != nil {
+		if err := outbox.Append(ctx, records); err != nil {
+			b.logger.Error().Err(err).Int("records", len(records)).Msg("Failed to append events to outbox")
+			return fmt.Errorf("failed to append events to outbox: %w", err)
+		}
+		return nil
+	}
+
+	detached := context.WithoutCancel(ctx)
+	for _, r := range records {
+		go func(r *Record) {
+			if err := b.deliver(detached, r); err != nil {
+				b.logger.Warn().Err(err).Str("event", r.Event).Str("subscriber", r.Subscriber).Msg("Asynchronous subscriber failed")
+			}
+		}(r)
+	}
+	return nil
+}
+
+// deliver runs the asynchronous subscriber of a record
+func (b *Bus) deliver(ctx context.Context, r *Record) error {
+	b.mu.RLock()
+	handle, ok := b.handlers[r.Subscriber]
+	b.mu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownSubscriber, r.Subscriber)
+	}
+	return handle(ctx, r.Payload)
+}
@@ -0,0 +1,263 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+// wordLooked is an event used by the tests
+type wordLooked struct {
+	Text string `json:"text"`
+}
+
+func (wordLooked) EventName() string { return "test.word_looked" }
+
+// MockOutbox is a mock implementation of the Outbox interface
+type MockOutbox struct {
+	mock.Mock
+}
+
+func (m *MockOutbox) Append(ctx context.Context, records []*Record) error {
+	args := m.Called(ctx, records)
+	return args.Error(0)
+}
+
+func (m *MockOutbox) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*Record, error) {
+	args := m.Called(ctx, now, lease, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Record), args.Error(1)
+}
+
+func (m *MockOutbox) MarkDelivered(ctx context.Context, id string, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+func (m *MockOutbox) MarkFailed(ctx context.Context, id, reason string, retryAt time.Time) error {
+	args := m.Called(ctx, id, reason, retryAt)
+	return args.Error(0)
+}
+
+// setupTestBus creates a bus with a mock outbox for testing
+func setupTestBus(t *testing.T) (*MockOutbox, *Bus) {
+	outbox := new(MockOutbox)
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	return outbox, NewBus(outbox, func() time.Time { return testNow }, logger)
+}
+
+func TestPublish_Sync(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("runs typed subscribers in order", func(t *testing.T) {
+		// Setup
+		_, bus := setupTestBus(t)
+		var seen []string
+		Subscribe(bus, func(_ context.Context, e wordLooked) error {
+			seen = append(seen, "first:"+e.Text)
+			return nil
+		})
+		Subscribe(bus, func(_ context.Context, e wordLooked) error {
+			seen = append(seen, "second:"+e.Text)
+			return nil
+		})
+
+		// Execute
+		err := bus.Publish(ctx, wordLooked{Text: "maison"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"first:maison", "second:maison"}, seen)
+	})
+
+	t.Run("failing subscriber does not stop the others", func(t *testing.T) {
+		// Setup
+		_, bus := setupTestBus(t)
+		called := false
+		Subscribe(bus, func(context.Context, wordLooked) error { return errors.New("boom") })
+		Subscribe(bus, func(context.Context, wordLooked) error {
+			called = true
+			return nil
+		})
+
+		// Execute
+		err := bus.Publish(ctx, wordLooked{Text: "maison"})
+
+		// Assert
+		assert.ErrorContains(t, err, "boom")
+		assert.True(t, called)
+	})
+}
+
+func TestPublish_Async(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("appends one record per subscriber", func(t *testing.T) {
+		// Setup
+		outbox, bus := setupTestBus(t)
+		require.NoError(t, SubscribeAsync(bus, "stats", func(context.Context, wordLooked) error { return nil }))
+		require.NoError(t, SubscribeAsync(bus, "mailer", func(context.Context, wordLooked) error { return nil }))
+		outbox.On("Append", ctx, mock.MatchedBy(func(records []*Record) bool {
+			return len(records) == 2 &&
+				records[0].Subscriber == "stats" && records[1].Subscriber == "mailer" &&
+				records[0].Event == "test.word_looked" && records[0].ID != records[1].ID &&
+				string(records[0].Payload) == `{"text":"maison"}` && records[0].OccurredAt.Equal(testNow)
+		})).Return(nil)
+
+		// Execute
+		err := bus.Publish(ctx, wordLooked{Text: "maison"})
+
+		// Assert
+		require.NoError(t, err)
+		outbox.AssertExpectations(t)
+	})
+
+	t.Run("no subscriber, no record", func(t *testing.T) {
+		// Setup
+		outbox, bus := setupTestBus(t)
+
+		// Execute
+		err := bus.Publish(ctx, wordLooked{Text: "maison"})
+
+		// Assert
+		require.NoError(t, err)
+		outbox.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+	})
+
+	t.Run("outbox error", func(t *testing.T) {
+		// Setup
+		outbox, bus := setupTestBus(t)
+		require.NoError(t, SubscribeAsync(bus, "stats", func(context.Context, wordLooked) error { return nil }))
+		outbox.On("Append", ctx, mock.Anything).Return(errors.New("db down"))
+
+		// Execute
+		err := bus.Publish(ctx, wordLooked{Text: "maison"})
+
+		// Assert
+		assert.ErrorContains(t, err, "db down")
+	})
+
+	t.Run("duplicate subscriber name", func(t *testing.T) {
+		// Setup
+		_, bus := setupTestBus(t)
+		require.NoError(t, SubscribeAsync(bus, "stats", func(context.Context, wordLooked) error { return nil }))
+
+		// Execute
+		err := SubscribeAsync(bus, "stats", func(context.Context, wordLooked) error { return nil })
+
+		// Assert
+		assert.ErrorIs(t, err, ErrDuplicateSubscriber)
+	})
+
+	t.Run("delivers in memory without outbox", func(t *testing.T) {
+		// Setup
+		bus := NewBus(nil, nil, zerolog.New(zerolog.NewTestWriter(t)))
+		received := make(chan wordLooked, 1)
+		require.NoError(t, SubscribeAsync(bus, "stats", func(_ context.Context, e wordLooked) error {
+			received <- e
+			return nil
+		}))
+
+		// Execute
+		err := bus.Publish(ctx, wordLooked{Text: "maison"})
+
+		// Assert
+		require.NoError(t, err)
+		select {
+		case e := <-received:
+			assert.Equal(t, "maison", e.Text)
+		case <-time.After(time.Second):
+			t.Fatal("event not delivered")
+		}
+	})
+}
+
+func TestEmit(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("appends to the outbox of the transaction", func(t *testing.T) {
+		// Setup
+		outbox, bus := setupTestBus(t)
+		txOutbox := new(MockOutbox)
+		require.NoError(t, SubscribeAsync(bus, "stats", func(context.Context, wordLooked) error { return nil }))
+		txOutbox.On("Append", ctx, mock.MatchedBy(func(records []*Record) bool {
+			return len(records) == 1 && records[0].Subscriber == "stats"
+		})).Return(nil)
+
+		// Execute
+		err := bus.Emit(wordLooked{Text: "maison"})(ctx, txOutbox)
+
+		// Assert
+		require.NoError(t, err)
+		txOutbox.AssertExpectations(t)
+		outbox.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+	})
+
+	t.Run("outbox error fails the write", func(t *testing.T) {
+		// Setup
+		_, bus := setupTestBus(t)
+		txOutbox := new(MockOutbox)
+		require.NoError(t, SubscribeAsync(bus, "stats", func(context.Context, wordLooked) error { return nil }))
+		txOutbox.On("Append", ctx, mock.Anything).Return(errors.New("tx aborted"))
+
+		// Execute
+		err := bus.Emit(wordLooked{Text: "maison"})(ctx, txOutbox)
+
+		// Assert
+		assert.ErrorContains(t, err, "tx aborted")
+	})
+
+	t.Run("delivers in memory without outbox", func(t *testing.T) {
+		// Setup
+		bus := NewBus(nil, nil, zerolog.New(zerolog.NewTestWriter(t)))
+		txOutbox := new(MockOutbox)
+		received := make(chan wordLooked, 1)
+		require.NoError(t, SubscribeAsync(bus, "stats", func(_ context.Context, e wordLooked) error {
+			received <- e
+			return nil
+		}))
+
+		// Execute
+		err := bus.Emit(wordLooked{Text: "maison"})(ctx, txOutbox)
+
+		// Assert
+		require.NoError(t, err)
+		select {
+		case e := <-received:
+			assert.Equal(t, "maison", e.Text)
+		case <-time.After(time.Second):
+			t.Fatal("event not delivered")
+		}
+		txOutbox.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDeliver_DecodesPayload(t *testing.T) {
+	// Setup
+	_, bus := setupTestBus(t)
+	var got wordLooked
+	require.NoError(t, SubscribeAsync(bus, "stats", func(_ context.Context, e wordLooked) error {
+		got = e
+		return nil
+	}))
+
+	// Execute
+	err := bus.deliver(context.Background(), &Record{Subscriber: "stats", Payload: json.RawMessage(`{"text":"chat"}`)})
+	errUnknown := bus.deliver(context.Background(), &Record{Subscriber: "gone"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "chat", got.Text)
+	assert.ErrorIs(t, errUnknown, ErrUnknownSubscriber)
+}
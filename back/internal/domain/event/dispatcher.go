@@ -0,0 +1,139 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// DispatcherConfig tunes the delivery of outbox records
+type DispatcherConfig struct {
+	PollInterval time.Duration // Delay between polls once the outbox is drained
+	BatchSize    int           // Records claimed per poll
+	Lease        time.Duration // Time a delivery may take before the record is claimed again
+	MaxAttempts  int           // Deliveries attempted before a record is given up
+	BaseBackoff  time.Duration // Delay before the first retry, doubled on each attempt
+	MaxBackoff   time.Duration
+}
+
+// DefaultDispatcherConfig returns the default dispatcher settings
+func DefaultDispatcherConfig() DispatcherConfig {
+	return DispatcherConfig{
+		PollInterval: time.Second,
+		BatchSize:    100,
+		Lease:        30 * time.Second,
+		MaxAttempts:  10,
+		BaseBackoff:  time.Second,
+		MaxBackoff:   10 * time.Minute,
+	}
+}
+
+// Backoff returns the delay before retrying a record after a failed attempt
+func (c DispatcherConfig) Backoff(attempts int) time.Duration {
+	delay := c.BaseBackoff
+	for i := 1; i < attempts && delay < c.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > c.MaxBackoff {
+		delay = c.MaxBackoff
+	}
+	return delay
+}
+
+// Dispatcher delivers the outbox records to the asynchronous subscribers of
+// a bus. Several dispatchers may share an outbox: claimed records are leased.
+type Dispatcher struct {
+	bus    *Bus
+	outbox Outbox
+	cfg    DispatcherConfig
+	clock  func() time.Time
+	logger zerolog.Logger
+}
+
+// NewDispatcher creates a new outbox dispatcher
+func NewDispatcher(bus *Bus, outbox Outbox, cfg DispatcherConfig, clock func() time.Time, logger zerolog.Logger) *Dispatcher {
+	if clock == nil {
+		clock = time.Now
+	}
+	return &Dispatcher{
+		bus:    bus,
+		outbox: outbox,
+		cfg:    cfg,
+		clock:  clock,
+		logger: logger.With().Str("component", "event_dispatcher").Logger(),
+	}
+}
+
+// Run delivers records until the context is canceled
+func (d *Dispatcher) Run(ctx context.Context) error {
+	d.logger.Info().Dur("pollInterval", d.cfg.PollInterval).Msg("Starting event dispatcher")
+
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Keep polling without waiting while full batches come back
+		for {
+			claimed, err := d.DispatchOnce(ctx)
+			if err != nil && !errors.Is(err, context.Canceled) {
+				d.logger.Error().Err(err).Msg("Failed to dispatch events")
+			}
+			if err != nil || claimed < d.cfg.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			d.logger.Info().Msg("Stopping event dispatcher")
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchOnce claims a batch of due records and delivers them, returning
+// the number of records claimed
+func (d *Dispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	records, err := d.outbox.Claim(ctx, d.clock(), d.cfg.Lease, d.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, r := range records {
+		d.dispatch(ctx, r)
+	}
+
+	return len(records), nil
+}
+
+// dispatch delivers a record and records the outcome
+func (d *Dispatcher) dispatch(ctx context.Context, r *Record) {
+	deliveryCtx, cancel := context.WithTimeout(ctx, d.cfg.Lease)
+	err := d.bus.deliver(deliveryCtx, r)
+	cancel()
+
+	now := d.clock()
+	if err == nil {
+		if err := d.outbox.MarkDelivered(ctx, r.ID, now); err != nil {
+			d.logger.Error().Err(err).Str("id", r.ID).Msg("Failed to mark event delivered")
+		}
+		return
+	}
+
+	var retryAt time.Time
+	if r.Attempts < d.cfg.MaxAttempts {
+		retryAt = now.Add(d.cfg.Backoff(r.Attempts))
+		d.logger.Warn().Err(err).Str("id", r.ID).Str("event", r.Event).Str("subscriber", r.Subscriber).
+			Int("attempts", r.Attempts).Time("retryAt", retryAt).Msg("Event delivery failed, retrying later")
+	} else {
+		d.logger.Error().Err(err).Str("id", r.ID).Str("event", r.Event).Str("subscriber", r.Subscriber).
+			Int("attempts", r.Attempts).Msg("Event delivery failed, giving up")
+	}
+
+	if err := d.outbox.MarkFailed(ctx, r.ID, err.Error(), retryAt); err != nil {
+		d.logger.Error().Err(err).Str("id", r.ID).Msg("Failed to mark event failed")
+	}
+}
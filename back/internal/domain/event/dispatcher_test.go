@@ -0,0 +1,96 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// setupTestDispatcher creates a dispatcher sharing a mock outbox with its bus
+func setupTestDispatcher(t *testing.T) (*MockOutbox, *Bus, *Dispatcher) {
+	outbox, bus := setupTestBus(t)
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	cfg := DefaultDispatcherConfig()
+	cfg.MaxAttempts = 3
+	return outbox, bus, NewDispatcher(bus, outbox, cfg, func() time.Time { return testNow }, logger)
+}
+
+func TestDispatcherConfig_Backoff(t *testing.T) {
+	cfg := DispatcherConfig{BaseBackoff: time.Second, MaxBackoff: 10 * time.Second}
+
+	assert.Equal(t, time.Second, cfg.Backoff(1))
+	assert.Equal(t, 2*time.Second, cfg.Backoff(2))
+	assert.Equal(t, 8*time.Second, cfg.Backoff(4))
+	assert.Equal(t, 10*time.Second, cfg.Backoff(5))
+	assert.Equal(t, 10*time.Second, cfg.Backoff(50))
+}
+
+func TestDispatchOnce(t *testing.T) {
+	ctx := context.Background()
+	payload := []byte(`{"text":"maison"}`)
+
+	t.Run("delivered", func(t *testing.T) {
+		// Setup
+		outbox, bus, dispatcher := setupTestDispatcher(t)
+		require.NoError(t, SubscribeAsync(bus, "stats", func(context.Context, wordLooked) error { return nil }))
+		outbox.On("Claim", ctx, testNow, 30*time.Second, 100).Return([]*Record{{ID: "r1", Subscriber: "stats", Payload: payload, Attempts: 1}}, nil)
+		outbox.On("MarkDelivered", ctx, "r1", testNow).Return(nil)
+
+		// Execute
+		claimed, err := dispatcher.DispatchOnce(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, claimed)
+		outbox.AssertExpectations(t)
+	})
+
+	t.Run("failure is retried with backoff", func(t *testing.T) {
+		// Setup
+		outbox, bus, dispatcher := setupTestDispatcher(t)
+		require.NoError(t, SubscribeAsync(bus, "stats", func(context.Context, wordLooked) error { return errors.New("busy") }))
+		outbox.On("Claim", ctx, mock.Anything, mock.Anything, mock.Anything).Return([]*Record{{ID: "r1", Subscriber: "stats", Payload: payload, Attempts: 2}}, nil)
+		outbox.On("MarkFailed", ctx, "r1", "busy", testNow.Add(2*time.Second)).Return(nil)
+
+		// Execute
+		_, err := dispatcher.DispatchOnce(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		outbox.AssertExpectations(t)
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		// Setup
+		outbox, bus, dispatcher := setupTestDispatcher(t)
+		require.NoError(t, SubscribeAsync(bus, "stats", func(context.Context, wordLooked) error { return errors.New("busy") }))
+		outbox.On("Claim", ctx, mock.Anything, mock.Anything, mock.Anything).Return([]*Record{{ID: "r1", Subscriber: "stats", Payload: payload, Attempts: 3}}, nil)
+		outbox.On("MarkFailed", ctx, "r1", "busy", time.Time{}).Return(nil)
+
+		// Execute
+		_, err := dispatcher.DispatchOnce(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		outbox.AssertExpectations(t)
+	})
+
+	t.Run("claim error", func(t *testing.T) {
+		// Setup
+		outbox, _, dispatcher := setupTestDispatcher(t)
+		outbox.On("Claim", ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
+
+		// Execute
+		claimed, err := dispatcher.DispatchOnce(ctx)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Equal(t, 0, claimed)
+	})
+}
@@ -0,0 +1,53 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Event is a domain event. Events are plain structs named after what
+// happened; they are encoded as JSON when delivered asynchronously, so their
+// fields must round-trip through encoding/json.
+type Event interface {
+	// EventName identifies the event type, e.g. "word.saved". It must not
+	// depend on the receiver's fields, as it is read from zero values.
+	EventName() string
+}
+
+// Publisher publishes domain events
+type Publisher interface {
+	// Publish runs the synchronous subscribers of the events and queues them
+	// for the asynchronous ones
+	Publish(ctx context.Context, events ...Event) error
+
+	// Emit returns the publication of events caused by a database write, for
+	// the repository to run in the transaction of the write
+	Emit(events ...Event) Emit
+}
+
+// Emit publishes the events of a database write. Repositories run it before
+// committing, with an outbox appending to their transaction, so that the
+// events are queued if and only if the write commits; its error fails the
+// write. A nil Emit publishes nothing.
+type Emit func(ctx context.Context, outbox Outbox) error
+
+// Nop is a Publisher that drops every event, for services built without a bus
+var Nop Publisher = nopPublisher{}
+
+type nopPublisher struct{}
+
+func (nopPublisher) Publish(context.Context, ...Event) error { return nil }
+
+func (nopPublisher) Emit(...Event) Emit { return nil }
+
+// Record is an event queued for an asynchronous subscriber in the outbox.
+// Each subscriber gets its own record so that it is retried independently.
+type Record struct {
+	ID         string          `json:"id"`
+	Event      string          `json:"event"`
+	Subscriber string          `json:"subscriber"`
+	Payload    json.RawMessage `json:"payload"`
+	Attempts   int             `json:"attempts"` // Including the current one once claimed
+	OccurredAt time.Time       `json:"occurred_at"`
+}
@@ -0,0 +1,9 @@
+package event
+
+import "errors"
+
+// Domain errors
+var (
+	ErrDuplicateSubscriber = errors.New("subscriber name already registered")
+	ErrUnknownSubscriber   = errors.New("no subscriber registered under this name")
+)
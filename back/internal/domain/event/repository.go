@@ -0,0 +1,24 @@
+package event
+
+import (
+	"context"
+	"time"
+)
+
+// Outbox defines the persistence of events awaiting asynchronous delivery
+type Outbox interface {
+	// Append stores records for delivery
+	Append(ctx context.Context, records []*Record) error
+
+	// Claim leases up to limit records due for delivery at now, incrementing
+	// their attempts. A leased record is not claimed again before the lease
+	// ends, and is claimed again afterwards if it was neither delivered nor failed.
+	Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*Record, error)
+
+	// MarkDelivered marks a record as delivered
+	MarkDelivered(ctx context.Context, id string, at time.Time) error
+
+	// MarkFailed records a delivery failure. The record is retried at retryAt,
+	// or never again if retryAt is zero.
+	MarkFailed(ctx context.Context, id, reason string, retryAt time.Time) error
+}
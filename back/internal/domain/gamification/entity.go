@@ -0,0 +1,200 @@
+package gamification
+
+import (
+	"math"
+	"time"
+)
+
+// EventType identifies a user activity that can earn XP
+type EventType string
+
+// Event types
+const (
+	EventWordSearched  EventType = "word_searched"
+	EventWordSaved     EventType = "word_saved"
+	EventReviewGraded  EventType = "review_graded"
+	EventQuizCompleted EventType = "quiz_completed"
+)
+
+// EventTypes lists the known event types
+var EventTypes = []EventType{EventWordSearched, EventWordSaved, EventReviewGraded, EventQuizCompleted}
+
+// IsValid reports whether the event type is known
+func (t EventType) IsValid() bool {
+	for _, known := range EventTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Event attribute keys
+const (
+	AttributeGrade   = "grade"   // Review grade: again, hard, good or easy
+	AttributeScore   = "score"   // Quiz score percentage
+	AttributePerfect = "perfect" // "true" when every quiz answer was correct
+)
+
+// Metrics that badges can use besides event counters
+const (
+	MetricXP            = "xp"
+	MetricLevel         = "level"
+	MetricStreak        = "streak"
+	MetricLongestStreak = "longest_streak"
+)
+
+// DefaultTimezone is used for day boundaries until the user sets a timezone
+const DefaultTimezone = "UTC"
+
+// DayLayout is the format of days in the user's timezone
+const DayLayout = "2006-01-02"
+
+// Event is a user activity reported by another domain. Events reported
+// again with the same key, as redelivered domain events are, earn nothing.
+type Event struct {
+	Type       EventType         `json:"type"`
+	UserID     string            `json:"user_id"`
+	Key        string            `json:"key,omitempty"` // Identifies the activity, e.g. the ID of a review log; empty to never deduplicate
+	OccurredAt time.Time         `json:"occurred_at"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// Progress is the XP, streak and activity counters of a user
+type Progress struct {
+	UserID        string         `json:"user_id"`
+	XP            int            `json:"xp"`
+	Level         int            `json:"level"`
+	CurrentStreak int            `json:"current_streak"`
+	LongestStreak int            `json:"longest_streak"`
+	LastActiveDay string         `json:"last_active_day,omitempty"` // In the user's timezone, formatted with DayLayout
+	FreezeTokens  int            `json:"freeze_tokens"`
+	Timezone      string         `json:"timezone"`
+	Counters      map[string]int `json:"counters"`
+	DayXP         map[string]int `json:"-"` // XP earned per rule on LastActiveDay, for daily caps
+	Version       int            `json:"-"` // Optimistic concurrency control
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+// NewProgress creates the progress of a user without activity
+func NewProgress(userID string) *Progress {
+	return &Progress{
+		UserID:   userID,
+		Level:    1,
+		Timezone: DefaultTimezone,
+		Counters: make(map[string]int),
+		DayXP:    make(map[string]int),
+	}
+}
+
+// Location returns the user's timezone, falling back to UTC if it is unknown
+func (p *Progress) Location() *time.Location {
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Day returns the day of t in the user's timezone
+func (p *Progress) Day(t time.Time) string {
+	return t.In(p.Location()).Format(DayLayout)
+}
+
+// StreakOn returns the streak as seen on a day: a streak whose missed days
+// can no longer be covered by freeze tokens is broken
+func (p *Progress) StreakOn(day string) int {
+	if p.LastActiveDay == "" {
+		return 0
+	}
+	missed := daysBetween(p.LastActiveDay, day) - 1
+	if missed > p.FreezeTokens {
+		return 0
+	}
+	return p.CurrentStreak
+}
+
+// Metric returns the value of a badge metric
+func (p *Progress) Metric(name string) int {
+	switch name {
+	case MetricXP:
+		return p.XP
+	case MetricLevel:
+		return p.Level
+	case MetricStreak:
+		return p.CurrentStreak
+	case MetricLongestStreak:
+		return p.LongestStreak
+	}
+	return p.Counters[name]
+}
+
+// Badge is an achievement unlocked when a metric reaches a threshold
+type Badge struct {
+	ID          string `json:"id" yaml:"id"`
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description" yaml:"description"`
+	Metric      string `json:"metric" yaml:"metric"`
+	Threshold   int    `json:"threshold" yaml:"threshold"`
+}
+
+// UserBadge is a badge unlocked by a user
+type UserBadge struct {
+	BadgeID    string    `json:"badge_id"`
+	UnlockedAt time.Time `json:"unlocked_at"`
+}
+
+// BadgeProgress is a badge with the user's progress toward it
+type BadgeProgress struct {
+	Badge
+	Value      int        `json:"value"` // Current value of the metric, capped at the threshold
+	Unlocked   bool       `json:"unlocked"`
+	UnlockedAt *time.Time `json:"unlocked_at,omitempty"`
+}
+
+// Award is the outcome of recording an event
+type Award struct {
+	Event       EventType `json:"event"`
+	EventKey    string    `json:"-"`
+	OccurredAt  time.Time `json:"occurred_at"`
+	XP          int       `json:"xp"`
+	Level       int       `json:"level"`
+	LevelUp     bool      `json:"level_up"`
+	Streak      int       `json:"streak"`
+	FreezesUsed int       `json:"freezes_used,omitempty"`
+	Badges      []Badge   `json:"badges,omitempty"` // Newly unlocked
+}
+
+// Overview is the progress of a user with level thresholds and badges
+type Overview struct {
+	Progress    *Progress       `json:"progress"`
+	Streak      int             `json:"streak"` // As of today in the user's timezone
+	LevelXP     int             `json:"level_xp"`
+	NextLevelXP int             `json:"next_level_xp"`
+	Badges      []BadgeProgress `json:"badges"`
+}
+
+// LevelForXP returns the level reached with an amount of XP: level n starts
+// at base * (n-1)^2 XP
+func LevelForXP(xp, base int) int {
+	if xp <= 0 || base <= 0 {
+		return 1
+	}
+	return int(math.Sqrt(float64(xp)/float64(base))) + 1
+}
+
+// XPForLevel returns the XP at which a level starts
+func XPForLevel(level, base int) int {
+	return base * (level - 1) * (level - 1)
+}
+
+// daysBetween returns the number of days from one day to another, both
+// formatted with DayLayout
+func daysBetween(from, to string) int {
+	a, errA := time.Parse(DayLayout, from)
+	b, errB := time.Parse(DayLayout, to)
+	if errA != nil || errB != nil {
+		return 0
+	}
+	return int(b.Sub(a).Hours() / 24)
+}
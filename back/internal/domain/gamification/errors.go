@@ -0,0 +1,13 @@
+package gamification
+
+import "errors"
+
+// Domain errors
+var (
+	ErrProgressNotFound = errors.New("progress not found")
+	ErrVersionConflict  = errors.New("progress was updated concurrently")
+	ErrDuplicateEvent   = errors.New("event already recorded")
+	ErrInvalidEvent     = errors.New("invalid event")
+	ErrInvalidTimezone  = errors.New("invalid timezone")
+	ErrInvalidRules     = errors.New("invalid gamification rules")
+)
@@ -0,0 +1,20 @@
+package gamification
+
+import (
+	"context"
+)
+
+// Repository defines the interface for gamification data access
+type Repository interface {
+	// FindProgress retrieves the progress of a user
+	FindProgress(ctx context.Context, userID string) (*Progress, error)
+
+	// SaveProgress stores the progress of a user if it was not updated since
+	// it was read (ErrVersionConflict otherwise), along with the XP and the
+	// badges of an award when award is not nil. An award for an event key
+	// already recorded for the user is rejected with ErrDuplicateEvent.
+	SaveProgress(ctx context.Context, progress *Progress, award *Award) error
+
+	// ListBadges retrieves the badges unlocked by a user
+	ListBadges(ctx context.Context, userID string) ([]*UserBadge, error)
+}
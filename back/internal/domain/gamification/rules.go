@@ -0,0 +1,224 @@
+package gamification
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultRules is the rules file used when none is configured
+//
+//go:embed rules.yaml
+var defaultRules []byte
+
+// DefaultLevelBaseXP is the XP of level 2 when the rules do not set it
+const DefaultLevelBaseXP = 50
+
+// Rule awards XP for the events it matches
+type Rule struct {
+	Event    EventType           `yaml:"event"`
+	XP       int                 `yaml:"xp"`
+	DailyCap int                 `yaml:"daily_cap"` // 0 for no cap
+	When     map[string][]string `yaml:"when"`      // Accepted values per attribute
+	Counter  string              `yaml:"counter"`   // Optional counter incremented on match
+}
+
+// Matches reports whether the rule applies to an event
+func (r Rule) Matches(event Event) bool {
+	if r.Event != event.Type {
+		return false
+	}
+	for attribute, values := range r.When {
+		if !slices.Contains(values, event.Attributes[attribute]) {
+			return false
+		}
+	}
+	return true
+}
+
+// StreakRules configure how freeze tokens are earned
+type StreakRules struct {
+	FreezeEveryDays int `yaml:"freeze_every_days"` // 0 disables freezes
+	MaxFreezes      int `yaml:"max_freezes"`
+}
+
+// Rules are the declarative XP, streak and badge settings
+type Rules struct {
+	LevelBaseXP int         `yaml:"level_base_xp"`
+	XP          []Rule      `yaml:"xp"`
+	Streak      StreakRules `yaml:"streak"`
+	Badges      []Badge     `yaml:"badges"`
+}
+
+// DefaultRules returns the built-in rules
+func DefaultRules() (*Rules, error) {
+	return ParseRules(defaultRules)
+}
+
+// LoadRules reads the rules file at path, or the built-in rules if path is empty
+func LoadRules(path string) (*Rules, error) {
+	if path == "" {
+		return DefaultRules()
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gamification rules: %w", err)
+	}
+
+	return ParseRules(data)
+}
+
+// ParseRules parses and validates a YAML rules file
+func ParseRules(data []byte) (*Rules, error) {
+	var rules Rules
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRules, err)
+	}
+
+	if rules.LevelBaseXP == 0 {
+		rules.LevelBaseXP = DefaultLevelBaseXP
+	}
+	if err := rules.validate(); err != nil {
+		return nil, err
+	}
+
+	return &rules, nil
+}
+
+// validate checks the rules for unknown events and inconsistent values
+func (r *Rules) validate() error {
+	if r.LevelBaseXP < 0 {
+		return fmt.Errorf("%w: level_base_xp must be positive", ErrInvalidRules)
+	}
+	if r.Streak.FreezeEveryDays < 0 || r.Streak.MaxFreezes < 0 {
+		return fmt.Errorf("%w: streak settings must not be negative", ErrInvalidRules)
+	}
+
+	for i, rule := range r.XP {
+		if !rule.Event.IsValid() {
+			return fmt.Errorf("%w: xp rule %d has unknown event %q", ErrInvalidRules, i, rule.Event)
+		}
+		if rule.XP < 0 || rule.DailyCap < 0 {
+			return fmt.Errorf("%w: xp rule %d must not be negative", ErrInvalidRules, i)
+		}
+	}
+
+	seen := make(map[string]bool, len(r.Badges))
+	for _, badge := range r.Badges {
+		if badge.ID == "" || badge.Metric == "" || badge.Threshold <= 0 {
+			return fmt.Errorf("%w: badge %q needs an id, a metric and a positive threshold", ErrInvalidRules, badge.ID)
+		}
+		if seen[badge.ID] {
+			return fmt.Errorf("%w: duplicate badge %q", ErrInvalidRules, badge.ID)
+		}
+		seen[badge.ID] = true
+	}
+
+	return nil
+}
+
+// Apply records an event on the progress and returns what it earned.
+// unlocked holds the IDs of the badges the user already has.
+func (r *Rules) Apply(p *Progress, event Event, unlocked map[string]bool) *Award {
+	award := &Award{Event: event.Type, EventKey: event.Key, OccurredAt: event.OccurredAt}
+	award.FreezesUsed = r.advanceStreak(p, p.Day(event.OccurredAt))
+
+	p.Counters[string(event.Type)]++
+	for i, rule := range r.XP {
+		if !rule.Matches(event) {
+			continue
+		}
+		if rule.Counter != "" {
+			p.Counters[rule.Counter]++
+		}
+
+		xp := rule.XP
+		if rule.DailyCap > 0 {
+			key := string(rule.Event) + "#" + strconv.Itoa(i)
+			xp = max(min(xp, rule.DailyCap-p.DayXP[key]), 0)
+			p.DayXP[key] += xp
+		}
+		award.XP += xp
+	}
+
+	p.XP += award.XP
+	level := LevelForXP(p.XP, r.LevelBaseXP)
+	award.LevelUp = level > p.Level
+	p.Level = level
+
+	for _, badge := range r.Badges {
+		if !unlocked[badge.ID] && p.Metric(badge.Metric) >= badge.Threshold {
+			award.Badges = append(award.Badges, badge)
+		}
+	}
+
+	award.Level = p.Level
+	award.Streak = p.CurrentStreak
+	return award
+}
+
+// advanceStreak moves the streak to the day of an activity, spending freeze
+// tokens to cover missed days, and returns the number of tokens spent
+func (r *Rules) advanceStreak(p *Progress, day string) int {
+	used := 0
+	if p.LastActiveDay == "" {
+		p.CurrentStreak = 1
+	} else {
+		gap := daysBetween(p.LastActiveDay, day)
+		switch {
+		case gap <= 0:
+			// Same day, or a late event for a day already counted
+			return 0
+		case gap == 1:
+			p.CurrentStreak++
+		case gap-1 <= p.FreezeTokens:
+			used = gap - 1
+			p.FreezeTokens -= used
+			p.CurrentStreak++
+		default:
+			p.CurrentStreak = 1
+		}
+	}
+
+	p.LastActiveDay = day
+	p.DayXP = make(map[string]int)
+	p.LongestStreak = max(p.LongestStreak, p.CurrentStreak)
+
+	if every := r.Streak.FreezeEveryDays; every > 0 && p.CurrentStreak%every == 0 && p.FreezeTokens < r.Streak.MaxFreezes {
+		p.FreezeTokens++
+	}
+
+	return used
+}
+
+// Overview builds the progress overview of a user on a day
+func (r *Rules) Overview(p *Progress, badges []*UserBadge, day string) *Overview {
+	unlockedAt := make(map[string]*UserBadge, len(badges))
+	for _, b := range badges {
+		unlockedAt[b.BadgeID] = b
+	}
+
+	overview := &Overview{
+		Progress:    p,
+		Streak:      p.StreakOn(day),
+		LevelXP:     XPForLevel(p.Level, r.LevelBaseXP),
+		NextLevelXP: XPForLevel(p.Level+1, r.LevelBaseXP),
+		Badges:      make([]BadgeProgress, 0, len(r.Badges)),
+	}
+	for _, badge := range r.Badges {
+		progress := BadgeProgress{Badge: badge, Value: min(p.Metric(badge.Metric), badge.Threshold)}
+		if b, ok := unlockedAt[badge.ID]; ok {
+			progress.Unlocked = true
+			progress.UnlockedAt = &b.UnlockedAt
+			progress.Value = badge.Threshold
+		}
+		overview.Badges = append(overview.Badges, progress)
+	}
+
+	return overview
+}
@@ -0,0 +1,193 @@
+package gamification
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRules returns small rules exercising caps, conditions and freezes
+func testRules(t *testing.T) *Rules {
+	t.Helper()
+
+	rules, err := ParseRules([]byte(`
+level_base_xp: 10
+xp:
+  - event: word_searched
+    xp: 2
+    daily_cap: 3
+  - event: review_graded
+    xp: 1
+  - event: review_graded
+    xp: 4
+    when:
+      grade: [good, easy]
+    counter: reviews_recalled
+streak:
+  freeze_every_days: 3
+  max_freezes: 1
+badges:
+  - id: first_search
+    name: Curious Mind
+    metric: word_searched
+    threshold: 1
+  - id: recall_2
+    name: Recaller
+    metric: reviews_recalled
+    threshold: 2
+  - id: streak_3
+    name: Three Days
+    metric: longest_streak
+    threshold: 3
+`))
+	require.NoError(t, err)
+	return rules
+}
+
+// at returns an event of the given type at a UTC time
+func at(eventType EventType, value string, attributes map[string]string) Event {
+	occurredAt, _ := time.Parse(time.RFC3339, value)
+	return Event{Type: eventType, UserID: "user-1", OccurredAt: occurredAt, Attributes: attributes}
+}
+
+func TestDefaultRules(t *testing.T) {
+	rules, err := DefaultRules()
+
+	require.NoError(t, err)
+	assert.Equal(t, DefaultLevelBaseXP, rules.LevelBaseXP)
+	assert.NotEmpty(t, rules.XP)
+	assert.NotEmpty(t, rules.Badges)
+}
+
+func TestParseRules_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+	}{
+		{"malformed", "xp: ["},
+		{"unknown event", "xp:\n  - event: word_deleted\n    xp: 1\n"},
+		{"negative xp", "xp:\n  - event: word_saved\n    xp: -1\n"},
+		{"badge without metric", "badges:\n  - id: b\n    threshold: 1\n"},
+		{"duplicate badge", "badges:\n  - {id: b, metric: xp, threshold: 1}\n  - {id: b, metric: xp, threshold: 2}\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRules([]byte(tt.yaml))
+			assert.ErrorIs(t, err, ErrInvalidRules)
+		})
+	}
+}
+
+func TestLevelForXP(t *testing.T) {
+	assert.Equal(t, 1, LevelForXP(0, 50))
+	assert.Equal(t, 1, LevelForXP(49, 50))
+	assert.Equal(t, 2, LevelForXP(50, 50))
+	assert.Equal(t, 3, LevelForXP(200, 50))
+	assert.Equal(t, 200, XPForLevel(3, 50))
+}
+
+func TestApply_XPRules(t *testing.T) {
+	rules := testRules(t)
+	p := NewProgress("user-1")
+
+	// Daily cap: 2 + 1 XP, then nothing
+	a1 := rules.Apply(p, at(EventWordSearched, "2024-03-01T09:00:00Z", nil), nil)
+	a2 := rules.Apply(p, at(EventWordSearched, "2024-03-01T10:00:00Z", nil), nil)
+	a3 := rules.Apply(p, at(EventWordSearched, "2024-03-01T11:00:00Z", nil), nil)
+	assert.Equal(t, []int{2, 1, 0}, []int{a1.XP, a2.XP, a3.XP})
+	assert.Equal(t, []Badge{rules.Badges[0]}, a1.Badges)
+
+	// The cap resets on the next day
+	a4 := rules.Apply(p, at(EventWordSearched, "2024-03-02T09:00:00Z", nil), map[string]bool{"first_search": true})
+	assert.Equal(t, 2, a4.XP)
+	assert.Empty(t, a4.Badges)
+
+	// Conditional rule and its counter
+	a5 := rules.Apply(p, at(EventReviewGraded, "2024-03-02T10:00:00Z", map[string]string{AttributeGrade: "good"}), nil)
+	a6 := rules.Apply(p, at(EventReviewGraded, "2024-03-02T10:01:00Z", map[string]string{AttributeGrade: "again"}), nil)
+	assert.Equal(t, 5, a5.XP)
+	assert.Equal(t, 1, a6.XP)
+	assert.Equal(t, 1, p.Counters["reviews_recalled"])
+	assert.Equal(t, 2, p.Counters[string(EventReviewGraded)])
+
+	// 11 XP at base 10 is level 2
+	assert.Equal(t, 11, p.XP)
+	assert.Equal(t, 2, p.Level)
+	assert.True(t, a5.LevelUp)
+}
+
+func TestApply_Streak(t *testing.T) {
+	tests := []struct {
+		name           string
+		days           []string
+		expectedStreak int
+		expectedTokens int
+		expectedUsed   int
+	}{
+		{"consecutive days", []string{"2024-03-01T09:00:00Z", "2024-03-02T09:00:00Z"}, 2, 0, 0},
+		{"same day counts once", []string{"2024-03-01T09:00:00Z", "2024-03-01T20:00:00Z"}, 1, 0, 0},
+		{"missed day breaks the streak", []string{"2024-03-01T09:00:00Z", "2024-03-03T09:00:00Z"}, 1, 0, 0},
+		{"freeze earned every 3 days", []string{"2024-03-01T09:00:00Z", "2024-03-02T09:00:00Z", "2024-03-03T09:00:00Z"}, 3, 1, 0},
+		{"freeze covers a missed day", []string{"2024-03-01T09:00:00Z", "2024-03-02T09:00:00Z", "2024-03-03T09:00:00Z", "2024-03-05T09:00:00Z"}, 4, 0, 1},
+		{"freeze does not cover two missed days", []string{"2024-03-01T09:00:00Z", "2024-03-02T09:00:00Z", "2024-03-03T09:00:00Z", "2024-03-06T09:00:00Z"}, 1, 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := testRules(t)
+			p := NewProgress("user-1")
+
+			var award *Award
+			for _, day := range tt.days {
+				award = rules.Apply(p, at(EventReviewGraded, day, nil), nil)
+			}
+
+			assert.Equal(t, tt.expectedStreak, p.CurrentStreak)
+			assert.Equal(t, tt.expectedStreak, award.Streak)
+			assert.Equal(t, tt.expectedTokens, p.FreezeTokens)
+			assert.Equal(t, tt.expectedUsed, award.FreezesUsed)
+			assert.GreaterOrEqual(t, p.LongestStreak, p.CurrentStreak)
+		})
+	}
+}
+
+func TestApply_TimezoneDayBoundaries(t *testing.T) {
+	rules := testRules(t)
+
+	// 23:30 UTC and 00:30 UTC the next day are the same day in New York...
+	ny := NewProgress("user-1")
+	ny.Timezone = "America/New_York"
+	rules.Apply(ny, at(EventWordSearched, "2024-03-01T23:30:00Z", nil), nil)
+	rules.Apply(ny, at(EventWordSearched, "2024-03-02T00:30:00Z", nil), nil)
+	assert.Equal(t, 1, ny.CurrentStreak)
+	assert.Equal(t, "2024-03-01", ny.LastActiveDay)
+
+	// ...but two days in UTC
+	utc := NewProgress("user-1")
+	rules.Apply(utc, at(EventWordSearched, "2024-03-01T23:30:00Z", nil), nil)
+	rules.Apply(utc, at(EventWordSearched, "2024-03-02T00:30:00Z", nil), nil)
+	assert.Equal(t, 2, utc.CurrentStreak)
+}
+
+func TestOverview(t *testing.T) {
+	rules := testRules(t)
+	p := NewProgress("user-1")
+	p.XP, p.Level = 15, 2
+	p.CurrentStreak, p.LongestStreak, p.LastActiveDay = 4, 4, "2024-03-01"
+	p.Counters["reviews_recalled"] = 1
+	unlockedAt := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	overview := rules.Overview(p, []*UserBadge{{BadgeID: "streak_3", UnlockedAt: unlockedAt}}, "2024-03-03")
+
+	assert.Equal(t, 0, overview.Streak) // A day was missed without freeze
+	assert.Equal(t, 10, overview.LevelXP)
+	assert.Equal(t, 40, overview.NextLevelXP)
+	require.Len(t, overview.Badges, 3)
+	assert.False(t, overview.Badges[1].Unlocked)
+	assert.Equal(t, 1, overview.Badges[1].Value)
+	assert.True(t, overview.Badges[2].Unlocked)
+	assert.Equal(t, &unlockedAt, overview.Badges[2].UnlockedAt)
+}
@@ -0,0 +1,17 @@
+package gamification
+
+import (
+	"context"
+)
+
+// Service defines the interface for gamification business logic
+type Service interface {
+	// Record awards the XP, streak and badges earned by an event
+	Record(ctx context.Context, event Event) (*Award, error)
+
+	// GetOverview retrieves the progress of a user with level thresholds and badges
+	GetOverview(ctx context.Context, userID string) (*Overview, error)
+
+	// SetTimezone sets the IANA timezone used for the user's day boundaries
+	SetTimezone(ctx context.Context, userID, timezone string) (*Progress, error)
+}
@@ -0,0 +1,154 @@
+package gamification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// maxSaveAttempts bounds the retries of a progress update that lost a race
+const maxSaveAttempts = 3
+
+// Ensure service implements Service interface
+var _ Service = (*service)(nil)
+
+// service implements the Service interface
+type service struct {
+	repo   Repository
+	rules  *Rules
+	clock  func() time.Time
+	logger zerolog.Logger
+}
+
+// NewService creates a new gamification service
+func NewService(repo Repository, rules *Rules, clock func() time.Time, logger zerolog.Logger) Service {
+	if clock == nil {
+		clock = time.Now
+	}
+	return &service{
+		repo:   repo,
+		rules:  rules,
+		clock:  clock,
+		logger: logger.With().Str("component", "gamification_service").Logger(),
+	}
+}
+
+// Record awards the XP, streak and badges earned by an event. An event whose
+// key was already recorded earns nothing and returns a nil award.
+func (s *service) Record(ctx context.Context, event Event) (*Award, error) {
+	s.logger.Debug().Str("userID", event.UserID).Str("event", string(event.Type)).Msg("Recording event")
+
+	if event.UserID == "" || !event.Type.IsValid() {
+		return nil, ErrInvalidEvent
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = s.clock()
+	}
+
+	for attempt := 1; ; attempt++ {
+		award, err := s.record(ctx, event)
+		if errors.Is(err, ErrDuplicateEvent) {
+			s.logger.Debug().Str("userID", event.UserID).Str("key", event.Key).Msg("Event already recorded")
+			return nil, nil
+		}
+		if !errors.Is(err, ErrVersionConflict) || attempt == maxSaveAttempts {
+			if err != nil {
+				s.logger.Error().Err(err).Str("userID", event.UserID).Msg("Failed to record event")
+				return nil, fmt.Errorf("failed to record event: %w", err)
+			}
+			return award, nil
+		}
+		s.logger.Debug().Str("userID", event.UserID).Int("attempt", attempt).Msg("Progress updated concurrently, retrying")
+	}
+}
+
+// record applies an event to the latest progress and saves it
+func (s *service) record(ctx context.Context, event Event) (*Award, error) {
+	progress, err := s.findProgress(ctx, event.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	badges, err := s.repo.ListBadges(ctx, event.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list badges: %w", err)
+	}
+	unlocked := make(map[string]bool, len(badges))
+	for _, b := range badges {
+		unlocked[b.BadgeID] = true
+	}
+
+	award := s.rules.Apply(progress, event, unlocked)
+	progress.UpdatedAt = s.clock()
+	if err := s.repo.SaveProgress(ctx, progress, award); err != nil {
+		return nil, err
+	}
+
+	return award, nil
+}
+
+// GetOverview retrieves the progress of a user with level thresholds and badges
+func (s *service) GetOverview(ctx context.Context, userID string) (*Overview, error) {
+	s.logger.Debug().Str("userID", userID).Msg("Getting progress overview")
+
+	progress, err := s.findProgress(ctx, userID)
+	if err != nil {
+		s.logger.Error().Err(err).Str("userID", userID).Msg("Failed to find progress")
+		return nil, fmt.Errorf("failed to find progress: %w", err)
+	}
+
+	badges, err := s.repo.ListBadges(ctx, userID)
+	if err != nil {
+		s.logger.Error().Err(err).Str("userID", userID).Msg("Failed to list badges")
+		return nil, fmt.Errorf("failed to list badges: %w", err)
+	}
+
+	return s.rules.Overview(progress, badges, progress.Day(s.clock())), nil
+}
+
+// SetTimezone sets the IANA timezone used for the user's day boundaries
+func (s *service) SetTimezone(ctx context.Context, userID, timezone string) (*Progress, error) {
+	s.logger.Debug().Str("userID", userID).Str("timezone", timezone).Msg("Setting timezone")
+
+	if timezone == "" {
+		return nil, ErrInvalidTimezone
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidTimezone, timezone)
+	}
+
+	for attempt := 1; ; attempt++ {
+		progress, err := s.findProgress(ctx, userID)
+		if err != nil {
+			s.logger.Error().Err(err).Str("userID", userID).Msg("Failed to find progress")
+			return nil, fmt.Errorf("failed to find progress: %w", err)
+		}
+
+		progress.Timezone = timezone
+		progress.UpdatedAt = s.clock()
+		err = s.repo.SaveProgress(ctx, progress, nil)
+		if err == nil {
+			return progress, nil
+		}
+		if !errors.Is(err, ErrVersionConflict) || attempt == maxSaveAttempts {
+			s.logger.Error().Err(err).Str("userID", userID).Msg("Failed to save timezone")
+			return nil, fmt.Errorf("failed to save timezone: %w", err)
+		}
+	}
+}
+
+// findProgress retrieves the progress of a user, or a fresh one if the user
+// has no activity yet
+func (s *service) findProgress(ctx context.Context, userID string) (*Progress, error) {
+	progress, err := s.repo.FindProgress(ctx, userID)
+	if errors.Is(err, ErrProgressNotFound) {
+		return NewProgress(userID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
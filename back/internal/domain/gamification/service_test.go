@@ -0,0 +1,209 @@
+package gamification
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+// MockRepository is a mock implementation of the Repository interface
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) FindProgress(ctx context.Context, userID string) (*Progress, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Progress), args.Error(1)
+}
+
+func (m *MockRepository) SaveProgress(ctx context.Context, progress *Progress, award *Award) error {
+	args := m.Called(ctx, progress, award)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListBadges(ctx context.Context, userID string) ([]*UserBadge, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*UserBadge), args.Error(1)
+}
+
+// setupTestService creates a service with a mock repository for testing
+func setupTestService(t *testing.T) (*MockRepository, Service) {
+	repo := new(MockRepository)
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	clock := func() time.Time { return testNow }
+	return repo, NewService(repo, testRules(t), clock, logger)
+}
+
+func TestRecord(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("first activity", func(t *testing.T) {
+		// Setup
+		repo, svc := setupTestService(t)
+		repo.On("FindProgress", ctx, "user-1").Return(nil, ErrProgressNotFound)
+		repo.On("ListBadges", ctx, "user-1").Return([]*UserBadge{}, nil)
+		repo.On("SaveProgress", ctx, mock.MatchedBy(func(p *Progress) bool {
+			return p.XP == 2 && p.CurrentStreak == 1 && p.LastActiveDay == "2024-03-01" && p.Version == 0
+		}), mock.MatchedBy(func(a *Award) bool {
+			return a.XP == 2 && len(a.Badges) == 1 && a.OccurredAt.Equal(testNow)
+		})).Return(nil)
+
+		// Execute
+		award, err := svc.Record(ctx, Event{Type: EventWordSearched, UserID: "user-1"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 2, award.XP)
+		assert.Equal(t, "first_search", award.Badges[0].ID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("retries on concurrent update", func(t *testing.T) {
+		// Setup
+		repo, svc := setupTestService(t)
+		repo.On("FindProgress", ctx, "user-1").Return(nil, ErrProgressNotFound).Once()
+		repo.On("FindProgress", ctx, "user-1").Return(&Progress{
+			UserID: "user-1", XP: 2, Level: 1, Timezone: "UTC", Version: 1,
+			Counters: map[string]int{}, DayXP: map[string]int{},
+		}, nil).Once()
+		repo.On("ListBadges", ctx, "user-1").Return([]*UserBadge{}, nil)
+		repo.On("SaveProgress", ctx, mock.Anything, mock.Anything).Return(ErrVersionConflict).Once()
+		repo.On("SaveProgress", ctx, mock.MatchedBy(func(p *Progress) bool { return p.Version == 1 }), mock.Anything).Return(nil).Once()
+
+		// Execute
+		_, err := svc.Record(ctx, Event{Type: EventReviewGraded, UserID: "user-1"})
+
+		// Assert
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("gives up after repeated conflicts", func(t *testing.T) {
+		// Setup
+		repo, svc := setupTestService(t)
+		repo.On("FindProgress", ctx, "user-1").Return(nil, ErrProgressNotFound)
+		repo.On("ListBadges", ctx, "user-1").Return([]*UserBadge{}, nil)
+		repo.On("SaveProgress", ctx, mock.Anything, mock.Anything).Return(ErrVersionConflict)
+
+		// Execute
+		award, err := svc.Record(ctx, Event{Type: EventReviewGraded, UserID: "user-1"})
+
+		// Assert
+		assert.Nil(t, award)
+		assert.ErrorIs(t, err, ErrVersionConflict)
+		repo.AssertNumberOfCalls(t, "SaveProgress", maxSaveAttempts)
+	})
+
+	t.Run("event already recorded", func(t *testing.T) {
+		// Setup
+		repo, svc := setupTestService(t)
+		repo.On("FindProgress", ctx, "user-1").Return(nil, ErrProgressNotFound)
+		repo.On("ListBadges", ctx, "user-1").Return([]*UserBadge{}, nil)
+		repo.On("SaveProgress", ctx, mock.Anything, mock.MatchedBy(func(a *Award) bool {
+			return a.EventKey == "log-1"
+		})).Return(ErrDuplicateEvent)
+
+		// Execute
+		award, err := svc.Record(ctx, Event{Type: EventReviewGraded, UserID: "user-1", Key: "log-1"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Nil(t, award)
+		repo.AssertNumberOfCalls(t, "SaveProgress", 1)
+	})
+
+	t.Run("invalid event", func(t *testing.T) {
+		// Setup
+		repo, svc := setupTestService(t)
+
+		// Execute
+		_, errType := svc.Record(ctx, Event{Type: "word_deleted", UserID: "user-1"})
+		_, errUser := svc.Record(ctx, Event{Type: EventWordSaved})
+
+		// Assert
+		assert.ErrorIs(t, errType, ErrInvalidEvent)
+		assert.ErrorIs(t, errUser, ErrInvalidEvent)
+		repo.AssertNotCalled(t, "FindProgress", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetOverview(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("user without activity", func(t *testing.T) {
+		// Setup
+		repo, svc := setupTestService(t)
+		repo.On("FindProgress", ctx, "user-1").Return(nil, ErrProgressNotFound)
+		repo.On("ListBadges", ctx, "user-1").Return([]*UserBadge{}, nil)
+
+		// Execute
+		overview, err := svc.GetOverview(ctx, "user-1")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, overview.Progress.Level)
+		assert.Equal(t, 0, overview.Streak)
+		assert.Len(t, overview.Badges, 3)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		// Setup
+		repo, svc := setupTestService(t)
+		repo.On("FindProgress", ctx, "user-1").Return(nil, errors.New("db down"))
+
+		// Execute
+		overview, err := svc.GetOverview(ctx, "user-1")
+
+		// Assert
+		assert.Nil(t, overview)
+		assert.Error(t, err)
+	})
+}
+
+func TestSetTimezone(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("valid timezone", func(t *testing.T) {
+		// Setup
+		repo, svc := setupTestService(t)
+		repo.On("FindProgress", ctx, "user-1").Return(nil, ErrProgressNotFound)
+		repo.On("SaveProgress", ctx, mock.MatchedBy(func(p *Progress) bool {
+			return p.Timezone == "Europe/Paris"
+		}), (*Award)(nil)).Return(nil)
+
+		// Execute
+		progress, err := svc.SetTimezone(ctx, "user-1", "Europe/Paris")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "Europe/Paris", progress.Timezone)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("unknown timezone", func(t *testing.T) {
+		// Setup
+		repo, svc := setupTestService(t)
+
+		// Execute
+		progress, err := svc.SetTimezone(ctx, "user-1", "Mars/Olympus_Mons")
+
+		// Assert
+		assert.Nil(t, progress)
+		assert.ErrorIs(t, err, ErrInvalidTimezone)
+		repo.AssertNotCalled(t, "SaveProgress", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
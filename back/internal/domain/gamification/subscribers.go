@@ -0,0 +1,90 @@
+package gamification
+
+import (
+	"context"
+	"strconv"
+
+	"voconsteroid/internal/domain/event"
+	"voconsteroid/internal/domain/quiz"
+	"voconsteroid/internal/domain/review"
+	"voconsteroid/internal/domain/savedword"
+	"voconsteroid/internal/domain/word"
+)
+
+// Subscriber names of gamification in the event outbox
+const (
+	SubscriberWordSearched  = "gamification.word_searched"
+	SubscriberWordSaved     = "gamification.word_saved"
+	SubscriberReviewGraded  = "gamification.review_graded"
+	SubscriberQuizCompleted = "gamification.quiz_completed"
+)
+
+// Subscribe records the activities reported by domain events. Each activity
+// is keyed by the ID of what it created, so that redeliveries earn nothing.
+//
+// Quiz answers grade reviews too, but only reviews from the review queue
+// earn review XP: a quiz earns XP once, when it is completed.
+func Subscribe(bus *event.Bus, svc Service) error {
+	if err := event.SubscribeAsync(bus, SubscriberWordSearched, func(ctx context.Context, e word.WordSearched) error {
+		_, err := svc.Record(ctx, Event{
+			Type:       EventWordSearched,
+			UserID:     e.UserID,
+			Key:        e.ID,
+			OccurredAt: e.SearchedAt,
+		})
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if err := event.SubscribeAsync(bus, SubscriberWordSaved, func(ctx context.Context, e savedword.SavedWordAdded) error {
+		_, err := svc.Record(ctx, Event{
+			Type:       EventWordSaved,
+			UserID:     e.SavedWord.UserID,
+			Key:        e.SavedWord.ID,
+			OccurredAt: e.SavedWord.SavedAt,
+		})
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if err := event.SubscribeAsync(bus, SubscriberReviewGraded, func(ctx context.Context, e review.ReviewGraded) error {
+		if e.Source != review.SourceQueue {
+			return nil
+		}
+		_, err := svc.Record(ctx, Event{
+			Type:       EventReviewGraded,
+			UserID:     e.Log.UserID,
+			Key:        e.Log.ID,
+			OccurredAt: e.Log.ReviewedAt,
+			Attributes: map[string]string{AttributeGrade: e.Log.Grade.String()},
+		})
+		return err
+	}); err != nil {
+		return err
+	}
+
+	return event.SubscribeAsync(bus, SubscriberQuizCompleted, func(ctx context.Context, e quiz.QuizCompleted) error {
+		_, err := svc.Record(ctx, quizCompletedEvent(e.Session))
+		return err
+	})
+}
+
+// quizCompletedEvent describes a completed quiz for gamification rules
+func quizCompletedEvent(session *quiz.Session) Event {
+	score := session.Score()
+	e := Event{
+		Type:   EventQuizCompleted,
+		UserID: session.UserID,
+		Key:    session.ID,
+		Attributes: map[string]string{
+			AttributeScore:   strconv.Itoa(score),
+			AttributePerfect: strconv.FormatBool(score == 100),
+		},
+	}
+	if session.CompletedAt != nil {
+		e.OccurredAt = *session.CompletedAt
+	}
+	return e
+}
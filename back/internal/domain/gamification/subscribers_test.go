@@ -0,0 +1,121 @@
+package gamification
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"voconsteroid/internal/domain/event"
+	"voconsteroid/internal/domain/quiz"
+	"voconsteroid/internal/domain/review"
+	"voconsteroid/internal/domain/savedword"
+	"voconsteroid/internal/domain/word"
+)
+
+// recordingService is a Service that reports the recorded events on a channel
+type recordingService struct {
+	Service
+	recorded chan Event
+}
+
+func (s *recordingService) Record(_ context.Context, e Event) (*Award, error) {
+	s.recorded <- e
+	return &Award{}, nil
+}
+
+// setupSubscribedBus creates an in-memory bus with gamification subscribed
+func setupSubscribedBus(t *testing.T) (*event.Bus, chan Event) {
+	svc := &recordingService{recorded: make(chan Event, 1)}
+	bus := event.NewBus(nil, func() time.Time { return testNow }, zerolog.New(zerolog.NewTestWriter(t)))
+	require.NoError(t, Subscribe(bus, svc))
+	return bus, svc.recorded
+}
+
+// awaitRecord waits for the asynchronous subscriber to record an event
+func awaitRecord(t *testing.T, recorded chan Event) Event {
+	select {
+	case e := <-recorded:
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("no event recorded")
+		return Event{}
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	ctx := context.Background()
+	completedAt := testNow.Add(time.Minute)
+
+	tests := []struct {
+		name      string
+		published event.Event
+		expected  Event
+	}{
+		{
+			name:      "word searched",
+			published: word.WordSearched{ID: "search-1", UserID: "user-1", Word: &word.Word{ID: "word-1"}, SearchedAt: testNow},
+			expected:  Event{Type: EventWordSearched, UserID: "user-1", Key: "search-1", OccurredAt: testNow},
+		},
+		{
+			name:      "word saved",
+			published: savedword.SavedWordAdded{SavedWord: &savedword.SavedWord{ID: "sw-1", UserID: "user-1", SavedAt: testNow}},
+			expected:  Event{Type: EventWordSaved, UserID: "user-1", Key: "sw-1", OccurredAt: testNow},
+		},
+		{
+			name: "review graded from the queue",
+			published: review.ReviewGraded{
+				Log:    &review.Log{ID: "log-1", UserID: "user-1", Grade: review.GradeEasy, ReviewedAt: testNow},
+				Card:   &review.Card{SavedWordID: "sw-1"},
+				Source: review.SourceQueue,
+			},
+			expected: Event{Type: EventReviewGraded, UserID: "user-1", Key: "log-1", OccurredAt: testNow, Attributes: map[string]string{AttributeGrade: "easy"}},
+		},
+		{
+			name: "quiz completed",
+			published: quiz.QuizCompleted{Session: &quiz.Session{
+				ID: "session-1", UserID: "user-1", Status: quiz.SessionCompleted,
+				QuestionCount: 4, AnsweredCount: 4, CorrectCount: 3, CompletedAt: &completedAt,
+			}},
+			expected: Event{Type: EventQuizCompleted, UserID: "user-1", Key: "session-1", OccurredAt: completedAt, Attributes: map[string]string{
+				AttributeScore: "75", AttributePerfect: "false",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			bus, recorded := setupSubscribedBus(t)
+
+			// Execute
+			require.NoError(t, bus.Publish(ctx, tt.published))
+
+			// Assert
+			assert.Equal(t, tt.expected, awaitRecord(t, recorded))
+		})
+	}
+
+	t.Run("ignores reviews graded by quizzes", func(t *testing.T) {
+		// Setup
+		bus, recorded := setupSubscribedBus(t)
+		log := &review.Log{ID: "log-1", UserID: "user-1", Grade: review.GradeGood, ReviewedAt: testNow}
+
+		// Execute
+		require.NoError(t, bus.Publish(ctx,
+			review.ReviewGraded{Log: log, Card: &review.Card{}, Source: review.SourceQuiz},
+			word.WordSearched{UserID: "user-1", SearchedAt: testNow},
+		))
+
+		// Assert: only the search is recorded
+		assert.Equal(t, EventWordSearched, awaitRecord(t, recorded).Type)
+		select {
+		case e := <-recorded:
+			t.Fatalf("unexpected event recorded: %v", e.Type)
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+}
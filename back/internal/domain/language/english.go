@@ -0,0 +1,14 @@
+package language
+
+import (
+	"voconsteroid/internal/domain/word/languages/english"
+)
+
+// English is the English language, which has no grammatical gender
+var English = Language{
+	Code:       "en",
+	Name:       "English",
+	NativeName: "English",
+	WordTypes:  names(english.WordTypes),
+	Sources:    []string{"enwiktionary"},
+}
@@ -0,0 +1,18 @@
+package language
+
+import (
+	"github.com/aaaton/golem/v4/dicts/fr"
+
+	"voconsteroid/internal/domain/word/languages/french"
+)
+
+// French is the French language, lemmatized with the golem dictionary
+var French = Language{
+	Code:       "fr",
+	Name:       "French",
+	NativeName: "Français",
+	WordTypes:  names(french.WordTypes),
+	Genders:    names(french.Genders),
+	Sources:    []string{"frwiktionary"},
+	Lemmas:     fr.New(),
+}
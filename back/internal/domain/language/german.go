@@ -0,0 +1,19 @@
+package language
+
+import (
+	"strings"
+
+	"voconsteroid/internal/domain/word/languages/german"
+)
+
+// German is the German language. Its nouns are capitalized, so searched
+// texts are only trimmed.
+var German = Language{
+	Code:       "de",
+	Name:       "German",
+	NativeName: "Deutsch",
+	WordTypes:  names(german.WordTypes),
+	Genders:    names(german.Genders),
+	Sources:    []string{"dewiktionary"},
+	Normalize:  strings.TrimSpace,
+}
@@ -0,0 +1,15 @@
+package language
+
+import (
+	"voconsteroid/internal/domain/word/languages/italian"
+)
+
+// Italian is the Italian language
+var Italian = Language{
+	Code:       "it",
+	Name:       "Italian",
+	NativeName: "Italiano",
+	WordTypes:  names(italian.WordTypes),
+	Genders:    names(italian.Genders),
+	Sources:    []string{"itwiktionary"},
+}
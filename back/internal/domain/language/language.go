@@ -0,0 +1,133 @@
+// Package language is the registry of the languages words can be looked up
+// in: what the application knows of each of them and where their words come
+// from. Adding a language is registering it in Default.
+package language
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/aaaton/golem/v4"
+)
+
+// Language is what the application knows of a language
+type Language struct {
+	Code       string   // ISO 639-1 code, as words store their language
+	Name       string   // Name in English
+	NativeName string   // Name in the language itself
+	WordTypes  []string // Word types its definitions may have
+	Genders    []string // Genders its definitions may have, none when it has no grammatical gender
+	Sources    []string // Dictionary sources its words are fetched from, as named in word provenance
+
+	Lemmas    golem.LanguagePack       // Lemmas of the language, nil when there are none
+	Normalize func(text string) string // Normalizes searched texts, lowercasing and trimming them when nil
+}
+
+// IsValidWordType reports whether a definition of the language may have the word type
+func (l Language) IsValidWordType(wordType string) bool {
+	return slices.Contains(l.WordTypes, wordType)
+}
+
+// IsValidGender reports whether a definition of the language may have the
+// gender. Genders are not checked for languages without grammatical gender.
+func (l Language) IsValidGender(gender string) bool {
+	return len(l.Genders) == 0 || slices.Contains(l.Genders, gender)
+}
+
+// NormalizeText normalizes a searched text of the language
+func (l Language) NormalizeText(text string) string {
+	if l.Normalize != nil {
+		return l.Normalize(text)
+	}
+	return lowerTrim(text)
+}
+
+// lowerTrim lowercases and trims a text
+func lowerTrim(text string) string {
+	return strings.ToLower(strings.TrimSpace(text))
+}
+
+// Registry holds the supported languages, in the order they were registered
+type Registry struct {
+	languages []Language
+}
+
+// NewRegistry creates a registry of the languages
+func NewRegistry(languages ...Language) *Registry {
+	r := &Registry{}
+	for _, l := range languages {
+		r.Register(l)
+	}
+	return r
+}
+
+// Register adds a language to the registry, replacing the one of the same code
+func (r *Registry) Register(l Language) {
+	for i, registered := range r.languages {
+		if registered.Code == l.Code {
+			r.languages[i] = l
+			return
+		}
+	}
+	r.languages = append(r.languages, l)
+}
+
+// Get returns the language of a code and whether it is registered
+func (r *Registry) Get(code string) (Language, bool) {
+	for _, l := range r.languages {
+		if l.Code == code {
+			return l, true
+		}
+	}
+	return Language{}, false
+}
+
+// IsSupported reports whether the language of a code is registered
+func (r *Registry) IsSupported(code string) bool {
+	_, ok := r.Get(code)
+	return ok
+}
+
+// All returns the registered languages, in registration order
+func (r *Registry) All() []Language {
+	return slices.Clone(r.languages)
+}
+
+// Codes returns the codes of the registered languages, in registration order
+func (r *Registry) Codes() []string {
+	codes := make([]string, len(r.languages))
+	for i, l := range r.languages {
+		codes[i] = l.Code
+	}
+	return codes
+}
+
+// Primary returns the language used when a request names none: the first
+// one registered
+func (r *Registry) Primary() Language {
+	if len(r.languages) == 0 {
+		return Language{}
+	}
+	return r.languages[0]
+}
+
+// Normalize normalizes a searched text of the language of a code, lowercasing
+// and trimming it for unknown languages
+func (r *Registry) Normalize(code, text string) string {
+	if l, ok := r.Get(code); ok {
+		return l.NormalizeText(text)
+	}
+	return lowerTrim(text)
+}
+
+// Default is the registry of the languages of the application
+var Default = NewRegistry(French, English, Spanish, German, Italian)
+
+// names returns the values of an enumeration as strings
+func names[T ~string](values []T) []string {
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = string(v)
+	}
+	return result
+}
@@ -0,0 +1,50 @@
+package language
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	// Setup
+	registry := NewRegistry(
+		Language{Code: "fr", Name: "French", WordTypes: []string{"nom"}, Genders: []string{"masculin"}},
+		Language{Code: "en", Name: "English", WordTypes: []string{"noun"}},
+	)
+
+	// Execute
+	registry.Register(Language{Code: "en", Name: "English", WordTypes: []string{"noun", "verb"}})
+	registry.Register(Language{Code: "de", Name: "German", Normalize: func(text string) string { return text }})
+
+	// Assert
+	assert.Equal(t, []string{"fr", "en", "de"}, registry.Codes(), "registering a code again replaces its language in place")
+	assert.Equal(t, "fr", registry.Primary().Code)
+	assert.True(t, registry.IsSupported("de"))
+	assert.False(t, registry.IsSupported("xx"))
+
+	english, ok := registry.Get("en")
+	assert.True(t, ok)
+	assert.True(t, english.IsValidWordType("verb"))
+	assert.False(t, english.IsValidWordType("nom"))
+	assert.True(t, english.IsValidGender("masculin"), "genders are not checked without grammatical gender")
+
+	french, _ := registry.Get("fr")
+	assert.False(t, french.IsValidGender("neutre"))
+
+	assert.Equal(t, "maison", registry.Normalize("fr", "  Maison "))
+	assert.Equal(t, "Haus", registry.Normalize("de", "Haus"))
+	assert.Equal(t, "casa", registry.Normalize("xx", "Casa"), "unknown languages are lowercased and trimmed")
+}
+
+func TestDefault(t *testing.T) {
+	assert.Equal(t, []string{"fr", "en", "es", "de", "it"}, Default.Codes())
+	for _, l := range Default.All() {
+		assert.NotEmpty(t, l.Name, l.Code)
+		assert.NotEmpty(t, l.WordTypes, l.Code)
+		assert.NotEmpty(t, l.Sources, l.Code)
+	}
+	french, _ := Default.Get("fr")
+	assert.NotNil(t, french.Lemmas)
+	assert.Equal(t, "Haus", Default.Normalize("de", " Haus "), "German nouns keep their capital")
+}
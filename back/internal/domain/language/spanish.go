@@ -0,0 +1,15 @@
+package language
+
+import (
+	"voconsteroid/internal/domain/word/languages/spanish"
+)
+
+// Spanish is the Spanish language
+var Spanish = Language{
+	Code:       "es",
+	Name:       "Spanish",
+	NativeName: "Español",
+	WordTypes:  names(spanish.WordTypes),
+	Genders:    names(spanish.Genders),
+	Sources:    []string{"eswiktionary"},
+}
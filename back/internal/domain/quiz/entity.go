@@ -0,0 +1,238 @@
+package quiz
+
+import (
+	"math"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"golang.org/x/text/unicode/norm"
+)
+
+// QuestionType identifies what a question asks about a word
+type QuestionType string
+
+// Question types
+const (
+	DefinitionToWord QuestionType = "definition_to_word" // Pick the word matching a definition
+	WordToDefinition QuestionType = "word_to_definition" // Pick the definition of a word
+	FillBlank        QuestionType = "fill_blank"         // Type the word missing from an example
+	Synonym          QuestionType = "synonym"            // Pick a synonym of a word
+	Antonym          QuestionType = "antonym"            // Pick an antonym of a word
+	Gender           QuestionType = "gender"             // Pick the grammatical gender of a noun
+	Translation      QuestionType = "translation"        // Type a translation of a word
+)
+
+// AllQuestionTypes lists every question type, in the order they are tried
+var AllQuestionTypes = []QuestionType{
+	DefinitionToWord, WordToDefinition, FillBlank, Synonym, Antonym, Gender, Translation,
+}
+
+// Quiz size limits
+const (
+	ChoiceCount          = 4   // Choices offered by multiple choice questions, answer included
+	DefaultQuestionCount = 10  // Questions generated when no count is given
+	MaxQuestionCount     = 50  // Questions generated at most for one quiz
+	DistractorPoolSize   = 30  // Candidate distractor words loaded per language and word type
+	MaxSessionWords      = 100 // Saved words loaded at most to build a session
+)
+
+// MaxLatency caps the recorded answer latency, so that a question left
+// unanswered for days does not skew statistics and review durations
+const MaxLatency = 5 * time.Minute
+
+// Blank replaces the masked word in fill-the-blank sentences
+const Blank = "____"
+
+// IsValid reports whether the question type is known
+func (t QuestionType) IsValid() bool {
+	for _, known := range AllQuestionTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// IsMultipleChoice reports whether questions of this type offer choices
+func (t QuestionType) IsMultipleChoice() bool {
+	return t != FillBlank && t != Translation
+}
+
+// Question is a single quiz item about a word
+type Question struct {
+	ID             string       `json:"id"`
+	Type           QuestionType `json:"type"`
+	WordID         string       `json:"-"` // Not serialized: the word gives the answer away
+	Language       string       `json:"language"`
+	Prompt         string       `json:"prompt"`
+	TargetLanguage string       `json:"target_language,omitempty"` // Language the answer is expected in, for translations
+	Choices        []string     `json:"choices,omitempty"`
+
+	// Answers lists the accepted answers, the expected one first. They are
+	// never serialized so the answer key does not reach clients.
+	Answers []string `json:"-"`
+}
+
+// Check grades an answer, ignoring case, accents and punctuation
+func (q *Question) Check(answer string) bool {
+	given := NormalizeAnswer(answer)
+	if given == "" {
+		return false
+	}
+	for _, accepted := range q.Answers {
+		if NormalizeAnswer(accepted) == given {
+			return true
+		}
+	}
+	return false
+}
+
+// ExpectedAnswer returns the answer shown when the question was missed
+func (q *Question) ExpectedAnswer() string {
+	if len(q.Answers) == 0 {
+		return ""
+	}
+	return q.Answers[0]
+}
+
+// Source identifies the saved words a quiz session is built from
+type Source string
+
+// Session sources
+const (
+	SourceList Source = "list" // The saved words of a word list
+	SourceTag  Source = "tag"  // The saved words matching a tag expression
+	SourceDue  Source = "due"  // The saved words due for review
+)
+
+// IsValid reports whether the source is known
+func (s Source) IsValid() bool {
+	return s == SourceList || s == SourceTag || s == SourceDue
+}
+
+// SessionStatus is the progress of a quiz session
+type SessionStatus string
+
+// Session statuses
+const (
+	SessionInProgress SessionStatus = "in_progress"
+	SessionCompleted  SessionStatus = "completed"
+)
+
+// SessionOptions describes the quiz session to start
+type SessionOptions struct {
+	Source    Source
+	SourceRef string         // Word list ID or tag expression, depending on the source
+	Types     []QuestionType // Question types to use; all when empty
+	Count     int            // Number of questions; DefaultQuestionCount when zero
+}
+
+// Session is a quiz taken by a user, answered one question at a time
+type Session struct {
+	ID            string        `json:"id"`
+	UserID        string        `json:"user_id"`
+	Source        Source        `json:"source"`
+	SourceRef     string        `json:"source_ref,omitempty"`
+	Status        SessionStatus `json:"status"`
+	QuestionCount int           `json:"question_count"`
+	AnsweredCount int           `json:"answered_count"`
+	CorrectCount  int           `json:"correct_count"`
+	StartedAt     time.Time     `json:"started_at"`
+	CompletedAt   *time.Time    `json:"completed_at,omitempty"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+}
+
+// SessionQuestion is a question of a session together with the saved word it tests
+type SessionQuestion struct {
+	*Question
+	SessionID   string     `json:"session_id"`
+	Position    int        `json:"position"` // Zero-based order in which questions are served
+	SavedWordID string     `json:"-"`        // Not serialized: the saved word gives the answer away
+	ServedAt    *time.Time `json:"served_at,omitempty"`
+}
+
+// Attempt records the answer given to a session question
+type Attempt struct {
+	ID         string        `json:"id"`
+	SessionID  string        `json:"session_id"`
+	QuestionID string        `json:"question_id"`
+	UserID     string        `json:"user_id"`
+	Answer     string        `json:"answer"`
+	Correct    bool          `json:"correct"`
+	Latency    time.Duration `json:"latency"` // Time between serving the question and the answer
+	AnsweredAt time.Time     `json:"answered_at"`
+}
+
+// AnswerResult is the outcome of answering a session question
+type AnswerResult struct {
+	Attempt        *Attempt `json:"attempt"`
+	ExpectedAnswer string   `json:"expected_answer"`
+	Session        *Session `json:"session"`
+}
+
+// NewSession creates a session in progress over the given number of questions
+func NewSession(userID string, options SessionOptions, questionCount int, now time.Time) *Session {
+	return &Session{
+		ID:            uuid.New().String(),
+		UserID:        userID,
+		Source:        options.Source,
+		SourceRef:     options.SourceRef,
+		Status:        SessionInProgress,
+		QuestionCount: questionCount,
+		StartedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// IsCompleted reports whether every question of the session was answered
+func (s *Session) IsCompleted() bool {
+	return s.Status == SessionCompleted
+}
+
+// Score returns the percentage of correct answers among the answered questions
+func (s *Session) Score() int {
+	if s.AnsweredCount == 0 {
+		return 0
+	}
+	return int(math.Round(float64(s.CorrectCount) * 100 / float64(s.AnsweredCount)))
+}
+
+// Record counts an answer and completes the session after its last question
+func (s *Session) Record(correct bool, now time.Time) {
+	s.AnsweredCount++
+	if correct {
+		s.CorrectCount++
+	}
+	if s.AnsweredCount >= s.QuestionCount {
+		completedAt := now
+		s.Status = SessionCompleted
+		s.CompletedAt = &completedAt
+	}
+	s.UpdatedAt = now
+}
+
+// ligatures are expanded before comparison since they have no decomposition
+var ligatures = strings.NewReplacer("œ", "oe", "æ", "ae", "ß", "ss")
+
+// NormalizeAnswer folds an answer for comparison: it is lowercased, accents
+// are removed, ligatures expanded and punctuation turned into single spaces,
+// so "Œuvre" matches "oeuvre" and "l’été" matches "l'ete".
+func NormalizeAnswer(answer string) string {
+	decomposed := norm.NFD.String(ligatures.Replace(strings.ToLower(answer)))
+
+	var b strings.Builder
+	for _, r := range decomposed {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Combining accent left over by the decomposition
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		default:
+			b.WriteRune(' ')
+		}
+	}
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
@@ -0,0 +1,16 @@
+package quiz
+
+import "errors"
+
+// Domain errors
+var (
+	ErrInvalidQuestionType = errors.New("invalid question type")
+	ErrNotApplicable       = errors.New("question type not applicable to word")
+	ErrNoQuestions         = errors.New("no question could be generated from the words")
+	ErrInvalidSource       = errors.New("invalid quiz source")
+	ErrSessionNotFound     = errors.New("quiz session not found")
+	ErrQuestionNotFound    = errors.New("quiz question not found")
+	ErrSessionCompleted    = errors.New("quiz session is completed")
+	ErrQuestionMismatch    = errors.New("question is not the current question of the session")
+	ErrAlreadyAnswered     = errors.New("question was already answered")
+)
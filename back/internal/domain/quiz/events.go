@@ -0,0 +1,12 @@
+package quiz
+
+// EventQuizCompleted is the name of the QuizCompleted event
+const EventQuizCompleted = "quiz.completed"
+
+// QuizCompleted is published when the last question of a session is answered
+type QuizCompleted struct {
+	Session *Session `json:"session"`
+}
+
+// EventName identifies the event type
+func (QuizCompleted) EventName() string { return EventQuizCompleted }
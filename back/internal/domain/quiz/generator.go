@@ -0,0 +1,429 @@
+package quiz
+
+import (
+	"errors"
+	"math/rand/v2"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	languageDomain "voconsteroid/internal/domain/language"
+	"voconsteroid/internal/domain/word"
+)
+
+// DistractorSource returns candidate distractor words of a language having
+// a definition of the given word type (any type when empty)
+type DistractorSource func(language, wordType string) ([]*word.Word, error)
+
+// Generator builds quiz questions from stored word data.
+// It is safe for concurrent use.
+type Generator struct {
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// NewGenerator creates a generator drawing from rng; a nil rng is seeded randomly
+func NewGenerator(rng *rand.Rand) *Generator {
+	if rng == nil {
+		rng = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+	return &Generator{rng: rng}
+}
+
+// Generate builds a question of the given type about w. It returns
+// ErrNotApplicable when the word lacks the data the type needs, or when no
+// distractor could be found for a multiple choice question.
+func (g *Generator) Generate(w *word.Word, questionType QuestionType, source DistractorSource) (*Question, error) {
+	switch questionType {
+	case DefinitionToWord:
+		return g.definitionToWord(w, source)
+	case WordToDefinition:
+		return g.wordToDefinition(w, source)
+	case FillBlank:
+		return g.fillBlank(w)
+	case Synonym:
+		return g.related(w, Synonym, w.Synonyms, source)
+	case Antonym:
+		return g.related(w, Antonym, w.Antonyms, source)
+	case Gender:
+		return g.gender(w)
+	case Translation:
+		return g.translation(w)
+	}
+	return nil, ErrInvalidQuestionType
+}
+
+// GenerateQuiz builds up to count questions, one per word in the given
+// order. Each word gets the first applicable type of a shuffled copy of types,
+// so a quiz mixes question types; words no type applies to are skipped.
+func (g *Generator) GenerateQuiz(words []*word.Word, types []QuestionType, count int, source DistractorSource) ([]*Question, error) {
+	questions := make([]*Question, 0)
+	for _, w := range words {
+		if len(questions) >= count {
+			break
+		}
+
+		order := append([]QuestionType(nil), types...)
+		g.shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+
+		for _, questionType := range order {
+			q, err := g.Generate(w, questionType, source)
+			if errors.Is(err, ErrNotApplicable) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			questions = append(questions, q)
+			break
+		}
+	}
+
+	if len(questions) == 0 {
+		return nil, ErrNoQuestions
+	}
+	return questions, nil
+}
+
+// definitionToWord asks which word matches one of its definitions
+func (g *Generator) definitionToWord(w *word.Word, source DistractorSource) (*Question, error) {
+	defs := definitionsWithText(w.Definitions)
+	if len(defs) == 0 {
+		return nil, ErrNotApplicable
+	}
+	def := defs[g.intn(len(defs))]
+
+	pool, err := source(w.Language, def.WordType)
+	if err != nil {
+		return nil, err
+	}
+
+	texts := make([]string, 0)
+	for _, candidate := range candidates(w, pool, def.WordType) {
+		texts = append(texts, candidate.Text)
+	}
+	distractors := g.pick(texts, ChoiceCount-1)
+	if len(distractors) == 0 {
+		return nil, ErrNotApplicable
+	}
+
+	// Definitions sometimes use the headword itself, which would give the answer away
+	prompt, _ := maskForms(def.Text, wordForms(w))
+
+	q := g.newQuestion(w, DefinitionToWord, prompt)
+	q.Choices = g.choices(w.Text, distractors)
+	q.Answers = []string{w.Text}
+	return q, nil
+}
+
+// wordToDefinition asks which definition belongs to a word
+func (g *Generator) wordToDefinition(w *word.Word, source DistractorSource) (*Question, error) {
+	defs := definitionsWithText(w.Definitions)
+	if len(defs) == 0 {
+		return nil, ErrNotApplicable
+	}
+	def := defs[g.intn(len(defs))]
+
+	pool, err := source(w.Language, def.WordType)
+	if err != nil {
+		return nil, err
+	}
+
+	// One definition per distractor word, of the same type as the answer
+	texts := make([]string, 0)
+	seen := map[string]bool{NormalizeAnswer(def.Text): true}
+	for _, candidate := range candidates(w, pool, def.WordType) {
+		options := make([]string, 0)
+		for _, other := range definitionsWithText(candidate.Definitions) {
+			if (def.WordType == "" || other.WordType == def.WordType) && !seen[NormalizeAnswer(other.Text)] {
+				options = append(options, other.Text)
+			}
+		}
+		if len(options) > 0 {
+			text := options[g.intn(len(options))]
+			seen[NormalizeAnswer(text)] = true
+			texts = append(texts, text)
+		}
+	}
+	distractors := g.pick(texts, ChoiceCount-1)
+	if len(distractors) == 0 {
+		return nil, ErrNotApplicable
+	}
+
+	q := g.newQuestion(w, WordToDefinition, w.Text)
+	q.Choices = g.choices(def.Text, distractors)
+	q.Answers = []string{def.Text}
+	return q, nil
+}
+
+// fillBlank asks for the word masked out of one of its examples
+func (g *Generator) fillBlank(w *word.Word) (*Question, error) {
+	type blank struct {
+		sentence string
+		masked   []string
+	}
+
+	forms := wordForms(w)
+	blanks := make([]blank, 0)
+	for _, def := range w.Definitions {
+		for _, example := range def.Examples {
+			if sentence, masked := maskForms(example, forms); len(masked) > 0 {
+				blanks = append(blanks, blank{sentence: sentence, masked: masked})
+			}
+		}
+	}
+	if len(blanks) == 0 {
+		return nil, ErrNotApplicable
+	}
+	chosen := blanks[g.intn(len(blanks))]
+
+	q := g.newQuestion(w, FillBlank, chosen.sentence)
+	q.Answers = uniqueStrings(chosen.masked)
+	return q, nil
+}
+
+// related asks which word is a synonym or an antonym of w
+func (g *Generator) related(w *word.Word, questionType QuestionType, relatedWords []string, source DistractorSource) (*Question, error) {
+	answers := nonEmpty(relatedWords)
+	if len(answers) == 0 {
+		return nil, ErrNotApplicable
+	}
+	answer := answers[g.intn(len(answers))]
+
+	wordType := w.GetPrimaryWordType()
+	pool, err := source(w.Language, wordType)
+	if err != nil {
+		return nil, err
+	}
+
+	// Neither a synonym nor an antonym may appear among the wrong choices
+	excluded := make(map[string]bool)
+	for _, text := range append(append([]string{}, w.Synonyms...), w.Antonyms...) {
+		excluded[NormalizeAnswer(text)] = true
+	}
+
+	texts := make([]string, 0)
+	for _, candidate := range candidates(w, pool, wordType) {
+		if !excluded[NormalizeAnswer(candidate.Text)] {
+			texts = append(texts, candidate.Text)
+		}
+	}
+	distractors := g.pick(texts, ChoiceCount-1)
+	if len(distractors) == 0 {
+		return nil, ErrNotApplicable
+	}
+
+	q := g.newQuestion(w, questionType, w.Text)
+	q.Choices = g.choices(answer, distractors)
+	q.Answers = []string{answer}
+	return q, nil
+}
+
+// gender asks for the grammatical gender of a word, offering the genders of
+// its language. Words of languages without grammatical gender and words
+// whose definitions disagree on the gender (e.g. "livre") are skipped.
+func (g *Generator) gender(w *word.Word) (*Question, error) {
+	lang, ok := languageDomain.Default.Get(w.Language)
+	if !ok || len(lang.Genders) < 2 {
+		return nil, ErrNotApplicable
+	}
+
+	genders := make([]string, 0)
+	for _, def := range w.Definitions {
+		if def.Gender != "" && lang.IsValidGender(def.Gender) {
+			genders = append(genders, def.Gender)
+		}
+	}
+	genders = uniqueStrings(genders)
+	if len(genders) != 1 {
+		return nil, ErrNotApplicable
+	}
+
+	q := g.newQuestion(w, Gender, w.Text)
+	q.Choices = append([]string(nil), lang.Genders...)
+	q.Answers = genders
+	return q, nil
+}
+
+// translation asks for a translation of w into one of the languages it has
+// a translation for. Every alternative of the translation is accepted.
+func (g *Generator) translation(w *word.Word) (*Question, error) {
+	languages := make([]string, 0, len(w.Translations))
+	for language, text := range w.Translations {
+		if len(splitAlternatives(text)) > 0 {
+			languages = append(languages, language)
+		}
+	}
+	if len(languages) == 0 {
+		return nil, ErrNotApplicable
+	}
+	// Map iteration order is random; sort so the seeded generator stays deterministic
+	sort.Strings(languages)
+	language := languages[g.intn(len(languages))]
+
+	q := g.newQuestion(w, Translation, w.Text)
+	q.TargetLanguage = language
+	q.Answers = splitAlternatives(w.Translations[language])
+	return q, nil
+}
+
+// newQuestion creates a question about w with a fresh ID
+func (g *Generator) newQuestion(w *word.Word, questionType QuestionType, prompt string) *Question {
+	return &Question{
+		ID:       uuid.New().String(),
+		Type:     questionType,
+		WordID:   w.ID,
+		Language: w.Language,
+		Prompt:   prompt,
+	}
+}
+
+// choices mixes the answer with the distractors in random order
+func (g *Generator) choices(answer string, distractors []string) []string {
+	choices := append([]string{answer}, distractors...)
+	g.shuffle(len(choices), func(i, j int) { choices[i], choices[j] = choices[j], choices[i] })
+	return choices
+}
+
+// pick returns up to n items chosen at random
+func (g *Generator) pick(items []string, n int) []string {
+	picked := append([]string(nil), items...)
+	g.shuffle(len(picked), func(i, j int) { picked[i], picked[j] = picked[j], picked[i] })
+	if len(picked) > n {
+		picked = picked[:n]
+	}
+	return picked
+}
+
+func (g *Generator) intn(n int) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.rng.IntN(n)
+}
+
+func (g *Generator) shuffle(n int, swap func(i, j int)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.rng.Shuffle(n, swap)
+}
+
+// candidates filters a distractor pool down to the words that could be
+// mistaken for w: same language, a definition of the given word type (any
+// when empty), and a text that is neither one of w's forms nor a repeat
+func candidates(w *word.Word, pool []*word.Word, wordType string) []*word.Word {
+	seen := wordForms(w)
+	result := make([]*word.Word, 0, len(pool))
+	for _, candidate := range pool {
+		if candidate == nil || candidate.ID == w.ID || candidate.Language != w.Language {
+			continue
+		}
+		if wordType != "" && len(candidate.GetDefinitionsByType(wordType)) == 0 {
+			continue
+		}
+		text := NormalizeAnswer(candidate.Text)
+		if text == "" || seen[text] {
+			continue
+		}
+		seen[text] = true
+		result = append(result, candidate)
+	}
+	return result
+}
+
+// wordForms returns the normalized headword, lemma and search terms of w
+func wordForms(w *word.Word) map[string]bool {
+	forms := make(map[string]bool)
+	for _, form := range append([]string{w.Text, w.Lemma}, w.SearchTerms...) {
+		if normalized := NormalizeAnswer(form); normalized != "" {
+			forms[normalized] = true
+		}
+	}
+	return forms
+}
+
+// maskForms replaces every word of text that is one of the forms with
+// Blank and returns the masked text along with the words it replaced.
+// Hyphenated words such as "peut-être" are matched as a whole.
+func maskForms(text string, forms map[string]bool) (string, []string) {
+	runes := []rune(text)
+	masked := make([]string, 0)
+
+	var b strings.Builder
+	for i := 0; i < len(runes); {
+		if !isWordRune(runes[i]) {
+			b.WriteRune(runes[i])
+			i++
+			continue
+		}
+
+		end := i
+		for end < len(runes) && (isWordRune(runes[end]) || isInnerHyphen(runes, end)) {
+			end++
+		}
+
+		token := string(runes[i:end])
+		if forms[NormalizeAnswer(token)] {
+			b.WriteString(Blank)
+			masked = append(masked, token)
+		} else {
+			b.WriteString(token)
+		}
+		i = end
+	}
+
+	return b.String(), masked
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)
+}
+
+func isInnerHyphen(runes []rune, i int) bool {
+	return runes[i] == '-' && i > 0 && i+1 < len(runes) && unicode.IsLetter(runes[i-1]) && unicode.IsLetter(runes[i+1])
+}
+
+// definitionsWithText drops definitions without text
+func definitionsWithText(defs []word.Definition) []word.Definition {
+	result := make([]word.Definition, 0, len(defs))
+	for _, def := range defs {
+		if strings.TrimSpace(def.Text) != "" {
+			result = append(result, def)
+		}
+	}
+	return result
+}
+
+// splitAlternatives splits a translation such as "tree, shaft" into its alternatives
+func splitAlternatives(text string) []string {
+	return nonEmpty(strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || r == ';' || r == '/'
+	}))
+}
+
+// nonEmpty trims the values and drops the empty ones
+func nonEmpty(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if trimmed := strings.TrimSpace(value); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
+
+// uniqueStrings removes values repeated up to case and accents, keeping the first occurrence
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		key := NormalizeAnswer(value)
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,288 @@
+package quiz
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"voconsteroid/internal/domain/word"
+)
+
+// testWord builds a word with one definition per given word type
+func testWord(id, text, language string, defs ...word.Definition) *word.Word {
+	w := word.NewWord(text, language)
+	w.ID = id
+	w.Definitions = defs
+	return w
+}
+
+// testPool returns French words usable as distractors
+func testPool() []*word.Word {
+	return []*word.Word{
+		testWord("w-maison", "maison", "fr", word.Definition{Text: "Bâtiment servant d'habitation.", WordType: "nom", Gender: "féminin"}),
+		testWord("w-voiture", "voiture", "fr", word.Definition{Text: "Véhicule à roues.", WordType: "nom", Gender: "féminin"}),
+		testWord("w-livre", "livre", "fr", word.Definition{Text: "Assemblage de feuilles imprimées.", WordType: "nom", Gender: "masculin"}),
+		testWord("w-courir", "courir", "fr", word.Definition{Text: "Aller très vite.", WordType: "verbe"}),
+		testWord("w-tree", "tree", "en", word.Definition{Text: "A woody plant.", WordType: "noun"}),
+	}
+}
+
+func testSource(pool []*word.Word) DistractorSource {
+	return func(language, wordType string) ([]*word.Word, error) {
+		return pool, nil
+	}
+}
+
+func newTestGenerator() *Generator {
+	return NewGenerator(rand.New(rand.NewPCG(1, 2)))
+}
+
+func TestNormalizeAnswer(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Arbre", "arbre"},
+		{"  élève ", "eleve"},
+		{"Œuvre", "oeuvre"},
+		{"L’été !", "l ete"},
+		{"peut-être", "peut etre"},
+		{"ÇA", "ca"},
+		{"...", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NormalizeAnswer(tt.input))
+		})
+	}
+}
+
+func TestQuestion_Check(t *testing.T) {
+	q := &Question{Answers: []string{"féminin", "fem"}}
+
+	assert.True(t, q.Check("féminin"))
+	assert.True(t, q.Check("FEMININ "))
+	assert.True(t, q.Check("fem"))
+	assert.False(t, q.Check("masculin"))
+	assert.False(t, q.Check(""))
+	assert.Equal(t, "féminin", q.ExpectedAnswer())
+}
+
+func TestMaskForms(t *testing.T) {
+	forms := map[string]bool{"manger": true, "mangeons": true, "peut etre": true}
+
+	tests := []struct {
+		name           string
+		text           string
+		expectedText   string
+		expectedMasked []string
+	}{
+		{
+			name:           "Inflected form",
+			text:           "Nous mangeons à midi.",
+			expectedText:   "Nous ____ à midi.",
+			expectedMasked: []string{"mangeons"},
+		},
+		{
+			name:           "Case and accents are ignored",
+			text:           "MANGER, c'est vivre.",
+			expectedText:   "____, c'est vivre.",
+			expectedMasked: []string{"MANGER"},
+		},
+		{
+			name:           "Longer words are kept",
+			text:           "La mangeoire est vide.",
+			expectedText:   "La mangeoire est vide.",
+			expectedMasked: []string{},
+		},
+		{
+			name:           "Hyphenated word",
+			text:           "Il viendra peut-être.",
+			expectedText:   "Il viendra ____.",
+			expectedMasked: []string{"peut-être"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, masked := maskForms(tt.text, forms)
+			assert.Equal(t, tt.expectedText, text)
+			assert.Equal(t, tt.expectedMasked, masked)
+		})
+	}
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	arbre := testWord("w-arbre", "arbre", "fr", word.Definition{
+		Text:     "Grand végétal dont la tige est un tronc.",
+		WordType: "nom",
+		Gender:   "masculin",
+		Examples: []string{"Les arbres perdent leurs feuilles.", "Un arbre pousse."},
+	})
+	arbre.SearchTerms = []string{"arbre", "arbres"}
+	arbre.Synonyms = []string{"arbuste"}
+	arbre.Antonyms = []string{}
+	arbre.Translations = map[string]string{"en": "tree, shaft"}
+
+	generator := newTestGenerator()
+	source := testSource(testPool())
+
+	t.Run("Definition to word", func(t *testing.T) {
+		q, err := generator.Generate(arbre, DefinitionToWord, source)
+
+		require.NoError(t, err)
+		assert.Equal(t, DefinitionToWord, q.Type)
+		assert.Equal(t, "w-arbre", q.WordID)
+		assert.Equal(t, "Grand végétal dont la tige est un tronc.", q.Prompt)
+		assert.Equal(t, []string{"arbre"}, q.Answers)
+		assert.ElementsMatch(t, []string{"arbre", "maison", "voiture", "livre"}, q.Choices)
+	})
+
+	t.Run("Word to definition", func(t *testing.T) {
+		q, err := generator.Generate(arbre, WordToDefinition, source)
+
+		require.NoError(t, err)
+		assert.Equal(t, "arbre", q.Prompt)
+		assert.Equal(t, []string{"Grand végétal dont la tige est un tronc."}, q.Answers)
+		assert.Len(t, q.Choices, ChoiceCount)
+		assert.Contains(t, q.Choices, "Grand végétal dont la tige est un tronc.")
+		assert.NotContains(t, q.Choices, "Aller très vite.")
+	})
+
+	t.Run("Fill the blank", func(t *testing.T) {
+		q, err := generator.Generate(arbre, FillBlank, source)
+
+		require.NoError(t, err)
+		assert.Contains(t, []string{"Les ____ perdent leurs feuilles.", "Un ____ pousse."}, q.Prompt)
+		assert.Empty(t, q.Choices)
+		assert.True(t, q.Check(q.ExpectedAnswer()))
+	})
+
+	t.Run("Synonym", func(t *testing.T) {
+		q, err := generator.Generate(arbre, Synonym, source)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"arbuste"}, q.Answers)
+		assert.Contains(t, q.Choices, "arbuste")
+		assert.NotContains(t, q.Choices, "courir")
+		assert.NotContains(t, q.Choices, "tree")
+	})
+
+	t.Run("Gender", func(t *testing.T) {
+		q, err := generator.Generate(arbre, Gender, source)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"masculin", "féminin", "pluriel"}, q.Choices)
+		assert.True(t, q.Check("Masculin"))
+		assert.False(t, q.Check("feminin"))
+
+		haus := testWord("w-haus", "Haus", "de", word.Definition{Text: "Gebäude zum Wohnen.", WordType: "substantiv", Gender: "neutrum"})
+		q, err = generator.Generate(haus, Gender, source)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"maskulin", "feminin", "neutrum"}, q.Choices)
+		assert.True(t, q.Check("Neutrum"))
+	})
+
+	t.Run("Translation", func(t *testing.T) {
+		q, err := generator.Generate(arbre, Translation, source)
+
+		require.NoError(t, err)
+		assert.Equal(t, "en", q.TargetLanguage)
+		assert.Equal(t, []string{"tree", "shaft"}, q.Answers)
+		assert.True(t, q.Check("Shaft"))
+	})
+
+	t.Run("Not applicable", func(t *testing.T) {
+		livre := testWord("w-livre", "livre", "fr",
+			word.Definition{Text: "Assemblage de feuilles imprimées.", WordType: "nom", Gender: "masculin"},
+			word.Definition{Text: "Unité de masse.", WordType: "nom", Gender: "féminin"},
+		)
+		tree := testWord("w-tree", "tree", "en", word.Definition{Text: "A woody plant.", WordType: "noun"})
+
+		cases := []struct {
+			name         string
+			word         *word.Word
+			questionType QuestionType
+		}{
+			{"No antonyms", arbre, Antonym},
+			{"Ambiguous gender", livre, Gender},
+			{"Language without gender", tree, Gender},
+			{"No examples", livre, FillBlank},
+			{"No translations", livre, Translation},
+			{"No distractors", tree, DefinitionToWord},
+		}
+
+		for _, c := range cases {
+			_, err := generator.Generate(c.word, c.questionType, source)
+			assert.ErrorIs(t, err, ErrNotApplicable, c.name)
+		}
+	})
+
+	t.Run("Unknown type", func(t *testing.T) {
+		_, err := generator.Generate(arbre, QuestionType("essay"), source)
+		assert.ErrorIs(t, err, ErrInvalidQuestionType)
+	})
+
+	t.Run("Source error", func(t *testing.T) {
+		sourceErr := errors.New("database error")
+		failing := func(language, wordType string) ([]*word.Word, error) {
+			return nil, sourceErr
+		}
+
+		_, err := generator.Generate(arbre, DefinitionToWord, failing)
+		assert.ErrorIs(t, err, sourceErr)
+	})
+}
+
+func TestGenerator_GenerateQuiz(t *testing.T) {
+	pool := testPool()
+	source := testSource(pool)
+
+	t.Run("One question per word up to count", func(t *testing.T) {
+		// Setup
+		generator := newTestGenerator()
+
+		// Execute
+		questions, err := generator.GenerateQuiz(pool[:4], AllQuestionTypes, 3, source)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, questions, 3)
+		for i, q := range questions {
+			assert.Equal(t, pool[i].ID, q.WordID)
+			assert.NotEmpty(t, q.Answers)
+		}
+	})
+
+	t.Run("Words no type applies to are skipped", func(t *testing.T) {
+		// Setup
+		generator := newTestGenerator()
+		bare := testWord("w-bare", "vide", "fr")
+
+		// Execute
+		questions, err := generator.GenerateQuiz([]*word.Word{bare, pool[0]}, []QuestionType{Gender}, 10, source)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, questions, 1)
+		assert.Equal(t, "w-maison", questions[0].WordID)
+		assert.Equal(t, []string{"féminin"}, questions[0].Answers)
+	})
+
+	t.Run("No question", func(t *testing.T) {
+		// Setup
+		generator := newTestGenerator()
+
+		// Execute
+		questions, err := generator.GenerateQuiz(pool[4:], []QuestionType{Gender}, 10, source)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrNoQuestions)
+		assert.Nil(t, questions)
+	})
+}
@@ -0,0 +1,32 @@
+package quiz
+
+import (
+	"context"
+	"time"
+
+	"voconsteroid/internal/domain/event"
+	"voconsteroid/internal/domain/word"
+)
+
+// Repository defines the interface for quiz data access
+type Repository interface {
+	// FindDistractors retrieves random words of a language with a definition
+	// of the given word type (any type when empty), skipping the excluded IDs
+	FindDistractors(ctx context.Context, language, wordType string, excludeIDs []string, limit int) ([]*word.Word, error)
+
+	// FindSession retrieves a quiz session by its ID
+	FindSession(ctx context.Context, id string) (*Session, error)
+
+	// FindQuestion retrieves the question of a session at the given position
+	FindQuestion(ctx context.Context, sessionID string, position int) (*SessionQuestion, error)
+
+	// CreateSession stores a new session together with its questions and answer keys
+	CreateSession(ctx context.Context, session *Session, questions []*SessionQuestion) error
+
+	// MarkServed records when a question was first shown
+	MarkServed(ctx context.Context, questionID string, servedAt time.Time) error
+
+	// SaveAttempt stores an attempt and the session counters it updated,
+	// running emit, when not nil, in the same transaction
+	SaveAttempt(ctx context.Context, session *Session, attempt *Attempt, emit event.Emit) error
+}
@@ -0,0 +1,30 @@
+package quiz
+
+import (
+	"context"
+
+	"voconsteroid/internal/domain/word"
+)
+
+// Service defines the interface for quiz business logic
+type Service interface {
+	// GenerateQuestion builds a question of the given type about a word
+	GenerateQuestion(ctx context.Context, w *word.Word, questionType QuestionType) (*Question, error)
+
+	// GenerateQuiz builds up to count questions, at most one per word, using
+	// the first applicable type of a shuffled copy of types (all when empty)
+	GenerateQuiz(ctx context.Context, words []*word.Word, types []QuestionType, count int) ([]*Question, error)
+
+	// StartSession generates the questions of a new session over the user's saved words
+	StartSession(ctx context.Context, userID string, options SessionOptions) (*Session, error)
+
+	// GetSession retrieves a session of the user
+	GetSession(ctx context.Context, userID, id string) (*Session, error)
+
+	// CurrentQuestion retrieves the next unanswered question of a session
+	CurrentQuestion(ctx context.Context, userID, sessionID string) (*SessionQuestion, error)
+
+	// Answer grades the answer to the current question of a session, records
+	// the attempt and feeds the result to spaced repetition
+	Answer(ctx context.Context, userID, sessionID, questionID, answer string) (*AnswerResult, error)
+}
@@ -0,0 +1,425 @@
+package quiz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/event"
+	"voconsteroid/internal/domain/review"
+	"voconsteroid/internal/domain/savedword"
+	"voconsteroid/internal/domain/tag"
+	"voconsteroid/internal/domain/word"
+	"voconsteroid/internal/domain/wordlist"
+)
+
+// Ensure service implements Service interface
+var _ Service = (*service)(nil)
+
+// service implements the Service interface
+type service struct {
+	repo          Repository
+	savedWordRepo savedword.Repository
+	wordListRepo  wordlist.Repository
+	wordRepo      word.Repository
+	reviewService review.Service
+	generator     *Generator
+	clock         review.Clock
+	events        event.Publisher
+	logger        zerolog.Logger
+}
+
+// studyItem is a saved word a session can ask about
+type studyItem struct {
+	savedWordID string
+	word        *word.Word
+}
+
+// NewService creates a new quiz service. Completed sessions are published as
+// events; a nil publisher drops them.
+func NewService(repo Repository, savedWordRepo savedword.Repository, wordListRepo wordlist.Repository, wordRepo word.Repository, reviewService review.Service, generator *Generator, clock review.Clock, events event.Publisher, logger zerolog.Logger) Service {
+	if generator == nil {
+		generator = NewGenerator(nil)
+	}
+	if clock == nil {
+		clock = time.Now
+	}
+	if events == nil {
+		events = event.Nop
+	}
+	return &service{
+		repo:          repo,
+		savedWordRepo: savedWordRepo,
+		wordListRepo:  wordListRepo,
+		wordRepo:      wordRepo,
+		reviewService: reviewService,
+		generator:     generator,
+		clock:         clock,
+		events:        events,
+		logger:        logger.With().Str("component", "quiz_service").Logger(),
+	}
+}
+
+// GenerateQuestion builds a question of the given type about a word
+func (s *service) GenerateQuestion(ctx context.Context, w *word.Word, questionType QuestionType) (*Question, error) {
+	s.logger.Debug().Str("wordID", w.ID).Str("type", string(questionType)).Msg("Generating question")
+
+	if !questionType.IsValid() {
+		return nil, ErrInvalidQuestionType
+	}
+
+	q, err := s.generator.Generate(w, questionType, s.distractorSource(ctx, []*word.Word{w}))
+	if err != nil {
+		if errors.Is(err, ErrNotApplicable) {
+			return nil, err
+		}
+		s.logger.Error().Err(err).Str("wordID", w.ID).Msg("Failed to generate question")
+		return nil, fmt.Errorf("failed to generate question: %w", err)
+	}
+
+	return q, nil
+}
+
+// GenerateQuiz builds up to count questions about the words
+func (s *service) GenerateQuiz(ctx context.Context, words []*word.Word, types []QuestionType, count int) ([]*Question, error) {
+	s.logger.Debug().Int("words", len(words)).Int("count", count).Msg("Generating quiz")
+
+	if count <= 0 {
+		count = DefaultQuestionCount
+	}
+	if count > MaxQuestionCount {
+		count = MaxQuestionCount
+	}
+	if len(types) == 0 {
+		types = AllQuestionTypes
+	}
+	for _, questionType := range types {
+		if !questionType.IsValid() {
+			return nil, ErrInvalidQuestionType
+		}
+	}
+
+	questions, err := s.generator.GenerateQuiz(words, types, count, s.distractorSource(ctx, words))
+	if err != nil {
+		if errors.Is(err, ErrNoQuestions) {
+			return nil, err
+		}
+		s.logger.Error().Err(err).Msg("Failed to generate quiz")
+		return nil, fmt.Errorf("failed to generate quiz: %w", err)
+	}
+
+	return questions, nil
+}
+
+// StartSession generates the questions of a new session over the user's saved words
+func (s *service) StartSession(ctx context.Context, userID string, options SessionOptions) (*Session, error) {
+	s.logger.Debug().Str("userID", userID).Str("source", string(options.Source)).Str("sourceRef", options.SourceRef).Msg("Starting quiz session")
+
+	options.SourceRef = strings.TrimSpace(options.SourceRef)
+	if !options.Source.IsValid() || (options.Source != SourceDue && options.SourceRef == "") {
+		return nil, ErrInvalidSource
+	}
+	if options.Source == SourceDue {
+		options.SourceRef = ""
+	}
+
+	items, err := s.loadItems(ctx, userID, options)
+	if err != nil {
+		return nil, err
+	}
+
+	words := make([]*word.Word, 0, len(items))
+	savedWordIDs := make(map[string]string, len(items))
+	for _, item := range items {
+		words = append(words, item.word)
+		savedWordIDs[item.word.ID] = item.savedWordID
+	}
+
+	questions, err := s.GenerateQuiz(ctx, words, options.Types, options.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	session := NewSession(userID, options, len(questions), s.clock())
+	sessionQuestions := make([]*SessionQuestion, len(questions))
+	for i, q := range questions {
+		sessionQuestions[i] = &SessionQuestion{
+			Question:    q,
+			SessionID:   session.ID,
+			Position:    i,
+			SavedWordID: savedWordIDs[q.WordID],
+		}
+	}
+
+	if err := s.repo.CreateSession(ctx, session, sessionQuestions); err != nil {
+		s.logger.Error().Err(err).Msg("Failed to create quiz session")
+		return nil, fmt.Errorf("failed to create quiz session: %w", err)
+	}
+
+	return session, nil
+}
+
+// GetSession retrieves a session of the user
+func (s *service) GetSession(ctx context.Context, userID, id string) (*Session, error) {
+	s.logger.Debug().Str("userID", userID).Str("id", id).Msg("Getting quiz session")
+	return s.findOwned(ctx, userID, id)
+}
+
+// CurrentQuestion retrieves the next unanswered question of a session. The
+// first time a question is served is recorded to measure the answer latency.
+func (s *service) CurrentQuestion(ctx context.Context, userID, sessionID string) (*SessionQuestion, error) {
+	s.logger.Debug().Str("userID", userID).Str("sessionID", sessionID).Msg("Getting current quiz question")
+
+	session, q, err := s.findCurrent(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if q.ServedAt == nil {
+		servedAt := s.clock()
+		if err := s.repo.MarkServed(ctx, q.ID, servedAt); err != nil {
+			s.logger.Error().Err(err).Str("sessionID", session.ID).Msg("Failed to mark quiz question served")
+			return nil, fmt.Errorf("failed to mark question served: %w", err)
+		}
+		q.ServedAt = &servedAt
+	}
+
+	return q, nil
+}
+
+// Answer grades the answer to the current question of a session
+func (s *service) Answer(ctx context.Context, userID, sessionID, questionID, answer string) (*AnswerResult, error) {
+	s.logger.Debug().Str("userID", userID).Str("sessionID", sessionID).Str("questionID", questionID).Msg("Answering quiz question")
+
+	session, q, err := s.findCurrent(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if q.ID != questionID {
+		return nil, ErrQuestionMismatch
+	}
+
+	// Latency is measured server-side from the moment the question was served
+	now := s.clock()
+	var latency time.Duration
+	if q.ServedAt != nil && now.After(*q.ServedAt) {
+		latency = min(now.Sub(*q.ServedAt), MaxLatency)
+	}
+
+	attempt := &Attempt{
+		ID:         uuid.New().String(),
+		SessionID:  session.ID,
+		QuestionID: q.ID,
+		UserID:     userID,
+		Answer:     strings.TrimSpace(answer),
+		Correct:    q.Check(answer),
+		Latency:    latency,
+		AnsweredAt: now,
+	}
+	session.Record(attempt.Correct, now)
+
+	var completed event.Emit
+	if session.IsCompleted() {
+		completed = s.events.Emit(QuizCompleted{Session: session})
+	}
+	if err := s.repo.SaveAttempt(ctx, session, attempt, completed); err != nil {
+		if errors.Is(err, ErrAlreadyAnswered) {
+			return nil, err
+		}
+		s.logger.Error().Err(err).Str("sessionID", session.ID).Msg("Failed to save quiz attempt")
+		return nil, fmt.Errorf("failed to save quiz attempt: %w", err)
+	}
+
+	s.gradeReview(ctx, userID, q.SavedWordID, attempt)
+
+	return &AnswerResult{
+		Attempt:        attempt,
+		ExpectedAnswer: q.ExpectedAnswer(),
+		Session:        session,
+	}, nil
+}
+
+// gradeReview feeds a quiz attempt to spaced repetition: a correct answer
+// counts as a good recall and a wrong one as a lapse. The attempt is already
+// recorded, so a failure here is logged rather than returned.
+func (s *service) gradeReview(ctx context.Context, userID, savedWordID string, attempt *Attempt) {
+	if savedWordID == "" {
+		return
+	}
+
+	grade := review.GradeAgain
+	if attempt.Correct {
+		grade = review.GradeGood
+	}
+
+	if _, err := s.reviewService.Grade(ctx, userID, savedWordID, grade, attempt.Latency, review.SourceQuiz); err != nil {
+		// The saved word may have been deleted since the session started
+		if errors.Is(err, review.ErrCardNotFound) {
+			return
+		}
+		s.logger.Error().Err(err).Str("savedWordID", savedWordID).Msg("Failed to grade review from quiz attempt")
+	}
+}
+
+// loadItems retrieves the saved words of the session source with their words
+func (s *service) loadItems(ctx context.Context, userID string, options SessionOptions) ([]studyItem, error) {
+	switch options.Source {
+	case SourceList:
+		list, err := s.wordListRepo.FindByID(ctx, options.SourceRef)
+		if err != nil {
+			if errors.Is(err, wordlist.ErrListNotFound) {
+				return nil, err
+			}
+			s.logger.Error().Err(err).Str("listID", options.SourceRef).Msg("Failed to find word list")
+			return nil, fmt.Errorf("failed to find word list: %w", err)
+		}
+		if list.UserID != userID {
+			return nil, wordlist.ErrListNotFound
+		}
+
+		ids := list.SavedWordIDs
+		if len(ids) > MaxSessionWords {
+			ids = ids[:MaxSessionWords]
+		}
+		savedWords, err := s.savedWordRepo.FindByIDs(ctx, ids)
+		if err != nil {
+			s.logger.Error().Err(err).Msg("Failed to find saved words of word list")
+			return nil, fmt.Errorf("failed to find saved words: %w", err)
+		}
+
+		// Keep the order of the list
+		byID := make(map[string]*savedword.SavedWord, len(savedWords))
+		for _, sw := range savedWords {
+			byID[sw.ID] = sw
+		}
+		ordered := make([]*savedword.SavedWord, 0, len(savedWords))
+		for _, id := range ids {
+			if sw, ok := byID[id]; ok {
+				ordered = append(ordered, sw)
+			}
+		}
+		return s.withWords(ctx, ordered)
+
+	case SourceTag:
+		expr, err := tag.ParseExpression(options.SourceRef)
+		if err != nil {
+			return nil, err
+		}
+
+		savedWords, err := s.savedWordRepo.ListByUser(ctx, userID, savedword.Filter{Tags: expr}, MaxSessionWords, 0)
+		if err != nil {
+			s.logger.Error().Err(err).Msg("Failed to list tagged saved words")
+			return nil, fmt.Errorf("failed to list saved words: %w", err)
+		}
+		return s.withWords(ctx, savedWords)
+
+	case SourceDue:
+		due, err := s.reviewService.GetDue(ctx, userID, MaxSessionWords)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get due reviews: %w", err)
+		}
+
+		items := make([]studyItem, 0, len(due))
+		for _, item := range due {
+			items = append(items, studyItem{savedWordID: item.SavedWord.ID, word: item.Word})
+		}
+		return items, nil
+	}
+
+	return nil, ErrInvalidSource
+}
+
+// withWords joins saved words with the words they reference, skipping
+// words that no longer exist
+func (s *service) withWords(ctx context.Context, savedWords []*savedword.SavedWord) ([]studyItem, error) {
+	items := make([]studyItem, 0, len(savedWords))
+	for _, sw := range savedWords {
+		w, err := s.wordRepo.FindByID(ctx, sw.WordID)
+		if err != nil {
+			if errors.Is(err, word.ErrWordNotFound) {
+				continue
+			}
+			s.logger.Error().Err(err).Str("wordID", sw.WordID).Msg("Failed to find word of saved word")
+			return nil, fmt.Errorf("failed to find word: %w", err)
+		}
+		items = append(items, studyItem{savedWordID: sw.ID, word: w})
+	}
+	return items, nil
+}
+
+// findOwned retrieves a session and checks that it belongs to the user.
+// Sessions of other users are reported as not found so their IDs do not leak.
+func (s *service) findOwned(ctx context.Context, userID, id string) (*Session, error) {
+	session, err := s.repo.FindSession(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrSessionNotFound) {
+			return nil, err
+		}
+		s.logger.Error().Err(err).Str("id", id).Msg("Failed to find quiz session")
+		return nil, fmt.Errorf("failed to find quiz session: %w", err)
+	}
+
+	if session.UserID != userID {
+		return nil, ErrSessionNotFound
+	}
+
+	return session, nil
+}
+
+// findCurrent retrieves a session of the user in progress and its next unanswered question
+func (s *service) findCurrent(ctx context.Context, userID, sessionID string) (*Session, *SessionQuestion, error) {
+	session, err := s.findOwned(ctx, userID, sessionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if session.IsCompleted() {
+		return nil, nil, ErrSessionCompleted
+	}
+
+	q, err := s.repo.FindQuestion(ctx, session.ID, session.AnsweredCount)
+	if err != nil {
+		if errors.Is(err, ErrQuestionNotFound) {
+			return nil, nil, err
+		}
+		s.logger.Error().Err(err).Str("sessionID", session.ID).Msg("Failed to find quiz question")
+		return nil, nil, fmt.Errorf("failed to find quiz question: %w", err)
+	}
+
+	return session, q, nil
+}
+
+// distractorSource loads distractors from the repository, once per language
+// and word type. The quiz words themselves are added to every pool since
+// words studied together make the most plausible distractors.
+func (s *service) distractorSource(ctx context.Context, words []*word.Word) DistractorSource {
+	excludeIDs := make([]string, 0, len(words))
+	for _, w := range words {
+		excludeIDs = append(excludeIDs, w.ID)
+	}
+
+	pools := make(map[string][]*word.Word)
+	return func(language, wordType string) ([]*word.Word, error) {
+		key := language + "/" + wordType
+		if pool, ok := pools[key]; ok {
+			return pool, nil
+		}
+
+		pool, err := s.repo.FindDistractors(ctx, language, wordType, excludeIDs, DistractorPoolSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find distractors: %w", err)
+		}
+		for _, w := range words {
+			if w.Language == language {
+				pool = append(pool, w)
+			}
+		}
+
+		pools[key] = pool
+		return pool, nil
+	}
+}
@@ -0,0 +1,697 @@
+package quiz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"voconsteroid/internal/domain/event"
+	"voconsteroid/internal/domain/review"
+	"voconsteroid/internal/domain/savedword"
+	"voconsteroid/internal/domain/tag"
+	"voconsteroid/internal/domain/word"
+	"voconsteroid/internal/domain/wordlist"
+)
+
+var testNow = time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+// MockRepository is a mock implementation of the Repository interface
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) FindDistractors(ctx context.Context, language, wordType string, excludeIDs []string, limit int) ([]*word.Word, error) {
+	args := m.Called(ctx, language, wordType, excludeIDs, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*word.Word), args.Error(1)
+}
+
+func (m *MockRepository) FindSession(ctx context.Context, id string) (*Session, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Session), args.Error(1)
+}
+
+func (m *MockRepository) FindQuestion(ctx context.Context, sessionID string, position int) (*SessionQuestion, error) {
+	args := m.Called(ctx, sessionID, position)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*SessionQuestion), args.Error(1)
+}
+
+func (m *MockRepository) CreateSession(ctx context.Context, session *Session, questions []*SessionQuestion) error {
+	args := m.Called(ctx, session, questions)
+	return args.Error(0)
+}
+
+func (m *MockRepository) MarkServed(ctx context.Context, questionID string, servedAt time.Time) error {
+	args := m.Called(ctx, questionID, servedAt)
+	return args.Error(0)
+}
+
+func (m *MockRepository) SaveAttempt(ctx context.Context, session *Session, attempt *Attempt, emit event.Emit) error {
+	args := m.Called(ctx, session, attempt)
+	if err := args.Error(0); err != nil || emit == nil {
+		return err
+	}
+	return emit(ctx, nil)
+}
+
+// MockWordListRepository is a mock implementation of the wordlist.Repository interface
+type MockWordListRepository struct {
+	mock.Mock
+}
+
+func (m *MockWordListRepository) FindByID(ctx context.Context, id string) (*wordlist.WordList, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*wordlist.WordList), args.Error(1)
+}
+
+func (m *MockWordListRepository) FindByShareToken(ctx context.Context, token string) (*wordlist.WordList, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*wordlist.WordList), args.Error(1)
+}
+
+func (m *MockWordListRepository) FindByUserAndName(ctx context.Context, userID, name string) (*wordlist.WordList, error) {
+	args := m.Called(ctx, userID, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*wordlist.WordList), args.Error(1)
+}
+
+func (m *MockWordListRepository) ListByUser(ctx context.Context, userID string, limit, offset int) ([]*wordlist.WordList, error) {
+	args := m.Called(ctx, userID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*wordlist.WordList), args.Error(1)
+}
+
+func (m *MockWordListRepository) Save(ctx context.Context, list *wordlist.WordList) error {
+	args := m.Called(ctx, list)
+	return args.Error(0)
+}
+
+func (m *MockWordListRepository) CreateWithSavedWords(ctx context.Context, list *wordlist.WordList, savedWords []*savedword.SavedWord, emit event.Emit) error {
+	args := m.Called(ctx, list, savedWords)
+	return args.Error(0)
+}
+
+func (m *MockWordListRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// MockSavedWordRepository is a mock implementation of the savedword.Repository interface
+type MockSavedWordRepository struct {
+	mock.Mock
+}
+
+func (m *MockSavedWordRepository) FindByID(ctx context.Context, id string) (*savedword.SavedWord, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*savedword.SavedWord), args.Error(1)
+}
+
+func (m *MockSavedWordRepository) FindByIDs(ctx context.Context, ids []string) ([]*savedword.SavedWord, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*savedword.SavedWord), args.Error(1)
+}
+
+func (m *MockSavedWordRepository) FindByUserAndWord(ctx context.Context, userID, wordID string) (*savedword.SavedWord, error) {
+	args := m.Called(ctx, userID, wordID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*savedword.SavedWord), args.Error(1)
+}
+
+func (m *MockSavedWordRepository) Save(ctx context.Context, savedWord *savedword.SavedWord, emit event.Emit) error {
+	args := m.Called(ctx, savedWord)
+	return args.Error(0)
+}
+
+func (m *MockSavedWordRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSavedWordRepository) ListByUser(ctx context.Context, userID string, filter savedword.Filter, limit, offset int) ([]*savedword.SavedWord, error) {
+	args := m.Called(ctx, userID, filter, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*savedword.SavedWord), args.Error(1)
+}
+
+// MockWordRepository is a mock implementation of the word.Repository interface
+type MockWordRepository struct {
+	mock.Mock
+}
+
+func (m *MockWordRepository) FindByID(ctx context.Context, id string) (*word.Word, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) FindByText(ctx context.Context, text, language string) (*word.Word, error) {
+	args := m.Called(ctx, text, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) FindByAnyForm(ctx context.Context, text, language string) (*word.Word, error) {
+	args := m.Called(ctx, text, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) Save(ctx context.Context, w *word.Word, emit event.Emit) error {
+	args := m.Called(ctx, w)
+	return args.Error(0)
+}
+
+func (m *MockWordRepository) List(ctx context.Context, filter map[string]interface{}, limit, offset int) ([]*word.Word, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) FindByPrefix(ctx context.Context, prefix, language string, limit int) ([]*word.Word, error) {
+	args := m.Called(ctx, prefix, language, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) FindSuggestions(ctx context.Context, prefix, language string, limit int) ([]string, error) {
+	args := m.Called(ctx, prefix, language, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// MockReviewService is a mock implementation of the review.Service interface
+type MockReviewService struct {
+	mock.Mock
+}
+
+func (m *MockReviewService) GetDue(ctx context.Context, userID string, limit int) ([]*review.DueItem, error) {
+	args := m.Called(ctx, userID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*review.DueItem), args.Error(1)
+}
+
+func (m *MockReviewService) Grade(ctx context.Context, userID, savedWordID string, grade review.Grade, duration time.Duration, source review.Source) (*review.Card, error) {
+	args := m.Called(ctx, userID, savedWordID, grade, duration, source)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*review.Card), args.Error(1)
+}
+
+// testMocks groups the dependencies of the service under test
+type testMocks struct {
+	repo          *MockRepository
+	wordListRepo  *MockWordListRepository
+	savedWordRepo *MockSavedWordRepository
+	wordRepo      *MockWordRepository
+	reviewService *MockReviewService
+	completed     []QuizCompleted // Published events
+}
+
+// setupTestService creates a service with mocks for testing
+func setupTestService(t *testing.T) (*testMocks, Service) {
+	m := &testMocks{
+		repo:          new(MockRepository),
+		wordListRepo:  new(MockWordListRepository),
+		savedWordRepo: new(MockSavedWordRepository),
+		wordRepo:      new(MockWordRepository),
+		reviewService: new(MockReviewService),
+	}
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	clock := func() time.Time { return testNow }
+	bus := event.NewBus(nil, clock, logger)
+	event.Subscribe(bus, func(_ context.Context, e QuizCompleted) error {
+		m.completed = append(m.completed, e)
+		return nil
+	})
+
+	svc := NewService(m.repo, m.savedWordRepo, m.wordListRepo, m.wordRepo, m.reviewService, newTestGenerator(), clock, bus, logger)
+	return m, svc
+}
+
+func TestService_GenerateQuestion(t *testing.T) {
+	t.Run("Distractors come from the repository", func(t *testing.T) {
+		// Setup
+		m, svc := setupTestService(t)
+		ctx := context.Background()
+		arbre := testWord("w-arbre", "arbre", "fr", word.Definition{Text: "Grand végétal.", WordType: "nom"})
+
+		m.repo.On("FindDistractors", ctx, "fr", "nom", []string{"w-arbre"}, DistractorPoolSize).Return(testPool(), nil)
+
+		// Execute
+		q, err := svc.GenerateQuestion(ctx, arbre, DefinitionToWord)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"arbre"}, q.Answers)
+		assert.ElementsMatch(t, []string{"arbre", "maison", "voiture", "livre"}, q.Choices)
+		m.repo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid type", func(t *testing.T) {
+		// Setup
+		m, svc := setupTestService(t)
+		arbre := testWord("w-arbre", "arbre", "fr")
+
+		// Execute
+		q, err := svc.GenerateQuestion(context.Background(), arbre, QuestionType("essay"))
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidQuestionType)
+		assert.Nil(t, q)
+		m.repo.AssertNotCalled(t, "FindDistractors")
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		// Setup
+		m, svc := setupTestService(t)
+		ctx := context.Background()
+		arbre := testWord("w-arbre", "arbre", "fr", word.Definition{Text: "Grand végétal.", WordType: "nom"})
+
+		m.repo.On("FindDistractors", ctx, "fr", "nom", []string{"w-arbre"}, DistractorPoolSize).Return(nil, errors.New("database error"))
+
+		// Execute
+		q, err := svc.GenerateQuestion(ctx, arbre, WordToDefinition)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to generate question")
+		assert.Nil(t, q)
+	})
+}
+
+func TestService_GenerateQuiz(t *testing.T) {
+	t.Run("Pools are loaded once per language and word type", func(t *testing.T) {
+		// Setup
+		m, svc := setupTestService(t)
+		ctx := context.Background()
+		words := testPool()[:3]
+		ids := []string{"w-maison", "w-voiture", "w-livre"}
+
+		m.repo.On("FindDistractors", ctx, "fr", "nom", ids, DistractorPoolSize).Return([]*word.Word{}, nil).Once()
+
+		// Execute
+		questions, err := svc.GenerateQuiz(ctx, words, []QuestionType{DefinitionToWord}, 0)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, questions, 3)
+		for i, q := range questions {
+			assert.Equal(t, ids[i], q.WordID)
+			// The other quiz words are the only distractors
+			assert.Len(t, q.Choices, 3)
+		}
+		m.repo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid type", func(t *testing.T) {
+		// Setup
+		_, svc := setupTestService(t)
+
+		// Execute
+		questions, err := svc.GenerateQuiz(context.Background(), testPool(), []QuestionType{Gender, "essay"}, 5)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidQuestionType)
+		assert.Nil(t, questions)
+	})
+
+	t.Run("No question", func(t *testing.T) {
+		// Setup
+		_, svc := setupTestService(t)
+
+		// Execute
+		questions, err := svc.GenerateQuiz(context.Background(), []*word.Word{}, nil, 5)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrNoQuestions)
+		assert.Nil(t, questions)
+	})
+}
+
+func TestService_StartSession(t *testing.T) {
+	t.Run("From a word list, in list order", func(t *testing.T) {
+		// Setup
+		m, svc := setupTestService(t)
+		ctx := context.Background()
+		pool := testPool()
+		list := &wordlist.WordList{ID: "list-1", UserID: "user-1", SavedWordIDs: []string{"sw-1", "sw-2"}}
+		savedWords := []*savedword.SavedWord{
+			{ID: "sw-2", UserID: "user-1", WordID: "w-voiture"},
+			{ID: "sw-1", UserID: "user-1", WordID: "w-maison"},
+		}
+
+		m.wordListRepo.On("FindByID", ctx, "list-1").Return(list, nil)
+		m.savedWordRepo.On("FindByIDs", ctx, []string{"sw-1", "sw-2"}).Return(savedWords, nil)
+		m.wordRepo.On("FindByID", ctx, "w-maison").Return(pool[0], nil)
+		m.wordRepo.On("FindByID", ctx, "w-voiture").Return(pool[1], nil)
+
+		var stored []*SessionQuestion
+		m.repo.On("CreateSession", ctx, mock.AnythingOfType("*quiz.Session"), mock.AnythingOfType("[]*quiz.SessionQuestion")).
+			Run(func(args mock.Arguments) { stored = args.Get(2).([]*SessionQuestion) }).
+			Return(nil)
+
+		// Execute
+		session, err := svc.StartSession(ctx, "user-1", SessionOptions{Source: SourceList, SourceRef: "list-1", Types: []QuestionType{Gender}})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", session.UserID)
+		assert.Equal(t, SourceList, session.Source)
+		assert.Equal(t, "list-1", session.SourceRef)
+		assert.Equal(t, SessionInProgress, session.Status)
+		assert.Equal(t, 2, session.QuestionCount)
+		assert.Equal(t, testNow, session.StartedAt)
+
+		require.Len(t, stored, 2)
+		assert.Equal(t, "sw-1", stored[0].SavedWordID)
+		assert.Equal(t, 0, stored[0].Position)
+		assert.Equal(t, "sw-2", stored[1].SavedWordID)
+		assert.Equal(t, session.ID, stored[1].SessionID)
+		m.repo.AssertExpectations(t)
+	})
+
+	t.Run("Word list of another user", func(t *testing.T) {
+		// Setup
+		m, svc := setupTestService(t)
+		ctx := context.Background()
+
+		m.wordListRepo.On("FindByID", ctx, "list-1").Return(&wordlist.WordList{ID: "list-1", UserID: "user-2"}, nil)
+
+		// Execute
+		session, err := svc.StartSession(ctx, "user-1", SessionOptions{Source: SourceList, SourceRef: "list-1"})
+
+		// Assert
+		assert.ErrorIs(t, err, wordlist.ErrListNotFound)
+		assert.Nil(t, session)
+		m.repo.AssertNotCalled(t, "CreateSession")
+	})
+
+	t.Run("From the due reviews", func(t *testing.T) {
+		// Setup
+		m, svc := setupTestService(t)
+		ctx := context.Background()
+		pool := testPool()
+		due := []*review.DueItem{
+			{SavedWord: &savedword.SavedWord{ID: "sw-3", WordID: "w-livre"}, Word: pool[2]},
+		}
+
+		m.reviewService.On("GetDue", ctx, "user-1", MaxSessionWords).Return(due, nil)
+		m.repo.On("CreateSession", ctx, mock.AnythingOfType("*quiz.Session"), mock.MatchedBy(func(questions []*SessionQuestion) bool {
+			return len(questions) == 1 && questions[0].SavedWordID == "sw-3" && questions[0].ExpectedAnswer() == "masculin"
+		})).Return(nil)
+
+		// Execute
+		session, err := svc.StartSession(ctx, "user-1", SessionOptions{Source: SourceDue, SourceRef: "ignored", Types: []QuestionType{Gender}})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, SourceDue, session.Source)
+		assert.Empty(t, session.SourceRef)
+		m.repo.AssertExpectations(t)
+	})
+
+	t.Run("From a tag expression", func(t *testing.T) {
+		// Setup
+		m, svc := setupTestService(t)
+		ctx := context.Background()
+
+		m.savedWordRepo.On("ListByUser", ctx, "user-1", mock.MatchedBy(func(filter savedword.Filter) bool {
+			return filter.Tags != nil && filter.Tags.String() == "verbs"
+		}), MaxSessionWords, 0).Return([]*savedword.SavedWord{}, nil)
+
+		// Execute
+		session, err := svc.StartSession(ctx, "user-1", SessionOptions{Source: SourceTag, SourceRef: "verbs"})
+
+		// Assert
+		assert.ErrorIs(t, err, ErrNoQuestions)
+		assert.Nil(t, session)
+		m.savedWordRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid options", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			options  SessionOptions
+			expected error
+		}{
+			{"Unknown source", SessionOptions{Source: "book"}, ErrInvalidSource},
+			{"Missing list", SessionOptions{Source: SourceList, SourceRef: " "}, ErrInvalidSource},
+			{"Invalid tag expression", SessionOptions{Source: SourceTag, SourceRef: "a AND"}, tag.ErrInvalidExpression},
+		}
+
+		for _, c := range cases {
+			_, svc := setupTestService(t)
+			_, err := svc.StartSession(context.Background(), "user-1", c.options)
+			assert.ErrorIs(t, err, c.expected, c.name)
+		}
+	})
+}
+
+func TestService_CurrentQuestion(t *testing.T) {
+	t.Run("First serve is recorded", func(t *testing.T) {
+		// Setup
+		m, svc := setupTestService(t)
+		ctx := context.Background()
+		session := &Session{ID: "session-1", UserID: "user-1", Status: SessionInProgress, QuestionCount: 3, AnsweredCount: 1}
+		q := &SessionQuestion{Question: &Question{ID: "q-2", WordID: "w-maison"}, SessionID: "session-1", Position: 1, SavedWordID: "sw-1"}
+
+		m.repo.On("FindSession", ctx, "session-1").Return(session, nil)
+		m.repo.On("FindQuestion", ctx, "session-1", 1).Return(q, nil)
+		m.repo.On("MarkServed", ctx, "q-2", testNow).Return(nil)
+
+		// Execute
+		result, err := svc.CurrentQuestion(ctx, "user-1", "session-1")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "q-2", result.ID)
+		require.NotNil(t, result.ServedAt)
+		assert.Equal(t, testNow, *result.ServedAt)
+		m.repo.AssertExpectations(t)
+
+		// The word would give the answer away
+		payload, err := json.Marshal(result)
+		require.NoError(t, err)
+		assert.NotContains(t, string(payload), "w-maison")
+		assert.NotContains(t, string(payload), "sw-1")
+	})
+
+	t.Run("Already served", func(t *testing.T) {
+		// Setup
+		m, svc := setupTestService(t)
+		ctx := context.Background()
+		servedAt := testNow.Add(-time.Minute)
+		session := &Session{ID: "session-1", UserID: "user-1", Status: SessionInProgress, QuestionCount: 3}
+		q := &SessionQuestion{Question: &Question{ID: "q-1"}, SessionID: "session-1", ServedAt: &servedAt}
+
+		m.repo.On("FindSession", ctx, "session-1").Return(session, nil)
+		m.repo.On("FindQuestion", ctx, "session-1", 0).Return(q, nil)
+
+		// Execute
+		result, err := svc.CurrentQuestion(ctx, "user-1", "session-1")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, servedAt, *result.ServedAt)
+		m.repo.AssertNotCalled(t, "MarkServed")
+	})
+
+	t.Run("Completed session", func(t *testing.T) {
+		// Setup
+		m, svc := setupTestService(t)
+		ctx := context.Background()
+
+		m.repo.On("FindSession", ctx, "session-1").Return(&Session{ID: "session-1", UserID: "user-1", Status: SessionCompleted}, nil)
+
+		// Execute
+		_, err := svc.CurrentQuestion(ctx, "user-1", "session-1")
+
+		// Assert
+		assert.ErrorIs(t, err, ErrSessionCompleted)
+	})
+
+	t.Run("Session of another user", func(t *testing.T) {
+		// Setup
+		m, svc := setupTestService(t)
+		ctx := context.Background()
+
+		m.repo.On("FindSession", ctx, "session-1").Return(&Session{ID: "session-1", UserID: "user-2"}, nil)
+
+		// Execute
+		_, err := svc.CurrentQuestion(ctx, "user-1", "session-1")
+
+		// Assert
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+	})
+}
+
+func TestService_Answer(t *testing.T) {
+	newFixture := func() (*Session, *SessionQuestion) {
+		servedAt := testNow.Add(-5 * time.Second)
+		session := &Session{ID: "session-1", UserID: "user-1", Status: SessionInProgress, QuestionCount: 2, AnsweredCount: 1, CorrectCount: 1}
+		q := &SessionQuestion{
+			Question:    &Question{ID: "q-2", Type: Gender, Answers: []string{"féminin"}},
+			SessionID:   "session-1",
+			Position:    1,
+			SavedWordID: "sw-1",
+			ServedAt:    &servedAt,
+		}
+		return session, q
+	}
+
+	t.Run("Correct answer completes the session", func(t *testing.T) {
+		// Setup
+		m, svc := setupTestService(t)
+		ctx := context.Background()
+		session, q := newFixture()
+
+		m.repo.On("FindSession", ctx, "session-1").Return(session, nil)
+		m.repo.On("FindQuestion", ctx, "session-1", 1).Return(q, nil)
+		m.repo.On("SaveAttempt", ctx, session, mock.AnythingOfType("*quiz.Attempt")).Return(nil)
+		m.reviewService.On("Grade", ctx, "user-1", "sw-1", review.GradeGood, 5*time.Second, review.SourceQuiz).Return(&review.Card{}, nil)
+
+		// Execute
+		result, err := svc.Answer(ctx, "user-1", "session-1", "q-2", " Feminin ")
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, result.Attempt.Correct)
+		assert.Equal(t, "Feminin", result.Attempt.Answer)
+		assert.Equal(t, 5*time.Second, result.Attempt.Latency)
+		assert.Equal(t, "féminin", result.ExpectedAnswer)
+		assert.Equal(t, SessionCompleted, result.Session.Status)
+		assert.Equal(t, 2, result.Session.CorrectCount)
+		assert.Equal(t, 100, result.Session.Score())
+		assert.Equal(t, []QuizCompleted{{Session: session}}, m.completed)
+		m.reviewService.AssertExpectations(t)
+	})
+
+	t.Run("Wrong answer is a lapse", func(t *testing.T) {
+		// Setup
+		m, svc := setupTestService(t)
+		ctx := context.Background()
+		session, q := newFixture()
+
+		m.repo.On("FindSession", ctx, "session-1").Return(session, nil)
+		m.repo.On("FindQuestion", ctx, "session-1", 1).Return(q, nil)
+		m.repo.On("SaveAttempt", ctx, session, mock.AnythingOfType("*quiz.Attempt")).Return(nil)
+		m.reviewService.On("Grade", ctx, "user-1", "sw-1", review.GradeAgain, 5*time.Second, review.SourceQuiz).Return(nil, errors.New("database error"))
+
+		// Execute
+		result, err := svc.Answer(ctx, "user-1", "session-1", "q-2", "masculin")
+
+		// Assert
+		require.NoError(t, err, "a failed review update does not lose the attempt")
+		assert.False(t, result.Attempt.Correct)
+		assert.Equal(t, 50, result.Session.Score())
+		m.reviewService.AssertExpectations(t)
+	})
+
+	t.Run("Latency is capped", func(t *testing.T) {
+		// Setup
+		m, svc := setupTestService(t)
+		ctx := context.Background()
+		session, q := newFixture()
+		servedAt := testNow.Add(-30 * 24 * time.Hour)
+		q.ServedAt = &servedAt
+
+		m.repo.On("FindSession", ctx, "session-1").Return(session, nil)
+		m.repo.On("FindQuestion", ctx, "session-1", 1).Return(q, nil)
+		m.repo.On("SaveAttempt", ctx, session, mock.AnythingOfType("*quiz.Attempt")).Return(nil)
+		m.reviewService.On("Grade", ctx, "user-1", "sw-1", review.GradeGood, MaxLatency, review.SourceQuiz).Return(&review.Card{}, nil)
+
+		// Execute
+		result, err := svc.Answer(ctx, "user-1", "session-1", "q-2", "féminin")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, MaxLatency, result.Attempt.Latency)
+		m.reviewService.AssertExpectations(t)
+	})
+
+	t.Run("Not the current question", func(t *testing.T) {
+		// Setup
+		m, svc := setupTestService(t)
+		ctx := context.Background()
+		session, q := newFixture()
+
+		m.repo.On("FindSession", ctx, "session-1").Return(session, nil)
+		m.repo.On("FindQuestion", ctx, "session-1", 1).Return(q, nil)
+
+		// Execute
+		result, err := svc.Answer(ctx, "user-1", "session-1", "q-1", "féminin")
+
+		// Assert
+		assert.ErrorIs(t, err, ErrQuestionMismatch)
+		assert.Nil(t, result)
+		m.repo.AssertNotCalled(t, "SaveAttempt")
+	})
+
+	t.Run("Concurrent answer", func(t *testing.T) {
+		// Setup
+		m, svc := setupTestService(t)
+		ctx := context.Background()
+		session, q := newFixture()
+
+		m.repo.On("FindSession", ctx, "session-1").Return(session, nil)
+		m.repo.On("FindQuestion", ctx, "session-1", 1).Return(q, nil)
+		m.repo.On("SaveAttempt", ctx, session, mock.AnythingOfType("*quiz.Attempt")).Return(ErrAlreadyAnswered)
+
+		// Execute
+		_, err := svc.Answer(ctx, "user-1", "session-1", "q-2", "féminin")
+
+		// Assert
+		assert.ErrorIs(t, err, ErrAlreadyAnswered)
+		m.reviewService.AssertNotCalled(t, "Grade")
+	})
+}
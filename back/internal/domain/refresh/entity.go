@@ -0,0 +1,118 @@
+package refresh
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"voconsteroid/internal/domain/word"
+)
+
+// JobStatus is the state of a refresh job
+type JobStatus string
+
+// Job statuses
+const (
+	JobRunning     JobStatus = "running"
+	JobCompleted   JobStatus = "completed"
+	JobPaused      JobStatus = "paused"      // Stopped early because refresh was paused
+	JobFailed      JobStatus = "failed"      // Stopped by an error selecting words
+	JobInterrupted JobStatus = "interrupted" // Left running by a replica that stopped
+)
+
+// Job is a run of the refresh worker over a batch of words
+type Job struct {
+	ID         string     `json:"id"`
+	Status     JobStatus  `json:"status"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	Selected   int        `json:"selected"`  // Words selected for a refresh
+	Refreshed  int        `json:"refreshed"` // Words saved with the fetched version
+	Unchanged  int        `json:"unchanged"` // Words whose fetched version was not better
+	Failed     int        `json:"failed"`    // Words that could not be fetched
+	Error      string     `json:"error,omitempty"`
+}
+
+// NewJob creates a running job
+func NewJob(now time.Time) *Job {
+	return &Job{
+		ID:        uuid.New().String(),
+		Status:    JobRunning,
+		StartedAt: now,
+	}
+}
+
+// Finish records the end of the job
+func (j *Job) Finish(status JobStatus, now time.Time) {
+	j.Status = status
+	j.FinishedAt = &now
+}
+
+// Criteria select the words needing a refresh. Words checked since
+// CheckedBefore are never selected, so that words the dictionary cannot
+// improve are not fetched on every run.
+type Criteria struct {
+	StaleBefore   time.Time // Words neither updated nor checked since
+	CheckedBefore time.Time
+	ParserVersion int // Words fetched with an older parser
+}
+
+// IsBetter reports whether a fetched version of a word should replace the
+// stored one. It must have definitions. A version from a newer parser is
+// trusted, as parser changes may drop content the old parser got wrong;
+// otherwise it must hold at least as much content and differ from it.
+func IsBetter(stored, fetched *word.Word) bool {
+	if len(fetched.Definitions) == 0 {
+		return false
+	}
+	if fetched.ParserVersion() > stored.ParserVersion() {
+		return true
+	}
+	return contentScore(fetched) >= contentScore(stored) && !SameContent(stored, fetched)
+}
+
+// contentScore weighs the content of a word, definitions first
+func contentScore(w *word.Word) int {
+	score := 0
+	for _, def := range w.Definitions {
+		score += 3 + len(def.Examples)
+	}
+	if w.Etymology != "" {
+		score += 2
+	}
+	if w.Lemma != "" {
+		score++
+	}
+	return score + len(w.Synonyms) + len(w.Antonyms) + len(w.Translations) + len(w.UsageNotes)
+}
+
+// SameContent reports whether two versions of a word hold the same content,
+// ignoring identifiers, timestamps and scores. Words are compared as JSON so
+// that empty and missing lists are alike, as they are once stored.
+func SameContent(a, b *word.Word) bool {
+	aJSON, errA := json.Marshal(content(a))
+	bJSON, errB := json.Marshal(content(b))
+	return errA == nil && errB == nil && bytes.Equal(aJSON, bJSON)
+}
+
+// content strips a word down to what a dictionary fetch produces
+func content(w *word.Word) word.Word {
+	definitions := make([]word.Definition, len(w.Definitions))
+	for i, def := range w.Definitions {
+		def.CreatedAt, def.UpdatedAt = time.Time{}, time.Time{}
+		definitions[i] = def
+	}
+	return word.Word{
+		Text:         w.Text,
+		Language:     w.Language,
+		Definitions:  definitions,
+		Etymology:    w.Etymology,
+		Translations: w.Translations,
+		Synonyms:     w.Synonyms,
+		Antonyms:     w.Antonyms,
+		Lemma:        w.Lemma,
+		UsageNotes:   w.UsageNotes,
+	}
+}
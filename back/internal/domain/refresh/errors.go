@@ -0,0 +1,10 @@
+package refresh
+
+import "errors"
+
+// Domain errors
+var (
+	ErrJobRunning  = errors.New("a refresh job is already running")
+	ErrPaused      = errors.New("refresh is paused")
+	ErrJobNotFound = errors.New("refresh job not found")
+)
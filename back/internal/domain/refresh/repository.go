@@ -0,0 +1,35 @@
+package refresh
+
+import (
+	"context"
+	"time"
+
+	"voconsteroid/internal/domain/word"
+)
+
+// Repository defines the data access of the refresh worker
+type Repository interface {
+	// ListCandidates retrieves up to limit words matching the criteria, or
+	// without definitions, the emptiest and oldest first
+	ListCandidates(ctx context.Context, criteria Criteria, limit int) ([]*word.Word, error)
+
+	// MarkChecked records that a word was compared with the dictionary
+	MarkChecked(ctx context.Context, wordID string, at time.Time) error
+
+	// StartJob stores a running job, first marking the jobs started before
+	// expiredBefore and still running as interrupted. It returns
+	// ErrJobRunning if another job is running.
+	StartJob(ctx context.Context, job *Job, expiredBefore time.Time) error
+
+	// FinishJob stores the outcome of a job
+	FinishJob(ctx context.Context, job *Job) error
+
+	// ListJobs retrieves the most recent jobs first
+	ListJobs(ctx context.Context, limit int) ([]*Job, error)
+
+	// IsPaused reports whether refreshing is paused
+	IsPaused(ctx context.Context) (bool, error)
+
+	// SetPaused pauses or resumes refreshing
+	SetPaused(ctx context.Context, paused bool, at time.Time) error
+}
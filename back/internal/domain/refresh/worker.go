@@ -0,0 +1,239 @@
+// Package refresh fetches stored words again in the background, keeping
+// them up to date with the dictionary and repairing words broken by older
+// parsers.
+package refresh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
+
+	"voconsteroid/internal/domain/word"
+)
+
+// Config tunes the refresh worker
+type Config struct {
+	Interval      time.Duration // Delay between jobs
+	BatchSize     int           // Words selected per job
+	Concurrency   int           // Words fetched at once
+	StaleAfter    time.Duration // Age after which a word is fetched again
+	RecheckAfter  time.Duration // Delay before a checked word may be selected again
+	JobExpiry     time.Duration // Time after which a running job is considered interrupted
+	ParserVersion int           // Version of the current dictionary parsers
+}
+
+// DefaultConfig returns the default worker settings
+func DefaultConfig() Config {
+	return Config{
+		Interval:     time.Hour,
+		BatchSize:    50,
+		Concurrency:  2,
+		StaleAfter:   90 * 24 * time.Hour,
+		RecheckAfter: 7 * 24 * time.Hour,
+		JobExpiry:    time.Hour,
+	}
+}
+
+// Worker periodically fetches stale, incomplete or outdated words again and
+// saves the fetched version when it is better. Replicas may all run a
+// worker: only one job runs at a time.
+type Worker struct {
+	repo      Repository
+	words     word.Repository
+	dictAPI   word.DictionaryAPI
+	frequency word.FrequencyIndex
+	cfg       Config
+	clock     func() time.Time
+	logger    zerolog.Logger
+}
+
+// NewWorker creates a new refresh worker. Words are saved through words,
+// so that caches in front of the repository are evicted, and fetched from
+// dictAPI, which should not be cached.
+func NewWorker(repo Repository, words word.Repository, dictAPI word.DictionaryAPI, frequency word.FrequencyIndex, cfg Config, clock func() time.Time, logger zerolog.Logger) *Worker {
+	if clock == nil {
+		clock = time.Now
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 1
+	}
+	return &Worker{
+		repo:      repo,
+		words:     words,
+		dictAPI:   dictAPI,
+		frequency: frequency,
+		cfg:       cfg,
+		clock:     clock,
+		logger:    logger.With().Str("component", "refresh_worker").Logger(),
+	}
+}
+
+// Run runs a job every interval until the context is canceled
+func (w *Worker) Run(ctx context.Context) error {
+	w.logger.Info().Dur("interval", w.cfg.Interval).Msg("Starting refresh worker")
+
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info().Msg("Stopping refresh worker")
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		job, err := w.RunOnce(ctx)
+		switch {
+		case errors.Is(err, ErrPaused), errors.Is(err, ErrJobRunning):
+			w.logger.Debug().Err(err).Msg("Skipping refresh job")
+		case err != nil && !errors.Is(err, context.Canceled):
+			w.logger.Error().Err(err).Msg("Refresh job failed")
+		case job != nil:
+			w.logger.Info().Str("jobID", job.ID).Str("status", string(job.Status)).
+				Int("selected", job.Selected).Int("refreshed", job.Refreshed).
+				Int("unchanged", job.Unchanged).Int("failed", job.Failed).Msg("Refresh job finished")
+		}
+	}
+}
+
+// RunOnce runs a job over a batch of words and returns it once finished
+func (w *Worker) RunOnce(ctx context.Context) (*Job, error) {
+	paused, err := w.repo.IsPaused(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pause state: %w", err)
+	}
+	if paused {
+		return nil, ErrPaused
+	}
+
+	now := w.clock()
+	job := NewJob(now)
+	if err := w.repo.StartJob(ctx, job, now.Add(-w.cfg.JobExpiry)); err != nil {
+		return nil, err
+	}
+
+	status := JobCompleted
+	if err := w.refresh(ctx, job); err != nil {
+		job.Error = err.Error()
+		status = JobFailed
+		if errors.Is(err, ErrPaused) {
+			job.Error, status = "", JobPaused
+		}
+	}
+
+	// Record the outcome even when the context was canceled
+	job.Finish(status, w.clock())
+	if err := w.repo.FinishJob(context.WithoutCancel(ctx), job); err != nil {
+		return job, fmt.Errorf("failed to record job: %w", err)
+	}
+	return job, nil
+}
+
+// Pause stops refreshing, including the running job after its current words
+func (w *Worker) Pause(ctx context.Context) error {
+	return w.repo.SetPaused(ctx, true, w.clock())
+}
+
+// Resume lets refreshing start again at the next interval
+func (w *Worker) Resume(ctx context.Context) error {
+	return w.repo.SetPaused(ctx, false, w.clock())
+}
+
+// Paused reports whether refreshing is paused
+func (w *Worker) Paused(ctx context.Context) (bool, error) {
+	return w.repo.IsPaused(ctx)
+}
+
+// History returns the most recent jobs first
+func (w *Worker) History(ctx context.Context, limit int) ([]*Job, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	return w.repo.ListJobs(ctx, limit)
+}
+
+// refresh selects the words of a job and refreshes them, a few at a time
+func (w *Worker) refresh(ctx context.Context, job *Job) error {
+	now := w.clock()
+	words, err := w.repo.ListCandidates(ctx, Criteria{
+		StaleBefore:   now.Add(-w.cfg.StaleAfter),
+		CheckedBefore: now.Add(-w.cfg.RecheckAfter),
+		ParserVersion: w.cfg.ParserVersion,
+	}, w.cfg.BatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to select words: %w", err)
+	}
+	job.Selected = len(words)
+
+	var mu sync.Mutex
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(w.cfg.Concurrency)
+	for _, stored := range words {
+		// Stop between words when paused from elsewhere
+		if paused, err := w.repo.IsPaused(groupCtx); err == nil && paused {
+			group.Wait()
+			return ErrPaused
+		}
+
+		group.Go(func() error {
+			outcome := w.refreshWord(groupCtx, stored)
+			mu.Lock()
+			defer mu.Unlock()
+			switch outcome {
+			case outcomeRefreshed:
+				job.Refreshed++
+			case outcomeUnchanged:
+				job.Unchanged++
+			default:
+				job.Failed++
+			}
+			return groupCtx.Err()
+		})
+	}
+	return group.Wait()
+}
+
+// outcome of refreshing a word
+type outcome int
+
+const (
+	outcomeRefreshed outcome = iota
+	outcomeUnchanged
+	outcomeFailed
+)
+
+// refreshWord fetches a word again and saves the fetched version if it is
+// better. Words the dictionary no longer knows are kept.
+func (w *Worker) refreshWord(ctx context.Context, stored *word.Word) outcome {
+	log := w.logger.With().Str("wordID", stored.ID).Str("text", stored.Text).Logger()
+
+	fetched, err := w.dictAPI.FetchWord(ctx, stored.Text, stored.Language)
+	if err != nil && !errors.Is(err, word.ErrWordNotFound) {
+		log.Warn().Err(err).Msg("Failed to fetch word again")
+		return outcomeFailed
+	}
+
+	result := outcomeUnchanged
+	if err == nil && IsBetter(stored, fetched) {
+		fetched.ID = stored.ID
+		fetched.CreatedAt = stored.CreatedAt
+		fetched.ScoreDifficulty(w.frequency)
+		if err := w.words.Save(ctx, fetched, nil); err != nil {
+			log.Error().Err(err).Msg("Failed to save refreshed word")
+			return outcomeFailed
+		}
+		log.Debug().Msg("Word refreshed")
+		result = outcomeRefreshed
+	}
+
+	if err := w.repo.MarkChecked(ctx, stored.ID, w.clock()); err != nil {
+		log.Error().Err(err).Msg("Failed to mark word checked")
+	}
+	return result
+}
@@ -0,0 +1,345 @@
+package refresh
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"voconsteroid/internal/domain/event"
+	"voconsteroid/internal/domain/word"
+)
+
+var testNow = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// MockRepository is a mock implementation of the Repository interface
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) ListCandidates(ctx context.Context, criteria Criteria, limit int) ([]*word.Word, error) {
+	args := m.Called(ctx, criteria, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*word.Word), args.Error(1)
+}
+
+func (m *MockRepository) MarkChecked(ctx context.Context, wordID string, at time.Time) error {
+	args := m.Called(ctx, wordID, at)
+	return args.Error(0)
+}
+
+func (m *MockRepository) StartJob(ctx context.Context, job *Job, expiredBefore time.Time) error {
+	args := m.Called(ctx, job, expiredBefore)
+	return args.Error(0)
+}
+
+func (m *MockRepository) FinishJob(ctx context.Context, job *Job) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListJobs(ctx context.Context, limit int) ([]*Job, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Job), args.Error(1)
+}
+
+func (m *MockRepository) IsPaused(ctx context.Context) (bool, error) {
+	args := m.Called(ctx)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) SetPaused(ctx context.Context, paused bool, at time.Time) error {
+	args := m.Called(ctx, paused, at)
+	return args.Error(0)
+}
+
+// MockWordRepository is a mock implementation of the word.Repository interface
+type MockWordRepository struct {
+	mock.Mock
+}
+
+func (m *MockWordRepository) FindByID(ctx context.Context, id string) (*word.Word, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) FindByText(ctx context.Context, text, language string) (*word.Word, error) {
+	args := m.Called(ctx, text, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) FindByAnyForm(ctx context.Context, text, language string) (*word.Word, error) {
+	args := m.Called(ctx, text, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) Save(ctx context.Context, w *word.Word, emit event.Emit) error {
+	args := m.Called(ctx, w)
+	return args.Error(0)
+}
+
+func (m *MockWordRepository) List(ctx context.Context, filter map[string]interface{}, limit, offset int) ([]*word.Word, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) FindByPrefix(ctx context.Context, prefix, language string, limit int) ([]*word.Word, error) {
+	args := m.Called(ctx, prefix, language, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) FindSuggestions(ctx context.Context, prefix, language string, limit int) ([]string, error) {
+	args := m.Called(ctx, prefix, language, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// MockDictionaryAPI is a mock implementation of the word.DictionaryAPI interface
+type MockDictionaryAPI struct {
+	mock.Mock
+}
+
+func (m *MockDictionaryAPI) FetchWord(ctx context.Context, text, language string) (*word.Word, error) {
+	args := m.Called(ctx, text, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.Word), args.Error(1)
+}
+
+func (m *MockDictionaryAPI) FetchRelatedWords(ctx context.Context, w *word.Word) (*word.RelatedWords, error) {
+	args := m.Called(ctx, w)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.RelatedWords), args.Error(1)
+}
+
+func (m *MockDictionaryAPI) FetchSuggestions(ctx context.Context, prefix, language string) ([]string, error) {
+	args := m.Called(ctx, prefix, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// setupTestWorker creates a worker with mocks and a fixed clock
+func setupTestWorker(t *testing.T) (*MockRepository, *MockWordRepository, *MockDictionaryAPI, *Worker) {
+	repo := new(MockRepository)
+	words := new(MockWordRepository)
+	dictAPI := new(MockDictionaryAPI)
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	cfg := DefaultConfig()
+	cfg.ParserVersion = 2
+	return repo, words, dictAPI, NewWorker(repo, words, dictAPI, nil, cfg, func() time.Time { return testNow }, logger)
+}
+
+func TestIsBetter(t *testing.T) {
+	stored := &word.Word{
+		ID: "id-1", Text: "maison", Language: "fr", CreatedAt: testNow,
+		Definitions: []word.Definition{{Text: "Bâtiment", WordType: "nom"}},
+	}
+
+	cases := []struct {
+		name    string
+		fetched *word.Word
+		want    bool
+	}{
+		{"no definitions", &word.Word{Text: "maison", Language: "fr"}, false},
+		{"same content", &word.Word{
+			Text: "maison", Language: "fr", Synonyms: []string{},
+			Definitions: []word.Definition{{Text: "Bâtiment", WordType: "nom"}},
+		}, false},
+		{"more content", &word.Word{
+			Text: "maison", Language: "fr", Etymology: "Du latin mansio",
+			Definitions: []word.Definition{{Text: "Bâtiment", WordType: "nom"}},
+		}, true},
+		{"same content from a newer parser", &word.Word{
+			Text: "maison", Language: "fr", Provenance: &word.Provenance{Source: "frwiktionary", ParserVersion: 1},
+			Definitions: []word.Definition{{Text: "Bâtiment", WordType: "nom"}},
+		}, true},
+		{"reworded", &word.Word{
+			Text: "maison", Language: "fr",
+			Definitions: []word.Definition{{Text: "Bâtiment d'habitation", WordType: "nom"}},
+		}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, IsBetter(stored, tc.fetched))
+		})
+	}
+
+	t.Run("fewer definitions", func(t *testing.T) {
+		rich := &word.Word{Text: "maison", Language: "fr", Definitions: []word.Definition{{Text: "Bâtiment"}, {Text: "Famille"}}}
+		poor := &word.Word{Text: "maison", Language: "fr", Definitions: []word.Definition{{Text: "Bâtiment"}}}
+		assert.False(t, IsBetter(rich, poor))
+	})
+
+	t.Run("fewer definitions from a newer parser", func(t *testing.T) {
+		rich := &word.Word{Text: "maison", Language: "fr", Definitions: []word.Definition{{Text: "Bâtiment"}, {Text: "Famille"}}}
+		poor := &word.Word{
+			Text: "maison", Language: "fr", Provenance: &word.Provenance{Source: "frwiktionary", ParserVersion: 1},
+			Definitions: []word.Definition{{Text: "Bâtiment"}},
+		}
+		assert.True(t, IsBetter(rich, poor))
+	})
+}
+
+func TestWorker_RunOnce(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("refreshes candidates", func(t *testing.T) {
+		// Setup
+		repo, words, dictAPI, worker := setupTestWorker(t)
+		created := testNow.Add(-365 * 24 * time.Hour)
+		empty := &word.Word{ID: "id-1", Text: "maison", Language: "fr", CreatedAt: created}
+		current := &word.Word{ID: "id-2", Text: "chat", Language: "fr", Definitions: []word.Definition{{Text: "Félin"}}}
+		gone := &word.Word{ID: "id-3", Text: "disparu", Language: "fr"}
+		broken := &word.Word{ID: "id-4", Text: "chien", Language: "fr"}
+
+		repo.On("IsPaused", mock.Anything).Return(false, nil)
+		repo.On("StartJob", ctx, mock.Anything, testNow.Add(-time.Hour)).Return(nil)
+		repo.On("ListCandidates", ctx, Criteria{
+			StaleBefore:   testNow.Add(-90 * 24 * time.Hour),
+			CheckedBefore: testNow.Add(-7 * 24 * time.Hour),
+			ParserVersion: 2,
+		}, 50).Return([]*word.Word{empty, current, gone, broken}, nil)
+		fetched := &word.Word{ID: "new-id", Text: "maison", Language: "fr", Definitions: []word.Definition{{Text: "Bâtiment"}}}
+		dictAPI.On("FetchWord", mock.Anything, "maison", "fr").Return(fetched, nil)
+		dictAPI.On("FetchWord", mock.Anything, "chat", "fr").Return(&word.Word{Text: "chat", Language: "fr", Definitions: []word.Definition{{Text: "Félin"}}}, nil)
+		dictAPI.On("FetchWord", mock.Anything, "disparu", "fr").Return(nil, word.ErrWordNotFound)
+		dictAPI.On("FetchWord", mock.Anything, "chien", "fr").Return(nil, word.ErrDictionaryUnavailable)
+		words.On("Save", mock.Anything, fetched).Return(nil)
+		repo.On("MarkChecked", mock.Anything, mock.Anything, testNow).Return(nil)
+		repo.On("FinishJob", mock.Anything, mock.Anything).Return(nil)
+
+		// Execute
+		job, err := worker.RunOnce(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, JobCompleted, job.Status)
+		assert.Equal(t, 4, job.Selected)
+		assert.Equal(t, 1, job.Refreshed)
+		assert.Equal(t, 2, job.Unchanged)
+		assert.Equal(t, 1, job.Failed)
+		assert.Equal(t, "id-1", fetched.ID)
+		assert.Equal(t, created, fetched.CreatedAt)
+		repo.AssertNumberOfCalls(t, "MarkChecked", 3)
+		repo.AssertNotCalled(t, "MarkChecked", mock.Anything, "id-4", mock.Anything)
+		repo.AssertCalled(t, "FinishJob", mock.Anything, job)
+		words.AssertExpectations(t)
+	})
+
+	t.Run("skipped while paused", func(t *testing.T) {
+		// Setup
+		repo, _, _, worker := setupTestWorker(t)
+		repo.On("IsPaused", ctx).Return(true, nil)
+
+		// Execute
+		_, err := worker.RunOnce(ctx)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrPaused)
+		repo.AssertNotCalled(t, "StartJob", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("stops when paused during the job", func(t *testing.T) {
+		// Setup
+		repo, _, dictAPI, worker := setupTestWorker(t)
+		repo.On("IsPaused", mock.Anything).Return(false, nil).Twice()
+		repo.On("IsPaused", mock.Anything).Return(true, nil)
+		repo.On("StartJob", ctx, mock.Anything, mock.Anything).Return(nil)
+		repo.On("ListCandidates", ctx, mock.Anything, 50).Return([]*word.Word{
+			{ID: "id-1", Text: "maison", Language: "fr"},
+			{ID: "id-2", Text: "chat", Language: "fr"},
+		}, nil)
+		dictAPI.On("FetchWord", mock.Anything, "maison", "fr").Return(nil, word.ErrWordNotFound)
+		repo.On("MarkChecked", mock.Anything, "id-1", testNow).Return(nil)
+		repo.On("FinishJob", mock.Anything, mock.Anything).Return(nil)
+
+		// Execute
+		job, err := worker.RunOnce(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, JobPaused, job.Status)
+		assert.Equal(t, 1, job.Unchanged)
+		dictAPI.AssertNotCalled(t, "FetchWord", mock.Anything, "chat", "fr")
+	})
+
+	t.Run("another job running", func(t *testing.T) {
+		// Setup
+		repo, _, _, worker := setupTestWorker(t)
+		repo.On("IsPaused", ctx).Return(false, nil)
+		repo.On("StartJob", ctx, mock.Anything, mock.Anything).Return(ErrJobRunning)
+
+		// Execute
+		_, err := worker.RunOnce(ctx)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrJobRunning)
+		repo.AssertNotCalled(t, "ListCandidates", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("selection failure is recorded", func(t *testing.T) {
+		// Setup
+		repo, _, _, worker := setupTestWorker(t)
+		repo.On("IsPaused", ctx).Return(false, nil)
+		repo.On("StartJob", ctx, mock.Anything, mock.Anything).Return(nil)
+		repo.On("ListCandidates", ctx, mock.Anything, 50).Return(nil, errors.New("db down"))
+		repo.On("FinishJob", mock.Anything, mock.Anything).Return(nil)
+
+		// Execute
+		job, err := worker.RunOnce(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, JobFailed, job.Status)
+		assert.Contains(t, job.Error, "db down")
+		require.NotNil(t, job.FinishedAt)
+	})
+}
+
+func TestWorker_PauseResume(t *testing.T) {
+	// Setup
+	repo, _, _, worker := setupTestWorker(t)
+	ctx := context.Background()
+	repo.On("SetPaused", ctx, true, testNow).Return(nil)
+	repo.On("SetPaused", ctx, false, testNow).Return(nil)
+
+	// Execute
+	require.NoError(t, worker.Pause(ctx))
+	require.NoError(t, worker.Resume(ctx))
+
+	// Assert
+	repo.AssertExpectations(t)
+}
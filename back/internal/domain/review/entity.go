@@ -0,0 +1,137 @@
+package review
+
+import (
+	"time"
+
+	"voconsteroid/internal/domain/savedword"
+	"voconsteroid/internal/domain/word"
+)
+
+// DefaultEase is the SM-2 easiness factor of a card that was never reviewed
+const DefaultEase = 2.5
+
+// MasteredInterval is the interval, in days, from which a card counts as mastered
+const MasteredInterval = 21
+
+// Grade is the answer quality given when reviewing a card
+type Grade int
+
+// Review grades, from forgotten to effortless recall
+const (
+	GradeAgain Grade = 1
+	GradeHard  Grade = 2
+	GradeGood  Grade = 3
+	GradeEasy  Grade = 4
+)
+
+// IsValid checks if the grade is one of the supported grades
+func (g Grade) IsValid() bool {
+	return g >= GradeAgain && g <= GradeEasy
+}
+
+// String returns the name of the grade
+func (g Grade) String() string {
+	switch g {
+	case GradeAgain:
+		return "again"
+	case GradeHard:
+		return "hard"
+	case GradeGood:
+		return "good"
+	case GradeEasy:
+		return "easy"
+	}
+	return "unknown"
+}
+
+// Source is where a review was answered
+type Source string
+
+// Review sources
+const (
+	SourceQueue Source = "queue" // The review queue
+	SourceQuiz  Source = "quiz"  // A quiz answer
+)
+
+// State is the learning state of a card
+type State string
+
+// Card states
+const (
+	StateNew        State = "new"        // Never reviewed
+	StateReview     State = "review"     // Last answer was a successful recall
+	StateRelearning State = "relearning" // Last answer was a lapse
+)
+
+// Card holds the memory state of a saved word for spaced repetition.
+// A saved word has at most one card, so the saved word ID identifies it.
+type Card struct {
+	SavedWordID    string     `json:"saved_word_id"`
+	UserID         string     `json:"user_id"`
+	State          State      `json:"state"`
+	Ease           float64    `json:"ease"`       // SM-2 easiness factor
+	Stability      float64    `json:"stability"`  // FSRS stability, in days
+	Difficulty     float64    `json:"difficulty"` // FSRS difficulty, from 1 to 10
+	IntervalDays   int        `json:"interval_days"`
+	Repetitions    int        `json:"repetitions"` // Consecutive successful reviews
+	Lapses         int        `json:"lapses"`
+	Due            time.Time  `json:"due"`
+	LastReviewedAt *time.Time `json:"last_reviewed_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// Log records a single review of a card
+type Log struct {
+	ID            string        `json:"id"`
+	SavedWordID   string        `json:"saved_word_id"`
+	UserID        string        `json:"user_id"`
+	Grade         Grade         `json:"grade"`
+	State         State         `json:"state"`          // State before the review
+	ElapsedDays   int           `json:"elapsed_days"`   // Days since the previous review
+	ScheduledDays int           `json:"scheduled_days"` // Interval given by the review
+	Duration      time.Duration `json:"duration"`       // Time spent answering, if known
+	ReviewedAt    time.Time     `json:"reviewed_at"`
+}
+
+// NewCard creates a new card for a saved word, due immediately
+func NewCard(savedWordID, userID string, now time.Time) *Card {
+	return &Card{
+		SavedWordID: savedWordID,
+		UserID:      userID,
+		State:       StateNew,
+		Ease:        DefaultEase,
+		Due:         now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// IsDue reports whether the card should be reviewed at the given time
+func (c *Card) IsDue(now time.Time) bool {
+	return !c.Due.After(now)
+}
+
+// IsMastered reports whether the card reached a long enough interval
+func (c *Card) IsMastered() bool {
+	return c.IntervalDays >= MasteredInterval
+}
+
+// ElapsedDays returns the number of days since the last review
+func (c *Card) ElapsedDays(now time.Time) float64 {
+	if c.LastReviewedAt == nil {
+		return 0
+	}
+	elapsed := now.Sub(*c.LastReviewedAt).Hours() / 24
+	if elapsed < 0 {
+		return 0
+	}
+	return elapsed
+}
+
+// DueItem is a card due for review together with the word to review
+type DueItem struct {
+	Card      *Card                `json:"card"`
+	SavedWord *savedword.SavedWord `json:"saved_word"`
+	Word      *word.Word           `json:"word"`
+}
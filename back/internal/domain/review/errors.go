@@ -0,0 +1,10 @@
+package review
+
+import "errors"
+
+// Domain errors
+var (
+	ErrCardNotFound     = errors.New("review card not found")
+	ErrInvalidGrade     = errors.New("grade must be between 1 (again) and 4 (easy)")
+	ErrUnknownScheduler = errors.New("unknown review scheduler")
+)
@@ -0,0 +1,15 @@
+package review
+
+// EventReviewGraded is the name of the ReviewGraded event
+const EventReviewGraded = "review.graded"
+
+// ReviewGraded is published when a review is graded, from the review queue
+// or from a quiz answer
+type ReviewGraded struct {
+	Log    *Log   `json:"log"`
+	Card   *Card  `json:"card"` // State after the review
+	Source Source `json:"source"`
+}
+
+// EventName identifies the event type
+func (ReviewGraded) EventName() string { return EventReviewGraded }
@@ -0,0 +1,122 @@
+package review
+
+import (
+	"math"
+	"time"
+)
+
+// DefaultDesiredRetention is the recall probability FSRS aims for at the due date
+const DefaultDesiredRetention = 0.9
+
+// maxIntervalDays caps FSRS intervals at about a hundred years
+const maxIntervalDays = 36500
+
+// FSRS forgetting curve constants
+const (
+	fsrsDecay  = -0.5
+	fsrsFactor = 19.0 / 81.0
+)
+
+// fsrsWeights are the default FSRS-4.5 model parameters
+var fsrsWeights = [17]float64{
+	0.4872, 1.4003, 3.7145, 13.8206, 5.1618, 1.2298, 0.8975, 0.031,
+	1.6474, 0.1367, 1.0461, 2.1072, 0.0793, 0.3246, 1.587, 0.2272, 2.8755,
+}
+
+// fsrsScheduler implements the Free Spaced Repetition Scheduler (FSRS-4.5)
+type fsrsScheduler struct {
+	w                [17]float64
+	desiredRetention float64
+}
+
+// Ensure fsrsScheduler implements Scheduler interface
+var _ Scheduler = (*fsrsScheduler)(nil)
+
+// NewFSRSScheduler creates an FSRS scheduler with the default parameters
+func NewFSRSScheduler(desiredRetention float64) Scheduler {
+	if desiredRetention <= 0 || desiredRetention >= 1 {
+		desiredRetention = DefaultDesiredRetention
+	}
+	return &fsrsScheduler{
+		w:                fsrsWeights,
+		desiredRetention: desiredRetention,
+	}
+}
+
+// Name implements Scheduler
+func (s *fsrsScheduler) Name() string {
+	return "fsrs"
+}
+
+// Schedule implements Scheduler
+func (s *fsrsScheduler) Schedule(card Card, grade Grade, now time.Time) Card {
+	g := float64(grade)
+
+	if card.State == StateNew || card.Stability == 0 {
+		card.Stability = s.initialStability(grade)
+		card.Difficulty = s.initialDifficulty(g)
+		// Cards reviewed by another scheduler keep their current interval as stability
+		if card.State != StateNew && card.IntervalDays > 0 && grade != GradeAgain {
+			card.Stability = math.Max(card.Stability, float64(card.IntervalDays))
+		}
+	} else {
+		r := s.retrievability(card.ElapsedDays(now), card.Stability)
+		if grade == GradeAgain {
+			card.Stability = s.forgetStability(card.Difficulty, card.Stability, r)
+		} else {
+			card.Stability = s.recallStability(card.Difficulty, card.Stability, r, grade)
+		}
+		card.Difficulty = s.nextDifficulty(card.Difficulty, g)
+	}
+
+	applyInterval(&card, grade, s.interval(card.Stability), now)
+	return card
+}
+
+// retrievability is the probability of recall after elapsed days
+func (s *fsrsScheduler) retrievability(elapsedDays, stability float64) float64 {
+	return math.Pow(1+fsrsFactor*elapsedDays/stability, fsrsDecay)
+}
+
+// interval returns the number of days until recall probability drops to the desired retention
+func (s *fsrsScheduler) interval(stability float64) int {
+	days := stability / fsrsFactor * (math.Pow(s.desiredRetention, 1/fsrsDecay) - 1)
+	return int(math.Min(math.Max(math.Round(days), 1), maxIntervalDays))
+}
+
+func (s *fsrsScheduler) initialStability(grade Grade) float64 {
+	return math.Max(s.w[grade-1], 0.1)
+}
+
+func (s *fsrsScheduler) initialDifficulty(g float64) float64 {
+	return clampDifficulty(s.w[4] - (g-3)*s.w[5])
+}
+
+// nextDifficulty moves difficulty with the grade, with mean reversion towards a "good" first answer
+func (s *fsrsScheduler) nextDifficulty(d, g float64) float64 {
+	next := d - s.w[6]*(g-3)
+	return clampDifficulty(s.w[7]*s.initialDifficulty(3) + (1-s.w[7])*next)
+}
+
+func (s *fsrsScheduler) recallStability(d, stability, r float64, grade Grade) float64 {
+	hardPenalty, easyBonus := 1.0, 1.0
+	if grade == GradeHard {
+		hardPenalty = s.w[15]
+	}
+	if grade == GradeEasy {
+		easyBonus = s.w[16]
+	}
+
+	return stability * (1 + math.Exp(s.w[8])*(11-d)*math.Pow(stability, -s.w[9])*
+		(math.Exp((1-r)*s.w[10])-1)*hardPenalty*easyBonus)
+}
+
+func (s *fsrsScheduler) forgetStability(d, stability, r float64) float64 {
+	next := s.w[11] * math.Pow(d, -s.w[12]) * (math.Pow(stability+1, s.w[13]) - 1) * math.Exp((1-r)*s.w[14])
+	// A lapse never makes a memory more stable than it was
+	return math.Min(next, stability)
+}
+
+func clampDifficulty(d float64) float64 {
+	return math.Min(math.Max(d, 1), 10)
+}
@@ -0,0 +1,24 @@
+package review
+
+import (
+	"context"
+	"time"
+
+	"voconsteroid/internal/domain/event"
+)
+
+// Repository defines the interface for review data access
+type Repository interface {
+	// FindBySavedWord retrieves the card of a saved word
+	FindBySavedWord(ctx context.Context, savedWordID string) (*Card, error)
+
+	// CreateMissingCards creates new cards for the user's saved words that have none
+	CreateMissingCards(ctx context.Context, userID string, now time.Time) error
+
+	// ListDue retrieves the user's cards due at the given time, earliest first
+	ListDue(ctx context.Context, userID string, now time.Time, limit int) ([]*Card, error)
+
+	// Save stores the state of a card and, when given, the log of the review
+	// that produced it, running emit, when not nil, in the same transaction
+	Save(ctx context.Context, card *Card, log *Log, emit event.Emit) error
+}
@@ -0,0 +1,48 @@
+package review
+
+import (
+	"fmt"
+	"time"
+)
+
+// Scheduler computes the next memory state of a card after a review
+type Scheduler interface {
+	// Name returns the identifier of the scheduling algorithm
+	Name() string
+
+	// Schedule returns the state of the card after it was graded at now.
+	// The given card is not modified.
+	Schedule(card Card, grade Grade, now time.Time) Card
+}
+
+// Clock returns the current time; tests inject a fixed clock
+type Clock func() time.Time
+
+// NewScheduler returns the scheduler registered under name
+func NewScheduler(name string) (Scheduler, error) {
+	switch name {
+	case "sm2":
+		return NewSM2Scheduler(), nil
+	case "fsrs":
+		return NewFSRSScheduler(DefaultDesiredRetention), nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownScheduler, name)
+}
+
+// applyInterval updates the fields shared by all schedulers once the next interval is known
+func applyInterval(card *Card, grade Grade, intervalDays int, now time.Time) {
+	if grade == GradeAgain {
+		card.State = StateRelearning
+		card.Repetitions = 0
+		card.Lapses++
+	} else {
+		card.State = StateReview
+		card.Repetitions++
+	}
+
+	reviewedAt := now
+	card.IntervalDays = intervalDays
+	card.Due = now.AddDate(0, 0, intervalDays)
+	card.LastReviewedAt = &reviewedAt
+	card.UpdatedAt = now
+}
@@ -0,0 +1,100 @@
+package review
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testNow is the fixed time used by deterministic scheduling tests
+var testNow = time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+// reviewOnDueDates grades a new card repeatedly, each time on its due date,
+// and returns the intervals given by the scheduler
+func reviewOnDueDates(s Scheduler, grades ...Grade) ([]int, Card) {
+	now := testNow
+	card := *NewCard("sw-1", "user-1", now)
+	intervals := make([]int, 0, len(grades))
+
+	for _, g := range grades {
+		card = s.Schedule(card, g, now)
+		intervals = append(intervals, card.IntervalDays)
+		now = card.Due
+	}
+
+	return intervals, card
+}
+
+func TestNewScheduler(t *testing.T) {
+	for _, name := range []string{"sm2", "fsrs"} {
+		s, err := NewScheduler(name)
+		require.NoError(t, err)
+		assert.Equal(t, name, s.Name())
+	}
+
+	_, err := NewScheduler("leitner")
+	assert.ErrorIs(t, err, ErrUnknownScheduler)
+}
+
+func TestSM2Scheduler(t *testing.T) {
+	intervals, card := reviewOnDueDates(NewSM2Scheduler(), GradeGood, GradeGood, GradeGood, GradeAgain, GradeGood)
+
+	assert.Equal(t, []int{1, 6, 15, 1, 1}, intervals)
+	assert.Equal(t, 1, card.Lapses)
+	assert.Equal(t, 1, card.Repetitions)
+	assert.InDelta(t, 1.96, card.Ease, 1e-9)
+	assert.Equal(t, StateReview, card.State)
+}
+
+func TestSM2Scheduler_MinimumEase(t *testing.T) {
+	_, card := reviewOnDueDates(NewSM2Scheduler(), GradeAgain, GradeAgain, GradeAgain, GradeAgain, GradeAgain)
+
+	assert.Equal(t, minEase, card.Ease)
+	assert.Equal(t, 5, card.Lapses)
+	assert.Equal(t, StateRelearning, card.State)
+}
+
+func TestFSRSScheduler(t *testing.T) {
+	intervals, card := reviewOnDueDates(NewFSRSScheduler(DefaultDesiredRetention), GradeGood, GradeGood, GradeGood, GradeAgain, GradeGood)
+
+	assert.Equal(t, []int{4, 15, 49, 6, 17}, intervals)
+	assert.Equal(t, 1, card.Lapses)
+	assert.InDelta(t, 16.6204, card.Stability, 1e-4)
+	assert.InDelta(t, 6.8472, card.Difficulty, 1e-4)
+}
+
+func TestFSRSScheduler_GradesOrderIntervals(t *testing.T) {
+	s := NewFSRSScheduler(DefaultDesiredRetention)
+	_, reviewed := reviewOnDueDates(s, GradeGood, GradeGood)
+	now := reviewed.Due
+
+	// On the same card, a better grade never gives a shorter interval
+	previous := 0
+	for _, g := range []Grade{GradeAgain, GradeHard, GradeGood, GradeEasy} {
+		next := s.Schedule(reviewed, g, now)
+		assert.GreaterOrEqual(t, next.IntervalDays, previous, "grade %d", g)
+		previous = next.IntervalDays
+	}
+}
+
+func TestFSRSScheduler_HigherRetentionShortensIntervals(t *testing.T) {
+	relaxed, _ := reviewOnDueDates(NewFSRSScheduler(0.8), GradeGood, GradeGood)
+	strict, _ := reviewOnDueDates(NewFSRSScheduler(0.95), GradeGood, GradeGood)
+
+	assert.Greater(t, relaxed[1], strict[1])
+}
+
+func TestSchedule_DoesNotModifyInput(t *testing.T) {
+	card := *NewCard("sw-1", "user-1", testNow)
+
+	for _, s := range []Scheduler{NewSM2Scheduler(), NewFSRSScheduler(DefaultDesiredRetention)} {
+		next := s.Schedule(card, GradeGood, testNow)
+
+		assert.Equal(t, StateNew, card.State)
+		assert.Nil(t, card.LastReviewedAt)
+		assert.Equal(t, testNow, *next.LastReviewedAt)
+		assert.True(t, next.Due.After(testNow))
+	}
+}
@@ -0,0 +1,16 @@
+package review
+
+import (
+	"context"
+	"time"
+)
+
+// Service defines the interface for spaced repetition business logic
+type Service interface {
+	// GetDue retrieves the saved words a user should review now
+	GetDue(ctx context.Context, userID string, limit int) ([]*DueItem, error)
+
+	// Grade records the answer to a review and schedules the next one. The
+	// source tells where the review was answered.
+	Grade(ctx context.Context, userID, savedWordID string, grade Grade, duration time.Duration, source Source) (*Card, error)
+}
@@ -0,0 +1,180 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/event"
+	"voconsteroid/internal/domain/savedword"
+	"voconsteroid/internal/domain/word"
+)
+
+// Ensure service implements Service interface
+var _ Service = (*service)(nil)
+
+// service implements the Service interface
+type service struct {
+	repo          Repository
+	savedWordRepo savedword.Repository
+	wordRepo      word.Repository
+	scheduler     Scheduler
+	clock         Clock
+	events        event.Publisher
+	logger        zerolog.Logger
+}
+
+// NewService creates a new review service. Graded reviews are published as
+// events; a nil publisher drops them.
+func NewService(repo Repository, savedWordRepo savedword.Repository, wordRepo word.Repository, scheduler Scheduler, clock Clock, events event.Publisher, logger zerolog.Logger) Service {
+	if clock == nil {
+		clock = time.Now
+	}
+	if events == nil {
+		events = event.Nop
+	}
+	return &service{
+		repo:          repo,
+		savedWordRepo: savedWordRepo,
+		wordRepo:      wordRepo,
+		scheduler:     scheduler,
+		clock:         clock,
+		events:        events,
+		logger:        logger.With().Str("component", "review_service").Str("scheduler", scheduler.Name()).Logger(),
+	}
+}
+
+// GetDue retrieves the saved words a user should review now
+func (s *service) GetDue(ctx context.Context, userID string, limit int) ([]*DueItem, error) {
+	s.logger.Debug().Str("userID", userID).Int("limit", limit).Msg("Getting due reviews")
+
+	if limit <= 0 {
+		limit = 20
+	}
+	now := s.clock()
+
+	// Words saved since the last visit enter the review queue as new cards
+	if err := s.repo.CreateMissingCards(ctx, userID, now); err != nil {
+		s.logger.Error().Err(err).Msg("Failed to create review cards")
+		return nil, fmt.Errorf("failed to create review cards: %w", err)
+	}
+
+	cards, err := s.repo.ListDue(ctx, userID, now, limit)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to list due cards")
+		return nil, fmt.Errorf("failed to list due cards: %w", err)
+	}
+	if len(cards) == 0 {
+		return []*DueItem{}, nil
+	}
+
+	ids := make([]string, len(cards))
+	for i, card := range cards {
+		ids[i] = card.SavedWordID
+	}
+
+	savedWords, err := s.savedWordRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to find saved words of due cards")
+		return nil, fmt.Errorf("failed to find saved words: %w", err)
+	}
+	byID := make(map[string]*savedword.SavedWord, len(savedWords))
+	for _, sw := range savedWords {
+		byID[sw.ID] = sw
+	}
+
+	items := make([]*DueItem, 0, len(cards))
+	for _, card := range cards {
+		sw, ok := byID[card.SavedWordID]
+		if !ok {
+			continue
+		}
+
+		w, err := s.wordRepo.FindByID(ctx, sw.WordID)
+		if err != nil {
+			if errors.Is(err, word.ErrWordNotFound) {
+				continue
+			}
+			s.logger.Error().Err(err).Str("wordID", sw.WordID).Msg("Failed to find word of due card")
+			return nil, fmt.Errorf("failed to find word: %w", err)
+		}
+
+		items = append(items, &DueItem{Card: card, SavedWord: sw, Word: w})
+	}
+
+	return items, nil
+}
+
+// Grade records the answer to a review and schedules the next one
+func (s *service) Grade(ctx context.Context, userID, savedWordID string, grade Grade, duration time.Duration, source Source) (*Card, error) {
+	s.logger.Debug().Str("userID", userID).Str("savedWordID", savedWordID).Int("grade", int(grade)).Msg("Grading review")
+
+	if !grade.IsValid() {
+		return nil, ErrInvalidGrade
+	}
+	if duration < 0 {
+		duration = 0
+	}
+	now := s.clock()
+
+	card, err := s.findOrCreateCard(ctx, userID, savedWordID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	next := s.scheduler.Schedule(*card, grade, now)
+
+	log := &Log{
+		ID:            uuid.New().String(),
+		SavedWordID:   savedWordID,
+		UserID:        userID,
+		Grade:         grade,
+		State:         card.State,
+		ElapsedDays:   int(math.Floor(card.ElapsedDays(now))),
+		ScheduledDays: next.IntervalDays,
+		Duration:      duration,
+		ReviewedAt:    now,
+	}
+
+	if err := s.repo.Save(ctx, &next, log, s.events.Emit(ReviewGraded{Log: log, Card: &next, Source: source})); err != nil {
+		s.logger.Error().Err(err).Str("savedWordID", savedWordID).Msg("Failed to save review")
+		return nil, fmt.Errorf("failed to save review: %w", err)
+	}
+
+	return &next, nil
+}
+
+// findOrCreateCard retrieves the card of a saved word owned by the user,
+// creating it when the saved word was never reviewed
+func (s *service) findOrCreateCard(ctx context.Context, userID, savedWordID string, now time.Time) (*Card, error) {
+	card, err := s.repo.FindBySavedWord(ctx, savedWordID)
+	if err == nil {
+		if card.UserID != userID {
+			return nil, ErrCardNotFound
+		}
+		return card, nil
+	}
+	if !errors.Is(err, ErrCardNotFound) {
+		s.logger.Error().Err(err).Str("savedWordID", savedWordID).Msg("Failed to find review card")
+		return nil, fmt.Errorf("failed to find review card: %w", err)
+	}
+
+	sw, err := s.savedWordRepo.FindByID(ctx, savedWordID)
+	if err != nil {
+		if errors.Is(err, savedword.ErrSavedWordNotFound) {
+			return nil, ErrCardNotFound
+		}
+		s.logger.Error().Err(err).Str("savedWordID", savedWordID).Msg("Failed to find saved word")
+		return nil, fmt.Errorf("failed to find saved word: %w", err)
+	}
+	if sw.UserID != userID {
+		return nil, ErrCardNotFound
+	}
+
+	return NewCard(sw.ID, userID, now), nil
+}
@@ -0,0 +1,302 @@
+package review
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"voconsteroid/internal/domain/event"
+	"voconsteroid/internal/domain/savedword"
+	"voconsteroid/internal/domain/word"
+)
+
+// MockRepository is a mock implementation of the Repository interface
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) FindBySavedWord(ctx context.Context, savedWordID string) (*Card, error) {
+	args := m.Called(ctx, savedWordID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Card), args.Error(1)
+}
+
+func (m *MockRepository) CreateMissingCards(ctx context.Context, userID string, now time.Time) error {
+	args := m.Called(ctx, userID, now)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListDue(ctx context.Context, userID string, now time.Time, limit int) ([]*Card, error) {
+	args := m.Called(ctx, userID, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Card), args.Error(1)
+}
+
+func (m *MockRepository) Save(ctx context.Context, card *Card, log *Log, emit event.Emit) error {
+	args := m.Called(ctx, card, log)
+	if err := args.Error(0); err != nil || emit == nil {
+		return err
+	}
+	return emit(ctx, nil)
+}
+
+// MockSavedWordRepository is a mock implementation of the savedword.Repository interface
+type MockSavedWordRepository struct {
+	mock.Mock
+}
+
+func (m *MockSavedWordRepository) FindByID(ctx context.Context, id string) (*savedword.SavedWord, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*savedword.SavedWord), args.Error(1)
+}
+
+func (m *MockSavedWordRepository) FindByIDs(ctx context.Context, ids []string) ([]*savedword.SavedWord, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*savedword.SavedWord), args.Error(1)
+}
+
+func (m *MockSavedWordRepository) FindByUserAndWord(ctx context.Context, userID, wordID string) (*savedword.SavedWord, error) {
+	args := m.Called(ctx, userID, wordID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*savedword.SavedWord), args.Error(1)
+}
+
+func (m *MockSavedWordRepository) Save(ctx context.Context, savedWord *savedword.SavedWord, emit event.Emit) error {
+	args := m.Called(ctx, savedWord)
+	return args.Error(0)
+}
+
+func (m *MockSavedWordRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSavedWordRepository) ListByUser(ctx context.Context, userID string, filter savedword.Filter, limit, offset int) ([]*savedword.SavedWord, error) {
+	args := m.Called(ctx, userID, filter, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*savedword.SavedWord), args.Error(1)
+}
+
+// MockWordRepository is a mock implementation of the word.Repository interface
+type MockWordRepository struct {
+	mock.Mock
+}
+
+func (m *MockWordRepository) FindByID(ctx context.Context, id string) (*word.Word, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) FindByText(ctx context.Context, text, language string) (*word.Word, error) {
+	args := m.Called(ctx, text, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) FindByAnyForm(ctx context.Context, text, language string) (*word.Word, error) {
+	args := m.Called(ctx, text, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) Save(ctx context.Context, w *word.Word, emit event.Emit) error {
+	args := m.Called(ctx, w)
+	return args.Error(0)
+}
+
+func (m *MockWordRepository) List(ctx context.Context, filter map[string]interface{}, limit, offset int) ([]*word.Word, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) FindByPrefix(ctx context.Context, prefix, language string, limit int) ([]*word.Word, error) {
+	args := m.Called(ctx, prefix, language, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) FindSuggestions(ctx context.Context, prefix, language string, limit int) ([]string, error) {
+	args := m.Called(ctx, prefix, language, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// setupTestService creates a service with mocks, the SM-2 scheduler and a fixed clock
+func setupTestService(t *testing.T) (*MockRepository, *MockSavedWordRepository, *MockWordRepository, Service) {
+	repo := new(MockRepository)
+	savedWordRepo := new(MockSavedWordRepository)
+	wordRepo := new(MockWordRepository)
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	clock := func() time.Time { return testNow }
+
+	return repo, savedWordRepo, wordRepo, NewService(repo, savedWordRepo, wordRepo, NewSM2Scheduler(), clock, nil, logger)
+}
+
+func TestGetDue(t *testing.T) {
+	// Setup
+	repo, savedWordRepo, wordRepo, svc := setupTestService(t)
+	ctx := context.Background()
+
+	w := word.NewWord("maison", "fr")
+	sw := &savedword.SavedWord{ID: "sw-1", UserID: "user-1", WordID: w.ID}
+	card := NewCard("sw-1", "user-1", testNow.Add(-time.Hour))
+
+	repo.On("CreateMissingCards", ctx, "user-1", testNow).Return(nil)
+	repo.On("ListDue", ctx, "user-1", testNow, 20).Return([]*Card{card}, nil)
+	savedWordRepo.On("FindByIDs", ctx, []string{"sw-1"}).Return([]*savedword.SavedWord{sw}, nil)
+	wordRepo.On("FindByID", ctx, w.ID).Return(w, nil)
+
+	// Execute
+	items, err := svc.GetDue(ctx, "user-1", 0)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, card, items[0].Card)
+	assert.Equal(t, sw, items[0].SavedWord)
+	assert.Equal(t, w, items[0].Word)
+	repo.AssertExpectations(t)
+}
+
+func TestGrade_NewCard(t *testing.T) {
+	// Setup
+	repo, savedWordRepo, _, svc := setupTestService(t)
+	ctx := context.Background()
+
+	repo.On("FindBySavedWord", ctx, "sw-1").Return(nil, ErrCardNotFound)
+	savedWordRepo.On("FindByID", ctx, "sw-1").Return(&savedword.SavedWord{ID: "sw-1", UserID: "user-1"}, nil)
+	repo.On("Save", ctx, mock.AnythingOfType("*review.Card"), mock.MatchedBy(func(log *Log) bool {
+		return log.Grade == GradeGood && log.State == StateNew && log.ScheduledDays == 1 &&
+			log.Duration == 3*time.Second && log.ReviewedAt.Equal(testNow)
+	})).Return(nil)
+
+	// Execute
+	card, err := svc.Grade(ctx, "user-1", "sw-1", GradeGood, 3*time.Second, SourceQueue)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, StateReview, card.State)
+	assert.Equal(t, 1, card.IntervalDays)
+	assert.Equal(t, testNow.AddDate(0, 0, 1), card.Due)
+	repo.AssertExpectations(t)
+}
+
+func TestGrade_ExistingCard(t *testing.T) {
+	// Setup
+	repo, _, _, svc := setupTestService(t)
+	ctx := context.Background()
+
+	lastReview := testNow.AddDate(0, 0, -6)
+	card := &Card{
+		SavedWordID:    "sw-1",
+		UserID:         "user-1",
+		State:          StateReview,
+		Ease:           DefaultEase,
+		IntervalDays:   6,
+		Repetitions:    2,
+		Due:            testNow,
+		LastReviewedAt: &lastReview,
+	}
+	repo.On("FindBySavedWord", ctx, "sw-1").Return(card, nil)
+	repo.On("Save", ctx, mock.AnythingOfType("*review.Card"), mock.MatchedBy(func(log *Log) bool {
+		return log.ElapsedDays == 6 && log.ScheduledDays == 15
+	})).Return(nil)
+
+	// Execute
+	next, err := svc.Grade(ctx, "user-1", "sw-1", GradeGood, 0, SourceQueue)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 15, next.IntervalDays)
+	assert.Equal(t, 3, next.Repetitions)
+	assert.Equal(t, 6, card.IntervalDays, "stored card must not be modified before saving")
+}
+
+func TestGrade_PublishesSource(t *testing.T) {
+	// Setup
+	repo := new(MockRepository)
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	bus := event.NewBus(nil, nil, logger)
+	svc := NewService(repo, new(MockSavedWordRepository), new(MockWordRepository), NewSM2Scheduler(), func() time.Time { return testNow }, bus, logger)
+	ctx := context.Background()
+
+	var published []ReviewGraded
+	event.Subscribe(bus, func(_ context.Context, e ReviewGraded) error {
+		published = append(published, e)
+		return nil
+	})
+	repo.On("FindBySavedWord", ctx, "sw-1").Return(&Card{SavedWordID: "sw-1", UserID: "user-1", State: StateNew, Ease: DefaultEase, Due: testNow}, nil)
+	repo.On("Save", ctx, mock.Anything, mock.Anything).Return(nil)
+
+	// Execute
+	_, err := svc.Grade(ctx, "user-1", "sw-1", GradeHard, 0, SourceQuiz)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, published, 1)
+	assert.Equal(t, SourceQuiz, published[0].Source)
+	assert.Equal(t, GradeHard, published[0].Log.Grade)
+}
+
+func TestGrade_OtherUser(t *testing.T) {
+	// Setup
+	repo, _, _, svc := setupTestService(t)
+	ctx := context.Background()
+
+	repo.On("FindBySavedWord", ctx, "sw-1").Return(NewCard("sw-1", "user-2", testNow), nil)
+
+	// Execute
+	card, err := svc.Grade(ctx, "user-1", "sw-1", GradeGood, 0, SourceQueue)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrCardNotFound)
+	assert.Nil(t, card)
+	repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGrade_InvalidGrade(t *testing.T) {
+	// Setup
+	repo, _, _, svc := setupTestService(t)
+	ctx := context.Background()
+
+	// Execute
+	card, err := svc.Grade(ctx, "user-1", "sw-1", Grade(5), 0, SourceQueue)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidGrade)
+	assert.Nil(t, card)
+	repo.AssertNotCalled(t, "FindBySavedWord", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,66 @@
+package review
+
+import (
+	"math"
+	"time"
+)
+
+// minEase is the lowest easiness factor allowed by SM-2
+const minEase = 1.3
+
+// sm2Scheduler implements the SuperMemo 2 algorithm
+type sm2Scheduler struct{}
+
+// Ensure sm2Scheduler implements Scheduler interface
+var _ Scheduler = (*sm2Scheduler)(nil)
+
+// NewSM2Scheduler creates a scheduler using the SuperMemo 2 algorithm
+func NewSM2Scheduler() Scheduler {
+	return &sm2Scheduler{}
+}
+
+// Name implements Scheduler
+func (s *sm2Scheduler) Name() string {
+	return "sm2"
+}
+
+// Schedule implements Scheduler
+func (s *sm2Scheduler) Schedule(card Card, grade Grade, now time.Time) Card {
+	q := sm2Quality(grade)
+
+	if card.Ease == 0 {
+		card.Ease = DefaultEase
+	}
+	card.Ease += 0.1 - (5-q)*(0.08+(5-q)*0.02)
+	if card.Ease < minEase {
+		card.Ease = minEase
+	}
+
+	var interval int
+	switch {
+	case grade == GradeAgain:
+		interval = 1
+	case card.Repetitions == 0:
+		interval = 1
+	case card.Repetitions == 1:
+		interval = 6
+	default:
+		interval = int(math.Round(float64(card.IntervalDays) * card.Ease))
+	}
+
+	applyInterval(&card, grade, interval, now)
+	return card
+}
+
+// sm2Quality maps a grade to the 0-5 response quality of SM-2
+func sm2Quality(grade Grade) float64 {
+	switch grade {
+	case GradeAgain:
+		return 1
+	case GradeHard:
+		return 3
+	case GradeGood:
+		return 4
+	}
+	return 5
+}
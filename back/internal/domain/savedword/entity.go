@@ -0,0 +1,107 @@
+package savedword
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"voconsteroid/internal/domain/tag"
+	"voconsteroid/internal/domain/word"
+)
+
+// Maximum lengths for the free-text reading context fields
+const (
+	MaxSentenceLength = 1000
+	MaxNoteLength     = 2000
+	MaxFieldLength    = 255
+)
+
+// ReadingContext captures where a user met a word while reading
+type ReadingContext struct {
+	Sentence   string `json:"sentence,omitempty"`    // The sentence the word appeared in
+	BookTitle  string `json:"book_title,omitempty"`  // Title of the book or article
+	BookAuthor string `json:"book_author,omitempty"` // Author of the book or article
+	Location   string `json:"location,omitempty"`    // Page, chapter or e-reader location
+	Note       string `json:"note,omitempty"`        // Free-text note from the user
+}
+
+// SavedWord represents a word saved by a user, with the context it was met in
+type SavedWord struct {
+	ID                  string         `json:"id"`
+	UserID              string         `json:"user_id"`
+	WordID              string         `json:"word_id"`
+	Context             ReadingContext `json:"context"`
+	SuggestedDefinition *int           `json:"suggested_definition,omitempty"` // Index into the word's definitions
+	SavedAt             time.Time      `json:"saved_at"`
+	LastInteractedAt    time.Time      `json:"last_interacted_at"`
+}
+
+// Detail is a saved word together with the word it references
+type Detail struct {
+	*SavedWord
+	Word *word.Word `json:"word"`
+}
+
+// Filter narrows down the saved words returned by a listing
+type Filter struct {
+	// Query is matched against the reading context and the word text
+	Query string
+
+	// Tags keeps only the saved words whose tags satisfy the expression; nil disables it
+	Tags tag.Expr
+}
+
+// NewSavedWord creates a new SavedWord entity
+func NewSavedWord(userID, wordID string) *SavedWord {
+	now := time.Now()
+	return &SavedWord{
+		ID:               uuid.New().String(),
+		UserID:           userID,
+		WordID:           wordID,
+		SavedAt:          now,
+		LastInteractedAt: now,
+	}
+}
+
+// Normalize trims surrounding whitespace from every field of the context
+func (c ReadingContext) Normalize() ReadingContext {
+	return ReadingContext{
+		Sentence:   strings.TrimSpace(c.Sentence),
+		BookTitle:  strings.TrimSpace(c.BookTitle),
+		BookAuthor: strings.TrimSpace(c.BookAuthor),
+		Location:   strings.TrimSpace(c.Location),
+		Note:       strings.TrimSpace(c.Note),
+	}
+}
+
+// Validate checks the context fields against their maximum lengths
+func (c ReadingContext) Validate() error {
+	if len([]rune(c.Sentence)) > MaxSentenceLength || len([]rune(c.Note)) > MaxNoteLength {
+		return ErrInvalidContext
+	}
+	for _, field := range []string{c.BookTitle, c.BookAuthor, c.Location} {
+		if len([]rune(field)) > MaxFieldLength {
+			return ErrInvalidContext
+		}
+	}
+	return nil
+}
+
+// SetContext replaces the reading context and suggests the sense of the word
+// that best fits the sentence
+func (s *SavedWord) SetContext(readingCtx ReadingContext, w *word.Word) {
+	s.Context = readingCtx
+	s.SuggestedDefinition = nil
+	if w != nil && readingCtx.Sentence != "" {
+		if idx := SuggestDefinition(w, readingCtx.Sentence); idx >= 0 {
+			s.SuggestedDefinition = &idx
+		}
+	}
+	s.Touch()
+}
+
+// Touch records a user interaction with the saved word
+func (s *SavedWord) Touch() {
+	s.LastInteractedAt = time.Now()
+}
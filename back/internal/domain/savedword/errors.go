@@ -0,0 +1,11 @@
+package savedword
+
+import "errors"
+
+// Domain errors
+var (
+	ErrSavedWordNotFound  = errors.New("saved word not found")
+	ErrDuplicateSavedWord = errors.New("word already saved")
+	ErrInvalidSavedWord   = errors.New("invalid saved word")
+	ErrInvalidContext     = errors.New("invalid reading context")
+)
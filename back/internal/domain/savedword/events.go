@@ -0,0 +1,12 @@
+package savedword
+
+// EventSavedWordAdded is the name of the SavedWordAdded event
+const EventSavedWordAdded = "saved_word.added"
+
+// SavedWordAdded is published when a user saves a word
+type SavedWordAdded struct {
+	SavedWord *SavedWord `json:"saved_word"`
+}
+
+// EventName identifies the event type
+func (SavedWordAdded) EventName() string { return EventSavedWordAdded }
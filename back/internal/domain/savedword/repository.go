@@ -0,0 +1,29 @@
+package savedword
+
+import (
+	"context"
+
+	"voconsteroid/internal/domain/event"
+)
+
+// Repository defines the interface for saved word data access
+type Repository interface {
+	// FindByID retrieves a saved word by its ID
+	FindByID(ctx context.Context, id string) (*SavedWord, error)
+
+	// FindByIDs retrieves the saved words matching the given IDs; unknown IDs are skipped
+	FindByIDs(ctx context.Context, ids []string) ([]*SavedWord, error)
+
+	// FindByUserAndWord retrieves the saved word a user created for a word
+	FindByUserAndWord(ctx context.Context, userID, wordID string) (*SavedWord, error)
+
+	// Save stores a saved word in the repository, running emit, when not
+	// nil, in the same transaction
+	Save(ctx context.Context, savedWord *SavedWord, emit event.Emit) error
+
+	// Delete removes a saved word from the repository
+	Delete(ctx context.Context, id string) error
+
+	// ListByUser retrieves the saved words of a user, most recently used first
+	ListByUser(ctx context.Context, userID string, filter Filter, limit, offset int) ([]*SavedWord, error)
+}
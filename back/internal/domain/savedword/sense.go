@@ -0,0 +1,113 @@
+package savedword
+
+import (
+	"strings"
+	"unicode"
+
+	"voconsteroid/internal/domain/word"
+)
+
+// stemLength is the number of leading runes two tokens must share to be
+// considered forms of the same word (e.g. "reading" and "reader")
+const stemLength = 5
+
+// stopWords are frequent function words that carry no sense information
+var stopWords = map[string]bool{
+	// English
+	"the": true, "and": true, "for": true, "that": true, "with": true, "this": true,
+	"from": true, "was": true, "were": true, "are": true, "his": true, "her": true,
+	"its": true, "which": true, "who": true, "not": true, "but": true, "had": true,
+	"has": true, "have": true, "one": true, "someone": true, "something": true,
+	// French
+	"les": true, "des": true, "une": true, "est": true, "pour": true, "dans": true,
+	"par": true, "sur": true, "qui": true, "que": true, "avec": true, "son": true,
+	"ses": true, "aux": true, "pas": true, "plus": true, "cette": true, "elle": true,
+	"lui": true, "leur": true, "quelque": true, "chose": true, "quelqu": true,
+}
+
+// SuggestDefinition returns the index of the definition of w whose text and
+// examples share the most content words with the sentence (a simplified Lesk
+// algorithm), or -1 when no definition overlaps with it.
+// Ties are resolved in favour of the earliest definition, which dictionaries
+// list first because it is the most common sense.
+func SuggestDefinition(w *word.Word, sentence string) int {
+	if w == nil || len(w.Definitions) == 0 {
+		return -1
+	}
+
+	// The headword and its forms appear in every example, so they say nothing
+	// about which sense was meant
+	ignored := make(map[string]bool)
+	for _, term := range append([]string{w.Text, w.Lemma}, w.SearchTerms...) {
+		for _, token := range tokenize(term) {
+			ignored[token] = true
+		}
+	}
+
+	sentenceTokens := make([]string, 0)
+	for _, token := range tokenize(sentence) {
+		if !ignored[token] {
+			sentenceTokens = append(sentenceTokens, token)
+		}
+	}
+	if len(sentenceTokens) == 0 {
+		return -1
+	}
+
+	best, bestScore := -1, 0
+	for i, def := range w.Definitions {
+		gloss := tokenize(def.Text + " " + strings.Join(def.Examples, " "))
+		if score := overlap(sentenceTokens, gloss); score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+
+	return best
+}
+
+// tokenize lowercases text and splits it into content words
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+
+	tokens := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if len([]rune(field)) < 3 || stopWords[field] {
+			continue
+		}
+		tokens = append(tokens, field)
+	}
+	return tokens
+}
+
+// overlap counts the distinct sentence tokens that match a gloss token
+func overlap(sentenceTokens, gloss []string) int {
+	score := 0
+	seen := make(map[string]bool)
+	for _, token := range sentenceTokens {
+		if seen[token] {
+			continue
+		}
+		seen[token] = true
+		for _, candidate := range gloss {
+			if sameStem(token, candidate) {
+				score++
+				break
+			}
+		}
+	}
+	return score
+}
+
+// sameStem reports whether two tokens are equal or share a long enough prefix
+func sameStem(a, b string) bool {
+	if a == b {
+		return true
+	}
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < stemLength || len(rb) < stemLength {
+		return false
+	}
+	return string(ra[:stemLength]) == string(rb[:stemLength])
+}
@@ -0,0 +1,23 @@
+package savedword
+
+import (
+	"context"
+)
+
+// Service defines the interface for saved word business logic
+type Service interface {
+	// SaveWord saves a word for a user along with the context it was met in
+	SaveWord(ctx context.Context, userID, wordID string, readingCtx ReadingContext) (*SavedWord, error)
+
+	// GetSavedWord retrieves a saved word of a user together with its word
+	GetSavedWord(ctx context.Context, userID, id string) (*Detail, error)
+
+	// ListSavedWords retrieves the saved words of a user, optionally filtered
+	ListSavedWords(ctx context.Context, userID string, filter Filter, limit, offset int) ([]*SavedWord, error)
+
+	// UpdateContext replaces the reading context of a saved word
+	UpdateContext(ctx context.Context, userID, id string, readingCtx ReadingContext) (*SavedWord, error)
+
+	// DeleteSavedWord removes a saved word of a user
+	DeleteSavedWord(ctx context.Context, userID, id string) error
+}
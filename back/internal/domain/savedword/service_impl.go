@@ -0,0 +1,188 @@
+package savedword
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/event"
+	"voconsteroid/internal/domain/word"
+)
+
+// Ensure service implements Service interface
+var _ Service = (*service)(nil)
+
+// service implements the Service interface
+type service struct {
+	repo     Repository
+	wordRepo word.Repository
+	events   event.Publisher
+	logger   zerolog.Logger
+}
+
+// NewService creates a new saved word service. Saved words are published
+// as events; a nil publisher drops them.
+func NewService(repo Repository, wordRepo word.Repository, events event.Publisher, logger zerolog.Logger) Service {
+	if events == nil {
+		events = event.Nop
+	}
+	return &service{
+		repo:     repo,
+		wordRepo: wordRepo,
+		events:   events,
+		logger:   logger.With().Str("component", "saved_word_service").Logger(),
+	}
+}
+
+// SaveWord saves a word for a user along with the context it was met in
+func (s *service) SaveWord(ctx context.Context, userID, wordID string, readingCtx ReadingContext) (*SavedWord, error) {
+	s.logger.Debug().Str("userID", userID).Str("wordID", wordID).Msg("Saving word")
+
+	if userID == "" || wordID == "" {
+		return nil, ErrInvalidSavedWord
+	}
+
+	readingCtx = readingCtx.Normalize()
+	if err := readingCtx.Validate(); err != nil {
+		return nil, err
+	}
+
+	// The word must exist before it can be saved
+	w, err := s.wordRepo.FindByID(ctx, wordID)
+	if err != nil {
+		s.logger.Debug().Err(err).Str("wordID", wordID).Msg("Failed to find word to save")
+		return nil, fmt.Errorf("failed to find word: %w", err)
+	}
+
+	// A user can only save a specific word once
+	if _, err := s.repo.FindByUserAndWord(ctx, userID, wordID); err == nil {
+		return nil, ErrDuplicateSavedWord
+	} else if !errors.Is(err, ErrSavedWordNotFound) {
+		s.logger.Error().Err(err).Str("wordID", wordID).Msg("Failed to check for existing saved word")
+		return nil, fmt.Errorf("failed to check saved word: %w", err)
+	}
+
+	savedWord := NewSavedWord(userID, wordID)
+	savedWord.SetContext(readingCtx, w)
+
+	if err := s.repo.Save(ctx, savedWord, s.events.Emit(SavedWordAdded{SavedWord: savedWord})); err != nil {
+		s.logger.Error().Err(err).Str("wordID", wordID).Msg("Failed to save word")
+		return nil, fmt.Errorf("failed to save word: %w", err)
+	}
+
+	return savedWord, nil
+}
+
+// GetSavedWord retrieves a saved word of a user together with its word
+func (s *service) GetSavedWord(ctx context.Context, userID, id string) (*Detail, error) {
+	s.logger.Debug().Str("userID", userID).Str("id", id).Msg("Getting saved word")
+
+	savedWord, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := s.wordRepo.FindByID(ctx, savedWord.WordID)
+	if err != nil {
+		s.logger.Error().Err(err).Str("wordID", savedWord.WordID).Msg("Failed to find saved word's word")
+		return nil, fmt.Errorf("failed to find word: %w", err)
+	}
+
+	return &Detail{SavedWord: savedWord, Word: w}, nil
+}
+
+// ListSavedWords retrieves the saved words of a user, optionally filtered
+func (s *service) ListSavedWords(ctx context.Context, userID string, filter Filter, limit, offset int) ([]*SavedWord, error) {
+	s.logger.Debug().Str("userID", userID).Str("query", filter.Query).Int("limit", limit).Msg("Listing saved words")
+
+	if limit <= 0 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	filter.Query = strings.TrimSpace(filter.Query)
+
+	savedWords, err := s.repo.ListByUser(ctx, userID, filter, limit, offset)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to list saved words")
+		return nil, fmt.Errorf("failed to list saved words: %w", err)
+	}
+
+	return savedWords, nil
+}
+
+// UpdateContext replaces the reading context of a saved word
+func (s *service) UpdateContext(ctx context.Context, userID, id string, readingCtx ReadingContext) (*SavedWord, error) {
+	s.logger.Debug().Str("userID", userID).Str("id", id).Msg("Updating saved word context")
+
+	readingCtx = readingCtx.Normalize()
+	if err := readingCtx.Validate(); err != nil {
+		return nil, err
+	}
+
+	savedWord, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := s.wordRepo.FindByID(ctx, savedWord.WordID)
+	if err != nil {
+		s.logger.Error().Err(err).Str("wordID", savedWord.WordID).Msg("Failed to find saved word's word")
+		return nil, fmt.Errorf("failed to find word: %w", err)
+	}
+
+	savedWord.SetContext(readingCtx, w)
+
+	if err := s.repo.Save(ctx, savedWord, nil); err != nil {
+		s.logger.Error().Err(err).Str("id", id).Msg("Failed to update saved word")
+		return nil, fmt.Errorf("failed to update saved word: %w", err)
+	}
+
+	return savedWord, nil
+}
+
+// DeleteSavedWord removes a saved word of a user
+func (s *service) DeleteSavedWord(ctx context.Context, userID, id string) error {
+	s.logger.Debug().Str("userID", userID).Str("id", id).Msg("Deleting saved word")
+
+	if _, err := s.findOwned(ctx, userID, id); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		s.logger.Error().Err(err).Str("id", id).Msg("Failed to delete saved word")
+		return fmt.Errorf("failed to delete saved word: %w", err)
+	}
+
+	return nil
+}
+
+// findOwned retrieves a saved word and checks that it belongs to the user.
+// Saved words of other users and malformed IDs are reported as not found,
+// so that IDs do not leak and malformed ones do not reach the database.
+func (s *service) findOwned(ctx context.Context, userID, id string) (*SavedWord, error) {
+	// Saved word IDs are UUIDs; anything else cannot name one
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrSavedWordNotFound
+	}
+
+	savedWord, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrSavedWordNotFound) {
+			return nil, err
+		}
+		s.logger.Error().Err(err).Str("id", id).Msg("Failed to find saved word")
+		return nil, fmt.Errorf("failed to find saved word: %w", err)
+	}
+
+	if savedWord.UserID != userID {
+		return nil, ErrSavedWordNotFound
+	}
+
+	return savedWord, nil
+}
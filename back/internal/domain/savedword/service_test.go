@@ -0,0 +1,374 @@
+package savedword
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"voconsteroid/internal/domain/event"
+	"voconsteroid/internal/domain/word"
+)
+
+// MockRepository is a mock implementation of the Repository interface
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) FindByID(ctx context.Context, id string) (*SavedWord, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*SavedWord), args.Error(1)
+}
+
+func (m *MockRepository) FindByIDs(ctx context.Context, ids []string) ([]*SavedWord, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*SavedWord), args.Error(1)
+}
+
+func (m *MockRepository) FindByUserAndWord(ctx context.Context, userID, wordID string) (*SavedWord, error) {
+	args := m.Called(ctx, userID, wordID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*SavedWord), args.Error(1)
+}
+
+func (m *MockRepository) Save(ctx context.Context, savedWord *SavedWord, emit event.Emit) error {
+	args := m.Called(ctx, savedWord)
+	if err := args.Error(0); err != nil || emit == nil {
+		return err
+	}
+	return emit(ctx, nil)
+}
+
+func (m *MockRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListByUser(ctx context.Context, userID string, filter Filter, limit, offset int) ([]*SavedWord, error) {
+	args := m.Called(ctx, userID, filter, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*SavedWord), args.Error(1)
+}
+
+// MockWordRepository is a mock implementation of the word.Repository interface
+type MockWordRepository struct {
+	mock.Mock
+}
+
+func (m *MockWordRepository) FindByID(ctx context.Context, id string) (*word.Word, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) FindByText(ctx context.Context, text, language string) (*word.Word, error) {
+	args := m.Called(ctx, text, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) FindByAnyForm(ctx context.Context, text, language string) (*word.Word, error) {
+	args := m.Called(ctx, text, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) Save(ctx context.Context, w *word.Word, emit event.Emit) error {
+	args := m.Called(ctx, w)
+	return args.Error(0)
+}
+
+func (m *MockWordRepository) List(ctx context.Context, filter map[string]interface{}, limit, offset int) ([]*word.Word, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) FindByPrefix(ctx context.Context, prefix, language string, limit int) ([]*word.Word, error) {
+	args := m.Called(ctx, prefix, language, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) FindSuggestions(ctx context.Context, prefix, language string, limit int) ([]string, error) {
+	args := m.Called(ctx, prefix, language, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// setupTestService creates a service with mocks for testing
+func setupTestService(t *testing.T) (*MockRepository, *MockWordRepository, Service) {
+	repo := new(MockRepository)
+	wordRepo := new(MockWordRepository)
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+
+	return repo, wordRepo, NewService(repo, wordRepo, nil, logger)
+}
+
+// testWord returns an English word with two distinct senses
+func testWord() *word.Word {
+	w := word.NewWord("bank", "en")
+	w.ID = "word-1"
+	w.AddDefinition(word.Definition{
+		Text:     "An institution where one can place and borrow money",
+		WordType: "noun",
+		Examples: []string{"I deposited my salary at the bank"},
+	})
+	w.AddDefinition(word.Definition{
+		Text:     "The edge of a river or lake",
+		WordType: "noun",
+		Examples: []string{"We sat on the grassy bank of the river"},
+	})
+	return w
+}
+
+func TestSaveWord(t *testing.T) {
+	// Setup
+	repo, wordRepo, svc := setupTestService(t)
+	ctx := context.Background()
+
+	wordRepo.On("FindByID", ctx, "word-1").Return(testWord(), nil)
+	repo.On("FindByUserAndWord", ctx, "user-1", "word-1").Return(nil, ErrSavedWordNotFound)
+	repo.On("Save", ctx, mock.AnythingOfType("*savedword.SavedWord")).Return(nil)
+
+	// Execute
+	savedWord, err := svc.SaveWord(ctx, "user-1", "word-1", ReadingContext{
+		Sentence:  "  The boat drifted toward the muddy bank of the river.  ",
+		BookTitle: "Three Men in a Boat",
+		Location:  "p. 42",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", savedWord.UserID)
+	assert.Equal(t, "word-1", savedWord.WordID)
+	assert.Equal(t, "The boat drifted toward the muddy bank of the river.", savedWord.Context.Sentence)
+	assert.Equal(t, "Three Men in a Boat", savedWord.Context.BookTitle)
+	require.NotNil(t, savedWord.SuggestedDefinition)
+	assert.Equal(t, 1, *savedWord.SuggestedDefinition)
+	repo.AssertExpectations(t)
+	wordRepo.AssertExpectations(t)
+}
+
+// MockPublisher is a mock implementation of the event.Publisher interface
+type MockPublisher struct {
+	mock.Mock
+}
+
+func (m *MockPublisher) Publish(ctx context.Context, events ...event.Event) error {
+	args := m.Called(ctx, events)
+	return args.Error(0)
+}
+
+// Emit publishes the events when the mock repository runs it
+func (m *MockPublisher) Emit(events ...event.Event) event.Emit {
+	return func(ctx context.Context, _ event.Outbox) error {
+		return m.Publish(ctx, events...)
+	}
+}
+
+func TestSaveWord_PublishesEvent(t *testing.T) {
+	// Setup
+	repo := new(MockRepository)
+	wordRepo := new(MockWordRepository)
+	publisher := new(MockPublisher)
+	svc := NewService(repo, wordRepo, publisher, zerolog.New(zerolog.NewTestWriter(t)))
+	ctx := context.Background()
+
+	wordRepo.On("FindByID", ctx, "word-1").Return(testWord(), nil)
+	repo.On("FindByUserAndWord", ctx, "user-1", "word-1").Return(nil, ErrSavedWordNotFound)
+	repo.On("Save", ctx, mock.AnythingOfType("*savedword.SavedWord")).Return(nil)
+	publisher.On("Publish", ctx, mock.MatchedBy(func(events []event.Event) bool {
+		added, ok := events[0].(SavedWordAdded)
+		return len(events) == 1 && ok && added.SavedWord.UserID == "user-1" && added.SavedWord.WordID == "word-1"
+	})).Return(nil)
+
+	// Execute
+	_, err := svc.SaveWord(ctx, "user-1", "word-1", ReadingContext{})
+
+	// Assert
+	require.NoError(t, err)
+	publisher.AssertExpectations(t)
+}
+
+func TestSaveWord_Duplicate(t *testing.T) {
+	// Setup
+	repo, wordRepo, svc := setupTestService(t)
+	ctx := context.Background()
+
+	wordRepo.On("FindByID", ctx, "word-1").Return(testWord(), nil)
+	repo.On("FindByUserAndWord", ctx, "user-1", "word-1").Return(NewSavedWord("user-1", "word-1"), nil)
+
+	// Execute
+	savedWord, err := svc.SaveWord(ctx, "user-1", "word-1", ReadingContext{})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrDuplicateSavedWord)
+	assert.Nil(t, savedWord)
+	repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestSaveWord_UnknownWord(t *testing.T) {
+	// Setup
+	repo, wordRepo, svc := setupTestService(t)
+	ctx := context.Background()
+
+	wordRepo.On("FindByID", ctx, "missing").Return(nil, word.ErrWordNotFound)
+
+	// Execute
+	savedWord, err := svc.SaveWord(ctx, "user-1", "missing", ReadingContext{})
+
+	// Assert
+	assert.ErrorIs(t, err, word.ErrWordNotFound)
+	assert.Nil(t, savedWord)
+	repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestSaveWord_InvalidContext(t *testing.T) {
+	// Setup
+	_, wordRepo, svc := setupTestService(t)
+	ctx := context.Background()
+
+	// Execute
+	longNote := make([]rune, MaxNoteLength+1)
+	for i := range longNote {
+		longNote[i] = 'a'
+	}
+	savedWord, err := svc.SaveWord(ctx, "user-1", "word-1", ReadingContext{Note: string(longNote)})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidContext)
+	assert.Nil(t, savedWord)
+	wordRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
+
+func TestGetSavedWord_OtherUser(t *testing.T) {
+	// Setup
+	repo, wordRepo, svc := setupTestService(t)
+	ctx := context.Background()
+
+	savedWord := NewSavedWord("user-2", "word-1")
+	repo.On("FindByID", ctx, savedWord.ID).Return(savedWord, nil)
+
+	// Execute
+	detail, err := svc.GetSavedWord(ctx, "user-1", savedWord.ID)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrSavedWordNotFound)
+	assert.Nil(t, detail)
+	wordRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
+
+func TestGetSavedWord(t *testing.T) {
+	// Setup
+	repo, wordRepo, svc := setupTestService(t)
+	ctx := context.Background()
+
+	savedWord := NewSavedWord("user-1", "word-1")
+	w := testWord()
+	repo.On("FindByID", ctx, savedWord.ID).Return(savedWord, nil)
+	wordRepo.On("FindByID", ctx, "word-1").Return(w, nil)
+
+	// Execute
+	detail, err := svc.GetSavedWord(ctx, "user-1", savedWord.ID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, savedWord, detail.SavedWord)
+	assert.Equal(t, w, detail.Word)
+}
+
+func TestListSavedWords_DefaultLimit(t *testing.T) {
+	// Setup
+	repo, _, svc := setupTestService(t)
+	ctx := context.Background()
+
+	expected := []*SavedWord{NewSavedWord("user-1", "word-1")}
+	repo.On("ListByUser", ctx, "user-1", Filter{Query: "river"}, 20, 0).Return(expected, nil)
+
+	// Execute
+	savedWords, err := svc.ListSavedWords(ctx, "user-1", Filter{Query: " river "}, 0, -5)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, expected, savedWords)
+	repo.AssertExpectations(t)
+}
+
+func TestDeleteSavedWord(t *testing.T) {
+	// Setup
+	repo, _, svc := setupTestService(t)
+	ctx := context.Background()
+
+	savedWord := NewSavedWord("user-1", "word-1")
+	repo.On("FindByID", ctx, savedWord.ID).Return(savedWord, nil)
+	repo.On("Delete", ctx, savedWord.ID).Return(nil)
+
+	// Execute
+	err := svc.DeleteSavedWord(ctx, "user-1", savedWord.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestSuggestDefinition(t *testing.T) {
+	w := testWord()
+
+	testCases := []struct {
+		name     string
+		sentence string
+		expected int
+	}{
+		{"financial sense", "She borrowed money from the bank", 0},
+		{"river sense", "Fishermen lined the bank of the river", 1},
+		{"stemmed match", "The rivers overflowed their banks", 1},
+		{"no overlap", "The bank was closed", -1},
+		{"empty sentence", "", -1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, SuggestDefinition(w, tc.sentence))
+		})
+	}
+}
+
+func TestGetSavedWord_MalformedID(t *testing.T) {
+	// Setup
+	repo, _, svc := setupTestService(t)
+	ctx := context.Background()
+
+	// Execute
+	detail, err := svc.GetSavedWord(ctx, "user-1", "not-a-uuid")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrSavedWordNotFound)
+	assert.Nil(t, detail)
+	repo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
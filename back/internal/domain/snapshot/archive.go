@@ -0,0 +1,174 @@
+// Package snapshot keeps the raw dictionary pages words were parsed from,
+// so that they can be parsed again offline after a parser fix.
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/refresh"
+	"voconsteroid/internal/domain/word"
+)
+
+// Archive records fetched pages in a blob store, with their metadata in a
+// repository
+type Archive struct {
+	repo   Repository
+	blobs  BlobStore
+	logger zerolog.Logger
+}
+
+// Ensure Archive implements Recorder
+var _ Recorder = (*Archive)(nil)
+
+// NewArchive creates a new page archive
+func NewArchive(repo Repository, blobs BlobStore, logger zerolog.Logger) *Archive {
+	return &Archive{
+		repo:   repo,
+		blobs:  blobs,
+		logger: logger.With().Str("component", "snapshot_archive").Logger(),
+	}
+}
+
+// Record stores a page fetched for a word
+func (a *Archive) Record(ctx context.Context, text, language string, provenance word.Provenance, page []byte) error {
+	digest, err := a.blobs.Put(ctx, page)
+	if err != nil {
+		return fmt.Errorf("failed to store page: %w", err)
+	}
+
+	snapshot := New(text, language, provenance)
+	snapshot.Digest = digest
+	snapshot.Size = len(page)
+	if err := a.repo.Save(ctx, snapshot); err != nil {
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+
+	a.logger.Debug().Str("text", text).Str("language", language).Str("digest", digest).Msg("Page recorded")
+	return nil
+}
+
+// Page retrieves the last page recorded for a word
+func (a *Archive) Page(ctx context.Context, text, language string) (*Snapshot, []byte, error) {
+	snapshot, err := a.repo.FindLatest(ctx, text, language)
+	if err != nil {
+		return nil, nil, err
+	}
+	page, err := a.blobs.Get(ctx, snapshot.Digest)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read page %s: %w", snapshot.Digest, err)
+	}
+	return snapshot, page, nil
+}
+
+// Reparser parses recorded pages again with the current parsers and saves
+// the words that changed
+type Reparser struct {
+	archive   *Archive
+	parser    Parser
+	words     word.Repository
+	frequency word.FrequencyIndex
+	logger    zerolog.Logger
+}
+
+// NewReparser creates a new reparser saving words through words
+func NewReparser(archive *Archive, parser Parser, words word.Repository, frequency word.FrequencyIndex, logger zerolog.Logger) *Reparser {
+	return &Reparser{
+		archive:   archive,
+		parser:    parser,
+		words:     words,
+		frequency: frequency,
+		logger:    logger.With().Str("component", "snapshot_reparser").Logger(),
+	}
+}
+
+// Reparse parses the last recorded page of every word of a language, or of
+// every language when empty, batchSize at a time. A parsed word is saved
+// when it has definitions and differs from the stored one or comes from a
+// newer parser; nothing is saved in a dry run.
+func (r *Reparser) Reparse(ctx context.Context, language string, batchSize int, dryRun bool) (ReparseReport, error) {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	var report ReparseReport
+	afterLanguage, afterText := "", ""
+	for {
+		snapshots, err := r.archive.repo.ListLatest(ctx, language, afterLanguage, afterText, batchSize)
+		if err != nil {
+			return report, fmt.Errorf("failed to list snapshots: %w", err)
+		}
+
+		for _, snapshot := range snapshots {
+			if err := ctx.Err(); err != nil {
+				return report, err
+			}
+			r.reparse(ctx, snapshot, dryRun, &report)
+		}
+
+		if len(snapshots) < batchSize {
+			return report, nil
+		}
+		last := snapshots[len(snapshots)-1]
+		afterLanguage, afterText = last.Language, last.Text
+	}
+}
+
+// reparse parses a snapshot and counts its outcome
+func (r *Reparser) reparse(ctx context.Context, snapshot *Snapshot, dryRun bool, report *ReparseReport) {
+	log := r.logger.With().Str("text", snapshot.Text).Str("language", snapshot.Language).Logger()
+
+	page, err := r.archive.blobs.Get(ctx, snapshot.Digest)
+	if err != nil {
+		log.Error().Err(err).Str("digest", snapshot.Digest).Msg("Failed to read page")
+		report.Failed++
+		return
+	}
+	parsed, err := r.parser.ParsePage(ctx, snapshot, page)
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to parse page")
+		report.Failed++
+		return
+	}
+	report.Parsed++
+
+	stored, err := r.words.FindByText(ctx, snapshot.Text, snapshot.Language)
+	switch {
+	case errors.Is(err, word.ErrWordNotFound):
+	case err != nil:
+		log.Error().Err(err).Msg("Failed to find stored word")
+		report.Failed++
+		return
+	case !changed(stored, parsed):
+		report.Unchanged++
+		return
+	default:
+		parsed.ID = stored.ID
+		parsed.CreatedAt = stored.CreatedAt
+	}
+
+	report.Updated++
+	if dryRun {
+		log.Info().Msg("Word would be updated")
+		return
+	}
+	parsed.ScoreDifficulty(r.frequency)
+	if err := r.words.Save(ctx, parsed, nil); err != nil {
+		log.Error().Err(err).Msg("Failed to save re-parsed word")
+		report.Updated--
+		report.Failed++
+	}
+}
+
+// changed reports whether a re-parsed word should replace the stored one.
+// Unlike a refresh, the page is the same, so the current parser is trusted
+// even when it extracts less content.
+func changed(stored, parsed *word.Word) bool {
+	if len(parsed.Definitions) == 0 {
+		return false
+	}
+	return !refresh.SameContent(stored, parsed) || parsed.ParserVersion() > stored.ParserVersion()
+}
@@ -0,0 +1,224 @@
+package snapshot
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"voconsteroid/internal/domain/event"
+	"voconsteroid/internal/domain/word"
+)
+
+var testNow = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// MockRepository is a mock implementation of the Repository interface
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) Save(ctx context.Context, snapshot *Snapshot) error {
+	args := m.Called(ctx, snapshot)
+	return args.Error(0)
+}
+
+func (m *MockRepository) FindLatest(ctx context.Context, text, language string) (*Snapshot, error) {
+	args := m.Called(ctx, text, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Snapshot), args.Error(1)
+}
+
+func (m *MockRepository) ListLatest(ctx context.Context, language, afterLanguage, afterText string, limit int) ([]*Snapshot, error) {
+	args := m.Called(ctx, language, afterLanguage, afterText, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Snapshot), args.Error(1)
+}
+
+// MockWordRepository is a mock implementation of the word.Repository interface
+type MockWordRepository struct {
+	mock.Mock
+}
+
+func (m *MockWordRepository) FindByID(ctx context.Context, id string) (*word.Word, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) FindByText(ctx context.Context, text, language string) (*word.Word, error) {
+	args := m.Called(ctx, text, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) FindByAnyForm(ctx context.Context, text, language string) (*word.Word, error) {
+	args := m.Called(ctx, text, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) Save(ctx context.Context, w *word.Word, emit event.Emit) error {
+	args := m.Called(ctx, w)
+	return args.Error(0)
+}
+
+func (m *MockWordRepository) List(ctx context.Context, filter map[string]interface{}, limit, offset int) ([]*word.Word, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) FindByPrefix(ctx context.Context, prefix, language string, limit int) ([]*word.Word, error) {
+	args := m.Called(ctx, prefix, language, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) FindSuggestions(ctx context.Context, prefix, language string, limit int) ([]string, error) {
+	args := m.Called(ctx, prefix, language, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// memoryBlobs is an in-memory BlobStore
+type memoryBlobs map[string][]byte
+
+func (m memoryBlobs) Put(_ context.Context, data []byte) (string, error) {
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+	m[digest] = data
+	return digest, nil
+}
+
+func (m memoryBlobs) Get(_ context.Context, digest string) ([]byte, error) {
+	data, ok := m[digest]
+	if !ok {
+		return nil, ErrBlobNotFound
+	}
+	return data, nil
+}
+
+// fakeParser parses a page into a word with one definition, the page itself
+type fakeParser struct{}
+
+func (fakeParser) ParsePage(_ context.Context, snapshot *Snapshot, page []byte) (*word.Word, error) {
+	if len(page) == 0 {
+		return nil, word.ErrWordNotFound
+	}
+	w := word.NewWord(snapshot.Text, snapshot.Language)
+	w.Definitions = []word.Definition{{Text: string(page)}}
+	w.Provenance = snapshot.Provenance(2)
+	return w, nil
+}
+
+// setupTestArchive creates an archive and reparser with mocks
+func setupTestArchive(t *testing.T) (*MockRepository, memoryBlobs, *MockWordRepository, *Archive, *Reparser) {
+	repo := new(MockRepository)
+	blobs := memoryBlobs{}
+	words := new(MockWordRepository)
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	archive := NewArchive(repo, blobs, logger)
+	return repo, blobs, words, archive, NewReparser(archive, fakeParser{}, words, nil, logger)
+}
+
+func TestArchive_Record(t *testing.T) {
+	// Setup
+	repo, blobs, _, archive, _ := setupTestArchive(t)
+	ctx := context.Background()
+	page := []byte("<html>maison</html>")
+	provenance := word.Provenance{Source: "frwiktionary", SourceURL: "https://fr.wiktionary.org/wiki/maison", RevisionID: 42, FetchedAt: testNow}
+	repo.On("Save", ctx, mock.Anything).Return(nil)
+
+	// Execute
+	err := archive.Record(ctx, "maison", "fr", provenance, page)
+
+	// Assert
+	require.NoError(t, err)
+	saved := repo.Calls[0].Arguments.Get(1).(*Snapshot)
+	assert.Equal(t, "maison", saved.Text)
+	assert.Equal(t, int64(42), saved.RevisionID)
+	assert.Equal(t, len(page), saved.Size)
+	assert.Equal(t, page, blobs[saved.Digest])
+}
+
+func TestReparser_Reparse(t *testing.T) {
+	ctx := context.Background()
+
+	// Setup
+	repo, blobs, words, _, reparser := setupTestArchive(t)
+	digest := func(page string) string {
+		d, _ := blobs.Put(ctx, []byte(page))
+		return d
+	}
+	created := testNow.Add(-time.Hour)
+	snapshots := []*Snapshot{
+		{Text: "chat", Language: "fr", Digest: digest("Félin")},
+		{Text: "maison", Language: "fr", Digest: digest("Bâtiment")},
+		{Text: "nouveau", Language: "fr", Digest: digest("Neuf")},
+		{Text: "perdu", Language: "fr", Digest: "missing"},
+	}
+	repo.On("ListLatest", ctx, "fr", "", "", 4).Return(snapshots, nil)
+	repo.On("ListLatest", ctx, "fr", "fr", "perdu", 4).Return([]*Snapshot{}, nil)
+	words.On("FindByText", ctx, "chat", "fr").Return(&word.Word{
+		ID: "chat-id", Text: "chat", Language: "fr",
+		Definitions: []word.Definition{{Text: "Félin"}},
+		Provenance:  &word.Provenance{ParserVersion: 2},
+	}, nil)
+	words.On("FindByText", ctx, "maison", "fr").Return(&word.Word{
+		ID: "maison-id", Text: "maison", Language: "fr", CreatedAt: created,
+		Definitions: []word.Definition{{Text: "Bâtiment mal parsé"}, {Text: "Bruit"}},
+	}, nil)
+	words.On("FindByText", ctx, "nouveau", "fr").Return(nil, word.ErrWordNotFound)
+	words.On("Save", ctx, mock.Anything).Return(nil)
+
+	// Execute
+	report, err := reparser.Reparse(ctx, "fr", 4, false)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, ReparseReport{Parsed: 3, Updated: 2, Unchanged: 1, Failed: 1}, report)
+	words.AssertNumberOfCalls(t, "Save", 2)
+	// The current parser is trusted over the stored content
+	words.AssertCalled(t, "Save", ctx, mock.MatchedBy(func(w *word.Word) bool {
+		return w.ID == "maison-id" && w.CreatedAt.Equal(created) && len(w.Definitions) == 1
+	}))
+}
+
+func TestReparser_Reparse_DryRun(t *testing.T) {
+	// Setup
+	repo, blobs, words, _, reparser := setupTestArchive(t)
+	ctx := context.Background()
+	digest, _ := blobs.Put(ctx, []byte("Bâtiment"))
+	repo.On("ListLatest", ctx, "", "", "", 100).Return([]*Snapshot{{Text: "maison", Language: "fr", Digest: digest}}, nil)
+	words.On("FindByText", ctx, "maison", "fr").Return(nil, word.ErrWordNotFound)
+
+	// Execute
+	report, err := reparser.Reparse(ctx, "", 0, true)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Updated)
+	words.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,53 @@
+package snapshot
+
+import (
+	"time"
+
+	"voconsteroid/internal/domain/word"
+)
+
+// Snapshot is a raw dictionary page a word was parsed from. The page itself
+// is kept in a BlobStore under its digest, so identical pages are stored once.
+type Snapshot struct {
+	ID         string    `json:"id"`
+	Text       string    `json:"text"`
+	Language   string    `json:"language"`
+	Source     string    `json:"source"`
+	SourceURL  string    `json:"source_url"`
+	RevisionID int64     `json:"revision_id,omitempty"` // 0 when unknown
+	Digest     string    `json:"digest"`                // SHA-256 of the page, in hex
+	Size       int       `json:"size"`                  // Uncompressed size of the page in bytes
+	FetchedAt  time.Time `json:"fetched_at"`
+}
+
+// New creates the snapshot of a page fetched for a word
+func New(text, language string, provenance word.Provenance) *Snapshot {
+	return &Snapshot{
+		Text:       text,
+		Language:   language,
+		Source:     provenance.Source,
+		SourceURL:  provenance.SourceURL,
+		RevisionID: provenance.RevisionID,
+		FetchedAt:  provenance.FetchedAt,
+	}
+}
+
+// Provenance returns the provenance of the words parsed from the snapshot
+// with the given parser version
+func (s *Snapshot) Provenance(parserVersion int) *word.Provenance {
+	return &word.Provenance{
+		Source:        s.Source,
+		SourceURL:     s.SourceURL,
+		RevisionID:    s.RevisionID,
+		ParserVersion: parserVersion,
+		FetchedAt:     s.FetchedAt,
+	}
+}
+
+// ReparseReport counts the outcomes of re-parsing snapshots
+type ReparseReport struct {
+	Parsed    int `json:"parsed"`    // Snapshots parsed into a word
+	Updated   int `json:"updated"`   // Words saved with the re-parsed version
+	Unchanged int `json:"unchanged"` // Words whose re-parsed version is the same
+	Failed    int `json:"failed"`    // Snapshots that could not be read or parsed
+}
@@ -0,0 +1,10 @@
+package snapshot
+
+import "errors"
+
+// Domain errors
+var (
+	ErrSnapshotNotFound = errors.New("snapshot not found")
+	ErrBlobNotFound     = errors.New("blob not found")
+	ErrCorruptBlob      = errors.New("blob does not match its digest")
+)
@@ -0,0 +1,41 @@
+package snapshot
+
+import (
+	"context"
+
+	"voconsteroid/internal/domain/word"
+)
+
+// BlobStore keeps content addressed by its SHA-256 digest
+type BlobStore interface {
+	// Put stores data and returns its digest; storing existing data is a no-op
+	Put(ctx context.Context, data []byte) (string, error)
+
+	// Get retrieves the data of a digest, or ErrBlobNotFound
+	Get(ctx context.Context, digest string) ([]byte, error)
+}
+
+// Repository defines the data access of snapshot metadata
+type Repository interface {
+	// Save stores a snapshot; saving the same page of a word again only
+	// updates its fetch time and ID
+	Save(ctx context.Context, snapshot *Snapshot) error
+
+	// FindLatest retrieves the last snapshot of a word, or ErrSnapshotNotFound
+	FindLatest(ctx context.Context, text, language string) (*Snapshot, error)
+
+	// ListLatest retrieves the last snapshot of each word of a language, or
+	// of every language when empty, ordered by language and text, starting
+	// after the given word
+	ListLatest(ctx context.Context, language, afterLanguage, afterText string, limit int) ([]*Snapshot, error)
+}
+
+// Parser parses a stored page into a word, without network access
+type Parser interface {
+	ParsePage(ctx context.Context, snapshot *Snapshot, page []byte) (*word.Word, error)
+}
+
+// Recorder records the pages dictionary scrapers fetch
+type Recorder interface {
+	Record(ctx context.Context, text, language string, provenance word.Provenance, page []byte) error
+}
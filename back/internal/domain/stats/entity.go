@@ -0,0 +1,160 @@
+package stats
+
+import "time"
+
+// Interval is the width of the buckets of a time series
+type Interval string
+
+// Intervals
+const (
+	IntervalDay   Interval = "day"
+	IntervalWeek  Interval = "week"  // Weeks start on Monday
+	IntervalMonth Interval = "month" // Calendar months
+)
+
+// Intervals lists the supported intervals
+var Intervals = []Interval{IntervalDay, IntervalWeek, IntervalMonth}
+
+// IsValid reports whether the interval is supported
+func (i Interval) IsValid() bool {
+	for _, known := range Intervals {
+		if i == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Truncate returns the start of the bucket holding t, at midnight in the
+// location of t
+func (i Interval) Truncate(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch i {
+	case IntervalWeek:
+		offset := (int(day.Weekday()) + 6) % 7 // Days since Monday
+		return day.AddDate(0, 0, -offset)
+	case IntervalMonth:
+		return day.AddDate(0, 0, 1-day.Day())
+	}
+	return day
+}
+
+// Next returns the start of the bucket following the one starting at t
+func (i Interval) Next(t time.Time) time.Time {
+	switch i {
+	case IntervalWeek:
+		return t.AddDate(0, 0, 7)
+	case IntervalMonth:
+		return t.AddDate(0, 1, 0)
+	}
+	return t.AddDate(0, 0, 1)
+}
+
+// DateLayout is the format of the dates of time series points
+const DateLayout = "2006-01-02"
+
+// ForecastDays is the number of days covered by the review forecast
+const ForecastDays = 30
+
+// Default bucket counts of the time series when no start date is given
+var defaultBuckets = map[Interval]int{
+	IntervalDay:   30,
+	IntervalWeek:  12,
+	IntervalMonth: 12,
+}
+
+// MaxBuckets caps the length of a time series
+const MaxBuckets = 366
+
+// Review states of saved words
+const (
+	StateNew        = "new"        // Saved but never reviewed
+	StateLearning   = "learning"   // Recalled, with an interval shorter than the mastered interval
+	StateRelearning = "relearning" // Last review was a lapse
+	StateMastered   = "mastered"   // Recalled, with an interval of at least the mastered interval
+)
+
+// Options selects the range, bucket width and timezone of statistics
+type Options struct {
+	Interval Interval // Defaults to IntervalDay
+	From     string   // First day, formatted with DateLayout; defaults to a range ending today
+	To       string   // Last day, formatted with DateLayout; defaults to today
+	Timezone string   // IANA name used for day boundaries; defaults to UTC
+}
+
+// Query is a time range split into buckets, as passed to the repository.
+// From is the start of the first bucket and To the end of the last one.
+type Query struct {
+	From     time.Time
+	To       time.Time
+	Interval Interval
+	Timezone string
+}
+
+// Count is an aggregated value for a bucket
+type Count struct {
+	Bucket time.Time // Start of the bucket in the query timezone; zero for values before the range
+	Value  int
+}
+
+// ReviewCount aggregates the reviews of a bucket
+type ReviewCount struct {
+	Bucket     time.Time
+	Reviews    int
+	Recalled   int   // Reviews graded above "again"
+	DurationMs int64 // Time spent answering
+}
+
+// Share is the number of saved words in a category
+type Share struct {
+	Key   string `json:"key"`
+	Count int    `json:"count"`
+}
+
+// Point is a value of a time series
+type Point struct {
+	Date  string `json:"date"`
+	Value int    `json:"value"`
+}
+
+// CumulativePoint is a value of a time series with its running total
+type CumulativePoint struct {
+	Date  string `json:"date"`
+	Added int    `json:"added"`
+	Total int    `json:"total"` // At the end of the bucket
+}
+
+// RatePoint is the retention rate of a bucket
+type RatePoint struct {
+	Date     string  `json:"date"`
+	Reviews  int     `json:"reviews"`
+	Recalled int     `json:"recalled"`
+	Rate     float64 `json:"rate"` // From 0 to 1, 0 without reviews
+}
+
+// Totals summarizes the statistics of a user
+type Totals struct {
+	SavedWords       int     `json:"saved_words"`    // Currently saved
+	MasteredWords    int     `json:"mastered_words"` // Currently mastered
+	Reviews          int     `json:"reviews"`        // In the range
+	RetentionRate    float64 `json:"retention_rate"` // In the range
+	TimeSpentSeconds int     `json:"time_spent_seconds"`
+	DueNext30Days    int     `json:"due_next_30_days"`
+}
+
+// Stats holds the learning statistics of a user as chart-ready time series
+type Stats struct {
+	From          string            `json:"from"`
+	To            string            `json:"to"`
+	Interval      Interval          `json:"interval"`
+	Timezone      string            `json:"timezone"`
+	Totals        Totals            `json:"totals"`
+	SavedWords    []CumulativePoint `json:"saved_words"`
+	MasteredWords []CumulativePoint `json:"mastered_words"` // By the date the mastered interval was first reached
+	Retention     []RatePoint       `json:"retention"`
+	TimeSpent     []Point           `json:"time_spent"` // Seconds spent in reviews and quizzes
+	Forecast      []Point           `json:"forecast"`   // Daily, overdue cards count for today
+	ByState       []Share           `json:"by_state"`
+	ByLanguage    []Share           `json:"by_language"`
+	ByWordType    []Share           `json:"by_word_type"`
+}
@@ -0,0 +1,10 @@
+package stats
+
+import "errors"
+
+// Domain errors
+var (
+	ErrInvalidInterval = errors.New("invalid interval")
+	ErrInvalidRange    = errors.New("invalid date range")
+	ErrInvalidTimezone = errors.New("invalid timezone")
+)
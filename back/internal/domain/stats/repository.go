@@ -0,0 +1,35 @@
+package stats
+
+import "context"
+
+// Repository defines the aggregate queries behind user statistics. Time
+// series methods return only non-empty buckets.
+type Repository interface {
+	// CountSaved counts the words saved per bucket, with a zero bucket for
+	// the words saved before the range
+	CountSaved(ctx context.Context, userID string, q Query) ([]Count, error)
+
+	// CountMastered counts the saved words that first reached the mastered
+	// interval per bucket, with a zero bucket for those mastered before the range
+	CountMastered(ctx context.Context, userID string, q Query, masteredInterval int) ([]Count, error)
+
+	// CountReviews aggregates the reviews per bucket
+	CountReviews(ctx context.Context, userID string, q Query) ([]ReviewCount, error)
+
+	// SumQuizTime sums the quiz answer latencies per bucket, in milliseconds
+	SumQuizTime(ctx context.Context, userID string, q Query) ([]Count, error)
+
+	// CountDue counts the cards falling due per bucket; overdue cards count
+	// in the first bucket
+	CountDue(ctx context.Context, userID string, q Query) ([]Count, error)
+
+	// CountByState counts the saved words per review state
+	CountByState(ctx context.Context, userID string, masteredInterval int) ([]Share, error)
+
+	// CountByLanguage counts the saved words per language
+	CountByLanguage(ctx context.Context, userID string) ([]Share, error)
+
+	// CountByWordType counts the saved words per definition word type; a
+	// word with several word types counts once for each
+	CountByWordType(ctx context.Context, userID string) ([]Share, error)
+}
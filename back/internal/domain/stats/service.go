@@ -0,0 +1,9 @@
+package stats
+
+import "context"
+
+// Service defines the interface for learning statistics business logic
+type Service interface {
+	// GetStats aggregates the statistics of a user over a date range
+	GetStats(ctx context.Context, userID string, opts Options) (*Stats, error)
+}
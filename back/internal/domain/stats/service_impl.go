@@ -0,0 +1,284 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/review"
+)
+
+// Ensure service implements Service interface
+var _ Service = (*service)(nil)
+
+// service implements the Service interface
+type service struct {
+	repo   Repository
+	clock  func() time.Time
+	logger zerolog.Logger
+}
+
+// NewService creates a new statistics service
+func NewService(repo Repository, clock func() time.Time, logger zerolog.Logger) Service {
+	if clock == nil {
+		clock = time.Now
+	}
+	return &service{
+		repo:   repo,
+		clock:  clock,
+		logger: logger.With().Str("component", "stats_service").Logger(),
+	}
+}
+
+// GetStats aggregates the statistics of a user over a date range
+func (s *service) GetStats(ctx context.Context, userID string, opts Options) (*Stats, error) {
+	s.logger.Debug().Str("userID", userID).Str("interval", string(opts.Interval)).Str("from", opts.From).Str("to", opts.To).Msg("Getting stats")
+
+	q, err := s.resolveQuery(opts)
+	if err != nil {
+		return nil, err
+	}
+	buckets := q.buckets()
+
+	today := IntervalDay.Truncate(s.clock().In(q.location()))
+	forecastQuery := Query{From: today, To: today.AddDate(0, 0, ForecastDays), Interval: IntervalDay, Timezone: q.Timezone}
+
+	saved, err := s.repo.CountSaved(ctx, userID, q)
+	if err != nil {
+		s.logger.Error().Err(err).Str("userID", userID).Msg("Failed to count saved words")
+		return nil, fmt.Errorf("failed to count saved words: %w", err)
+	}
+
+	mastered, err := s.repo.CountMastered(ctx, userID, q, review.MasteredInterval)
+	if err != nil {
+		s.logger.Error().Err(err).Str("userID", userID).Msg("Failed to count mastered words")
+		return nil, fmt.Errorf("failed to count mastered words: %w", err)
+	}
+
+	reviews, err := s.repo.CountReviews(ctx, userID, q)
+	if err != nil {
+		s.logger.Error().Err(err).Str("userID", userID).Msg("Failed to count reviews")
+		return nil, fmt.Errorf("failed to count reviews: %w", err)
+	}
+
+	quizTime, err := s.repo.SumQuizTime(ctx, userID, q)
+	if err != nil {
+		s.logger.Error().Err(err).Str("userID", userID).Msg("Failed to sum quiz time")
+		return nil, fmt.Errorf("failed to sum quiz time: %w", err)
+	}
+
+	due, err := s.repo.CountDue(ctx, userID, forecastQuery)
+	if err != nil {
+		s.logger.Error().Err(err).Str("userID", userID).Msg("Failed to count due cards")
+		return nil, fmt.Errorf("failed to count due cards: %w", err)
+	}
+
+	byState, err := s.repo.CountByState(ctx, userID, review.MasteredInterval)
+	if err != nil {
+		s.logger.Error().Err(err).Str("userID", userID).Msg("Failed to count words by state")
+		return nil, fmt.Errorf("failed to count words by state: %w", err)
+	}
+
+	byLanguage, err := s.repo.CountByLanguage(ctx, userID)
+	if err != nil {
+		s.logger.Error().Err(err).Str("userID", userID).Msg("Failed to count words by language")
+		return nil, fmt.Errorf("failed to count words by language: %w", err)
+	}
+
+	byWordType, err := s.repo.CountByWordType(ctx, userID)
+	if err != nil {
+		s.logger.Error().Err(err).Str("userID", userID).Msg("Failed to count words by word type")
+		return nil, fmt.Errorf("failed to count words by word type: %w", err)
+	}
+
+	stats := &Stats{
+		From:          q.From.Format(DateLayout),
+		To:            q.To.AddDate(0, 0, -1).Format(DateLayout),
+		Interval:      q.Interval,
+		Timezone:      q.Timezone,
+		SavedWords:    cumulativeSeries(buckets, saved),
+		MasteredWords: cumulativeSeries(buckets, mastered),
+		Retention:     retentionSeries(buckets, reviews),
+		TimeSpent:     timeSpentSeries(buckets, reviews, quizTime),
+		Forecast:      series(forecastQuery.buckets(), due),
+		ByState:       byState,
+		ByLanguage:    byLanguage,
+		ByWordType:    byWordType,
+	}
+	stats.Totals = totals(stats)
+
+	return stats, nil
+}
+
+// resolveQuery validates the options and turns them into bucket boundaries
+func (s *service) resolveQuery(opts Options) (Query, error) {
+	interval := opts.Interval
+	if interval == "" {
+		interval = IntervalDay
+	}
+	if !interval.IsValid() {
+		return Query{}, fmt.Errorf("%w: %q", ErrInvalidInterval, interval)
+	}
+
+	timezone := opts.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return Query{}, fmt.Errorf("%w: %q", ErrInvalidTimezone, timezone)
+	}
+
+	last := IntervalDay.Truncate(s.clock().In(loc))
+	if opts.To != "" {
+		if last, err = time.ParseInLocation(DateLayout, opts.To, loc); err != nil {
+			return Query{}, fmt.Errorf("%w: invalid end date %q", ErrInvalidRange, opts.To)
+		}
+	}
+	end := interval.Next(interval.Truncate(last))
+
+	var start time.Time
+	if opts.From != "" {
+		first, err := time.ParseInLocation(DateLayout, opts.From, loc)
+		if err != nil {
+			return Query{}, fmt.Errorf("%w: invalid start date %q", ErrInvalidRange, opts.From)
+		}
+		if first.After(last) {
+			return Query{}, fmt.Errorf("%w: start date is after end date", ErrInvalidRange)
+		}
+		start = interval.Truncate(first)
+	} else {
+		start = interval.Truncate(last)
+		for i := 1; i < defaultBuckets[interval]; i++ {
+			start = interval.Truncate(start.AddDate(0, 0, -1))
+		}
+	}
+
+	q := Query{From: start, To: end, Interval: interval, Timezone: loc.String()}
+	if len(q.buckets()) > MaxBuckets {
+		return Query{}, fmt.Errorf("%w: more than %d %ss", ErrInvalidRange, MaxBuckets, interval)
+	}
+
+	return q, nil
+}
+
+// location returns the location of the query timezone
+func (q Query) location() *time.Location {
+	loc, err := time.LoadLocation(q.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// buckets returns the start dates of the buckets of the query
+func (q Query) buckets() []string {
+	var buckets []string
+	for t := q.From; t.Before(q.To) && len(buckets) <= MaxBuckets; t = q.Interval.Next(t) {
+		buckets = append(buckets, t.Format(DateLayout))
+	}
+	return buckets
+}
+
+// byBucket indexes counts by bucket date, returning the value of the zero
+// bucket separately
+func byBucket(counts []Count) (map[string]int, int) {
+	values := make(map[string]int, len(counts))
+	before := 0
+	for _, c := range counts {
+		if c.Bucket.IsZero() {
+			before += c.Value
+			continue
+		}
+		values[c.Bucket.Format(DateLayout)] += c.Value
+	}
+	return values, before
+}
+
+// series fills a time series, with zeros for the missing buckets
+func series(buckets []string, counts []Count) []Point {
+	values, _ := byBucket(counts)
+	points := make([]Point, len(buckets))
+	for i, date := range buckets {
+		points[i] = Point{Date: date, Value: values[date]}
+	}
+	return points
+}
+
+// cumulativeSeries fills a time series with running totals starting from
+// the value before the range
+func cumulativeSeries(buckets []string, counts []Count) []CumulativePoint {
+	values, total := byBucket(counts)
+	points := make([]CumulativePoint, len(buckets))
+	for i, date := range buckets {
+		total += values[date]
+		points[i] = CumulativePoint{Date: date, Added: values[date], Total: total}
+	}
+	return points
+}
+
+// retentionSeries computes the share of recalled reviews per bucket
+func retentionSeries(buckets []string, counts []ReviewCount) []RatePoint {
+	values := make(map[string]ReviewCount, len(counts))
+	for _, c := range counts {
+		values[c.Bucket.Format(DateLayout)] = c
+	}
+	points := make([]RatePoint, len(buckets))
+	for i, date := range buckets {
+		c := values[date]
+		points[i] = RatePoint{Date: date, Reviews: c.Reviews, Recalled: c.Recalled, Rate: rate(c.Recalled, c.Reviews)}
+	}
+	return points
+}
+
+// timeSpentSeries sums the review durations and quiz latencies per bucket,
+// in seconds
+func timeSpentSeries(buckets []string, reviews []ReviewCount, quizTime []Count) []Point {
+	ms := make(map[string]int64, len(buckets))
+	for _, c := range reviews {
+		ms[c.Bucket.Format(DateLayout)] += c.DurationMs
+	}
+	for _, c := range quizTime {
+		ms[c.Bucket.Format(DateLayout)] += int64(c.Value)
+	}
+	points := make([]Point, len(buckets))
+	for i, date := range buckets {
+		points[i] = Point{Date: date, Value: int(ms[date] / 1000)}
+	}
+	return points
+}
+
+// totals summarizes the series and breakdowns of the statistics
+func totals(stats *Stats) Totals {
+	var t Totals
+	for _, share := range stats.ByState {
+		t.SavedWords += share.Count
+		if share.Key == StateMastered {
+			t.MasteredWords = share.Count
+		}
+	}
+	recalled := 0
+	for _, p := range stats.Retention {
+		t.Reviews += p.Reviews
+		recalled += p.Recalled
+	}
+	t.RetentionRate = rate(recalled, t.Reviews)
+	for _, p := range stats.TimeSpent {
+		t.TimeSpentSeconds += p.Value
+	}
+	for _, p := range stats.Forecast {
+		t.DueNext30Days += p.Value
+	}
+	return t
+}
+
+// rate returns a ratio rounded to three decimals, 0 when the total is 0
+func rate(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(part)/float64(total)*1000) / 1000
+}
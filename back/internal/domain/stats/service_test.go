@@ -0,0 +1,238 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"voconsteroid/internal/domain/review"
+)
+
+var testNow = time.Date(2024, 3, 14, 9, 0, 0, 0, time.UTC) // A Thursday
+
+// MockRepository is a mock implementation of the Repository interface
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) CountSaved(ctx context.Context, userID string, q Query) ([]Count, error) {
+	args := m.Called(ctx, userID, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Count), args.Error(1)
+}
+
+func (m *MockRepository) CountMastered(ctx context.Context, userID string, q Query, masteredInterval int) ([]Count, error) {
+	args := m.Called(ctx, userID, q, masteredInterval)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Count), args.Error(1)
+}
+
+func (m *MockRepository) CountReviews(ctx context.Context, userID string, q Query) ([]ReviewCount, error) {
+	args := m.Called(ctx, userID, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ReviewCount), args.Error(1)
+}
+
+func (m *MockRepository) SumQuizTime(ctx context.Context, userID string, q Query) ([]Count, error) {
+	args := m.Called(ctx, userID, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Count), args.Error(1)
+}
+
+func (m *MockRepository) CountDue(ctx context.Context, userID string, q Query) ([]Count, error) {
+	args := m.Called(ctx, userID, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Count), args.Error(1)
+}
+
+func (m *MockRepository) CountByState(ctx context.Context, userID string, masteredInterval int) ([]Share, error) {
+	args := m.Called(ctx, userID, masteredInterval)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Share), args.Error(1)
+}
+
+func (m *MockRepository) CountByLanguage(ctx context.Context, userID string) ([]Share, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Share), args.Error(1)
+}
+
+func (m *MockRepository) CountByWordType(ctx context.Context, userID string) ([]Share, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Share), args.Error(1)
+}
+
+// setupTestService creates a service with a mock repository for testing
+func setupTestService(t *testing.T) (*MockRepository, Service) {
+	repo := new(MockRepository)
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	clock := func() time.Time { return testNow }
+	return repo, NewService(repo, clock, logger)
+}
+
+// day returns midnight of a date in UTC
+func day(value string) time.Time {
+	t, _ := time.Parse(DateLayout, value)
+	return t
+}
+
+func TestInterval_Truncate(t *testing.T) {
+	tests := []struct {
+		interval Interval
+		expected string
+	}{
+		{IntervalDay, "2024-03-14"},
+		{IntervalWeek, "2024-03-11"},
+		{IntervalMonth, "2024-03-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.interval), func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.interval.Truncate(testNow).Format(DateLayout))
+		})
+	}
+}
+
+func TestGetStats(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("fills and accumulates series", func(t *testing.T) {
+		// Setup
+		repo, svc := setupTestService(t)
+		q := Query{From: day("2024-03-12"), To: day("2024-03-15"), Interval: IntervalDay, Timezone: "UTC"}
+		forecast := Query{From: day("2024-03-14"), To: day("2024-04-13"), Interval: IntervalDay, Timezone: "UTC"}
+		repo.On("CountSaved", ctx, "user-1", q).Return([]Count{{Value: 10}, {Bucket: day("2024-03-13"), Value: 2}}, nil)
+		repo.On("CountMastered", ctx, "user-1", q, review.MasteredInterval).Return([]Count{{Bucket: day("2024-03-14"), Value: 1}}, nil)
+		repo.On("CountReviews", ctx, "user-1", q).Return([]ReviewCount{
+			{Bucket: day("2024-03-12"), Reviews: 4, Recalled: 3, DurationMs: 20000},
+			{Bucket: day("2024-03-14"), Reviews: 2, Recalled: 2, DurationMs: 5000},
+		}, nil)
+		repo.On("SumQuizTime", ctx, "user-1", q).Return([]Count{{Bucket: day("2024-03-14"), Value: 7000}}, nil)
+		repo.On("CountDue", ctx, "user-1", forecast).Return([]Count{{Bucket: day("2024-03-14"), Value: 5}, {Bucket: day("2024-03-20"), Value: 1}}, nil)
+		repo.On("CountByState", ctx, "user-1", review.MasteredInterval).Return([]Share{{Key: StateNew, Count: 8}, {Key: StateMastered, Count: 4}}, nil)
+		repo.On("CountByLanguage", ctx, "user-1").Return([]Share{{Key: "fr", Count: 12}}, nil)
+		repo.On("CountByWordType", ctx, "user-1").Return([]Share{{Key: "noun", Count: 9}}, nil)
+
+		// Execute
+		stats, err := svc.GetStats(ctx, "user-1", Options{From: "2024-03-12"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "2024-03-12", stats.From)
+		assert.Equal(t, "2024-03-14", stats.To)
+		assert.Equal(t, []CumulativePoint{
+			{Date: "2024-03-12", Added: 0, Total: 10},
+			{Date: "2024-03-13", Added: 2, Total: 12},
+			{Date: "2024-03-14", Added: 0, Total: 12},
+		}, stats.SavedWords)
+		assert.Equal(t, 1, stats.MasteredWords[2].Total)
+		assert.Equal(t, 0.75, stats.Retention[0].Rate)
+		assert.Equal(t, 0.0, stats.Retention[1].Rate)
+		assert.Equal(t, []Point{{"2024-03-12", 20}, {"2024-03-13", 0}, {"2024-03-14", 12}}, stats.TimeSpent)
+		assert.Len(t, stats.Forecast, ForecastDays)
+		assert.Equal(t, 5, stats.Forecast[0].Value)
+		assert.Equal(t, 1, stats.Forecast[6].Value)
+		assert.Equal(t, Totals{
+			SavedWords:       12,
+			MasteredWords:    4,
+			Reviews:          6,
+			RetentionRate:    0.833,
+			TimeSpentSeconds: 32,
+			DueNext30Days:    6,
+		}, stats.Totals)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("default weekly range in a timezone", func(t *testing.T) {
+		// Setup
+		repo, svc := setupTestService(t)
+		loc, _ := time.LoadLocation("Pacific/Auckland")
+		repo.On("CountSaved", ctx, "user-1", mock.MatchedBy(func(q Query) bool {
+			return q.Interval == IntervalWeek && q.Timezone == "Pacific/Auckland" &&
+				q.From.Equal(time.Date(2023, 12, 25, 0, 0, 0, 0, loc)) &&
+				q.To.Equal(time.Date(2024, 3, 18, 0, 0, 0, 0, loc))
+		})).Return([]Count{}, nil)
+		repo.On("CountMastered", ctx, "user-1", mock.Anything, mock.Anything).Return([]Count{}, nil)
+		repo.On("CountReviews", ctx, "user-1", mock.Anything).Return([]ReviewCount{}, nil)
+		repo.On("SumQuizTime", ctx, "user-1", mock.Anything).Return([]Count{}, nil)
+		repo.On("CountDue", ctx, "user-1", mock.Anything).Return([]Count{}, nil)
+		repo.On("CountByState", ctx, "user-1", mock.Anything).Return([]Share{}, nil)
+		repo.On("CountByLanguage", ctx, "user-1").Return([]Share{}, nil)
+		repo.On("CountByWordType", ctx, "user-1").Return([]Share{}, nil)
+
+		// Execute
+		stats, err := svc.GetStats(ctx, "user-1", Options{Interval: IntervalWeek, Timezone: "Pacific/Auckland"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Len(t, stats.SavedWords, 12)
+		assert.Equal(t, "2024-03-11", stats.SavedWords[11].Date)
+		assert.Equal(t, "2024-03-17", stats.To)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			opts     Options
+			expected error
+		}{
+			{"unknown interval", Options{Interval: "year"}, ErrInvalidInterval},
+			{"unknown timezone", Options{Timezone: "Mars/Olympus_Mons"}, ErrInvalidTimezone},
+			{"malformed date", Options{From: "14/03/2024"}, ErrInvalidRange},
+			{"start after end", Options{From: "2024-03-10", To: "2024-03-01"}, ErrInvalidRange},
+			{"too many buckets", Options{From: "2020-01-01"}, ErrInvalidRange},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// Setup
+				repo, svc := setupTestService(t)
+
+				// Execute
+				stats, err := svc.GetStats(ctx, "user-1", tt.opts)
+
+				// Assert
+				assert.Nil(t, stats)
+				assert.ErrorIs(t, err, tt.expected)
+				repo.AssertNotCalled(t, "CountSaved", mock.Anything, mock.Anything, mock.Anything)
+			})
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		// Setup
+		repo, svc := setupTestService(t)
+		repo.On("CountSaved", ctx, "user-1", mock.Anything).Return(nil, errors.New("db down"))
+
+		// Execute
+		stats, err := svc.GetStats(ctx, "user-1", Options{})
+
+		// Assert
+		assert.Nil(t, stats)
+		assert.Error(t, err)
+	})
+}
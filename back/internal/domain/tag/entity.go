@@ -0,0 +1,94 @@
+package tag
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxNameLength is the maximum length of a tag name
+const MaxNameLength = 50
+
+// TargetType identifies the kind of object a tag is assigned to
+type TargetType string
+
+// Supported target types
+const (
+	TargetSavedWord TargetType = "saved_word"
+	TargetWordList  TargetType = "word_list"
+)
+
+// IsValidTargetType checks if the target type can be tagged
+func IsValidTargetType(t TargetType) bool {
+	switch t {
+	case TargetSavedWord, TargetWordList:
+		return true
+	}
+	return false
+}
+
+// Tag represents a user-defined label that can be applied to saved words and word lists
+type Tag struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Assignment links a tag to the object it is applied to
+type Assignment struct {
+	ID         string     `json:"id"`
+	TagID      string     `json:"tag_id"`
+	TargetID   string     `json:"target_id"`
+	TargetType TargetType `json:"target_type"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// NewTag creates a new Tag entity
+func NewTag(userID, name string) *Tag {
+	now := time.Now()
+	return &Tag{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Name:      NormalizeName(name),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// NewAssignment creates a new Assignment of a tag to a target
+func NewAssignment(tagID string, targetType TargetType, targetID string) *Assignment {
+	return &Assignment{
+		ID:         uuid.New().String(),
+		TagID:      tagID,
+		TargetID:   targetID,
+		TargetType: targetType,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// NormalizeName trims a tag name and collapses inner whitespace.
+// Names keep their case; uniqueness is checked case-insensitively.
+func NormalizeName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// Validate checks the tag name
+func (t *Tag) Validate() error {
+	if t.Name == "" || len([]rune(t.Name)) > MaxNameLength {
+		return ErrInvalidName
+	}
+	// Parentheses and quotes are reserved by tag expressions
+	if strings.ContainsAny(t.Name, `()"`) {
+		return ErrInvalidName
+	}
+	return nil
+}
+
+// Rename changes the name of the tag
+func (t *Tag) Rename(name string) {
+	t.Name = NormalizeName(name)
+	t.UpdatedAt = time.Now()
+}
@@ -0,0 +1,14 @@
+package tag
+
+import "errors"
+
+// Domain errors
+var (
+	ErrTagNotFound       = errors.New("tag not found")
+	ErrDuplicateTagName  = errors.New("tag name already exists")
+	ErrInvalidName       = errors.New("invalid tag name")
+	ErrInvalidTargetType = errors.New("invalid tag target type")
+	ErrInvalidTargets    = errors.New("targets must exist and belong to the tag owner")
+	ErrInvalidMerge      = errors.New("a tag cannot be merged into itself")
+	ErrInvalidExpression = errors.New("invalid tag expression")
+)
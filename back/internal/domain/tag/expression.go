@@ -0,0 +1,271 @@
+package tag
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// maxExpressionTerms bounds the number of tag names in an expression
+const maxExpressionTerms = 20
+
+// Expr is a boolean expression over tag names, such as
+// `verbs AND (food OR travel) AND NOT "to review"`.
+type Expr interface {
+	// Match reports whether a set of tag names satisfies the expression.
+	// Keys of names must be lowercased.
+	Match(names map[string]bool) bool
+
+	// String returns the canonical form of the expression
+	String() string
+}
+
+// NameExpr matches objects tagged with Name (case-insensitive)
+type NameExpr struct {
+	Name string
+}
+
+// AndExpr matches objects matching both operands
+type AndExpr struct {
+	Left, Right Expr
+}
+
+// OrExpr matches objects matching at least one operand
+type OrExpr struct {
+	Left, Right Expr
+}
+
+// NotExpr matches objects not matching its operand
+type NotExpr struct {
+	Expr Expr
+}
+
+// Match implements Expr
+func (e NameExpr) Match(names map[string]bool) bool { return names[strings.ToLower(e.Name)] }
+
+// Match implements Expr
+func (e AndExpr) Match(names map[string]bool) bool { return e.Left.Match(names) && e.Right.Match(names) }
+
+// Match implements Expr
+func (e OrExpr) Match(names map[string]bool) bool { return e.Left.Match(names) || e.Right.Match(names) }
+
+// Match implements Expr
+func (e NotExpr) Match(names map[string]bool) bool { return !e.Expr.Match(names) }
+
+// String implements Expr
+func (e NameExpr) String() string {
+	if strings.ContainsAny(e.Name, " \t") || isKeyword(e.Name) {
+		return `"` + e.Name + `"`
+	}
+	return e.Name
+}
+
+// String implements Expr
+func (e AndExpr) String() string { return "(" + e.Left.String() + " AND " + e.Right.String() + ")" }
+
+// String implements Expr
+func (e OrExpr) String() string { return "(" + e.Left.String() + " OR " + e.Right.String() + ")" }
+
+// String implements Expr
+func (e NotExpr) String() string { return "NOT " + e.Expr.String() }
+
+// ParseExpression parses a tag expression. Operators are AND, OR and NOT
+// (case-insensitive), with NOT binding tightest and OR loosest; adjacent
+// terms are joined with AND. Parentheses group sub-expressions and double
+// quotes delimit tag names containing spaces.
+func ParseExpression(input string) (Expr, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%w: empty expression", ErrInvalidExpression)
+	}
+
+	p := &parser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidExpression, p.tokens[p.pos].text)
+	}
+	if p.terms > maxExpressionTerms {
+		return nil, fmt.Errorf("%w: more than %d tags", ErrInvalidExpression, maxExpressionTerms)
+	}
+
+	return expr, nil
+}
+
+// tokenKind classifies expression tokens
+type tokenKind int
+
+const (
+	tokenName tokenKind = iota
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenOpen
+	tokenClose
+)
+
+// token is a lexical unit of a tag expression
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// tokenize splits an expression into tokens
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenOpen, text: "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenClose, text: ")"})
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("%w: unterminated quote", ErrInvalidExpression)
+			}
+			name := NormalizeName(string(runes[i+1 : end]))
+			if name == "" {
+				return nil, fmt.Errorf("%w: empty tag name", ErrInvalidExpression)
+			}
+			tokens = append(tokens, token{kind: tokenName, text: name})
+			i = end + 1
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune(`()"`, runes[end]) {
+				end++
+			}
+			word := string(runes[i:end])
+			tokens = append(tokens, keywordOrName(word))
+			i = end
+		}
+	}
+
+	return tokens, nil
+}
+
+// keywordOrName turns an unquoted word into an operator or a tag name token
+func keywordOrName(word string) token {
+	switch strings.ToUpper(word) {
+	case "AND":
+		return token{kind: tokenAnd, text: word}
+	case "OR":
+		return token{kind: tokenOr, text: word}
+	case "NOT":
+		return token{kind: tokenNot, text: word}
+	}
+	return token{kind: tokenName, text: word}
+}
+
+// isKeyword reports whether a name would be read as an operator when unquoted
+func isKeyword(name string) bool {
+	return keywordOrName(name).kind != tokenName
+}
+
+// parser is a recursive descent parser over expression tokens
+type parser struct {
+	tokens []token
+	pos    int
+	terms  int
+}
+
+func (p *parser) peek() (token, bool) {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos], true
+	}
+	return token{}, false
+}
+
+// parseOr parses: and { OR and }
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok, ok := p.peek()
+		if !ok || tok.kind != tokenOr {
+			return left, nil
+		}
+		p.pos++
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = OrExpr{Left: left, Right: right}
+	}
+}
+
+// parseAnd parses: unary { [AND] unary }
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok, ok := p.peek()
+		if !ok || tok.kind == tokenOr || tok.kind == tokenClose {
+			return left, nil
+		}
+		if tok.kind == tokenAnd {
+			p.pos++
+		}
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = AndExpr{Left: left, Right: right}
+	}
+}
+
+// parseUnary parses: NOT unary | '(' or ')' | name
+func (p *parser) parseUnary() (Expr, error) {
+	tok, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected end of expression", ErrInvalidExpression)
+	}
+	p.pos++
+
+	switch tok.kind {
+	case tokenNot:
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return NotExpr{Expr: operand}, nil
+	case tokenOpen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing, ok := p.peek(); !ok || closing.kind != tokenClose {
+			return nil, fmt.Errorf("%w: missing closing parenthesis", ErrInvalidExpression)
+		}
+		p.pos++
+		return inner, nil
+	case tokenName:
+		p.terms++
+		return NameExpr{Name: tok.text}, nil
+	}
+
+	return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidExpression, tok.text)
+}
@@ -0,0 +1,68 @@
+package tag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExpression(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"single tag", "verbs", "verbs"},
+		{"and", "verbs AND food", "(verbs AND food)"},
+		{"implicit and", "verbs food", "(verbs AND food)"},
+		{"precedence", "a OR b AND NOT c", "(a OR (b AND NOT c))"},
+		{"parentheses", "(a OR b) and not c", "((a OR b) AND NOT c)"},
+		{"quoted name", `"to review" OR "and"`, `("to review" OR "and")`},
+		{"double negation", "NOT NOT a", "NOT NOT a"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			expr, err := ParseExpression(tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, expr.String())
+		})
+	}
+}
+
+func TestParseExpression_Invalid(t *testing.T) {
+	for _, input := range []string{"", "   ", "a AND", "(a OR b", "a)", "OR a", `"unterminated`, `""`, "NOT"} {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseExpression(input)
+			assert.ErrorIs(t, err, ErrInvalidExpression)
+		})
+	}
+}
+
+func TestExpr_Match(t *testing.T) {
+	expr, err := ParseExpression(`Verbs AND (food OR travel) AND NOT "to review"`)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		tags     []string
+		expected bool
+	}{
+		{"all required", []string{"verbs", "food"}, true},
+		{"alternative", []string{"verbs", "travel"}, true},
+		{"excluded", []string{"verbs", "food", "to review"}, false},
+		{"missing alternative", []string{"verbs"}, false},
+		{"no tags", nil, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			names := make(map[string]bool)
+			for _, tag := range tc.tags {
+				names[tag] = true
+			}
+			assert.Equal(t, tc.expected, expr.Match(names))
+		})
+	}
+}
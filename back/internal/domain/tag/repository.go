@@ -0,0 +1,42 @@
+package tag
+
+import (
+	"context"
+)
+
+// Repository defines the interface for tag data access
+type Repository interface {
+	// FindByID retrieves a tag by its ID
+	FindByID(ctx context.Context, id string) (*Tag, error)
+
+	// FindByUserAndName retrieves a user's tag by its name, ignoring case
+	FindByUserAndName(ctx context.Context, userID, name string) (*Tag, error)
+
+	// ListByUser retrieves the tags of a user, ordered by name
+	ListByUser(ctx context.Context, userID string) ([]*Tag, error)
+
+	// ListByTarget retrieves the tags assigned to an object
+	ListByTarget(ctx context.Context, targetType TargetType, targetID string) ([]*Tag, error)
+
+	// FindSuggestions retrieves the names of a user's tags starting with a prefix
+	FindSuggestions(ctx context.Context, userID, prefix string, limit int) ([]string, error)
+
+	// Save stores a tag in the repository
+	Save(ctx context.Context, tag *Tag) error
+
+	// Delete removes a tag and its assignments from the repository
+	Delete(ctx context.Context, id string) error
+
+	// Merge moves the assignments of the source tag to the target tag and
+	// deletes the source tag
+	Merge(ctx context.Context, sourceID, targetID string) error
+
+	// Assign stores tag assignments; assignments that already exist are skipped
+	Assign(ctx context.Context, assignments []*Assignment) error
+
+	// Unassign removes a tag from the given objects
+	Unassign(ctx context.Context, tagID string, targetType TargetType, targetIDs []string) error
+
+	// CountOwnedTargets counts how many of the given objects exist and belong to the user
+	CountOwnedTargets(ctx context.Context, userID string, targetType TargetType, targetIDs []string) (int, error)
+}
@@ -0,0 +1,36 @@
+package tag
+
+import (
+	"context"
+)
+
+// Service defines the interface for tag business logic
+type Service interface {
+	// CreateTag creates a new tag for a user
+	CreateTag(ctx context.Context, userID, name string) (*Tag, error)
+
+	// ListTags retrieves the tags of a user
+	ListTags(ctx context.Context, userID string) ([]*Tag, error)
+
+	// ListTargetTags retrieves the tags a user assigned to an object
+	ListTargetTags(ctx context.Context, userID string, targetType TargetType, targetID string) ([]*Tag, error)
+
+	// RenameTag changes the name of a tag
+	RenameTag(ctx context.Context, userID, id, name string) (*Tag, error)
+
+	// MergeTags moves every assignment of the source tag to the target tag
+	// and deletes the source tag
+	MergeTags(ctx context.Context, userID, sourceID, targetID string) (*Tag, error)
+
+	// DeleteTag removes a tag and its assignments
+	DeleteTag(ctx context.Context, userID, id string) error
+
+	// AssignTag applies a tag to saved words or word lists of the user
+	AssignTag(ctx context.Context, userID, id string, targetType TargetType, targetIDs []string) error
+
+	// UnassignTag removes a tag from saved words or word lists
+	UnassignTag(ctx context.Context, userID, id string, targetType TargetType, targetIDs []string) error
+
+	// GetSuggestions retrieves tag name suggestions for autocomplete
+	GetSuggestions(ctx context.Context, userID, prefix string) ([]string, error)
+}
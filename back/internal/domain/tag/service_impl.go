@@ -0,0 +1,283 @@
+package tag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// Ensure service implements Service interface
+var _ Service = (*service)(nil)
+
+// service implements the Service interface
+type service struct {
+	repo   Repository
+	logger zerolog.Logger
+}
+
+// NewService creates a new tag service
+func NewService(repo Repository, logger zerolog.Logger) Service {
+	return &service{
+		repo:   repo,
+		logger: logger.With().Str("component", "tag_service").Logger(),
+	}
+}
+
+// CreateTag creates a new tag for a user
+func (s *service) CreateTag(ctx context.Context, userID, name string) (*Tag, error) {
+	s.logger.Debug().Str("userID", userID).Str("name", name).Msg("Creating tag")
+
+	tag := NewTag(userID, name)
+	if err := tag.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureNameAvailable(ctx, userID, tag.Name, ""); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Save(ctx, tag); err != nil {
+		s.logger.Error().Err(err).Str("name", tag.Name).Msg("Failed to create tag")
+		return nil, fmt.Errorf("failed to create tag: %w", err)
+	}
+
+	return tag, nil
+}
+
+// ListTags retrieves the tags of a user
+func (s *service) ListTags(ctx context.Context, userID string) ([]*Tag, error) {
+	s.logger.Debug().Str("userID", userID).Msg("Listing tags")
+
+	tags, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to list tags")
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// ListTargetTags retrieves the tags a user assigned to an object
+func (s *service) ListTargetTags(ctx context.Context, userID string, targetType TargetType, targetID string) ([]*Tag, error) {
+	s.logger.Debug().Str("userID", userID).Str("targetType", string(targetType)).Str("targetID", targetID).Msg("Listing target tags")
+
+	if err := s.checkTargets(ctx, userID, targetType, []string{targetID}); err != nil {
+		return nil, err
+	}
+
+	tags, err := s.repo.ListByTarget(ctx, targetType, targetID)
+	if err != nil {
+		s.logger.Error().Err(err).Str("targetID", targetID).Msg("Failed to list target tags")
+		return nil, fmt.Errorf("failed to list target tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// RenameTag changes the name of a tag
+func (s *service) RenameTag(ctx context.Context, userID, id, name string) (*Tag, error) {
+	s.logger.Debug().Str("userID", userID).Str("id", id).Str("name", name).Msg("Renaming tag")
+
+	tag, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	tag.Rename(name)
+	if err := tag.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureNameAvailable(ctx, userID, tag.Name, tag.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Save(ctx, tag); err != nil {
+		s.logger.Error().Err(err).Str("id", id).Msg("Failed to rename tag")
+		return nil, fmt.Errorf("failed to rename tag: %w", err)
+	}
+
+	return tag, nil
+}
+
+// MergeTags moves every assignment of the source tag to the target tag
+// and deletes the source tag
+func (s *service) MergeTags(ctx context.Context, userID, sourceID, targetID string) (*Tag, error) {
+	s.logger.Debug().Str("userID", userID).Str("sourceID", sourceID).Str("targetID", targetID).Msg("Merging tags")
+
+	if sourceID == targetID {
+		return nil, ErrInvalidMerge
+	}
+
+	if _, err := s.findOwned(ctx, userID, sourceID); err != nil {
+		return nil, err
+	}
+
+	target, err := s.findOwned(ctx, userID, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Merge(ctx, sourceID, targetID); err != nil {
+		s.logger.Error().Err(err).Str("sourceID", sourceID).Str("targetID", targetID).Msg("Failed to merge tags")
+		return nil, fmt.Errorf("failed to merge tags: %w", err)
+	}
+
+	return target, nil
+}
+
+// DeleteTag removes a tag and its assignments
+func (s *service) DeleteTag(ctx context.Context, userID, id string) error {
+	s.logger.Debug().Str("userID", userID).Str("id", id).Msg("Deleting tag")
+
+	if _, err := s.findOwned(ctx, userID, id); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		s.logger.Error().Err(err).Str("id", id).Msg("Failed to delete tag")
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+
+	return nil
+}
+
+// AssignTag applies a tag to saved words or word lists of the user
+func (s *service) AssignTag(ctx context.Context, userID, id string, targetType TargetType, targetIDs []string) error {
+	s.logger.Debug().Str("userID", userID).Str("id", id).Str("targetType", string(targetType)).Int("count", len(targetIDs)).Msg("Assigning tag")
+
+	if _, err := s.findOwned(ctx, userID, id); err != nil {
+		return err
+	}
+
+	targetIDs = uniqueIDs(targetIDs)
+	if err := s.checkTargets(ctx, userID, targetType, targetIDs); err != nil {
+		return err
+	}
+
+	assignments := make([]*Assignment, 0, len(targetIDs))
+	for _, targetID := range targetIDs {
+		assignments = append(assignments, NewAssignment(id, targetType, targetID))
+	}
+
+	if err := s.repo.Assign(ctx, assignments); err != nil {
+		s.logger.Error().Err(err).Str("id", id).Msg("Failed to assign tag")
+		return fmt.Errorf("failed to assign tag: %w", err)
+	}
+
+	return nil
+}
+
+// UnassignTag removes a tag from saved words or word lists
+func (s *service) UnassignTag(ctx context.Context, userID, id string, targetType TargetType, targetIDs []string) error {
+	s.logger.Debug().Str("userID", userID).Str("id", id).Str("targetType", string(targetType)).Int("count", len(targetIDs)).Msg("Unassigning tag")
+
+	if !IsValidTargetType(targetType) {
+		return ErrInvalidTargetType
+	}
+	if len(targetIDs) == 0 {
+		return ErrInvalidTargets
+	}
+
+	if _, err := s.findOwned(ctx, userID, id); err != nil {
+		return err
+	}
+
+	if err := s.repo.Unassign(ctx, id, targetType, uniqueIDs(targetIDs)); err != nil {
+		s.logger.Error().Err(err).Str("id", id).Msg("Failed to unassign tag")
+		return fmt.Errorf("failed to unassign tag: %w", err)
+	}
+
+	return nil
+}
+
+// GetSuggestions retrieves tag name suggestions for autocomplete
+func (s *service) GetSuggestions(ctx context.Context, userID, prefix string) ([]string, error) {
+	s.logger.Debug().Str("userID", userID).Str("prefix", prefix).Msg("Getting tag suggestions")
+
+	normalizedPrefix := strings.ToLower(NormalizeName(prefix))
+	if normalizedPrefix == "" {
+		return []string{}, nil
+	}
+
+	suggestions, err := s.repo.FindSuggestions(ctx, userID, normalizedPrefix, 10)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to get tag suggestions")
+		return nil, fmt.Errorf("failed to get tag suggestions: %w", err)
+	}
+
+	return suggestions, nil
+}
+
+// findOwned retrieves a tag and checks that it belongs to the user.
+// Tags of other users are reported as not found so their IDs do not leak.
+func (s *service) findOwned(ctx context.Context, userID, id string) (*Tag, error) {
+	tag, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrTagNotFound) {
+			return nil, err
+		}
+		s.logger.Error().Err(err).Str("id", id).Msg("Failed to find tag")
+		return nil, fmt.Errorf("failed to find tag: %w", err)
+	}
+
+	if tag.UserID != userID {
+		return nil, ErrTagNotFound
+	}
+
+	return tag, nil
+}
+
+// ensureNameAvailable checks that no other tag of the user has the given name
+func (s *service) ensureNameAvailable(ctx context.Context, userID, name, exceptID string) error {
+	existing, err := s.repo.FindByUserAndName(ctx, userID, name)
+	if err != nil {
+		if errors.Is(err, ErrTagNotFound) {
+			return nil
+		}
+		s.logger.Error().Err(err).Str("name", name).Msg("Failed to check tag name")
+		return fmt.Errorf("failed to check tag name: %w", err)
+	}
+
+	if existing.ID != exceptID {
+		return ErrDuplicateTagName
+	}
+	return nil
+}
+
+// checkTargets verifies that all the targets exist and belong to the user
+func (s *service) checkTargets(ctx context.Context, userID string, targetType TargetType, targetIDs []string) error {
+	if !IsValidTargetType(targetType) {
+		return ErrInvalidTargetType
+	}
+	if len(targetIDs) == 0 {
+		return ErrInvalidTargets
+	}
+
+	count, err := s.repo.CountOwnedTargets(ctx, userID, targetType, targetIDs)
+	if err != nil {
+		s.logger.Error().Err(err).Str("targetType", string(targetType)).Msg("Failed to check tag targets")
+		return fmt.Errorf("failed to check tag targets: %w", err)
+	}
+
+	if count != len(targetIDs) {
+		return ErrInvalidTargets
+	}
+	return nil
+}
+
+// uniqueIDs removes repeated IDs, keeping the first occurrence
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
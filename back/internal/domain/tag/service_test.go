@@ -0,0 +1,269 @@
+package tag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockRepository is a mock implementation of the Repository interface
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) FindByID(ctx context.Context, id string) (*Tag, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Tag), args.Error(1)
+}
+
+func (m *MockRepository) FindByUserAndName(ctx context.Context, userID, name string) (*Tag, error) {
+	args := m.Called(ctx, userID, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Tag), args.Error(1)
+}
+
+func (m *MockRepository) ListByUser(ctx context.Context, userID string) ([]*Tag, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Tag), args.Error(1)
+}
+
+func (m *MockRepository) ListByTarget(ctx context.Context, targetType TargetType, targetID string) ([]*Tag, error) {
+	args := m.Called(ctx, targetType, targetID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Tag), args.Error(1)
+}
+
+func (m *MockRepository) FindSuggestions(ctx context.Context, userID, prefix string, limit int) ([]string, error) {
+	args := m.Called(ctx, userID, prefix, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockRepository) Save(ctx context.Context, tag *Tag) error {
+	args := m.Called(ctx, tag)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Merge(ctx context.Context, sourceID, targetID string) error {
+	args := m.Called(ctx, sourceID, targetID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Assign(ctx context.Context, assignments []*Assignment) error {
+	args := m.Called(ctx, assignments)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Unassign(ctx context.Context, tagID string, targetType TargetType, targetIDs []string) error {
+	args := m.Called(ctx, tagID, targetType, targetIDs)
+	return args.Error(0)
+}
+
+func (m *MockRepository) CountOwnedTargets(ctx context.Context, userID string, targetType TargetType, targetIDs []string) (int, error) {
+	args := m.Called(ctx, userID, targetType, targetIDs)
+	return args.Int(0), args.Error(1)
+}
+
+// setupTestService creates a service with mocks for testing
+func setupTestService(t *testing.T) (*MockRepository, Service) {
+	repo := new(MockRepository)
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+
+	return repo, NewService(repo, logger)
+}
+
+func TestCreateTag(t *testing.T) {
+	// Setup
+	repo, svc := setupTestService(t)
+	ctx := context.Background()
+
+	repo.On("FindByUserAndName", ctx, "user-1", "to review").Return(nil, ErrTagNotFound)
+	repo.On("Save", ctx, mock.AnythingOfType("*tag.Tag")).Return(nil)
+
+	// Execute
+	tag, err := svc.CreateTag(ctx, "user-1", "  to   review ")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "to review", tag.Name)
+	assert.Equal(t, "user-1", tag.UserID)
+	repo.AssertExpectations(t)
+}
+
+func TestCreateTag_DuplicateName(t *testing.T) {
+	// Setup
+	repo, svc := setupTestService(t)
+	ctx := context.Background()
+
+	repo.On("FindByUserAndName", ctx, "user-1", "Verbs").Return(NewTag("user-1", "verbs"), nil)
+
+	// Execute
+	tag, err := svc.CreateTag(ctx, "user-1", "Verbs")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrDuplicateTagName)
+	assert.Nil(t, tag)
+	repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestCreateTag_InvalidName(t *testing.T) {
+	// Setup
+	_, svc := setupTestService(t)
+	ctx := context.Background()
+
+	for _, name := range []string{"", "   ", "(verbs)", `say "hi"`} {
+		// Execute
+		tag, err := svc.CreateTag(ctx, "user-1", name)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidName, name)
+		assert.Nil(t, tag)
+	}
+}
+
+func TestRenameTag_ChangeCase(t *testing.T) {
+	// Setup
+	repo, svc := setupTestService(t)
+	ctx := context.Background()
+
+	tag := NewTag("user-1", "verbs")
+	repo.On("FindByID", ctx, tag.ID).Return(tag, nil)
+	repo.On("FindByUserAndName", ctx, "user-1", "Verbs").Return(tag, nil)
+	repo.On("Save", ctx, tag).Return(nil)
+
+	// Execute
+	renamed, err := svc.RenameTag(ctx, "user-1", tag.ID, "Verbs")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Verbs", renamed.Name)
+}
+
+func TestMergeTags(t *testing.T) {
+	// Setup
+	repo, svc := setupTestService(t)
+	ctx := context.Background()
+
+	source := NewTag("user-1", "verb")
+	target := NewTag("user-1", "verbs")
+	repo.On("FindByID", ctx, source.ID).Return(source, nil)
+	repo.On("FindByID", ctx, target.ID).Return(target, nil)
+	repo.On("Merge", ctx, source.ID, target.ID).Return(nil)
+
+	// Execute
+	merged, err := svc.MergeTags(ctx, "user-1", source.ID, target.ID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, target, merged)
+	repo.AssertExpectations(t)
+}
+
+func TestMergeTags_OtherUserTarget(t *testing.T) {
+	// Setup
+	repo, svc := setupTestService(t)
+	ctx := context.Background()
+
+	source := NewTag("user-1", "verb")
+	target := NewTag("user-2", "verbs")
+	repo.On("FindByID", ctx, source.ID).Return(source, nil)
+	repo.On("FindByID", ctx, target.ID).Return(target, nil)
+
+	// Execute
+	merged, err := svc.MergeTags(ctx, "user-1", source.ID, target.ID)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrTagNotFound)
+	assert.Nil(t, merged)
+	repo.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAssignTag(t *testing.T) {
+	// Setup
+	repo, svc := setupTestService(t)
+	ctx := context.Background()
+
+	tag := NewTag("user-1", "verbs")
+	repo.On("FindByID", ctx, tag.ID).Return(tag, nil)
+	repo.On("CountOwnedTargets", ctx, "user-1", TargetSavedWord, []string{"sw-1", "sw-2"}).Return(2, nil)
+	repo.On("Assign", ctx, mock.MatchedBy(func(assignments []*Assignment) bool {
+		return len(assignments) == 2 &&
+			assignments[0].TargetID == "sw-1" && assignments[1].TargetID == "sw-2" &&
+			assignments[0].TagID == tag.ID && assignments[0].TargetType == TargetSavedWord
+	})).Return(nil)
+
+	// Execute
+	err := svc.AssignTag(ctx, "user-1", tag.ID, TargetSavedWord, []string{"sw-1", "sw-2", "sw-1"})
+
+	// Assert
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestAssignTag_ForeignTarget(t *testing.T) {
+	// Setup
+	repo, svc := setupTestService(t)
+	ctx := context.Background()
+
+	tag := NewTag("user-1", "verbs")
+	repo.On("FindByID", ctx, tag.ID).Return(tag, nil)
+	repo.On("CountOwnedTargets", ctx, "user-1", TargetWordList, []string{"list-1", "list-2"}).Return(1, nil)
+
+	// Execute
+	err := svc.AssignTag(ctx, "user-1", tag.ID, TargetWordList, []string{"list-1", "list-2"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidTargets)
+	repo.AssertNotCalled(t, "Assign", mock.Anything, mock.Anything)
+}
+
+func TestAssignTag_InvalidTargetType(t *testing.T) {
+	// Setup
+	repo, svc := setupTestService(t)
+	ctx := context.Background()
+
+	tag := NewTag("user-1", "verbs")
+	repo.On("FindByID", ctx, tag.ID).Return(tag, nil)
+
+	// Execute
+	err := svc.AssignTag(ctx, "user-1", tag.ID, TargetType("word"), []string{"word-1"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidTargetType)
+}
+
+func TestGetSuggestions(t *testing.T) {
+	// Setup
+	repo, svc := setupTestService(t)
+	ctx := context.Background()
+
+	repo.On("FindSuggestions", ctx, "user-1", "ver", 10).Return([]string{"verbs", "Vernacular"}, nil)
+
+	// Execute
+	suggestions, err := svc.GetSuggestions(ctx, "user-1", " Ver ")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"verbs", "Vernacular"}, suggestions)
+}
@@ -0,0 +1,175 @@
+package word
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// Difficulty is a CEFR-like band estimated from how frequent a word is
+type Difficulty string
+
+// Difficulty bands, from the most frequent words to the rarest
+const (
+	DifficultyA1 Difficulty = "A1"
+	DifficultyA2 Difficulty = "A2"
+	DifficultyB1 Difficulty = "B1"
+	DifficultyB2 Difficulty = "B2"
+	DifficultyC1 Difficulty = "C1"
+	DifficultyC2 Difficulty = "C2"
+)
+
+// Difficulties lists the difficulty bands in increasing order
+var Difficulties = []Difficulty{DifficultyA1, DifficultyA2, DifficultyB1, DifficultyB2, DifficultyC1, DifficultyC2}
+
+// difficultyMaxRanks holds the last frequency rank of each band below C2
+var difficultyMaxRanks = []int{750, 1500, 3000, 5000, 10000}
+
+// IsValid reports whether the difficulty is a known band
+func (d Difficulty) IsValid() bool {
+	return d.Level() > 0
+}
+
+// Level returns the position of the band, from 1 for A1 to 6 for C2, or 0
+// for an unknown band
+func (d Difficulty) Level() int {
+	for i, band := range Difficulties {
+		if band == d {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// Next returns the band above, or the band itself for C2 and unknown bands
+func (d Difficulty) Next() Difficulty {
+	if level := d.Level(); level > 0 && level < len(Difficulties) {
+		return Difficulties[level]
+	}
+	return d
+}
+
+// ParseDifficulty parses a band, ignoring case
+func ParseDifficulty(s string) (Difficulty, error) {
+	d := Difficulty(strings.ToUpper(strings.TrimSpace(s)))
+	if !d.IsValid() {
+		return "", fmt.Errorf("%w: %q", ErrInvalidDifficulty, s)
+	}
+	return d, nil
+}
+
+// DifficultyForRank returns the band of a frequency rank. Words missing from
+// the frequency list (rank 0) are considered the rarest.
+func DifficultyForRank(rank int) Difficulty {
+	if rank <= 0 {
+		return DifficultyC2
+	}
+	for i, maxRank := range difficultyMaxRanks {
+		if rank <= maxRank {
+			return Difficulties[i]
+		}
+	}
+	return DifficultyC2
+}
+
+// FrequencyIndex gives the frequency rank of words per language
+type FrequencyIndex interface {
+	// HasLanguage reports whether a frequency list is loaded for the language
+	HasLanguage(language string) bool
+
+	// Rank returns the rank of a form in the language's frequency list,
+	// 1 being the most frequent, and whether the form is listed
+	Rank(text, language string) (int, bool)
+}
+
+// ScoreDifficulty sets the frequency rank and difficulty of the word from its
+// best ranked form (text, lemma or search term). It returns false, leaving the
+// word unscored, when no frequency list is loaded for its language.
+func (w *Word) ScoreDifficulty(index FrequencyIndex) bool {
+	if index == nil || !index.HasLanguage(w.Language) {
+		return false
+	}
+
+	best := 0
+	forms := append([]string{w.Text, w.Lemma}, w.SearchTerms...)
+	for _, form := range forms {
+		if form == "" {
+			continue
+		}
+		if rank, ok := index.Rank(form, w.Language); ok && (best == 0 || rank < best) {
+			best = rank
+		}
+	}
+
+	w.FrequencyRank = best
+	w.Difficulty = DifficultyForRank(best)
+	return true
+}
+
+// DifficultyRepository defines the data access needed to score stored words
+type DifficultyRepository interface {
+	// ListUnscored retrieves words without difficulty whose ID sorts after
+	// afterID, in ID order
+	ListUnscored(ctx context.Context, afterID string, limit int) ([]*Word, error)
+
+	// UpdateDifficulty stores the frequency rank and difficulty of a word
+	UpdateDifficulty(ctx context.Context, id string, rank int, difficulty Difficulty) error
+}
+
+// DifficultyBackfiller scores the stored words saved before their language's
+// frequency list was loaded
+type DifficultyBackfiller struct {
+	repo   DifficultyRepository
+	index  FrequencyIndex
+	logger zerolog.Logger
+}
+
+// NewDifficultyBackfiller creates a new difficulty backfiller
+func NewDifficultyBackfiller(repo DifficultyRepository, index FrequencyIndex, logger zerolog.Logger) *DifficultyBackfiller {
+	return &DifficultyBackfiller{
+		repo:   repo,
+		index:  index,
+		logger: logger.With().Str("component", "difficulty_backfiller").Logger(),
+	}
+}
+
+// Run scores unscored words in batches and returns the number of words
+// scored. Words of languages without a frequency list are left unscored.
+func (b *DifficultyBackfiller) Run(ctx context.Context, batchSize int) (int, error) {
+	b.logger.Debug().Int("batchSize", batchSize).Msg("Backfilling word difficulty")
+
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+
+	scored := 0
+	afterID := ""
+	for {
+		words, err := b.repo.ListUnscored(ctx, afterID, batchSize)
+		if err != nil {
+			b.logger.Error().Err(err).Msg("Failed to list unscored words")
+			return scored, fmt.Errorf("failed to list unscored words: %w", err)
+		}
+
+		for _, w := range words {
+			if !w.ScoreDifficulty(b.index) {
+				continue
+			}
+			if err := b.repo.UpdateDifficulty(ctx, w.ID, w.FrequencyRank, w.Difficulty); err != nil {
+				b.logger.Error().Err(err).Str("wordID", w.ID).Msg("Failed to update word difficulty")
+				return scored, fmt.Errorf("failed to update word difficulty: %w", err)
+			}
+			scored++
+		}
+
+		if len(words) < batchSize {
+			break
+		}
+		afterID = words[len(words)-1].ID
+	}
+
+	b.logger.Info().Int("scored", scored).Msg("Word difficulty backfill completed")
+	return scored, nil
+}
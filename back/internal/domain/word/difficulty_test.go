@@ -0,0 +1,164 @@
+package word
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFrequencyIndex is an in-memory FrequencyIndex keyed by language then form
+type fakeFrequencyIndex map[string]map[string]int
+
+func (f fakeFrequencyIndex) HasLanguage(language string) bool {
+	return len(f[language]) > 0
+}
+
+func (f fakeFrequencyIndex) Rank(text, language string) (int, bool) {
+	rank, ok := f[language][text]
+	return rank, ok
+}
+
+// MockDifficultyRepository is a mock implementation of the DifficultyRepository interface
+type MockDifficultyRepository struct {
+	mock.Mock
+}
+
+func (m *MockDifficultyRepository) ListUnscored(ctx context.Context, afterID string, limit int) ([]*Word, error) {
+	args := m.Called(ctx, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Word), args.Error(1)
+}
+
+func (m *MockDifficultyRepository) UpdateDifficulty(ctx context.Context, id string, rank int, difficulty Difficulty) error {
+	args := m.Called(ctx, id, rank, difficulty)
+	return args.Error(0)
+}
+
+func TestDifficultyForRank(t *testing.T) {
+	tests := []struct {
+		rank     int
+		expected Difficulty
+	}{
+		{1, DifficultyA1},
+		{750, DifficultyA1},
+		{751, DifficultyA2},
+		{3000, DifficultyB1},
+		{4999, DifficultyB2},
+		{10000, DifficultyC1},
+		{10001, DifficultyC2},
+		{0, DifficultyC2},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, DifficultyForRank(tt.rank), "rank %d", tt.rank)
+	}
+}
+
+func TestParseDifficulty(t *testing.T) {
+	d, err := ParseDifficulty(" b2 ")
+	require.NoError(t, err)
+	assert.Equal(t, DifficultyB2, d)
+	assert.Equal(t, DifficultyC1, d.Next())
+	assert.Equal(t, DifficultyC2, DifficultyC2.Next())
+
+	_, err = ParseDifficulty("D1")
+	assert.ErrorIs(t, err, ErrInvalidDifficulty)
+}
+
+func TestScoreDifficulty(t *testing.T) {
+	index := fakeFrequencyIndex{"fr": {"être": 2, "suis": 40, "maison": 900}}
+
+	tests := []struct {
+		name         string
+		word         *Word
+		scored       bool
+		expectedRank int
+		expected     Difficulty
+	}{
+		{
+			name:         "best ranked form wins",
+			word:         &Word{Text: "suis", Language: "fr", Lemma: "être", SearchTerms: []string{"suis"}},
+			scored:       true,
+			expectedRank: 2,
+			expected:     DifficultyA1,
+		},
+		{
+			name:         "listed word",
+			word:         &Word{Text: "maison", Language: "fr"},
+			scored:       true,
+			expectedRank: 900,
+			expected:     DifficultyA2,
+		},
+		{
+			name:     "unlisted word is the rarest",
+			word:     &Word{Text: "procrastiner", Language: "fr"},
+			scored:   true,
+			expected: DifficultyC2,
+		},
+		{
+			name:   "language without list",
+			word:   &Word{Text: "house", Language: "en"},
+			scored: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Execute
+			scored := tt.word.ScoreDifficulty(index)
+
+			// Assert
+			assert.Equal(t, tt.scored, scored)
+			assert.Equal(t, tt.expectedRank, tt.word.FrequencyRank)
+			assert.Equal(t, tt.expected, tt.word.Difficulty)
+		})
+	}
+}
+
+func TestDifficultyBackfiller_Run(t *testing.T) {
+	ctx := context.Background()
+	index := fakeFrequencyIndex{"fr": {"maison": 900}}
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+
+	t.Run("scores words in batches", func(t *testing.T) {
+		// Setup
+		repo := new(MockDifficultyRepository)
+		repo.On("ListUnscored", ctx, "", 2).Return([]*Word{
+			{ID: "id-1", Text: "maison", Language: "fr"},
+			{ID: "id-2", Text: "house", Language: "en"},
+		}, nil)
+		repo.On("ListUnscored", ctx, "id-2", 2).Return([]*Word{
+			{ID: "id-3", Text: "flâner", Language: "fr"},
+		}, nil)
+		repo.On("UpdateDifficulty", ctx, "id-1", 900, DifficultyA2).Return(nil)
+		repo.On("UpdateDifficulty", ctx, "id-3", 0, DifficultyC2).Return(nil)
+
+		// Execute
+		scored, err := NewDifficultyBackfiller(repo, index, logger).Run(ctx, 2)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 2, scored)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		// Setup
+		repo := new(MockDifficultyRepository)
+		repo.On("ListUnscored", ctx, "", 500).Return(nil, errors.New("db down"))
+
+		// Execute
+		scored, err := NewDifficultyBackfiller(repo, index, logger).Run(ctx, 0)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Zero(t, scored)
+	})
+}
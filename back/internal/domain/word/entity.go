@@ -1,12 +1,13 @@
 package word
 
 import (
+	"slices"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
 
-	"voconsteroid/internal/domain/word/languages/english"
-	"voconsteroid/internal/domain/word/languages/french"
+	"voconsteroid/internal/domain/language"
 )
 
 // Definition represents a single definition with its type and examples
@@ -35,8 +36,34 @@ type Word struct {
 	SearchTerms  []string          `json:"search_terms,omitempty"` // All searchable forms of the word
 	Lemma        string            `json:"lemma,omitempty"`        // Base form of the word
 	UsageNotes   []string          `json:"usage_notes,omitempty"`  // General usage information
-	CreatedAt    time.Time         `json:"created_at"`
-	UpdatedAt    time.Time         `json:"updated_at"`
+	// FrequencyRank is the position of the word in its language's frequency
+	// list, 1 being the most frequent; 0 when the word is not listed
+	FrequencyRank int        `json:"frequency_rank,omitempty"`
+	Difficulty    Difficulty `json:"difficulty,omitempty"` // Empty until the word is scored
+	// Provenance records where the word was fetched from; nil for words
+	// fetched before it was recorded. It is internal: the API only shows it
+	// to maintainers.
+	Provenance *Provenance `json:"provenance,omitempty"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+}
+
+// Provenance records the source page and parser a word was fetched with
+type Provenance struct {
+	Source        string    `json:"source"` // Dictionary name, like frwiktionary
+	SourceURL     string    `json:"source_url"`
+	RevisionID    int64     `json:"revision_id,omitempty"` // Revision of the source page; 0 when unknown
+	ParserVersion int       `json:"parser_version"`
+	FetchedAt     time.Time `json:"fetched_at"`
+}
+
+// ParserVersion returns the version of the parser the word was fetched
+// with, 0 when unknown
+func (w *Word) ParserVersion() int {
+	if w.Provenance == nil {
+		return 0
+	}
+	return w.Provenance.ParserVersion
 }
 
 // NewWord creates a new Word entity
@@ -146,21 +173,90 @@ func (w *Word) AddUsageNote(note string) {
 	w.UpdatedAt = time.Now()
 }
 
-// ValidateDefinition validates a definition based on language rules
-func (w *Word) ValidateDefinition(def Definition) error {
-	switch w.Language {
-	case "fr":
-		if def.WordType != "" && !french.IsValidWordType(french.WordType(def.WordType)) {
-			return ErrInvalidWordType
+// RebuildSearchTerms sets the search terms again from the text, the lemma
+// and the forms given in the definitions' language specifics
+func (w *Word) RebuildSearchTerms() {
+	w.SearchTerms = []string{w.Text}
+	if w.Lemma != "" {
+		w.AddSearchTerm(w.Lemma)
+	}
+	for _, def := range w.Definitions {
+		keys := make([]string, 0, len(def.LanguageSpecifics))
+		for key := range def.LanguageSpecifics {
+			keys = append(keys, key)
 		}
-		if def.Gender != "" && !french.IsValidGender(french.Gender(def.Gender)) {
-			return ErrInvalidGender
+		sort.Strings(keys)
+		for _, key := range keys {
+			if term := def.LanguageSpecifics[key]; term != "" {
+				w.AddSearchTerm(term)
+			}
 		}
-	case "en":
-		if def.WordType != "" && !english.IsValidWordType(english.WordType(def.WordType)) {
-			return ErrInvalidWordType
+	}
+}
+
+// Merge folds another entry of the same word into this one: its text and
+// search terms become search terms, and its definitions, relations, notes
+// and translations are added when missing
+func (w *Word) Merge(other *Word) {
+	w.AddSearchTerm(other.Text)
+	for _, term := range other.SearchTerms {
+		w.AddSearchTerm(term)
+	}
+	for _, def := range other.Definitions {
+		if !w.hasDefinition(def) {
+			w.Definitions = append(w.Definitions, def)
+		}
+	}
+	for _, synonym := range other.Synonyms {
+		w.AddSynonym(synonym)
+	}
+	for _, antonym := range other.Antonyms {
+		w.AddAntonym(antonym)
+	}
+	for _, note := range other.UsageNotes {
+		if !slices.Contains(w.UsageNotes, note) {
+			w.AddUsageNote(note)
 		}
-		// English doesn't have grammatical gender
+	}
+	if w.Translations == nil {
+		w.Translations = make(map[string]string)
+	}
+	for language, translation := range other.Translations {
+		if _, ok := w.Translations[language]; !ok {
+			w.Translations[language] = translation
+		}
+	}
+	if w.Etymology == "" {
+		w.Etymology = other.Etymology
+	}
+	if w.Lemma == "" {
+		w.Lemma = other.Lemma
+	}
+	w.UpdatedAt = time.Now()
+}
+
+// hasDefinition reports whether the word has a definition of the same text and type
+func (w *Word) hasDefinition(def Definition) bool {
+	for _, d := range w.Definitions {
+		if d.Text == def.Text && d.WordType == def.WordType {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateDefinition validates a definition based on the rules of the
+// language of the word, as registered in the language registry
+func (w *Word) ValidateDefinition(def Definition) error {
+	lang, ok := language.Default.Get(w.Language)
+	if !ok {
+		return nil
+	}
+	if def.WordType != "" && !lang.IsValidWordType(def.WordType) {
+		return ErrInvalidWordType
+	}
+	if def.Gender != "" && !lang.IsValidGender(def.Gender) {
+		return ErrInvalidGender
 	}
 	return nil
 }
@@ -4,9 +4,19 @@ import "errors"
 
 // Domain errors
 var (
-	ErrInvalidWordType = errors.New("invalid word type")
-	ErrInvalidGender   = errors.New("invalid gender")
-	ErrWordNotFound    = errors.New("word not found")
-	ErrInvalidWord     = errors.New("invalid word")
-	ErrDuplicateWord   = errors.New("word already exists")
+	ErrInvalidWordType   = errors.New("invalid word type")
+	ErrInvalidGender     = errors.New("invalid gender")
+	ErrWordNotFound      = errors.New("word not found")
+	ErrInvalidWord       = errors.New("invalid word")
+	ErrDuplicateWord     = errors.New("word already exists")
+	ErrInvalidDifficulty = errors.New("invalid difficulty")
+	ErrInvalidSort       = errors.New("invalid sort order")
+
+	// ErrDictionaryUnavailable is returned when the external dictionary is
+	// down or throttling requests; retrying later may succeed
+	ErrDictionaryUnavailable = errors.New("dictionary unavailable")
+
+	// ErrFetchLimited is returned when a request used up its budget of
+	// external dictionary fetches
+	ErrFetchLimited = errors.New("dictionary fetch limit reached")
 )
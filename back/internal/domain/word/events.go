@@ -0,0 +1,49 @@
+package word
+
+import "time"
+
+// Word event names
+const (
+	EventWordFetched  = "word.fetched"
+	EventWordSaved    = "word.saved"
+	EventWordEnriched = "word.enriched"
+	EventWordSearched = "word.searched"
+)
+
+// WordFetched is published when a word is fetched from the dictionary API
+type WordFetched struct {
+	Word *Word `json:"word"`
+}
+
+// EventName identifies the event type
+func (WordFetched) EventName() string { return EventWordFetched }
+
+// WordSaved is published when a word is stored, whether new or updated
+type WordSaved struct {
+	Word *Word `json:"word"`
+}
+
+// EventName identifies the event type
+func (WordSaved) EventName() string { return EventWordSaved }
+
+// WordEnriched is published when data fetched later, such as related
+// words, is added to a stored word
+type WordEnriched struct {
+	Word   *Word    `json:"word"`
+	Fields []string `json:"fields"` // JSON names of the enriched fields
+}
+
+// EventName identifies the event type
+func (WordEnriched) EventName() string { return EventWordEnriched }
+
+// WordSearched is published when a user finds a word by searching for it,
+// whether it was stored already or fetched from the dictionary API
+type WordSearched struct {
+	ID         string    `json:"id"`
+	UserID     string    `json:"user_id"`
+	Word       *Word     `json:"word"`
+	SearchedAt time.Time `json:"searched_at"`
+}
+
+// EventName identifies the event type
+func (WordSearched) EventName() string { return EventWordSearched }
@@ -0,0 +1,34 @@
+package word
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWordEvents_KeepProvenance(t *testing.T) {
+	// Setup
+	w := NewWord("maison", "fr")
+	w.Provenance = &Provenance{
+		Source:        "frwiktionary",
+		SourceURL:     "https://fr.wiktionary.org/wiki/maison",
+		RevisionID:    34803495,
+		ParserVersion: 2,
+		FetchedAt:     time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	for _, e := range []interface{}{WordFetched{Word: w}, WordSaved{Word: w}} {
+		// Execute: events are stored in the outbox as JSON
+		payload, err := json.Marshal(e)
+		require.NoError(t, err)
+		var decoded WordSaved
+		require.NoError(t, json.Unmarshal(payload, &decoded))
+
+		// Assert
+		require.NotNil(t, decoded.Word)
+		assert.Equal(t, w.Provenance, decoded.Word.Provenance)
+	}
+}
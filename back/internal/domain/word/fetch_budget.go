@@ -0,0 +1,26 @@
+package word
+
+import "context"
+
+// FetchBudget is consulted before fetching from the external dictionary on
+// behalf of a request. It returns an error wrapping ErrFetchLimited when
+// the request may not fetch.
+type FetchBudget func(ctx context.Context) error
+
+// fetchBudgetKey is the context key of the fetch budget
+type fetchBudgetKey struct{}
+
+// WithFetchBudget returns a context whose dictionary fetches are charged to budget
+func WithFetchBudget(ctx context.Context, budget FetchBudget) context.Context {
+	return context.WithValue(ctx, fetchBudgetKey{}, budget)
+}
+
+// spendFetchBudget charges a dictionary fetch to the budget of the context,
+// if it has one
+func spendFetchBudget(ctx context.Context) error {
+	budget, ok := ctx.Value(fetchBudgetKey{}).(FetchBudget)
+	if !ok {
+		return nil
+	}
+	return budget(ctx)
+}
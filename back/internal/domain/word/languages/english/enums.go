@@ -13,6 +13,9 @@ const (
 	Interjection WordType = "interjection"
 )
 
+// WordTypes lists the word types
+var WordTypes = []WordType{Noun, Verb, Adjective, Adverb, Pronoun, Preposition, Conjunction, Interjection}
+
 func IsValidWordType(wt WordType) bool {
 	switch wt {
 	case Noun, Verb, Adjective, Adverb, Pronoun, Preposition, Conjunction, Interjection:
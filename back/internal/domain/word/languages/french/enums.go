@@ -21,6 +21,12 @@ const (
 	Plural    Gender = "pluriel"
 )
 
+// WordTypes lists the word types
+var WordTypes = []WordType{Noun, Verb, Adjective, Adverb, Pronoun, Preposition, Conjunction, Interjection}
+
+// Genders lists the genders
+var Genders = []Gender{Masculine, Feminine, Plural}
+
 func IsValidWordType(wt WordType) bool {
 	switch wt {
 	case Noun, Verb, Adjective, Adverb, Pronoun, Preposition, Conjunction, Interjection:
@@ -0,0 +1,66 @@
+package german
+
+type WordType string
+
+const (
+	Noun         WordType = "substantiv"
+	Verb         WordType = "verb"
+	Adjective    WordType = "adjektiv"
+	Adverb       WordType = "adverb"
+	Pronoun      WordType = "pronomen"
+	Preposition  WordType = "präposition"
+	Conjunction  WordType = "konjunktion"
+	Interjection WordType = "interjektion"
+)
+
+type Gender string
+
+const (
+	Masculine Gender = "maskulin"
+	Feminine  Gender = "feminin"
+	Neuter    Gender = "neutrum"
+)
+
+// Case is a grammatical case. The singular forms of a noun are kept in
+// the language specifics of its definitions, under their case.
+type Case string
+
+const (
+	Nominative Case = "nominativ"
+	Genitive   Case = "genitiv"
+	Dative     Case = "dativ"
+	Accusative Case = "akkusativ"
+)
+
+// WordTypes lists the word types
+var WordTypes = []WordType{Noun, Verb, Adjective, Adverb, Pronoun, Preposition, Conjunction, Interjection}
+
+// Genders lists the genders
+var Genders = []Gender{Masculine, Feminine, Neuter}
+
+func IsValidWordType(wt WordType) bool {
+	switch wt {
+	case Noun, Verb, Adjective, Adverb, Pronoun, Preposition, Conjunction, Interjection:
+		return true
+	default:
+		return false
+	}
+}
+
+func IsValidGender(g Gender) bool {
+	switch g {
+	case Masculine, Feminine, Neuter:
+		return true
+	default:
+		return false
+	}
+}
+
+func IsValidCase(c Case) bool {
+	switch c {
+	case Nominative, Genitive, Dative, Accusative:
+		return true
+	default:
+		return false
+	}
+}
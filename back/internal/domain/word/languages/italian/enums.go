@@ -0,0 +1,45 @@
+package italian
+
+type WordType string
+
+const (
+	Noun         WordType = "sostantivo"
+	Verb         WordType = "verbo"
+	Adjective    WordType = "aggettivo"
+	Adverb       WordType = "avverbio"
+	Pronoun      WordType = "pronome"
+	Preposition  WordType = "preposizione"
+	Conjunction  WordType = "congiunzione"
+	Interjection WordType = "interiezione"
+)
+
+type Gender string
+
+const (
+	Masculine Gender = "maschile"
+	Feminine  Gender = "femminile"
+)
+
+// WordTypes lists the word types
+var WordTypes = []WordType{Noun, Verb, Adjective, Adverb, Pronoun, Preposition, Conjunction, Interjection}
+
+// Genders lists the genders
+var Genders = []Gender{Masculine, Feminine}
+
+func IsValidWordType(wt WordType) bool {
+	switch wt {
+	case Noun, Verb, Adjective, Adverb, Pronoun, Preposition, Conjunction, Interjection:
+		return true
+	default:
+		return false
+	}
+}
+
+func IsValidGender(g Gender) bool {
+	switch g {
+	case Masculine, Feminine:
+		return true
+	default:
+		return false
+	}
+}
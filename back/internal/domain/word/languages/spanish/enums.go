@@ -0,0 +1,45 @@
+package spanish
+
+type WordType string
+
+const (
+	Noun         WordType = "sustantivo"
+	Verb         WordType = "verbo"
+	Adjective    WordType = "adjetivo"
+	Adverb       WordType = "adverbio"
+	Pronoun      WordType = "pronombre"
+	Preposition  WordType = "preposición"
+	Conjunction  WordType = "conjunción"
+	Interjection WordType = "interjección"
+)
+
+type Gender string
+
+const (
+	Masculine Gender = "masculino"
+	Feminine  Gender = "femenino"
+)
+
+// WordTypes lists the word types
+var WordTypes = []WordType{Noun, Verb, Adjective, Adverb, Pronoun, Preposition, Conjunction, Interjection}
+
+// Genders lists the genders
+var Genders = []Gender{Masculine, Feminine}
+
+func IsValidWordType(wt WordType) bool {
+	switch wt {
+	case Noun, Verb, Adjective, Adverb, Pronoun, Preposition, Conjunction, Interjection:
+		return true
+	default:
+		return false
+	}
+}
+
+func IsValidGender(g Gender) bool {
+	switch g {
+	case Masculine, Feminine:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,278 @@
+package word
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// MaintenanceRepository defines the data access needed to maintain the
+// stored dictionary
+type MaintenanceRepository interface {
+	Repository
+
+	// ListBatch retrieves words whose ID sorts after afterID, in ID order,
+	// only those updated before updatedBefore unless it is zero
+	ListBatch(ctx context.Context, afterID string, updatedBefore time.Time, limit int) ([]*Word, error)
+
+	// Delete removes a word, along with the saved and daily words pointing to it
+	Delete(ctx context.Context, id string) error
+
+	// Merge saves target and moves everything pointing to source onto it
+	// before deleting source, in a single transaction
+	Merge(ctx context.Context, source, target *Word) error
+
+	// Stats summarizes the stored words
+	Stats(ctx context.Context) (*TableStats, error)
+}
+
+// Lemmatizer gives the base form of words
+type Lemmatizer interface {
+	// Lemma returns the lemma of a form and whether the form is known
+	Lemma(text, language string) (string, bool)
+}
+
+// TableStats summarizes the stored words
+type TableStats struct {
+	Total              int            `json:"total"`
+	ByLanguage         map[string]int `json:"by_language"`
+	ByDifficulty       map[string]int `json:"by_difficulty"`     // Unscored words under ""
+	BySource           map[string]int `json:"by_source"`         // Words of unknown source under ""
+	ByParserVersion    map[string]int `json:"by_parser_version"` // Words of unknown parser under "0"
+	WithoutDefinitions int            `json:"without_definitions"`
+	WithoutLemma       int            `json:"without_lemma"`
+	OldestUpdate       time.Time      `json:"oldest_update"`
+	NewestUpdate       time.Time      `json:"newest_update"`
+}
+
+// DefinitionIssue is a stored definition failing ValidateDefinition
+type DefinitionIssue struct {
+	WordID   string
+	Text     string
+	Language string
+	Index    int // Position of the definition in the word
+	Err      error
+}
+
+// RescrapeReport counts the outcome of a rescrape
+type RescrapeReport struct {
+	Refreshed int
+	NotFound  int
+	Failed    int
+}
+
+// Maintainer runs the maintenance operations of the stored dictionary
+type Maintainer struct {
+	repo       MaintenanceRepository
+	dictAPI    DictionaryAPI
+	frequency  FrequencyIndex
+	lemmatizer Lemmatizer
+	logger     zerolog.Logger
+}
+
+// NewMaintainer creates a new dictionary maintainer
+func NewMaintainer(repo MaintenanceRepository, dictAPI DictionaryAPI, frequency FrequencyIndex, lemmatizer Lemmatizer, logger zerolog.Logger) *Maintainer {
+	return &Maintainer{
+		repo:       repo,
+		dictAPI:    dictAPI,
+		frequency:  frequency,
+		lemmatizer: lemmatizer,
+		logger:     logger.With().Str("component", "word_maintainer").Logger(),
+	}
+}
+
+// Refresh fetches a word from the dictionary and stores it, replacing the
+// stored version while keeping its ID and creation date
+func (m *Maintainer) Refresh(ctx context.Context, text, language string) (*Word, error) {
+	fetched, err := m.dictAPI.FetchWord(ctx, text, language)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch word: %w", err)
+	}
+
+	stored, err := m.repo.FindByText(ctx, fetched.Text, fetched.Language)
+	switch {
+	case err == nil:
+		fetched.ID = stored.ID
+		fetched.CreatedAt = stored.CreatedAt
+	case !errors.Is(err, ErrWordNotFound):
+		return nil, fmt.Errorf("failed to find stored word: %w", err)
+	}
+
+	fetched.ScoreDifficulty(m.frequency)
+	if err := m.repo.Save(ctx, fetched, nil); err != nil {
+		return nil, fmt.Errorf("failed to save word: %w", err)
+	}
+
+	m.logger.Info().Str("wordID", fetched.ID).Str("text", fetched.Text).Msg("Word refreshed")
+	return fetched, nil
+}
+
+// RescrapeBefore refreshes the words last updated before a date. Words the
+// dictionary no longer knows, or fails to return, are left as they are.
+func (m *Maintainer) RescrapeBefore(ctx context.Context, before time.Time, batchSize int) (RescrapeReport, error) {
+	var report RescrapeReport
+	err := m.each(ctx, before, batchSize, func(w *Word) error {
+		_, err := m.Refresh(ctx, w.Text, w.Language)
+		switch {
+		case err == nil:
+			report.Refreshed++
+		case errors.Is(err, ErrWordNotFound):
+			report.NotFound++
+		case ctx.Err() != nil:
+			return ctx.Err()
+		default:
+			m.logger.Warn().Err(err).Str("wordID", w.ID).Msg("Failed to rescrape word")
+			report.Failed++
+		}
+		return nil
+	})
+	return report, err
+}
+
+// Find retrieves a stored word by ID, or by text in the given language
+// when ref is not an ID, without fetching it
+func (m *Maintainer) Find(ctx context.Context, ref, language string) (*Word, error) {
+	if _, err := uuid.Parse(ref); err == nil {
+		return m.repo.FindByID(ctx, ref)
+	}
+	return m.repo.FindByText(ctx, ref, language)
+}
+
+// Delete removes a stored word
+func (m *Maintainer) Delete(ctx context.Context, id string) error {
+	if err := m.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete word: %w", err)
+	}
+	m.logger.Info().Str("wordID", id).Msg("Word deleted")
+	return nil
+}
+
+// Merge folds the source word into the target, typically a duplicate stored
+// under another form, and deletes the source
+func (m *Maintainer) Merge(ctx context.Context, sourceID, targetID string) (*Word, error) {
+	if sourceID == targetID {
+		return nil, fmt.Errorf("%w: cannot merge a word into itself", ErrInvalidWord)
+	}
+	source, err := m.repo.FindByID(ctx, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find source word: %w", err)
+	}
+	target, err := m.repo.FindByID(ctx, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find target word: %w", err)
+	}
+	if source.Language != target.Language {
+		return nil, fmt.Errorf("%w: cannot merge a %s word into a %s one", ErrInvalidWord, source.Language, target.Language)
+	}
+
+	target.Merge(source)
+	target.ScoreDifficulty(m.frequency)
+	if err := m.repo.Merge(ctx, source, target); err != nil {
+		return nil, fmt.Errorf("failed to merge words: %w", err)
+	}
+
+	m.logger.Info().Str("sourceID", sourceID).Str("targetID", targetID).Msg("Words merged")
+	return target, nil
+}
+
+// Relemmatize sets the lemma of every stored word again and rebuilds its
+// search terms, saving the words that changed. It returns how many did.
+func (m *Maintainer) Relemmatize(ctx context.Context, batchSize int) (int, error) {
+	updated := 0
+	err := m.each(ctx, time.Time{}, batchSize, func(w *Word) error {
+		lemma, terms := w.Lemma, w.SearchTerms
+		if m.lemmatizer != nil {
+			if l, ok := m.lemmatizer.Lemma(w.Text, w.Language); ok {
+				w.Lemma = l
+			}
+		}
+		w.RebuildSearchTerms()
+		if w.Lemma == lemma && sameTerms(w.SearchTerms, terms) {
+			return nil
+		}
+
+		if err := m.repo.Save(ctx, w, nil); err != nil {
+			return fmt.Errorf("failed to save word %s: %w", w.ID, err)
+		}
+		updated++
+		return nil
+	})
+	return updated, err
+}
+
+// Validate checks every stored definition against the rules of its language
+func (m *Maintainer) Validate(ctx context.Context, batchSize int) ([]DefinitionIssue, error) {
+	var issues []DefinitionIssue
+	err := m.each(ctx, time.Time{}, batchSize, func(w *Word) error {
+		for i, def := range w.Definitions {
+			if err := w.ValidateDefinition(def); err != nil {
+				issues = append(issues, DefinitionIssue{
+					WordID:   w.ID,
+					Text:     w.Text,
+					Language: w.Language,
+					Index:    i,
+					Err:      err,
+				})
+			}
+		}
+		return nil
+	})
+	return issues, err
+}
+
+// Stats summarizes the stored words
+func (m *Maintainer) Stats(ctx context.Context) (*TableStats, error) {
+	stats, err := m.repo.Stats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get word statistics: %w", err)
+	}
+	return stats, nil
+}
+
+// each calls fn on the stored words in batches, only those updated before
+// updatedBefore unless it is zero
+func (m *Maintainer) each(ctx context.Context, updatedBefore time.Time, batchSize int, fn func(w *Word) error) error {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	afterID := ""
+	for {
+		words, err := m.repo.ListBatch(ctx, afterID, updatedBefore, batchSize)
+		if err != nil {
+			return fmt.Errorf("failed to list words: %w", err)
+		}
+
+		for _, w := range words {
+			if err := fn(w); err != nil {
+				return err
+			}
+		}
+
+		if len(words) < batchSize {
+			return nil
+		}
+		afterID = words[len(words)-1].ID
+	}
+}
+
+// sameTerms reports whether two lists hold the same search terms
+func sameTerms(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = append([]string(nil), a...), append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,235 @@
+package word
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockMaintenanceRepository is a mock implementation of the MaintenanceRepository interface
+type MockMaintenanceRepository struct {
+	MockRepository
+}
+
+func (m *MockMaintenanceRepository) ListBatch(ctx context.Context, afterID string, updatedBefore time.Time, limit int) ([]*Word, error) {
+	args := m.Called(ctx, afterID, updatedBefore, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Word), args.Error(1)
+}
+
+func (m *MockMaintenanceRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockMaintenanceRepository) Merge(ctx context.Context, source, target *Word) error {
+	args := m.Called(ctx, source, target)
+	return args.Error(0)
+}
+
+func (m *MockMaintenanceRepository) Stats(ctx context.Context) (*TableStats, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*TableStats), args.Error(1)
+}
+
+// fakeLemmatizer is an in-memory Lemmatizer keyed by form
+type fakeLemmatizer map[string]string
+
+func (f fakeLemmatizer) Lemma(text, _ string) (string, bool) {
+	lemma, ok := f[text]
+	return lemma, ok
+}
+
+// setupTestMaintainer creates a maintainer with mocks for testing
+func setupTestMaintainer(t *testing.T) (*MockMaintenanceRepository, *MockDictionaryAPI, *Maintainer) {
+	repo := new(MockMaintenanceRepository)
+	dictAPI := new(MockDictionaryAPI)
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	lemmatizer := fakeLemmatizer{"chevaux": "cheval"}
+
+	return repo, dictAPI, NewMaintainer(repo, dictAPI, nil, lemmatizer, logger)
+}
+
+func TestMaintainer_Refresh(t *testing.T) {
+	// Setup
+	repo, dictAPI, maintainer := setupTestMaintainer(t)
+	ctx := context.Background()
+	created := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	fetched := &Word{ID: "new-id", Text: "maison", Language: "fr"}
+	dictAPI.On("FetchWord", ctx, "maison", "fr").Return(fetched, nil)
+	repo.On("FindByText", ctx, "maison", "fr").Return(&Word{ID: "stored-id", CreatedAt: created}, nil)
+	repo.On("Save", ctx, fetched).Return(nil)
+
+	// Execute
+	w, err := maintainer.Refresh(ctx, "maison", "fr")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "stored-id", w.ID)
+	assert.Equal(t, created, w.CreatedAt)
+	repo.AssertExpectations(t)
+}
+
+func TestMaintainer_RescrapeBefore(t *testing.T) {
+	// Setup
+	repo, dictAPI, maintainer := setupTestMaintainer(t)
+	ctx := context.Background()
+	before := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	repo.On("ListBatch", ctx, "", before, 2).Return([]*Word{
+		{ID: "id-1", Text: "maison", Language: "fr"},
+		{ID: "id-2", Text: "disparu", Language: "fr"},
+	}, nil)
+	repo.On("ListBatch", ctx, "id-2", before, 2).Return([]*Word{
+		{ID: "id-3", Text: "chat", Language: "fr"},
+	}, nil)
+	dictAPI.On("FetchWord", ctx, "maison", "fr").Return(&Word{Text: "maison", Language: "fr"}, nil)
+	dictAPI.On("FetchWord", ctx, "disparu", "fr").Return(nil, ErrWordNotFound)
+	dictAPI.On("FetchWord", ctx, "chat", "fr").Return(nil, ErrDictionaryUnavailable)
+	repo.On("FindByText", ctx, "maison", "fr").Return(&Word{ID: "id-1"}, nil)
+	repo.On("Save", ctx, mock.Anything).Return(nil)
+
+	// Execute
+	report, err := maintainer.RescrapeBefore(ctx, before, 2)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, RescrapeReport{Refreshed: 1, NotFound: 1, Failed: 1}, report)
+	repo.AssertNumberOfCalls(t, "Save", 1)
+}
+
+func TestMaintainer_Find(t *testing.T) {
+	// Setup
+	repo, _, maintainer := setupTestMaintainer(t)
+	ctx := context.Background()
+	id := "7f1c9a2e-3b4d-4e5f-8a6b-9c0d1e2f3a4b"
+	repo.On("FindByID", ctx, id).Return(&Word{ID: id}, nil)
+	repo.On("FindByText", ctx, "maison", "fr").Return(&Word{ID: "maison-id"}, nil)
+
+	// Execute
+	byID, errID := maintainer.Find(ctx, id, "fr")
+	byText, errText := maintainer.Find(ctx, "maison", "fr")
+
+	// Assert
+	require.NoError(t, errID)
+	require.NoError(t, errText)
+	assert.Equal(t, id, byID.ID)
+	assert.Equal(t, "maison-id", byText.ID)
+}
+
+func TestMaintainer_Merge(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("folds the source into the target", func(t *testing.T) {
+		// Setup
+		repo, _, maintainer := setupTestMaintainer(t)
+		source := &Word{
+			ID: "source", Text: "chevaux", Language: "fr",
+			Definitions: []Definition{{Text: "Pluriel de cheval", WordType: "nom"}, {Text: "Animal", WordType: "nom"}},
+			Synonyms:    []string{"destrier"},
+		}
+		target := &Word{
+			ID: "target", Text: "cheval", Language: "fr",
+			Definitions: []Definition{{Text: "Animal", WordType: "nom"}},
+			SearchTerms: []string{"cheval"},
+		}
+		repo.On("FindByID", ctx, "source").Return(source, nil)
+		repo.On("FindByID", ctx, "target").Return(target, nil)
+		repo.On("Merge", ctx, source, target).Return(nil)
+
+		// Execute
+		merged, err := maintainer.Merge(ctx, "source", "target")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"cheval", "chevaux"}, merged.SearchTerms)
+		assert.Len(t, merged.Definitions, 2)
+		assert.Equal(t, []string{"destrier"}, merged.Synonyms)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects words of different languages", func(t *testing.T) {
+		// Setup
+		repo, _, maintainer := setupTestMaintainer(t)
+		repo.On("FindByID", ctx, "source").Return(&Word{ID: "source", Language: "en"}, nil)
+		repo.On("FindByID", ctx, "target").Return(&Word{ID: "target", Language: "fr"}, nil)
+
+		// Execute
+		_, err := maintainer.Merge(ctx, "source", "target")
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidWord)
+		repo.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestMaintainer_Relemmatize(t *testing.T) {
+	// Setup
+	repo, _, maintainer := setupTestMaintainer(t)
+	ctx := context.Background()
+
+	unchanged := &Word{ID: "id-1", Text: "maison", Language: "fr", SearchTerms: []string{"maison"}}
+	changed := &Word{
+		ID: "id-2", Text: "chevaux", Language: "fr", SearchTerms: []string{"chevaux"},
+		Definitions: []Definition{{LanguageSpecifics: map[string]string{"singulier": "cheval"}}},
+	}
+	repo.On("ListBatch", ctx, "", time.Time{}, 100).Return([]*Word{unchanged, changed}, nil)
+	repo.On("Save", ctx, changed).Return(nil)
+
+	// Execute
+	updated, err := maintainer.Relemmatize(ctx, 0)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, updated)
+	assert.Equal(t, "cheval", changed.Lemma)
+	assert.Equal(t, []string{"chevaux", "cheval"}, changed.SearchTerms)
+	repo.AssertExpectations(t)
+}
+
+func TestMaintainer_Validate(t *testing.T) {
+	// Setup
+	repo, _, maintainer := setupTestMaintainer(t)
+	ctx := context.Background()
+
+	repo.On("ListBatch", ctx, "", time.Time{}, 100).Return([]*Word{
+		{ID: "id-1", Text: "maison", Language: "fr", Definitions: []Definition{
+			{Text: "Bâtiment", WordType: "nom", Gender: "féminin"},
+			{Text: "Famille", WordType: "noun"},
+		}},
+	}, nil)
+
+	// Execute
+	issues, err := maintainer.Validate(ctx, 0)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, 1, issues[0].Index)
+	assert.ErrorIs(t, issues[0].Err, ErrInvalidWordType)
+}
+
+func TestMaintainer_ListError(t *testing.T) {
+	// Setup
+	repo, _, maintainer := setupTestMaintainer(t)
+	ctx := context.Background()
+	repo.On("ListBatch", ctx, "", time.Time{}, 100).Return(nil, errors.New("db down"))
+
+	// Execute
+	_, err := maintainer.Validate(ctx, 0)
+
+	// Assert
+	assert.Error(t, err)
+}
@@ -2,8 +2,30 @@ package word
 
 import (
 	"context"
+
+	"voconsteroid/internal/domain/event"
+)
+
+// List filter keys handled specially by Repository.List; any other key
+// matches the column of the same name exactly
+const (
+	FilterMinDifficulty = "min_difficulty" // Difficulty value, inclusive
+	FilterMaxDifficulty = "max_difficulty" // Difficulty value, inclusive
+	FilterSort          = "sort"           // One of the Sort values, SortRecent by default
 )
 
+// Sort orders accepted by the FilterSort filter
+const (
+	SortRecent     = "recent"     // Most recently updated first
+	SortFrequency  = "frequency"  // Most frequent first, unranked words last
+	SortDifficulty = "difficulty" // Easiest band first, unscored words last
+)
+
+// IsValidSort reports whether s is a known sort order
+func IsValidSort(s string) bool {
+	return s == SortRecent || s == SortFrequency || s == SortDifficulty
+}
+
 // Repository defines the interface for word data access
 type Repository interface {
 	// FindByID retrieves a word by its ID
@@ -15,10 +37,11 @@ type Repository interface {
 	// FindByAnyForm retrieves a word by any of its forms (using search terms)
 	FindByAnyForm(ctx context.Context, text, language string) (*Word, error)
 
-	// Save stores a word in the repository
-	Save(ctx context.Context, word *Word) error
+	// Save stores a word in the repository, running emit, when not nil, in
+	// the same transaction
+	Save(ctx context.Context, word *Word, emit event.Emit) error
 
-	// List retrieves words with optional filtering
+	// List retrieves words with optional filtering and sorting (see the Filter keys)
 	List(ctx context.Context, filter map[string]interface{}, limit, offset int) ([]*Word, error)
 
 	// FindByPrefix retrieves words by prefix and language
@@ -28,7 +28,7 @@ func (s *RepositoryTestSuite) TestFindByText(t *testing.T) {
 	}
 
 	// Save the word first
-	err := s.Repo.Save(ctx, testWord)
+	err := s.Repo.Save(ctx, testWord, nil)
 	assert.NoError(t, err)
 
 	// Execute
@@ -72,7 +72,7 @@ func (s *RepositoryTestSuite) TestSave(t *testing.T) {
 	}
 
 	// Execute
-	err := s.Repo.Save(ctx, testWord)
+	err := s.Repo.Save(ctx, testWord, nil)
 
 	// Assert
 	assert.NoError(t, err)
@@ -100,7 +100,7 @@ func (s *RepositoryTestSuite) TestSave_Update(t *testing.T) {
 	}
 
 	// Save the word first
-	err := s.Repo.Save(ctx, testWord)
+	err := s.Repo.Save(ctx, testWord, nil)
 	assert.NoError(t, err)
 
 	// Update the word
@@ -110,7 +110,7 @@ func (s *RepositoryTestSuite) TestSave_Update(t *testing.T) {
 			WordType: "noun",
 		},
 	}
-	err = s.Repo.Save(ctx, testWord)
+	err = s.Repo.Save(ctx, testWord, nil)
 	assert.NoError(t, err)
 
 	// Verify the word was updated
@@ -133,7 +133,7 @@ func (s *RepositoryTestSuite) TestList(t *testing.T) {
 	}
 	
 	for _, w := range testWords {
-		err := s.Repo.Save(ctx, w)
+		err := s.Repo.Save(ctx, w, nil)
 		assert.NoError(t, err)
 	}
 
@@ -173,7 +173,7 @@ func (s *RepositoryTestSuite) TestList_Limit(t *testing.T) {
 			Text:     "limit_test" + string(rune('a'+i)),
 			Language: "en",
 		}
-		err := s.Repo.Save(ctx, testWord)
+		err := s.Repo.Save(ctx, testWord, nil)
 		assert.NoError(t, err)
 	}
 
@@ -6,8 +6,12 @@ import (
 
 // Service defines the interface for word business logic
 type Service interface {
-	// Search finds a word by text and language, fetching from external API if needed
-	Search(ctx context.Context, text, language string) (*Word, error)
+	// Search finds a word by text and language, fetching from external API if
+	// needed. The search is published for gamification unless userID is empty.
+	Search(ctx context.Context, userID, text, language string) (*Word, error)
+
+	// GetWord retrieves a stored word by its ID, with its provenance
+	GetWord(ctx context.Context, wordID string) (*Word, error)
 
 	// GetRecentWords retrieves recently searched words
 	GetRecentWords(ctx context.Context, language string, limit int) ([]*Word, error)
@@ -20,6 +24,19 @@ type Service interface {
 
 	// GetSuggestions retrieves word suggestions based on a prefix
 	GetSuggestions(ctx context.Context, prefix, language string) ([]string, error)
+
+	// ListWords browses stored words filtered and sorted by difficulty
+	ListWords(ctx context.Context, options ListOptions) ([]*Word, error)
+}
+
+// ListOptions filters and orders the words returned by ListWords
+type ListOptions struct {
+	Language      string
+	MinDifficulty Difficulty // Optional, inclusive
+	MaxDifficulty Difficulty // Optional, inclusive
+	Sort          string     // One of the Sort values, SortRecent by default
+	Limit         int
+	Offset        int
 }
 
 // RelatedWords groups words related to a specific word
@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
+	"golang.org/x/sync/singleflight"
+
+	"voconsteroid/internal/domain/event"
+	languageDomain "voconsteroid/internal/domain/language"
 )
 
 // Ensure service implements Service interface
@@ -16,22 +19,51 @@ var _ Service = (*service)(nil)
 
 // service implements the Service interface
 type service struct {
-	repo    Repository
-	dictAPI DictionaryAPI
-	logger  zerolog.Logger
+	repo      Repository
+	dictAPI   DictionaryAPI
+	frequency FrequencyIndex
+	events    event.Publisher
+	logger    zerolog.Logger
+
+	// fetches shares the fetch and save of a missing word between the
+	// concurrent searches for it
+	fetches singleflight.Group
 }
 
-// NewService creates a new word service
-func NewService(repo Repository, dictAPI DictionaryAPI, logger zerolog.Logger) Service {
+// NewService creates a new word service. Words are scored against the
+// frequency index before being saved; a nil index leaves them unscored.
+// Fetched, saved and enriched words are published as events; a nil
+// publisher drops them.
+func NewService(repo Repository, dictAPI DictionaryAPI, frequency FrequencyIndex, events event.Publisher, logger zerolog.Logger) Service {
+	if events == nil {
+		events = event.Nop
+	}
 	return &service{
-		repo:    repo,
-		dictAPI: dictAPI,
-		logger:  logger.With().Str("component", "word_service").Logger(),
+		repo:      repo,
+		dictAPI:   dictAPI,
+		frequency: frequency,
+		events:    events,
+		logger:    logger.With().Str("component", "word_service").Logger(),
+	}
+}
+
+// Search finds a word by text and language, fetching from external API if
+// needed, and publishes the search of a signed-in user
+func (s *service) Search(ctx context.Context, userID, text, language string) (*Word, error) {
+	word, err := s.find(ctx, text, language)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID != "" {
+		s.publish(ctx, WordSearched{ID: uuid.New().String(), UserID: userID, Word: word, SearchedAt: time.Now()})
 	}
+
+	return word, nil
 }
 
-// Search finds a word by text and language, fetching from external API if needed
-func (s *service) Search(ctx context.Context, text, language string) (*Word, error) {
+// find finds a word by text and language, fetching from external API if needed
+func (s *service) find(ctx context.Context, text, language string) (*Word, error) {
 	s.logger.Debug().Str("text", text).Str("language", language).Msg("Searching for word")
 
 	if text == "" {
@@ -39,8 +71,8 @@ func (s *service) Search(ctx context.Context, text, language string) (*Word, err
 		return nil, ErrInvalidWord
 	}
 
-	// Normalize the input text (lowercase, trim spaces)
-	normalizedText := strings.ToLower(strings.TrimSpace(text))
+	// Normalize the input text as its language does (lowercase, trim spaces)
+	normalizedText := languageDomain.Default.Normalize(language, text)
 
 	// First, try to find the word in the repository
 	word, err := s.repo.FindByText(ctx, normalizedText, language)
@@ -61,19 +93,11 @@ func (s *service) Search(ctx context.Context, text, language string) (*Word, err
 	// If not found in repository, fetch from external API
 	if errors.Is(err, ErrWordNotFound) {
 		s.logger.Debug().Str("text", normalizedText).Msg("Word not found in repository, fetching from API")
-		word, err = s.dictAPI.FetchWord(ctx, normalizedText, language)
-		if err != nil {
-			s.logger.Error().Err(err).Str("text", normalizedText).Msg("Failed to fetch word from API")
-			return nil, fmt.Errorf("failed to fetch word: %w", err)
-		}
-
-		// Save the fetched word to the repository
-		if err := s.repo.Save(ctx, word); err != nil {
-			s.logger.Error().Err(err).Str("text", normalizedText).Msg("Failed to save word to repository")
-			// Don't return error here, we still want to return the word to the user
+		if err := spendFetchBudget(ctx); err != nil {
+			s.logger.Warn().Err(err).Str("text", normalizedText).Msg("Fetch budget exhausted")
+			return nil, err
 		}
-
-		return word, nil
+		return s.fetch(ctx, normalizedText, language)
 	}
 
 	// If there was a different error with the repository
@@ -81,10 +105,61 @@ func (s *service) Search(ctx context.Context, text, language string) (*Word, err
 	return nil, fmt.Errorf("repository error: %w", err)
 }
 
+// fetch fetches a word missing from the repository and saves it. Concurrent
+// fetches of the same word share one fetch and one save, and get the same
+// word, which they must not modify. The shared call outlives the
+// cancellation of the caller that started it, as other callers may still
+// wait for it.
+func (s *service) fetch(ctx context.Context, text, language string) (*Word, error) {
+	runCtx := context.WithoutCancel(ctx)
+	result := s.fetches.DoChan(language+":"+text, func() (any, error) {
+		return s.fetchAndSave(runCtx, text, language)
+	})
+
+	select {
+	case r := <-result:
+		if r.Err != nil {
+			return nil, r.Err
+		}
+		return r.Val.(*Word), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// fetchAndSave fetches a word from the external API and saves it
+func (s *service) fetchAndSave(ctx context.Context, text, language string) (*Word, error) {
+	word, err := s.dictAPI.FetchWord(ctx, text, language)
+	if err != nil {
+		s.logger.Error().Err(err).Str("text", text).Msg("Failed to fetch word from API")
+		return nil, fmt.Errorf("failed to fetch word: %w", err)
+	}
+
+	// Another replica may have saved the word while it was fetched
+	if saved, err := s.repo.FindByText(ctx, word.Text, word.Language); err == nil {
+		s.logger.Debug().Str("text", text).Msg("Word saved while it was fetched")
+		return saved, nil
+	}
+
+	// Save the fetched word to the repository
+	word.ScoreDifficulty(s.frequency)
+	if err := s.repo.Save(ctx, word, s.events.Emit(WordFetched{Word: word}, WordSaved{Word: word})); err != nil {
+		s.logger.Error().Err(err).Str("text", text).Msg("Failed to save word to repository")
+		// Don't return error here, we still want to return the word to the user
+		s.publish(ctx, WordFetched{Word: word})
+	}
+
+	return word, nil
+}
+
 // GetRecentWords retrieves recently searched words
 func (s *service) GetRecentWords(ctx context.Context, language string, limit int) ([]*Word, error) {
 	s.logger.Debug().Str("language", language).Int("limit", limit).Msg("Getting recent words")
 
+	if limit <= 0 {
+		limit = 10
+	}
+
 	// Create filter for the repository
 	filter := map[string]interface{}{
 		"language": language,
@@ -100,6 +175,15 @@ func (s *service) GetRecentWords(ctx context.Context, language string, limit int
 	return words, nil
 }
 
+// GetWord retrieves a stored word by its ID
+func (s *service) GetWord(ctx context.Context, wordID string) (*Word, error) {
+	word, err := s.repo.FindByID(ctx, wordID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find word: %w", err)
+	}
+	return word, nil
+}
+
 // GetRelatedWords finds words related to the given word (synonyms, antonyms)
 func (s *service) GetRelatedWords(ctx context.Context, wordID string) (*RelatedWords, error) {
 	s.logger.Debug().Str("wordID", wordID).Msg("Getting related words")
@@ -121,6 +205,11 @@ func (s *service) GetRelatedWords(ctx context.Context, wordID string) (*RelatedW
 	// If the word has no synonyms or antonyms, fetch them
 	if len(word.Synonyms) == 0 && len(word.Antonyms) == 0 {
 		s.logger.Debug().Str("wordID", wordID).Msg("Fetching related words from API")
+		if err := spendFetchBudget(ctx); err != nil {
+			s.logger.Warn().Err(err).Str("wordID", wordID).Msg("Fetch budget exhausted")
+			// Return what we have so far, as when the fetch fails
+			return result, nil
+		}
 
 		// Fetch related words from API
 		relatedWords, err := s.dictAPI.FetchRelatedWords(ctx, word)
@@ -136,7 +225,9 @@ func (s *service) GetRelatedWords(ctx context.Context, wordID string) (*RelatedW
 		word.UpdatedAt = time.Now()
 
 		// Save the updated word
-		if err := s.repo.Save(ctx, word); err != nil {
+		word.ScoreDifficulty(s.frequency)
+		enriched := s.events.Emit(WordSaved{Word: word}, WordEnriched{Word: word, Fields: []string{"synonyms", "antonyms"}})
+		if err := s.repo.Save(ctx, word, enriched); err != nil {
 			s.logger.Error().Err(err).Str("wordID", wordID).Msg("Failed to save updated word")
 			// Don't fail completely, continue with what we have
 		}
@@ -189,7 +280,7 @@ func (s *service) GetSuggestions(ctx context.Context, prefix, language string) (
 	s.logger.Debug().Str("prefix", prefix).Str("language", language).Msg("Getting word suggestions")
 
 	// Normalize the prefix
-	normalizedPrefix := strings.ToLower(strings.TrimSpace(prefix))
+	normalizedPrefix := languageDomain.Default.Normalize(language, prefix)
 	if normalizedPrefix == "" {
 		return []string{}, nil
 	}
@@ -204,6 +295,10 @@ func (s *service) GetSuggestions(ctx context.Context, prefix, language string) (
 	}
 
 	// If no suggestions from repository, try the API
+	if err := spendFetchBudget(ctx); err != nil {
+		s.logger.Warn().Err(err).Str("prefix", normalizedPrefix).Msg("Fetch budget exhausted")
+		return nil, err
+	}
 	suggestions, err = s.dictAPI.FetchSuggestions(ctx, normalizedPrefix, language)
 	if err != nil {
 		s.logger.Error().Err(err).Msg("Failed to fetch suggestions from API")
@@ -212,3 +307,59 @@ func (s *service) GetSuggestions(ctx context.Context, prefix, language string) (
 
 	return suggestions, nil
 }
+
+// ListWords browses stored words filtered and sorted by difficulty
+func (s *service) ListWords(ctx context.Context, options ListOptions) ([]*Word, error) {
+	s.logger.Debug().Interface("options", options).Msg("Listing words")
+
+	if options.Sort == "" {
+		options.Sort = SortRecent
+	}
+	if !IsValidSort(options.Sort) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidSort, options.Sort)
+	}
+	for _, d := range []Difficulty{options.MinDifficulty, options.MaxDifficulty} {
+		if d != "" && !d.IsValid() {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidDifficulty, d)
+		}
+	}
+	if options.MinDifficulty != "" && options.MaxDifficulty != "" &&
+		options.MinDifficulty.Level() > options.MaxDifficulty.Level() {
+		return nil, fmt.Errorf("%w: %s is above %s", ErrInvalidDifficulty, options.MinDifficulty, options.MaxDifficulty)
+	}
+	if options.Limit <= 0 {
+		options.Limit = 20
+	}
+	if options.Offset < 0 {
+		options.Offset = 0
+	}
+
+	filter := map[string]interface{}{
+		FilterSort: options.Sort,
+	}
+	if options.Language != "" {
+		filter["language"] = options.Language
+	}
+	if options.MinDifficulty != "" {
+		filter[FilterMinDifficulty] = options.MinDifficulty
+	}
+	if options.MaxDifficulty != "" {
+		filter[FilterMaxDifficulty] = options.MaxDifficulty
+	}
+
+	words, err := s.repo.List(ctx, filter, options.Limit, options.Offset)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to list words")
+		return nil, fmt.Errorf("failed to list words: %w", err)
+	}
+
+	return words, nil
+}
+
+// publish publishes events, only logging failures: subscribers must never
+// fail the operation that triggered them
+func (s *service) publish(ctx context.Context, events ...event.Event) {
+	if err := s.events.Publish(ctx, events...); err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to publish events")
+	}
+}
@@ -3,12 +3,15 @@ package word
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+
+	"voconsteroid/internal/domain/event"
 )
 
 // MockRepository is a mock implementation of the Repository interface
@@ -16,6 +19,14 @@ type MockRepository struct {
 	mock.Mock
 }
 
+func (m *MockRepository) FindByID(ctx context.Context, id string) (*Word, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Word), args.Error(1)
+}
+
 func (m *MockRepository) FindByText(ctx context.Context, text, language string) (*Word, error) {
 	args := m.Called(ctx, text, language)
 	if args.Get(0) == nil {
@@ -32,9 +43,12 @@ func (m *MockRepository) FindByAnyForm(ctx context.Context, text, language strin
 	return args.Get(0).(*Word), args.Error(1)
 }
 
-func (m *MockRepository) Save(ctx context.Context, word *Word) error {
+func (m *MockRepository) Save(ctx context.Context, word *Word, emit event.Emit) error {
 	args := m.Called(ctx, word)
-	return args.Error(0)
+	if err := args.Error(0); err != nil || emit == nil {
+		return err
+	}
+	return emit(ctx, nil)
 }
 
 func (m *MockRepository) List(ctx context.Context, filter map[string]interface{}, limit, offset int) ([]*Word, error) {
@@ -53,6 +67,14 @@ func (m *MockRepository) FindByPrefix(ctx context.Context, prefix, language stri
 	return args.Get(0).([]*Word), args.Error(1)
 }
 
+func (m *MockRepository) FindSuggestions(ctx context.Context, prefix, language string, limit int) ([]string, error) {
+	args := m.Called(ctx, prefix, language, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
 // MockDictionaryAPI is a mock implementation of the DictionaryAPI interface
 type MockDictionaryAPI struct {
 	mock.Mock
@@ -89,7 +111,7 @@ func TestNewService(t *testing.T) {
 	logger := zerolog.New(zerolog.NewTestWriter(t))
 
 	// Execute
-	svc := NewService(repo, dictAPI, logger)
+	svc := NewService(repo, dictAPI, nil, nil, logger)
 
 	// Assert
 	assert.NotNil(t, svc)
@@ -101,7 +123,7 @@ func setupTestService(t *testing.T) (*MockRepository, *MockDictionaryAPI, Servic
 	dictAPI := new(MockDictionaryAPI)
 	logger := zerolog.New(zerolog.NewTestWriter(t))
 
-	svc := NewService(repo, dictAPI, logger)
+	svc := NewService(repo, dictAPI, nil, nil, logger)
 
 	return repo, dictAPI, svc
 }
@@ -120,11 +142,8 @@ func TestSearch_ExistingWord(t *testing.T) {
 	// Expect repository to find the word by text first
 	repo.On("FindByText", ctx, "test", "en").Return(expectedWord, nil)
 
-	// Add expectation for FindByAnyForm (won't be called but needs to be mocked)
-	repo.On("FindByAnyForm", ctx, "test", "en").Return(nil, errors.New("not found"))
-
 	// Execute
-	word, err := svc.Search(ctx, "test", "en")
+	word, err := svc.Search(ctx, "", "test", "en")
 
 	// Assert
 	assert.NoError(t, err)
@@ -148,19 +167,19 @@ func TestSearch_NewWord(t *testing.T) {
 	}
 
 	// Expect repository to not find the word by text
-	repo.On("FindByText", ctx, "test", "en").Return(nil, errors.New("not found"))
+	repo.On("FindByText", mock.Anything, "test", "en").Return(nil, ErrWordNotFound)
 
 	// Expect repository to not find the word by any form
-	repo.On("FindByAnyForm", ctx, "test", "en").Return(nil, errors.New("not found"))
+	repo.On("FindByAnyForm", ctx, "test", "en").Return(nil, ErrWordNotFound)
 
 	// Expect dictionary API to fetch the word
-	dictAPI.On("FetchWord", ctx, "test", "en").Return(expectedWord, nil)
+	dictAPI.On("FetchWord", mock.Anything, "test", "en").Return(expectedWord, nil)
 
 	// Expect repository to save the word
-	repo.On("Save", ctx, expectedWord).Return(nil)
+	repo.On("Save", mock.Anything, expectedWord).Return(nil)
 
 	// Execute
-	word, err := svc.Search(ctx, "test", "en")
+	word, err := svc.Search(ctx, "", "test", "en")
 
 	// Assert
 	assert.NoError(t, err)
@@ -169,6 +188,193 @@ func TestSearch_NewWord(t *testing.T) {
 	dictAPI.AssertExpectations(t)
 }
 
+func TestSearch_FetchBudget(t *testing.T) {
+	// Setup
+	repo, dictAPI, svc := setupTestService(t)
+
+	spent := 0
+	ctx := WithFetchBudget(context.Background(), func(ctx context.Context) error {
+		spent++
+		return ErrFetchLimited
+	})
+	repo.On("FindByText", ctx, "test", "en").Return(nil, ErrWordNotFound)
+	repo.On("FindByAnyForm", ctx, "test", "en").Return(nil, ErrWordNotFound)
+
+	// Execute
+	word, err := svc.Search(ctx, "", "test", "en")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrFetchLimited)
+	assert.Nil(t, word)
+	assert.Equal(t, 1, spent)
+	dictAPI.AssertNotCalled(t, "FetchWord")
+}
+
+func TestGetSuggestions_FetchBudgetNotSpentOnStoredWords(t *testing.T) {
+	// Setup
+	repo, dictAPI, svc := setupTestService(t)
+
+	ctx := WithFetchBudget(context.Background(), func(ctx context.Context) error {
+		return ErrFetchLimited
+	})
+	repo.On("FindSuggestions", ctx, "ma", "fr", 10).Return([]string{"maison"}, nil)
+
+	// Execute
+	suggestions, err := svc.GetSuggestions(ctx, "ma", "fr")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"maison"}, suggestions)
+	dictAPI.AssertNotCalled(t, "FetchSuggestions")
+}
+
+// MockPublisher is a mock implementation of the event.Publisher interface
+type MockPublisher struct {
+	mock.Mock
+}
+
+func (m *MockPublisher) Publish(ctx context.Context, events ...event.Event) error {
+	args := m.Called(ctx, events)
+	return args.Error(0)
+}
+
+// Emit publishes the events when the mock repository runs it
+func (m *MockPublisher) Emit(events ...event.Event) event.Emit {
+	return func(ctx context.Context, _ event.Outbox) error {
+		return m.Publish(ctx, events...)
+	}
+}
+
+func TestSearch_NewWordPublishesEvents(t *testing.T) {
+	// Setup
+	repo := new(MockRepository)
+	dictAPI := new(MockDictionaryAPI)
+	publisher := new(MockPublisher)
+	svc := NewService(repo, dictAPI, nil, publisher, zerolog.New(zerolog.NewTestWriter(t)))
+
+	ctx := context.Background()
+	fetched := &Word{Text: "test", Language: "en"}
+	repo.On("FindByText", mock.Anything, "test", "en").Return(nil, ErrWordNotFound)
+	repo.On("FindByAnyForm", ctx, "test", "en").Return(nil, ErrWordNotFound)
+	dictAPI.On("FetchWord", mock.Anything, "test", "en").Return(fetched, nil)
+	repo.On("Save", mock.Anything, fetched).Return(nil)
+	publisher.On("Publish", mock.Anything, []event.Event{WordFetched{Word: fetched}, WordSaved{Word: fetched}}).Return(nil)
+
+	// Execute
+	word, err := svc.Search(ctx, "", "test", "en")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, fetched, word)
+	publisher.AssertExpectations(t)
+}
+
+func TestSearch_UnsavedWordPublishesFetch(t *testing.T) {
+	// Setup
+	repo := new(MockRepository)
+	dictAPI := new(MockDictionaryAPI)
+	publisher := new(MockPublisher)
+	svc := NewService(repo, dictAPI, nil, publisher, zerolog.New(zerolog.NewTestWriter(t)))
+
+	ctx := context.Background()
+	fetched := &Word{Text: "test", Language: "en"}
+	repo.On("FindByText", mock.Anything, "test", "en").Return(nil, ErrWordNotFound)
+	repo.On("FindByAnyForm", ctx, "test", "en").Return(nil, ErrWordNotFound)
+	dictAPI.On("FetchWord", mock.Anything, "test", "en").Return(fetched, nil)
+	repo.On("Save", mock.Anything, fetched).Return(errors.New("db down"))
+	publisher.On("Publish", mock.Anything, []event.Event{WordFetched{Word: fetched}}).Return(nil)
+
+	// Execute
+	word, err := svc.Search(ctx, "", "test", "en")
+
+	// Assert: the word is still returned, and only its fetch published
+	assert.NoError(t, err)
+	assert.Equal(t, fetched, word)
+	publisher.AssertExpectations(t)
+}
+
+func TestSearch_ConcurrentFetchesSaveOnce(t *testing.T) {
+	// Setup
+	repo := new(MockRepository)
+	dictAPI := new(MockDictionaryAPI)
+	publisher := new(MockPublisher)
+	svc := NewService(repo, dictAPI, nil, publisher, zerolog.New(zerolog.NewTestWriter(t)))
+
+	ctx := context.Background()
+	fetched := &Word{Text: "test", Language: "en"}
+	repo.On("FindByText", mock.Anything, "test", "en").Return(nil, ErrWordNotFound)
+	repo.On("FindByAnyForm", ctx, "test", "en").Return(nil, ErrWordNotFound)
+	dictAPI.On("FetchWord", mock.Anything, "test", "en").WaitUntil(time.After(50*time.Millisecond)).Return(fetched, nil)
+	repo.On("Save", mock.Anything, fetched).Return(nil)
+	publisher.On("Publish", mock.Anything, []event.Event{WordFetched{Word: fetched}, WordSaved{Word: fetched}}).Return(nil)
+
+	// Execute
+	const searches = 5
+	var wg sync.WaitGroup
+	results := make([]*Word, searches)
+	for i := range searches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = svc.Search(ctx, "", "test", "en")
+		}()
+	}
+	wg.Wait()
+
+	// Assert: every search gets the word, fetched, saved and published once
+	for _, result := range results {
+		assert.Equal(t, fetched, result)
+	}
+	dictAPI.AssertNumberOfCalls(t, "FetchWord", 1)
+	repo.AssertNumberOfCalls(t, "Save", 1)
+	publisher.AssertNumberOfCalls(t, "Publish", 1)
+}
+
+func TestSearch_WordSavedWhileFetched(t *testing.T) {
+	// Setup
+	repo, dictAPI, svc := setupTestService(t)
+
+	ctx := context.Background()
+	fetched := &Word{Text: "test", Language: "en"}
+	stored := &Word{ID: "word-1", Text: "test", Language: "en"}
+	repo.On("FindByText", ctx, "test", "en").Return(nil, ErrWordNotFound).Once()
+	repo.On("FindByAnyForm", ctx, "test", "en").Return(nil, ErrWordNotFound)
+	dictAPI.On("FetchWord", mock.Anything, "test", "en").Return(fetched, nil)
+	repo.On("FindByText", mock.Anything, "test", "en").Return(stored, nil).Once()
+
+	// Execute
+	word, err := svc.Search(ctx, "", "test", "en")
+
+	// Assert: the word saved by another replica is returned, and not saved again
+	assert.NoError(t, err)
+	assert.Equal(t, stored, word)
+	repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestSearch_PublishesUserSearch(t *testing.T) {
+	// Setup
+	repo := new(MockRepository)
+	publisher := new(MockPublisher)
+	svc := NewService(repo, new(MockDictionaryAPI), nil, publisher, zerolog.New(zerolog.NewTestWriter(t)))
+
+	ctx := context.Background()
+	stored := &Word{ID: "word-1", Text: "maison", Language: "fr"}
+	repo.On("FindByText", ctx, "maison", "fr").Return(stored, nil)
+	publisher.On("Publish", ctx, mock.MatchedBy(func(events []event.Event) bool {
+		searched, ok := events[0].(WordSearched)
+		return len(events) == 1 && ok && searched.UserID == "user-1" && searched.Word == stored && !searched.SearchedAt.IsZero()
+	})).Return(nil).Once()
+
+	// Execute
+	_, err := svc.Search(ctx, "user-1", "maison", "fr")
+	assert.NoError(t, err)
+	_, err = svc.Search(ctx, "", "maison", "fr")
+
+	// Assert: anonymous searches are not published
+	assert.NoError(t, err)
+	publisher.AssertExpectations(t)
+}
+
 func TestSearch_EmptyText(t *testing.T) {
 	// Setup
 	repo, dictAPI, svc := setupTestService(t)
@@ -176,7 +382,7 @@ func TestSearch_EmptyText(t *testing.T) {
 	ctx := context.Background()
 
 	// Execute
-	word, err := svc.Search(ctx, "", "en")
+	word, err := svc.Search(ctx, "", "", "en")
 
 	// Assert
 	assert.Error(t, err)
@@ -195,16 +401,16 @@ func TestSearch_APIError(t *testing.T) {
 	apiErr := errors.New("API error")
 
 	// Expect repository to not find the word by text
-	repo.On("FindByText", ctx, "test", "en").Return(nil, errors.New("not found"))
+	repo.On("FindByText", mock.Anything, "test", "en").Return(nil, ErrWordNotFound)
 
 	// Expect repository to not find the word by any form
-	repo.On("FindByAnyForm", ctx, "test", "en").Return(nil, errors.New("not found"))
+	repo.On("FindByAnyForm", ctx, "test", "en").Return(nil, ErrWordNotFound)
 
 	// Expect dictionary API to return an error
-	dictAPI.On("FetchWord", ctx, "test", "en").Return(nil, apiErr)
+	dictAPI.On("FetchWord", mock.Anything, "test", "en").Return(nil, apiErr)
 
 	// Execute
-	word, err := svc.Search(ctx, "test", "en")
+	word, err := svc.Search(ctx, "", "test", "en")
 
 	// Assert
 	assert.Error(t, err)
@@ -214,6 +420,36 @@ func TestSearch_APIError(t *testing.T) {
 	dictAPI.AssertExpectations(t)
 }
 
+func TestGetWord(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns the stored word with its provenance", func(t *testing.T) {
+		// Setup
+		repo, _, svc := setupTestService(t)
+		stored := &Word{ID: "word-1", Text: "maison", Language: "fr", Provenance: &Provenance{Source: "frwiktionary", ParserVersion: 2}}
+		repo.On("FindByID", ctx, "word-1").Return(stored, nil)
+
+		// Execute
+		found, err := svc.GetWord(ctx, "word-1")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, stored, found)
+	})
+
+	t.Run("reports a missing word", func(t *testing.T) {
+		// Setup
+		repo, _, svc := setupTestService(t)
+		repo.On("FindByID", ctx, "missing").Return(nil, ErrWordNotFound)
+
+		// Execute
+		_, err := svc.GetWord(ctx, "missing")
+
+		// Assert
+		assert.ErrorIs(t, err, ErrWordNotFound)
+	})
+}
+
 func TestGetRecentWords(t *testing.T) {
 	// Setup
 	repo, _, svc := setupTestService(t)
@@ -277,7 +513,7 @@ func TestGetRecentWords_RepositoryError(t *testing.T) {
 	// Assert
 	assert.Error(t, err)
 	assert.Nil(t, words)
-	assert.Equal(t, repoErr, err)
+	assert.ErrorIs(t, err, repoErr)
 	repo.AssertExpectations(t)
 }
 
@@ -286,53 +522,37 @@ func TestAutoComplete(t *testing.T) {
 	repo, dictAPI, svc := setupTestService(t)
 
 	ctx := context.Background()
+	localSuggestions := []string{"test1", "test2"}
 
-	// Create test data
-	localWords := []*Word{
-		{Text: "test1", Language: "en"},
-		{Text: "test2", Language: "en"},
-	}
-
-	apiSuggestions := []string{"test2", "test3"} // Duplicate that should be deduplicated
-
-	// Expect repository to find words by prefix
-	repo.On("FindByPrefix", ctx, "test", "en", 5).Return(localWords, nil)
-
-	// Expect dictionary API to fetch suggestions
-	dictAPI.On("FetchSuggestions", ctx, "test", "en").Return(apiSuggestions, nil)
+	// Expect repository to find suggestions by prefix
+	repo.On("FindSuggestions", ctx, "test", "en", 10).Return(localSuggestions, nil)
 
 	// Execute
-	results, err := svc.AutoComplete(ctx, "test", "en")
+	results, err := svc.AutoComplete(ctx, "Test ", "en")
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Len(t, results, 3)
-
-	// Check that results are sorted alphabetically
-	assert.Equal(t, "test1", results[0])
-	assert.Equal(t, "test2", results[1])
-	assert.Equal(t, "test3", results[2])
+	assert.Equal(t, localSuggestions, results)
 
 	repo.AssertExpectations(t)
-	dictAPI.AssertExpectations(t)
+	dictAPI.AssertNotCalled(t, "FetchSuggestions")
 }
 
-func TestAutoComplete_ShortPrefix(t *testing.T) {
+func TestAutoComplete_EmptyPrefix(t *testing.T) {
 	// Setup
 	repo, dictAPI, svc := setupTestService(t)
 
 	ctx := context.Background()
 
-	// Execute with prefix that's too short
-	results, err := svc.AutoComplete(ctx, "t", "en")
+	// Execute with a blank prefix
+	results, err := svc.AutoComplete(ctx, "  ", "en")
 
 	// Assert
-	assert.Error(t, err)
-	assert.Equal(t, ErrInvalidWord, err)
-	assert.Nil(t, results)
+	assert.NoError(t, err)
+	assert.Empty(t, results)
 
 	// Verify no calls were made
-	repo.AssertNotCalled(t, "FindByPrefix")
+	repo.AssertNotCalled(t, "FindSuggestions")
 	dictAPI.AssertNotCalled(t, "FetchSuggestions")
 }
 
@@ -342,12 +562,10 @@ func TestAutoComplete_RepositoryError(t *testing.T) {
 
 	ctx := context.Background()
 	repoErr := errors.New("repository error")
-
-	// Create test data for API
 	apiSuggestions := []string{"test3"}
 
 	// Expect repository to return an error
-	repo.On("FindByPrefix", ctx, "test", "en", 5).Return(nil, repoErr)
+	repo.On("FindSuggestions", ctx, "test", "en", 10).Return(nil, repoErr)
 
 	// API should still be called and succeed
 	dictAPI.On("FetchSuggestions", ctx, "test", "en").Return(apiSuggestions, nil)
@@ -357,8 +575,7 @@ func TestAutoComplete_RepositoryError(t *testing.T) {
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Len(t, results, 1)
-	assert.Equal(t, "test3", results[0])
+	assert.Equal(t, apiSuggestions, results)
 
 	repo.AssertExpectations(t)
 	dictAPI.AssertExpectations(t)
@@ -371,13 +588,8 @@ func TestAutoComplete_APIError(t *testing.T) {
 	ctx := context.Background()
 	apiErr := errors.New("API error")
 
-	// Create test data for repository
-	localWords := []*Word{
-		{Text: "test1", Language: "en"},
-	}
-
-	// Repository should succeed
-	repo.On("FindByPrefix", ctx, "test", "en", 5).Return(localWords, nil)
+	// Repository has nothing for this prefix
+	repo.On("FindSuggestions", ctx, "test", "en", 10).Return([]string{}, nil)
 
 	// API should fail
 	dictAPI.On("FetchSuggestions", ctx, "test", "en").Return([]string(nil), apiErr)
@@ -386,10 +598,103 @@ func TestAutoComplete_APIError(t *testing.T) {
 	results, err := svc.AutoComplete(ctx, "test", "en")
 
 	// Assert
-	assert.NoError(t, err)
-	assert.Len(t, results, 1)
-	assert.Equal(t, "test1", results[0])
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, apiErr)
+	assert.Nil(t, results)
 
 	repo.AssertExpectations(t)
 	dictAPI.AssertExpectations(t)
 }
+
+func TestSearch_NewWordScoresDifficulty(t *testing.T) {
+	// Setup
+	repo := new(MockRepository)
+	dictAPI := new(MockDictionaryAPI)
+	index := fakeFrequencyIndex{"en": {"run": 80}}
+	svc := NewService(repo, dictAPI, index, nil, zerolog.New(zerolog.NewTestWriter(t)))
+
+	ctx := context.Background()
+	fetched := &Word{Text: "running", Language: "en", Lemma: "run"}
+
+	repo.On("FindByText", mock.Anything, "running", "en").Return(nil, ErrWordNotFound)
+	repo.On("FindByAnyForm", ctx, "running", "en").Return(nil, ErrWordNotFound)
+	dictAPI.On("FetchWord", mock.Anything, "running", "en").Return(fetched, nil)
+	repo.On("Save", mock.Anything, mock.MatchedBy(func(w *Word) bool {
+		return w.FrequencyRank == 80 && w.Difficulty == DifficultyA1
+	})).Return(nil)
+
+	// Execute
+	result, err := svc.Search(ctx, "", "running", "en")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, DifficultyA1, result.Difficulty)
+	repo.AssertExpectations(t)
+}
+
+func TestListWords(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("builds the filter", func(t *testing.T) {
+		// Setup
+		repo, _, svc := setupTestService(t)
+		words := []*Word{{ID: "word-1", Text: "maison", Difficulty: DifficultyA1}}
+		expectedFilter := map[string]interface{}{
+			"language":          "fr",
+			FilterSort:          SortDifficulty,
+			FilterMinDifficulty: DifficultyA1,
+			FilterMaxDifficulty: DifficultyB1,
+		}
+		repo.On("List", ctx, expectedFilter, 20, 0).Return(words, nil)
+
+		// Execute
+		result, err := svc.ListWords(ctx, ListOptions{
+			Language:      "fr",
+			MinDifficulty: DifficultyA1,
+			MaxDifficulty: DifficultyB1,
+			Sort:          SortDifficulty,
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, words, result)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("defaults to recent words", func(t *testing.T) {
+		// Setup
+		repo, _, svc := setupTestService(t)
+		repo.On("List", ctx, map[string]interface{}{FilterSort: SortRecent}, 5, 10).Return([]*Word{}, nil)
+
+		// Execute
+		_, err := svc.ListWords(ctx, ListOptions{Limit: 5, Offset: 10})
+
+		// Assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	invalid := []struct {
+		name     string
+		options  ListOptions
+		expected error
+	}{
+		{"inverted range", ListOptions{MinDifficulty: DifficultyC1, MaxDifficulty: DifficultyA2}, ErrInvalidDifficulty},
+		{"unknown band", ListOptions{MinDifficulty: "D1"}, ErrInvalidDifficulty},
+		{"unknown sort", ListOptions{Sort: "alphabetical"}, ErrInvalidSort},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			repo, _, svc := setupTestService(t)
+
+			// Execute
+			result, err := svc.ListWords(ctx, tt.options)
+
+			// Assert
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, tt.expected)
+			repo.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package wordlist
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"voconsteroid/internal/domain/word"
+)
+
+// Maximum lengths for the list name and description
+const (
+	MaxNameLength        = 100
+	MaxDescriptionLength = 1000
+)
+
+// WordList represents a user-defined, ordered collection of saved words
+type WordList struct {
+	ID           string    `json:"id"`
+	UserID       string    `json:"user_id"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description,omitempty"`
+	SavedWordIDs []string  `json:"saved_word_ids"`        // Saved words in their manual order
+	ShareToken   string    `json:"share_token,omitempty"` // Set while the list is shared publicly
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	Version      int       `json:"-"` // Optimistic concurrency control
+}
+
+// SharedList is the read-only, public view of a shared word list.
+// It carries no user data: neither the owner nor the reading contexts.
+type SharedList struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Words       []*word.Word `json:"words"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// NewWordList creates a new WordList entity
+func NewWordList(userID, name, description string) *WordList {
+	now := time.Now()
+	return &WordList{
+		ID:           uuid.New().String(),
+		UserID:       userID,
+		Name:         strings.TrimSpace(name),
+		Description:  strings.TrimSpace(description),
+		SavedWordIDs: []string{},
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+// Validate checks the list name and description
+func (l *WordList) Validate() error {
+	if l.Name == "" || len([]rune(l.Name)) > MaxNameLength {
+		return ErrInvalidName
+	}
+	if len([]rune(l.Description)) > MaxDescriptionLength {
+		return ErrInvalidDescription
+	}
+	return nil
+}
+
+// Rename changes the name and description of the list
+func (l *WordList) Rename(name, description string) {
+	l.Name = strings.TrimSpace(name)
+	l.Description = strings.TrimSpace(description)
+	l.UpdatedAt = time.Now()
+}
+
+// Contains reports whether the saved word is in the list
+func (l *WordList) Contains(savedWordID string) bool {
+	for _, id := range l.SavedWordIDs {
+		if id == savedWordID {
+			return true
+		}
+	}
+	return false
+}
+
+// AddWords appends saved words to the end of the list, skipping those already in it
+func (l *WordList) AddWords(savedWordIDs ...string) {
+	for _, id := range savedWordIDs {
+		if !l.Contains(id) {
+			l.SavedWordIDs = append(l.SavedWordIDs, id)
+		}
+	}
+	l.UpdatedAt = time.Now()
+}
+
+// RemoveWords removes saved words from the list, keeping the order of the others
+func (l *WordList) RemoveWords(savedWordIDs ...string) {
+	removed := make(map[string]bool, len(savedWordIDs))
+	for _, id := range savedWordIDs {
+		removed[id] = true
+	}
+
+	kept := make([]string, 0, len(l.SavedWordIDs))
+	for _, id := range l.SavedWordIDs {
+		if !removed[id] {
+			kept = append(kept, id)
+		}
+	}
+
+	l.SavedWordIDs = kept
+	l.UpdatedAt = time.Now()
+}
+
+// Reorder sets a new manual order. The new order must contain exactly the
+// saved words already in the list.
+func (l *WordList) Reorder(savedWordIDs []string) error {
+	if len(savedWordIDs) != len(l.SavedWordIDs) {
+		return ErrInvalidOrder
+	}
+
+	seen := make(map[string]bool, len(savedWordIDs))
+	for _, id := range savedWordIDs {
+		if seen[id] || !l.Contains(id) {
+			return ErrInvalidOrder
+		}
+		seen[id] = true
+	}
+
+	l.SavedWordIDs = append([]string{}, savedWordIDs...)
+	l.UpdatedAt = time.Now()
+	return nil
+}
+
+// Share makes the list readable through a public link. Sharing an already
+// shared list keeps its token so existing links remain valid.
+func (l *WordList) Share() error {
+	if l.ShareToken != "" {
+		return nil
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		return err
+	}
+
+	l.ShareToken = token
+	l.UpdatedAt = time.Now()
+	return nil
+}
+
+// Unshare revokes the public link of the list
+func (l *WordList) Unshare() {
+	l.ShareToken = ""
+	l.UpdatedAt = time.Now()
+}
+
+// newShareToken generates an unguessable, URL-safe token
+func newShareToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
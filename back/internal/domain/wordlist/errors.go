@@ -0,0 +1,14 @@
+package wordlist
+
+import "errors"
+
+// Domain errors
+var (
+	ErrListNotFound       = errors.New("word list not found")
+	ErrDuplicateListName  = errors.New("word list name already exists")
+	ErrInvalidName        = errors.New("invalid word list name")
+	ErrInvalidDescription = errors.New("invalid word list description")
+	ErrInvalidSavedWords  = errors.New("saved words must exist and belong to the list owner")
+	ErrInvalidOrder       = errors.New("order must contain exactly the words of the list")
+	ErrVersionConflict    = errors.New("word list was updated concurrently")
+)
@@ -0,0 +1,34 @@
+package wordlist
+
+import (
+	"context"
+
+	"voconsteroid/internal/domain/event"
+	"voconsteroid/internal/domain/savedword"
+)
+
+// Repository defines the interface for word list data access
+type Repository interface {
+	// FindByID retrieves a word list by its ID
+	FindByID(ctx context.Context, id string) (*WordList, error)
+
+	// FindByShareToken retrieves a shared word list by its public token
+	FindByShareToken(ctx context.Context, token string) (*WordList, error)
+
+	// FindByUserAndName retrieves a user's word list by its name
+	FindByUserAndName(ctx context.Context, userID, name string) (*WordList, error)
+
+	// ListByUser retrieves the word lists of a user, ordered by name
+	ListByUser(ctx context.Context, userID string, limit, offset int) ([]*WordList, error)
+
+	// Save stores a word list and the order of its saved words
+	Save(ctx context.Context, list *WordList) error
+
+	// CreateWithSavedWords stores a new word list together with the saved
+	// words created for it, publishing the events of these writes, in a
+	// single transaction
+	CreateWithSavedWords(ctx context.Context, list *WordList, savedWords []*savedword.SavedWord, emit event.Emit) error
+
+	// Delete removes a word list from the repository
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,45 @@
+package wordlist
+
+import (
+	"context"
+)
+
+// Service defines the interface for word list business logic
+type Service interface {
+	// CreateList creates a new, empty word list for a user
+	CreateList(ctx context.Context, userID, name, description string) (*WordList, error)
+
+	// GetList retrieves a word list of a user
+	GetList(ctx context.Context, userID, id string) (*WordList, error)
+
+	// ListLists retrieves the word lists of a user
+	ListLists(ctx context.Context, userID string, limit, offset int) ([]*WordList, error)
+
+	// UpdateList changes the name and description of a word list
+	UpdateList(ctx context.Context, userID, id, name, description string) (*WordList, error)
+
+	// DeleteList removes a word list; the saved words themselves are kept
+	DeleteList(ctx context.Context, userID, id string) error
+
+	// AddWords appends saved words to a word list
+	AddWords(ctx context.Context, userID, id string, savedWordIDs []string) (*WordList, error)
+
+	// RemoveWords removes saved words from a word list
+	RemoveWords(ctx context.Context, userID, id string, savedWordIDs []string) (*WordList, error)
+
+	// ReorderWords sets the manual order of the saved words in a word list
+	ReorderWords(ctx context.Context, userID, id string, savedWordIDs []string) (*WordList, error)
+
+	// ShareList makes a word list readable through a public link
+	ShareList(ctx context.Context, userID, id string) (*WordList, error)
+
+	// UnshareList revokes the public link of a word list
+	UnshareList(ctx context.Context, userID, id string) (*WordList, error)
+
+	// GetSharedList retrieves the public view of a shared word list
+	GetSharedList(ctx context.Context, token string) (*SharedList, error)
+
+	// DuplicateSharedList copies a shared word list into the lists of a user,
+	// saving the words the user has not saved yet
+	DuplicateSharedList(ctx context.Context, userID, token string) (*WordList, error)
+}
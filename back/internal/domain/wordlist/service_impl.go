@@ -0,0 +1,438 @@
+package wordlist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/event"
+	"voconsteroid/internal/domain/savedword"
+	"voconsteroid/internal/domain/word"
+)
+
+// Ensure service implements Service interface
+var _ Service = (*service)(nil)
+
+// maxCopyAttempts bounds the search for a free name when duplicating a list
+const maxCopyAttempts = 100
+
+// maxSaveAttempts bounds the retries of a list update that lost a race
+const maxSaveAttempts = 3
+
+// service implements the Service interface
+type service struct {
+	repo          Repository
+	savedWordRepo savedword.Repository
+	wordRepo      word.Repository
+	events        event.Publisher
+	logger        zerolog.Logger
+}
+
+// NewService creates a new word list service. The words saved when
+// duplicating a list are published as events; a nil publisher drops them.
+func NewService(repo Repository, savedWordRepo savedword.Repository, wordRepo word.Repository, events event.Publisher, logger zerolog.Logger) Service {
+	if events == nil {
+		events = event.Nop
+	}
+	return &service{
+		repo:          repo,
+		savedWordRepo: savedWordRepo,
+		wordRepo:      wordRepo,
+		events:        events,
+		logger:        logger.With().Str("component", "word_list_service").Logger(),
+	}
+}
+
+// CreateList creates a new, empty word list for a user
+func (s *service) CreateList(ctx context.Context, userID, name, description string) (*WordList, error) {
+	s.logger.Debug().Str("userID", userID).Str("name", name).Msg("Creating word list")
+
+	list := NewWordList(userID, name, description)
+	if err := list.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureNameAvailable(ctx, userID, list.Name, ""); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Save(ctx, list); err != nil {
+		s.logger.Error().Err(err).Str("name", list.Name).Msg("Failed to create word list")
+		return nil, fmt.Errorf("failed to create word list: %w", err)
+	}
+
+	return list, nil
+}
+
+// GetList retrieves a word list of a user
+func (s *service) GetList(ctx context.Context, userID, id string) (*WordList, error) {
+	s.logger.Debug().Str("userID", userID).Str("id", id).Msg("Getting word list")
+	return s.findOwned(ctx, userID, id)
+}
+
+// ListLists retrieves the word lists of a user
+func (s *service) ListLists(ctx context.Context, userID string, limit, offset int) ([]*WordList, error) {
+	s.logger.Debug().Str("userID", userID).Int("limit", limit).Msg("Listing word lists")
+
+	if limit <= 0 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	lists, err := s.repo.ListByUser(ctx, userID, limit, offset)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to list word lists")
+		return nil, fmt.Errorf("failed to list word lists: %w", err)
+	}
+
+	return lists, nil
+}
+
+// UpdateList changes the name and description of a word list
+func (s *service) UpdateList(ctx context.Context, userID, id, name, description string) (*WordList, error) {
+	s.logger.Debug().Str("userID", userID).Str("id", id).Msg("Updating word list")
+
+	return s.update(ctx, userID, id, func(list *WordList) error {
+		list.Rename(name, description)
+		if err := list.Validate(); err != nil {
+			return err
+		}
+		return s.ensureNameAvailable(ctx, userID, list.Name, list.ID)
+	})
+}
+
+// DeleteList removes a word list; the saved words themselves are kept
+func (s *service) DeleteList(ctx context.Context, userID, id string) error {
+	s.logger.Debug().Str("userID", userID).Str("id", id).Msg("Deleting word list")
+
+	if _, err := s.findOwned(ctx, userID, id); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		s.logger.Error().Err(err).Str("id", id).Msg("Failed to delete word list")
+		return fmt.Errorf("failed to delete word list: %w", err)
+	}
+
+	return nil
+}
+
+// AddWords appends saved words to a word list
+func (s *service) AddWords(ctx context.Context, userID, id string, savedWordIDs []string) (*WordList, error) {
+	s.logger.Debug().Str("userID", userID).Str("id", id).Int("count", len(savedWordIDs)).Msg("Adding words to word list")
+
+	if err := s.checkSavedWords(ctx, userID, savedWordIDs); err != nil {
+		return nil, err
+	}
+
+	return s.update(ctx, userID, id, func(list *WordList) error {
+		list.AddWords(savedWordIDs...)
+		return nil
+	})
+}
+
+// RemoveWords removes saved words from a word list
+func (s *service) RemoveWords(ctx context.Context, userID, id string, savedWordIDs []string) (*WordList, error) {
+	s.logger.Debug().Str("userID", userID).Str("id", id).Int("count", len(savedWordIDs)).Msg("Removing words from word list")
+
+	return s.update(ctx, userID, id, func(list *WordList) error {
+		list.RemoveWords(savedWordIDs...)
+		return nil
+	})
+}
+
+// ReorderWords sets the manual order of the saved words in a word list
+func (s *service) ReorderWords(ctx context.Context, userID, id string, savedWordIDs []string) (*WordList, error) {
+	s.logger.Debug().Str("userID", userID).Str("id", id).Msg("Reordering word list")
+
+	return s.update(ctx, userID, id, func(list *WordList) error {
+		return list.Reorder(savedWordIDs)
+	})
+}
+
+// ShareList makes a word list readable through a public link
+func (s *service) ShareList(ctx context.Context, userID, id string) (*WordList, error) {
+	s.logger.Debug().Str("userID", userID).Str("id", id).Msg("Sharing word list")
+
+	return s.update(ctx, userID, id, func(list *WordList) error {
+		if err := list.Share(); err != nil {
+			s.logger.Error().Err(err).Str("id", id).Msg("Failed to generate share token")
+			return fmt.Errorf("failed to generate share token: %w", err)
+		}
+		return nil
+	})
+}
+
+// UnshareList revokes the public link of a word list
+func (s *service) UnshareList(ctx context.Context, userID, id string) (*WordList, error) {
+	s.logger.Debug().Str("userID", userID).Str("id", id).Msg("Unsharing word list")
+
+	return s.update(ctx, userID, id, func(list *WordList) error {
+		list.Unshare()
+		return nil
+	})
+}
+
+// GetSharedList retrieves the public view of a shared word list
+func (s *service) GetSharedList(ctx context.Context, token string) (*SharedList, error) {
+	s.logger.Debug().Msg("Getting shared word list")
+
+	list, err := s.findShared(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	savedWords, err := s.savedWordsInOrder(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+
+	shared := &SharedList{
+		Name:        list.Name,
+		Description: list.Description,
+		Words:       make([]*word.Word, 0, len(savedWords)),
+		UpdatedAt:   list.UpdatedAt,
+	}
+
+	for _, sw := range savedWords {
+		w, err := s.wordRepo.FindByID(ctx, sw.WordID)
+		if err != nil {
+			if errors.Is(err, word.ErrWordNotFound) {
+				continue
+			}
+			s.logger.Error().Err(err).Str("wordID", sw.WordID).Msg("Failed to find shared word")
+			return nil, fmt.Errorf("failed to find word: %w", err)
+		}
+		shared.Words = append(shared.Words, w)
+	}
+
+	return shared, nil
+}
+
+// DuplicateSharedList copies a shared word list into the lists of a user,
+// saving the words the user has not saved yet
+func (s *service) DuplicateSharedList(ctx context.Context, userID, token string) (*WordList, error) {
+	s.logger.Debug().Str("userID", userID).Msg("Duplicating shared word list")
+
+	source, err := s.findShared(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceWords, err := s.savedWordsInOrder(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := s.copyName(ctx, userID, source.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	list := NewWordList(userID, name, source.Description)
+
+	// Words the user has not saved yet are saved along with the list.
+	// Reading contexts are personal, so they start without one.
+	var added []*savedword.SavedWord
+	var events []event.Event
+	for _, sw := range sourceWords {
+		own, err := s.findSavedWord(ctx, userID, sw.WordID)
+		if err != nil {
+			return nil, err
+		}
+		if own == nil {
+			own = savedword.NewSavedWord(userID, sw.WordID)
+			added = append(added, own)
+			events = append(events, savedword.SavedWordAdded{SavedWord: own})
+		}
+		list.AddWords(own.ID)
+	}
+
+	if err := s.repo.CreateWithSavedWords(ctx, list, added, s.events.Emit(events...)); err != nil {
+		s.logger.Error().Err(err).Str("name", list.Name).Msg("Failed to save duplicated word list")
+		return nil, fmt.Errorf("failed to save word list: %w", err)
+	}
+
+	return list, nil
+}
+
+// findOwned retrieves a word list and checks that it belongs to the user.
+// Lists of other users are reported as not found so their IDs do not leak.
+func (s *service) findOwned(ctx context.Context, userID, id string) (*WordList, error) {
+	list, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrListNotFound) {
+			return nil, err
+		}
+		s.logger.Error().Err(err).Str("id", id).Msg("Failed to find word list")
+		return nil, fmt.Errorf("failed to find word list: %w", err)
+	}
+
+	if list.UserID != userID {
+		return nil, ErrListNotFound
+	}
+
+	return list, nil
+}
+
+// findShared retrieves a word list by its share token
+func (s *service) findShared(ctx context.Context, token string) (*WordList, error) {
+	if token == "" {
+		return nil, ErrListNotFound
+	}
+
+	list, err := s.repo.FindByShareToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, ErrListNotFound) {
+			return nil, err
+		}
+		s.logger.Error().Err(err).Msg("Failed to find shared word list")
+		return nil, fmt.Errorf("failed to find word list: %w", err)
+	}
+
+	return list, nil
+}
+
+// update applies a change to the latest version of a word list of the user
+// and saves it, applying it again when the list was updated concurrently
+func (s *service) update(ctx context.Context, userID, id string, change func(list *WordList) error) (*WordList, error) {
+	for attempt := 1; ; attempt++ {
+		list, err := s.findOwned(ctx, userID, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := change(list); err != nil {
+			return nil, err
+		}
+
+		err = s.repo.Save(ctx, list)
+		if err == nil {
+			return list, nil
+		}
+		if !errors.Is(err, ErrVersionConflict) || attempt == maxSaveAttempts {
+			s.logger.Error().Err(err).Str("id", list.ID).Msg("Failed to save word list")
+			return nil, fmt.Errorf("failed to save word list: %w", err)
+		}
+		s.logger.Debug().Str("id", list.ID).Int("attempt", attempt).Msg("Word list updated concurrently, retrying")
+	}
+}
+
+// ensureNameAvailable checks that no other list of the user has the given name
+func (s *service) ensureNameAvailable(ctx context.Context, userID, name, exceptID string) error {
+	existing, err := s.repo.FindByUserAndName(ctx, userID, name)
+	if err != nil {
+		if errors.Is(err, ErrListNotFound) {
+			return nil
+		}
+		s.logger.Error().Err(err).Str("name", name).Msg("Failed to check word list name")
+		return fmt.Errorf("failed to check word list name: %w", err)
+	}
+
+	if existing.ID != exceptID {
+		return ErrDuplicateListName
+	}
+	return nil
+}
+
+// copyName finds a free name for a copy of a list, e.g. "Verbs (copy 2)"
+func (s *service) copyName(ctx context.Context, userID, name string) (string, error) {
+	for i := 0; i < maxCopyAttempts; i++ {
+		candidate := name
+		switch {
+		case i == 1:
+			candidate = name + " (copy)"
+		case i > 1:
+			candidate = fmt.Sprintf("%s (copy %d)", name, i)
+		}
+
+		// Keep the suffix visible when the original name is already at the limit
+		if runes := []rune(candidate); len(runes) > MaxNameLength {
+			suffix := strings.TrimPrefix(candidate, name)
+			candidate = string([]rune(name)[:MaxNameLength-len([]rune(suffix))]) + suffix
+		}
+
+		err := s.ensureNameAvailable(ctx, userID, candidate, "")
+		if err == nil {
+			return candidate, nil
+		}
+		if !errors.Is(err, ErrDuplicateListName) {
+			return "", err
+		}
+	}
+
+	return "", ErrDuplicateListName
+}
+
+// checkSavedWords verifies that all the saved words exist and belong to the user
+func (s *service) checkSavedWords(ctx context.Context, userID string, savedWordIDs []string) error {
+	if len(savedWordIDs) == 0 {
+		return ErrInvalidSavedWords
+	}
+
+	savedWords, err := s.savedWordRepo.FindByIDs(ctx, savedWordIDs)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to find saved words")
+		return fmt.Errorf("failed to find saved words: %w", err)
+	}
+
+	owned := make(map[string]bool, len(savedWords))
+	for _, sw := range savedWords {
+		if sw.UserID == userID {
+			owned[sw.ID] = true
+		}
+	}
+
+	for _, id := range savedWordIDs {
+		if !owned[id] {
+			return ErrInvalidSavedWords
+		}
+	}
+
+	return nil
+}
+
+// savedWordsInOrder retrieves the saved words of a list in the list's order
+func (s *service) savedWordsInOrder(ctx context.Context, list *WordList) ([]*savedword.SavedWord, error) {
+	if len(list.SavedWordIDs) == 0 {
+		return []*savedword.SavedWord{}, nil
+	}
+
+	savedWords, err := s.savedWordRepo.FindByIDs(ctx, list.SavedWordIDs)
+	if err != nil {
+		s.logger.Error().Err(err).Str("id", list.ID).Msg("Failed to find saved words of word list")
+		return nil, fmt.Errorf("failed to find saved words: %w", err)
+	}
+
+	byID := make(map[string]*savedword.SavedWord, len(savedWords))
+	for _, sw := range savedWords {
+		byID[sw.ID] = sw
+	}
+
+	ordered := make([]*savedword.SavedWord, 0, len(savedWords))
+	for _, id := range list.SavedWordIDs {
+		if sw, ok := byID[id]; ok {
+			ordered = append(ordered, sw)
+		}
+	}
+
+	return ordered, nil
+}
+
+// findSavedWord returns the user's saved word for a word, nil when the user
+// has not saved it
+func (s *service) findSavedWord(ctx context.Context, userID, wordID string) (*savedword.SavedWord, error) {
+	existing, err := s.savedWordRepo.FindByUserAndWord(ctx, userID, wordID)
+	if errors.Is(err, savedword.ErrSavedWordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		s.logger.Error().Err(err).Str("wordID", wordID).Msg("Failed to check for existing saved word")
+		return nil, fmt.Errorf("failed to check saved word: %w", err)
+	}
+	return existing, nil
+}
@@ -0,0 +1,517 @@
+package wordlist
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"voconsteroid/internal/domain/event"
+	"voconsteroid/internal/domain/savedword"
+	"voconsteroid/internal/domain/word"
+)
+
+// MockRepository is a mock implementation of the Repository interface
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) FindByID(ctx context.Context, id string) (*WordList, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*WordList), args.Error(1)
+}
+
+func (m *MockRepository) FindByShareToken(ctx context.Context, token string) (*WordList, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*WordList), args.Error(1)
+}
+
+func (m *MockRepository) FindByUserAndName(ctx context.Context, userID, name string) (*WordList, error) {
+	args := m.Called(ctx, userID, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*WordList), args.Error(1)
+}
+
+func (m *MockRepository) ListByUser(ctx context.Context, userID string, limit, offset int) ([]*WordList, error) {
+	args := m.Called(ctx, userID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*WordList), args.Error(1)
+}
+
+func (m *MockRepository) Save(ctx context.Context, list *WordList) error {
+	args := m.Called(ctx, list)
+	return args.Error(0)
+}
+
+func (m *MockRepository) CreateWithSavedWords(ctx context.Context, list *WordList, savedWords []*savedword.SavedWord, emit event.Emit) error {
+	args := m.Called(ctx, list, savedWords)
+	if err := args.Error(0); err != nil || emit == nil {
+		return err
+	}
+	return emit(ctx, nil)
+}
+
+func (m *MockRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// MockSavedWordRepository is a mock implementation of the savedword.Repository interface
+type MockSavedWordRepository struct {
+	mock.Mock
+}
+
+func (m *MockSavedWordRepository) FindByID(ctx context.Context, id string) (*savedword.SavedWord, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*savedword.SavedWord), args.Error(1)
+}
+
+func (m *MockSavedWordRepository) FindByIDs(ctx context.Context, ids []string) ([]*savedword.SavedWord, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*savedword.SavedWord), args.Error(1)
+}
+
+func (m *MockSavedWordRepository) FindByUserAndWord(ctx context.Context, userID, wordID string) (*savedword.SavedWord, error) {
+	args := m.Called(ctx, userID, wordID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*savedword.SavedWord), args.Error(1)
+}
+
+func (m *MockSavedWordRepository) Save(ctx context.Context, savedWord *savedword.SavedWord, emit event.Emit) error {
+	args := m.Called(ctx, savedWord)
+	return args.Error(0)
+}
+
+func (m *MockSavedWordRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSavedWordRepository) ListByUser(ctx context.Context, userID string, filter savedword.Filter, limit, offset int) ([]*savedword.SavedWord, error) {
+	args := m.Called(ctx, userID, filter, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*savedword.SavedWord), args.Error(1)
+}
+
+// MockWordRepository is a mock implementation of the word.Repository interface
+type MockWordRepository struct {
+	mock.Mock
+}
+
+func (m *MockWordRepository) FindByID(ctx context.Context, id string) (*word.Word, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) FindByText(ctx context.Context, text, language string) (*word.Word, error) {
+	args := m.Called(ctx, text, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) FindByAnyForm(ctx context.Context, text, language string) (*word.Word, error) {
+	args := m.Called(ctx, text, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) Save(ctx context.Context, w *word.Word, emit event.Emit) error {
+	args := m.Called(ctx, w)
+	return args.Error(0)
+}
+
+func (m *MockWordRepository) List(ctx context.Context, filter map[string]interface{}, limit, offset int) ([]*word.Word, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) FindByPrefix(ctx context.Context, prefix, language string, limit int) ([]*word.Word, error) {
+	args := m.Called(ctx, prefix, language, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) FindSuggestions(ctx context.Context, prefix, language string, limit int) ([]string, error) {
+	args := m.Called(ctx, prefix, language, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// setupTestService creates a service with mocks for testing
+func setupTestService(t *testing.T) (*MockRepository, *MockSavedWordRepository, *MockWordRepository, Service) {
+	repo := new(MockRepository)
+	savedWordRepo := new(MockSavedWordRepository)
+	wordRepo := new(MockWordRepository)
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+
+	return repo, savedWordRepo, wordRepo, NewService(repo, savedWordRepo, wordRepo, nil, logger)
+}
+
+func TestCreateList(t *testing.T) {
+	// Setup
+	repo, _, _, svc := setupTestService(t)
+	ctx := context.Background()
+
+	repo.On("FindByUserAndName", ctx, "user-1", "Verbs").Return(nil, ErrListNotFound)
+	repo.On("Save", ctx, mock.AnythingOfType("*wordlist.WordList")).Return(nil)
+
+	// Execute
+	list, err := svc.CreateList(ctx, "user-1", "  Verbs ", "Irregular verbs")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", list.UserID)
+	assert.Equal(t, "Verbs", list.Name)
+	assert.Empty(t, list.SavedWordIDs)
+	repo.AssertExpectations(t)
+}
+
+func TestCreateList_DuplicateName(t *testing.T) {
+	// Setup
+	repo, _, _, svc := setupTestService(t)
+	ctx := context.Background()
+
+	repo.On("FindByUserAndName", ctx, "user-1", "Verbs").Return(NewWordList("user-1", "Verbs", ""), nil)
+
+	// Execute
+	list, err := svc.CreateList(ctx, "user-1", "Verbs", "")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrDuplicateListName)
+	assert.Nil(t, list)
+	repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestCreateList_InvalidName(t *testing.T) {
+	// Setup
+	repo, _, _, svc := setupTestService(t)
+	ctx := context.Background()
+
+	// Execute
+	list, err := svc.CreateList(ctx, "user-1", "   ", "")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidName)
+	assert.Nil(t, list)
+	repo.AssertNotCalled(t, "FindByUserAndName", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateList_KeepsOwnName(t *testing.T) {
+	// Setup
+	repo, _, _, svc := setupTestService(t)
+	ctx := context.Background()
+
+	list := NewWordList("user-1", "Verbs", "")
+	repo.On("FindByID", ctx, list.ID).Return(list, nil)
+	repo.On("FindByUserAndName", ctx, "user-1", "Verbs").Return(list, nil)
+	repo.On("Save", ctx, list).Return(nil)
+
+	// Execute
+	updated, err := svc.UpdateList(ctx, "user-1", list.ID, "Verbs", "New description")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "New description", updated.Description)
+	repo.AssertExpectations(t)
+}
+
+func TestGetList_OtherUser(t *testing.T) {
+	// Setup
+	repo, _, _, svc := setupTestService(t)
+	ctx := context.Background()
+
+	list := NewWordList("user-2", "Verbs", "")
+	repo.On("FindByID", ctx, list.ID).Return(list, nil)
+
+	// Execute
+	result, err := svc.GetList(ctx, "user-1", list.ID)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrListNotFound)
+	assert.Nil(t, result)
+}
+
+func TestAddWords(t *testing.T) {
+	// Setup
+	repo, savedWordRepo, _, svc := setupTestService(t)
+	ctx := context.Background()
+
+	list := NewWordList("user-1", "Verbs", "")
+	list.AddWords("sw-1")
+	repo.On("FindByID", ctx, list.ID).Return(list, nil)
+	savedWordRepo.On("FindByIDs", ctx, []string{"sw-2", "sw-1"}).Return([]*savedword.SavedWord{
+		{ID: "sw-1", UserID: "user-1"},
+		{ID: "sw-2", UserID: "user-1"},
+	}, nil)
+	repo.On("Save", ctx, list).Return(nil)
+
+	// Execute
+	updated, err := svc.AddWords(ctx, "user-1", list.ID, []string{"sw-2", "sw-1"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sw-1", "sw-2"}, updated.SavedWordIDs)
+	repo.AssertExpectations(t)
+}
+
+func TestAddWords_ConcurrentUpdate(t *testing.T) {
+	// Setup
+	repo, savedWordRepo, _, svc := setupTestService(t)
+	ctx := context.Background()
+
+	stale := NewWordList("user-1", "Verbs", "")
+	stale.Version = 1
+	latest := NewWordList("user-1", "Verbs", "")
+	latest.ID, latest.Version = stale.ID, 2
+	latest.AddWords("sw-1")
+	repo.On("FindByID", ctx, stale.ID).Return(stale, nil).Once()
+	repo.On("FindByID", ctx, stale.ID).Return(latest, nil).Once()
+	savedWordRepo.On("FindByIDs", ctx, []string{"sw-2"}).Return([]*savedword.SavedWord{{ID: "sw-2", UserID: "user-1"}}, nil)
+	repo.On("Save", ctx, stale).Return(ErrVersionConflict).Once()
+	repo.On("Save", ctx, latest).Return(nil).Once()
+
+	// Execute
+	updated, err := svc.AddWords(ctx, "user-1", stale.ID, []string{"sw-2"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sw-1", "sw-2"}, updated.SavedWordIDs, "the words added concurrently are kept")
+	repo.AssertExpectations(t)
+}
+
+func TestAddWords_ForeignSavedWord(t *testing.T) {
+	// Setup
+	repo, savedWordRepo, _, svc := setupTestService(t)
+	ctx := context.Background()
+
+	list := NewWordList("user-1", "Verbs", "")
+	repo.On("FindByID", ctx, list.ID).Return(list, nil)
+	savedWordRepo.On("FindByIDs", ctx, []string{"sw-1", "sw-9"}).Return([]*savedword.SavedWord{
+		{ID: "sw-1", UserID: "user-1"},
+		{ID: "sw-9", UserID: "user-2"},
+	}, nil)
+
+	// Execute
+	updated, err := svc.AddWords(ctx, "user-1", list.ID, []string{"sw-1", "sw-9"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidSavedWords)
+	assert.Nil(t, updated)
+	repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestReorderWords(t *testing.T) {
+	// Setup
+	repo, _, _, svc := setupTestService(t)
+	ctx := context.Background()
+
+	list := NewWordList("user-1", "Verbs", "")
+	list.AddWords("sw-1", "sw-2", "sw-3")
+	repo.On("FindByID", ctx, list.ID).Return(list, nil)
+	repo.On("Save", ctx, list).Return(nil)
+
+	// Execute
+	updated, err := svc.ReorderWords(ctx, "user-1", list.ID, []string{"sw-3", "sw-1", "sw-2"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sw-3", "sw-1", "sw-2"}, updated.SavedWordIDs)
+}
+
+func TestReorder_Invalid(t *testing.T) {
+	list := NewWordList("user-1", "Verbs", "")
+	list.AddWords("sw-1", "sw-2")
+
+	testCases := []struct {
+		name  string
+		order []string
+	}{
+		{"missing word", []string{"sw-1"}},
+		{"unknown word", []string{"sw-1", "sw-9"}},
+		{"repeated word", []string{"sw-1", "sw-1"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.ErrorIs(t, list.Reorder(tc.order), ErrInvalidOrder)
+			assert.Equal(t, []string{"sw-1", "sw-2"}, list.SavedWordIDs)
+		})
+	}
+}
+
+func TestRemoveWords_KeepsOrder(t *testing.T) {
+	list := NewWordList("user-1", "Verbs", "")
+	list.AddWords("sw-1", "sw-2", "sw-3", "sw-4")
+
+	list.RemoveWords("sw-3", "sw-1", "sw-9")
+
+	assert.Equal(t, []string{"sw-2", "sw-4"}, list.SavedWordIDs)
+}
+
+func TestShareList_KeepsToken(t *testing.T) {
+	// Setup
+	repo, _, _, svc := setupTestService(t)
+	ctx := context.Background()
+
+	list := NewWordList("user-1", "Verbs", "")
+	repo.On("FindByID", ctx, list.ID).Return(list, nil)
+	repo.On("Save", ctx, list).Return(nil)
+
+	// Execute
+	first, err := svc.ShareList(ctx, "user-1", list.ID)
+	require.NoError(t, err)
+	token := first.ShareToken
+	second, err := svc.ShareList(ctx, "user-1", list.ID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.Equal(t, token, second.ShareToken)
+}
+
+func TestGetSharedList(t *testing.T) {
+	// Setup
+	repo, savedWordRepo, wordRepo, svc := setupTestService(t)
+	ctx := context.Background()
+
+	list := NewWordList("user-2", "Verbs", "Shared verbs")
+	list.AddWords("sw-2", "sw-1")
+	list.ShareToken = "token"
+	manger := word.NewWord("manger", "fr")
+	boire := word.NewWord("boire", "fr")
+
+	repo.On("FindByShareToken", ctx, "token").Return(list, nil)
+	savedWordRepo.On("FindByIDs", ctx, list.SavedWordIDs).Return([]*savedword.SavedWord{
+		{ID: "sw-1", UserID: "user-2", WordID: manger.ID},
+		{ID: "sw-2", UserID: "user-2", WordID: boire.ID},
+	}, nil)
+	wordRepo.On("FindByID", ctx, manger.ID).Return(manger, nil)
+	wordRepo.On("FindByID", ctx, boire.ID).Return(boire, nil)
+
+	// Execute
+	shared, err := svc.GetSharedList(ctx, "token")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Verbs", shared.Name)
+	assert.Equal(t, []*word.Word{boire, manger}, shared.Words)
+}
+
+func TestGetSharedList_UnknownToken(t *testing.T) {
+	// Setup
+	repo, _, _, svc := setupTestService(t)
+	ctx := context.Background()
+
+	repo.On("FindByShareToken", ctx, "nope").Return(nil, ErrListNotFound)
+
+	// Execute
+	shared, err := svc.GetSharedList(ctx, "nope")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrListNotFound)
+	assert.Nil(t, shared)
+}
+
+// MockPublisher is a mock implementation of the event.Publisher interface
+type MockPublisher struct {
+	mock.Mock
+}
+
+func (m *MockPublisher) Publish(ctx context.Context, events ...event.Event) error {
+	args := m.Called(ctx, events)
+	return args.Error(0)
+}
+
+// Emit publishes the events when the mock repository runs it
+func (m *MockPublisher) Emit(events ...event.Event) event.Emit {
+	return func(ctx context.Context, _ event.Outbox) error {
+		return m.Publish(ctx, events...)
+	}
+}
+
+func TestDuplicateSharedList(t *testing.T) {
+	// Setup
+	repo := new(MockRepository)
+	savedWordRepo := new(MockSavedWordRepository)
+	publisher := new(MockPublisher)
+	svc := NewService(repo, savedWordRepo, new(MockWordRepository), publisher, zerolog.New(zerolog.NewTestWriter(t)))
+	ctx := context.Background()
+
+	source := NewWordList("user-2", "Verbs", "")
+	source.AddWords("sw-a", "sw-b")
+	source.ShareToken = "token"
+	ownExisting := savedword.NewSavedWord("user-1", "word-a")
+
+	repo.On("FindByShareToken", ctx, "token").Return(source, nil)
+	savedWordRepo.On("FindByIDs", ctx, source.SavedWordIDs).Return([]*savedword.SavedWord{
+		{ID: "sw-a", UserID: "user-2", WordID: "word-a"},
+		{ID: "sw-b", UserID: "user-2", WordID: "word-b"},
+	}, nil)
+	repo.On("FindByUserAndName", ctx, "user-1", "Verbs").Return(NewWordList("user-1", "Verbs", ""), nil)
+	repo.On("FindByUserAndName", ctx, "user-1", "Verbs (copy)").Return(nil, ErrListNotFound)
+	savedWordRepo.On("FindByUserAndWord", ctx, "user-1", "word-a").Return(ownExisting, nil)
+	savedWordRepo.On("FindByUserAndWord", ctx, "user-1", "word-b").Return(nil, savedword.ErrSavedWordNotFound)
+	var added []*savedword.SavedWord
+	repo.On("CreateWithSavedWords", ctx, mock.AnythingOfType("*wordlist.WordList"), mock.MatchedBy(func(savedWords []*savedword.SavedWord) bool {
+		return len(savedWords) == 1 && savedWords[0].UserID == "user-1" && savedWords[0].WordID == "word-b"
+	})).Run(func(args mock.Arguments) {
+		added = args.Get(2).([]*savedword.SavedWord)
+	}).Return(nil)
+	publisher.On("Publish", ctx, mock.MatchedBy(func(events []event.Event) bool {
+		return len(events) == 1 && events[0].(savedword.SavedWordAdded).SavedWord.WordID == "word-b"
+	})).Return(nil)
+
+	// Execute
+	list, err := svc.DuplicateSharedList(ctx, "user-1", "token")
+
+	// Assert: the missing word is saved with the list, and its saving published
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", list.UserID)
+	assert.Equal(t, "Verbs (copy)", list.Name)
+	assert.Empty(t, list.ShareToken)
+	require.Len(t, list.SavedWordIDs, 2)
+	assert.Equal(t, ownExisting.ID, list.SavedWordIDs[0])
+	require.Len(t, added, 1)
+	assert.Equal(t, added[0].ID, list.SavedWordIDs[1])
+	repo.AssertExpectations(t)
+	savedWordRepo.AssertExpectations(t)
+	savedWordRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	publisher.AssertExpectations(t)
+}
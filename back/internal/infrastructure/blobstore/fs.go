@@ -0,0 +1,108 @@
+// Package blobstore implements snapshot.BlobStore backends.
+package blobstore
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"voconsteroid/internal/domain/snapshot"
+)
+
+// FS stores gzip-compressed blobs on the filesystem, at
+// <root>/<first two digest characters>/<digest>.gz
+type FS struct {
+	root string
+}
+
+// Ensure FS implements snapshot.BlobStore
+var _ snapshot.BlobStore = (*FS)(nil)
+
+// NewFS creates a filesystem blob store rooted at root, creating it if needed
+func NewFS(root string) (*FS, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	return &FS{root: root}, nil
+}
+
+// Put compresses and stores data unless a blob with its digest exists. The
+// blob is written to a temporary file first, so that readers never see a
+// partial one.
+func (s *FS) Put(_ context.Context, data []byte) (string, error) {
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+	path := s.path(digest)
+	if _, err := os.Stat(path); err == nil {
+		return digest, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), digest+".*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("failed to create blob file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+
+	zw := gzip.NewWriter(tmp)
+	if _, err := zw.Write(data); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to write blob: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to write blob: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to write blob: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", fmt.Errorf("failed to store blob: %w", err)
+	}
+
+	return digest, nil
+}
+
+// Get reads and decompresses a blob, checking it against its digest
+func (s *FS) Get(_ context.Context, digest string) ([]byte, error) {
+	if _, err := hex.DecodeString(digest); err != nil || len(digest) != sha256.Size*2 {
+		return nil, fmt.Errorf("invalid digest %q: %w", digest, snapshot.ErrBlobNotFound)
+	}
+
+	compressed, err := os.ReadFile(s.path(digest))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, snapshot.ErrBlobNotFound
+		}
+		return nil, fmt.Errorf("failed to read blob: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", snapshot.ErrCorruptBlob, err)
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", snapshot.ErrCorruptBlob, err)
+	}
+
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != digest {
+		return nil, snapshot.ErrCorruptBlob
+	}
+	return data, nil
+}
+
+// path returns the file of a digest
+func (s *FS) path(digest string) string {
+	return filepath.Join(s.root, digest[:2], digest+".gz")
+}
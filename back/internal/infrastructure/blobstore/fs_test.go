@@ -0,0 +1,66 @@
+package blobstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"voconsteroid/internal/domain/snapshot"
+)
+
+func TestFS_PutGet(t *testing.T) {
+	// Setup
+	store, err := NewFS(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+	page := []byte("<html><body>" + strings.Repeat("maison ", 1000) + "</body></html>")
+
+	// Execute
+	digest, err := store.Put(ctx, page)
+	require.NoError(t, err)
+	again, err := store.Put(ctx, page)
+	require.NoError(t, err)
+	data, err := store.Get(ctx, digest)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, digest, again)
+	assert.Len(t, digest, 64)
+	assert.Equal(t, page, data)
+
+	info, err := os.Stat(filepath.Join(store.root, digest[:2], digest+".gz"))
+	require.NoError(t, err)
+	assert.Less(t, info.Size(), int64(len(page)), "blob should be compressed")
+}
+
+func TestFS_Get_Errors(t *testing.T) {
+	store, err := NewFS(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := store.Get(ctx, strings.Repeat("ab", 32))
+		assert.ErrorIs(t, err, snapshot.ErrBlobNotFound)
+	})
+
+	t.Run("invalid digest", func(t *testing.T) {
+		_, err := store.Get(ctx, "../../etc/passwd")
+		assert.ErrorIs(t, err, snapshot.ErrBlobNotFound)
+	})
+
+	t.Run("corrupt", func(t *testing.T) {
+		digest, err := store.Put(ctx, []byte("maison"))
+		require.NoError(t, err)
+		other, err := store.Put(ctx, []byte("chat"))
+		require.NoError(t, err)
+		require.NoError(t, os.Rename(store.path(other), store.path(digest)))
+
+		_, err = store.Get(ctx, digest)
+		assert.ErrorIs(t, err, snapshot.ErrCorruptBlob)
+	})
+}
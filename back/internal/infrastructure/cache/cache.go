@@ -0,0 +1,118 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/word"
+)
+
+// TTLs sets how long each kind of lookup stays cached
+type TTLs struct {
+	Word        time.Duration // Words found by ID, text or form
+	NotFound    time.Duration // Lookups that found no word
+	Suggestions time.Duration // Prefix and suggestion lists from the repository
+	Fetch       time.Duration // Words and related words fetched from the dictionary
+	FetchPrefix time.Duration // Suggestions fetched from the dictionary
+}
+
+// DefaultTTLs returns the default TTLs. Repository entries are invalidated
+// on Save, so they can live long; negative entries stay short so that a
+// word missing because of a transient scraping failure is retried soon.
+func DefaultTTLs() TTLs {
+	return TTLs{
+		Word:        time.Hour,
+		NotFound:    5 * time.Minute,
+		Suggestions: 10 * time.Minute,
+		Fetch:       24 * time.Hour,
+		FetchPrefix: 6 * time.Hour,
+	}
+}
+
+// notFoundMarker is the cached value of a lookup that found no word. It is
+// not valid JSON, so it cannot collide with a cached value.
+var notFoundMarker = []byte{0, 'n', 'f'}
+
+// base holds what the decorators share
+type base struct {
+	store  Store
+	ttls   TTLs
+	logger zerolog.Logger
+}
+
+// cached returns the cached value of a key, or loads and caches it. A
+// word.ErrWordNotFound from load is cached as well, for the NotFound TTL.
+// Cache failures are logged and never fail the lookup.
+func cached[T any](ctx context.Context, b *base, key string, ttl time.Duration, load func() (T, error)) (T, error) {
+	data, err := b.store.Get(ctx, key)
+	switch {
+	case err == nil && bytes.Equal(data, notFoundMarker):
+		var zero T
+		return zero, word.ErrWordNotFound
+	case err == nil:
+		var value T
+		if err := json.Unmarshal(data, &value); err == nil {
+			return value, nil
+		}
+		b.logger.Warn().Str("key", key).Msg("Ignoring undecodable cache entry")
+	case !errors.Is(err, ErrMiss):
+		b.logger.Warn().Err(err).Str("key", key).Msg("Failed to read cache")
+	}
+
+	value, err := load()
+	if errors.Is(err, word.ErrWordNotFound) {
+		b.set(ctx, key, notFoundMarker, b.ttls.NotFound)
+		return value, err
+	}
+	if err != nil {
+		return value, err
+	}
+
+	if data, err := json.Marshal(value); err == nil {
+		b.set(ctx, key, data, ttl)
+	}
+	return value, nil
+}
+
+// set stores a value, logging failures
+func (b *base) set(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	if err := b.store.Set(ctx, key, value, ttl); err != nil {
+		b.logger.Warn().Err(err).Str("key", key).Msg("Failed to write cache")
+	}
+}
+
+// delete removes keys, logging failures
+func (b *base) delete(ctx context.Context, keys ...string) {
+	if err := b.store.Delete(ctx, keys...); err != nil {
+		b.logger.Warn().Err(err).Strs("keys", keys).Msg("Failed to invalidate cache")
+	}
+}
+
+// generation returns the current generation of a language's list entries.
+// List keys embed it, so that bumping it invalidates them all at once.
+func (b *base) generation(ctx context.Context, language string) string {
+	gen, err := b.store.Get(ctx, generationKey(language))
+	if err != nil {
+		if !errors.Is(err, ErrMiss) {
+			b.logger.Warn().Err(err).Str("language", language).Msg("Failed to read cache generation")
+		}
+		return "0"
+	}
+	return string(gen)
+}
+
+// bumpGeneration invalidates the list entries of a language
+func (b *base) bumpGeneration(ctx context.Context, language string) {
+	b.set(ctx, generationKey(language), []byte(uuid.New().String()), 0)
+}
+
+// generationKey returns the key of a language's list generation
+func generationKey(language string) string {
+	return "word:gen:" + language
+}
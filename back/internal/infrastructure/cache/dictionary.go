@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/word"
+)
+
+// DictionaryAPI caches the results of a word.DictionaryAPI, so that
+// repeated lookups do not scrape the same pages again
+type DictionaryAPI struct {
+	next word.DictionaryAPI
+	base
+}
+
+// Ensure DictionaryAPI implements word.DictionaryAPI
+var _ word.DictionaryAPI = (*DictionaryAPI)(nil)
+
+// NewDictionaryAPI creates a caching decorator around a dictionary API
+func NewDictionaryAPI(next word.DictionaryAPI, store Store, ttls TTLs, logger zerolog.Logger) *DictionaryAPI {
+	return &DictionaryAPI{
+		next: next,
+		base: base{
+			store:  store,
+			ttls:   ttls,
+			logger: logger.With().Str("component", "dictionary_cache").Logger(),
+		},
+	}
+}
+
+// FetchWord retrieves word information from the dictionary
+func (d *DictionaryAPI) FetchWord(ctx context.Context, text, language string) (*word.Word, error) {
+	key := "dict:word:" + language + ":" + text
+	return cached(ctx, &d.base, key, d.ttls.Fetch, func() (*word.Word, error) {
+		return d.next.FetchWord(ctx, text, language)
+	})
+}
+
+// FetchRelatedWords retrieves words related to the given word
+func (d *DictionaryAPI) FetchRelatedWords(ctx context.Context, w *word.Word) (*word.RelatedWords, error) {
+	key := "dict:related:" + w.Language + ":" + w.Text
+	return cached(ctx, &d.base, key, d.ttls.Fetch, func() (*word.RelatedWords, error) {
+		return d.next.FetchRelatedWords(ctx, w)
+	})
+}
+
+// FetchSuggestions retrieves suggestions for a given prefix and language
+func (d *DictionaryAPI) FetchSuggestions(ctx context.Context, prefix, language string) ([]string, error) {
+	key := "dict:suggest:" + language + ":" + prefix
+	return cached(ctx, &d.base, key, d.ttls.FetchPrefix, func() ([]string, error) {
+		return d.next.FetchSuggestions(ctx, prefix, language)
+	})
+}
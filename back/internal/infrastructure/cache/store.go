@@ -0,0 +1,159 @@
+// Package cache provides caching decorators for word lookups, backed by
+// Redis or an in-memory LRU.
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrMiss is returned by a Store when a key is absent or expired
+var ErrMiss = errors.New("cache miss")
+
+// Store is a cache backend holding byte values with a time to live
+type Store interface {
+	// Get returns the value of a key, or ErrMiss
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Set stores a value; a zero ttl keeps it until evicted
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes keys, ignoring missing ones
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// Ensure both backends implement Store
+var (
+	_ Store = (*MemoryStore)(nil)
+	_ Store = (*RedisStore)(nil)
+)
+
+// memoryEntry is a value of the in-memory store with its expiry
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time // Zero for no expiry
+}
+
+// MemoryStore is a Store keeping the most recently used entries in memory.
+// It is local to the process: replicas do not see each other's invalidations.
+type MemoryStore struct {
+	entries *lru.Cache[string, memoryEntry]
+	clock   func() time.Time
+}
+
+// NewMemoryStore creates an in-memory store holding up to size entries
+func NewMemoryStore(size int, clock func() time.Time) (*MemoryStore, error) {
+	if clock == nil {
+		clock = time.Now
+	}
+	entries, err := lru.New[string, memoryEntry](size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create LRU cache: %w", err)
+	}
+	return &MemoryStore{entries: entries, clock: clock}, nil
+}
+
+// Get returns the value of a key, or ErrMiss
+func (s *MemoryStore) Get(_ context.Context, key string) ([]byte, error) {
+	entry, ok := s.entries.Get(key)
+	if !ok {
+		return nil, ErrMiss
+	}
+	if !entry.expiresAt.IsZero() && !s.clock().Before(entry.expiresAt) {
+		s.entries.Remove(key)
+		return nil, ErrMiss
+	}
+	return entry.value, nil
+}
+
+// Set stores a value; a zero ttl keeps it until evicted
+func (s *MemoryStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	entry := memoryEntry{value: value}
+	if ttl > 0 {
+		entry.expiresAt = s.clock().Add(ttl)
+	}
+	s.entries.Add(key, entry)
+	return nil
+}
+
+// Delete removes keys, ignoring missing ones
+func (s *MemoryStore) Delete(_ context.Context, keys ...string) error {
+	for _, key := range keys {
+		s.entries.Remove(key)
+	}
+	return nil
+}
+
+// Len returns the number of entries, expired ones included
+func (s *MemoryStore) Len() int {
+	return s.entries.Len()
+}
+
+// RedisStore is a Store backed by Redis, shared by every replica
+type RedisStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisStore creates a store prefixing every key, so that the Redis
+// database can be shared with other uses
+func NewRedisStore(client redis.UniversalClient, prefix string) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+// NewRedisClient connects to the Redis server of a redis:// URL and checks
+// that it answers
+func NewRedisClient(ctx context.Context, url string) (*redis.Client, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+
+	client := redis.NewClient(opts)
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to ping Redis: %w", err)
+	}
+
+	return client, nil
+}
+
+// Get returns the value of a key, or ErrMiss
+func (s *RedisStore) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := s.client.Get(ctx, s.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrMiss
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", key, err)
+	}
+	return value, nil
+}
+
+// Set stores a value; a zero ttl keeps it until evicted
+func (s *RedisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := s.client.Set(ctx, s.prefix+key, value, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to set %s: %w", key, err)
+	}
+	return nil
+}
+
+// Delete removes keys, ignoring missing ones
+func (s *RedisStore) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = s.prefix + key
+	}
+	if err := s.client.Del(ctx, prefixed...).Err(); err != nil {
+		return fmt.Errorf("failed to delete keys: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRedisStore creates a store backed by an in-process Redis server
+func newTestRedisStore(t *testing.T) (*miniredis.Miniredis, *RedisStore) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return server, NewRedisStore(client, "test:")
+}
+
+func TestStores(t *testing.T) {
+	ctx := context.Background()
+	memory, err := NewMemoryStore(10, nil)
+	require.NoError(t, err)
+	_, redisStore := newTestRedisStore(t)
+
+	for name, store := range map[string]Store{"memory": memory, "redis": redisStore} {
+		t.Run(name, func(t *testing.T) {
+			// Miss
+			_, err := store.Get(ctx, "k")
+			assert.ErrorIs(t, err, ErrMiss)
+
+			// Set and get
+			require.NoError(t, store.Set(ctx, "k", []byte("v"), time.Minute))
+			value, err := store.Get(ctx, "k")
+			require.NoError(t, err)
+			assert.Equal(t, []byte("v"), value)
+
+			// Delete, missing keys included
+			require.NoError(t, store.Delete(ctx, "k", "missing"))
+			_, err = store.Get(ctx, "k")
+			assert.ErrorIs(t, err, ErrMiss)
+		})
+	}
+}
+
+func TestMemoryStore_Expiry(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	store, err := NewMemoryStore(10, func() time.Time { return now })
+	require.NoError(t, err)
+	require.NoError(t, store.Set(ctx, "short", []byte("v"), time.Minute))
+	require.NoError(t, store.Set(ctx, "forever", []byte("v"), 0))
+
+	// Execute
+	now = now.Add(time.Minute)
+	_, errShort := store.Get(ctx, "short")
+	_, errForever := store.Get(ctx, "forever")
+
+	// Assert
+	assert.ErrorIs(t, errShort, ErrMiss)
+	assert.NoError(t, errForever)
+}
+
+func TestMemoryStore_EvictsLeastRecentlyUsed(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	store, err := NewMemoryStore(2, nil)
+	require.NoError(t, err)
+	require.NoError(t, store.Set(ctx, "a", []byte("1"), 0))
+	require.NoError(t, store.Set(ctx, "b", []byte("2"), 0))
+	_, _ = store.Get(ctx, "a")
+
+	// Execute
+	require.NoError(t, store.Set(ctx, "c", []byte("3"), 0))
+
+	// Assert
+	_, errA := store.Get(ctx, "a")
+	_, errB := store.Get(ctx, "b")
+	assert.NoError(t, errA)
+	assert.ErrorIs(t, errB, ErrMiss)
+	assert.Equal(t, 2, store.Len())
+}
+
+func TestRedisStore_PrefixAndTTL(t *testing.T) {
+	// Setup
+	server, store := newTestRedisStore(t)
+
+	// Execute
+	require.NoError(t, store.Set(context.Background(), "k", []byte("v"), time.Minute))
+
+	// Assert
+	assert.True(t, server.Exists("test:k"))
+	assert.Equal(t, time.Minute, server.TTL("test:k"))
+	server.FastForward(time.Minute)
+	_, err := store.Get(context.Background(), "k")
+	assert.ErrorIs(t, err, ErrMiss)
+}
@@ -0,0 +1,225 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/event"
+	"voconsteroid/internal/domain/word"
+)
+
+// WordRepository caches the lookups of a word.Repository. Saving a word
+// invalidates its entries, including negative ones, and every prefix and
+// suggestion list of its language. List is not cached.
+type WordRepository struct {
+	next word.Repository
+	base
+}
+
+// Ensure WordRepository implements word.Repository
+var _ word.Repository = (*WordRepository)(nil)
+
+// NewWordRepository creates a caching decorator around a word repository
+func NewWordRepository(next word.Repository, store Store, ttls TTLs, logger zerolog.Logger) *WordRepository {
+	return &WordRepository{
+		next: next,
+		base: base{
+			store:  store,
+			ttls:   ttls,
+			logger: logger.With().Str("component", "word_repository_cache").Logger(),
+		},
+	}
+}
+
+// FindByID retrieves a word by its ID
+func (r *WordRepository) FindByID(ctx context.Context, id string) (*word.Word, error) {
+	return cached(ctx, &r.base, idKey(id), r.ttls.Word, func() (*word.Word, error) {
+		return r.next.FindByID(ctx, id)
+	})
+}
+
+// FindByText retrieves a word by its text and language
+func (r *WordRepository) FindByText(ctx context.Context, text, language string) (*word.Word, error) {
+	return cached(ctx, &r.base, textKey(text, language), r.ttls.Word, func() (*word.Word, error) {
+		return r.next.FindByText(ctx, text, language)
+	})
+}
+
+// FindByAnyForm retrieves a word by any of its forms
+func (r *WordRepository) FindByAnyForm(ctx context.Context, text, language string) (*word.Word, error) {
+	return cached(ctx, &r.base, formKey(text, language), r.ttls.Word, func() (*word.Word, error) {
+		return r.next.FindByAnyForm(ctx, text, language)
+	})
+}
+
+// Save stores a word and invalidates its cache entries
+func (r *WordRepository) Save(ctx context.Context, w *word.Word, emit event.Emit) error {
+	if err := r.next.Save(ctx, w, emit); err != nil {
+		return err
+	}
+
+	r.invalidate(ctx, w)
+	return nil
+}
+
+// invalidate removes the entries of a word and every prefix and suggestion
+// list of its language
+func (r *WordRepository) invalidate(ctx context.Context, w *word.Word) {
+	keys := []string{idKey(w.ID), textKey(w.Text, w.Language), formKey(w.Text, w.Language)}
+	for _, term := range w.SearchTerms {
+		keys = append(keys, textKey(term, w.Language), formKey(term, w.Language))
+	}
+	r.delete(ctx, keys...)
+	r.bumpGeneration(ctx, w.Language)
+}
+
+// List retrieves words with optional filtering and sorting, uncached
+func (r *WordRepository) List(ctx context.Context, filter map[string]interface{}, limit, offset int) ([]*word.Word, error) {
+	return r.next.List(ctx, filter, limit, offset)
+}
+
+// FindByPrefix retrieves words by prefix and language
+func (r *WordRepository) FindByPrefix(ctx context.Context, prefix, language string, limit int) ([]*word.Word, error) {
+	key := "word:prefix:" + language + ":" + r.generation(ctx, language) + ":" + strconv.Itoa(limit) + ":" + prefix
+	return cached(ctx, &r.base, key, r.ttls.Suggestions, func() ([]*word.Word, error) {
+		return r.next.FindByPrefix(ctx, prefix, language, limit)
+	})
+}
+
+// FindSuggestions retrieves word suggestions based on a prefix
+func (r *WordRepository) FindSuggestions(ctx context.Context, prefix, language string, limit int) ([]string, error) {
+	key := "word:suggest:" + language + ":" + r.generation(ctx, language) + ":" + strconv.Itoa(limit) + ":" + prefix
+	return cached(ctx, &r.base, key, r.ttls.Suggestions, func() ([]string, error) {
+		return r.next.FindSuggestions(ctx, prefix, language, limit)
+	})
+}
+
+// MaintenanceRepository is a WordRepository around a
+// word.MaintenanceRepository, which also invalidates the entries of deleted
+// and merged words. ListBatch and Stats are not cached.
+type MaintenanceRepository struct {
+	*WordRepository
+	next word.MaintenanceRepository
+}
+
+// Ensure MaintenanceRepository implements word.MaintenanceRepository
+var _ word.MaintenanceRepository = (*MaintenanceRepository)(nil)
+
+// NewMaintenanceRepository creates a caching decorator around a maintenance repository
+func NewMaintenanceRepository(next word.MaintenanceRepository, store Store, ttls TTLs, logger zerolog.Logger) *MaintenanceRepository {
+	return &MaintenanceRepository{
+		WordRepository: NewWordRepository(next, store, ttls, logger),
+		next:           next,
+	}
+}
+
+// ListBatch retrieves a batch of words in ID order, uncached
+func (r *MaintenanceRepository) ListBatch(ctx context.Context, afterID string, updatedBefore time.Time, limit int) ([]*word.Word, error) {
+	return r.next.ListBatch(ctx, afterID, updatedBefore, limit)
+}
+
+// Delete removes a word and invalidates its cache entries. The word is read
+// first, uncached, to find the entries of its forms.
+func (r *MaintenanceRepository) Delete(ctx context.Context, id string) error {
+	w, err := r.next.FindByID(ctx, id)
+	if err != nil && !errors.Is(err, word.ErrWordNotFound) {
+		return err
+	}
+
+	if err := r.next.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	if w != nil {
+		r.invalidate(ctx, w)
+	} else {
+		r.delete(ctx, idKey(id))
+	}
+	return nil
+}
+
+// Merge merges source into target and invalidates the entries of both
+func (r *MaintenanceRepository) Merge(ctx context.Context, source, target *word.Word) error {
+	if err := r.next.Merge(ctx, source, target); err != nil {
+		return err
+	}
+
+	r.invalidate(ctx, source)
+	r.invalidate(ctx, target)
+	return nil
+}
+
+// Stats summarizes the stored words, uncached
+func (r *MaintenanceRepository) Stats(ctx context.Context) (*word.TableStats, error) {
+	return r.next.Stats(ctx)
+}
+
+// ScoringRepository is a word.Repository that also stores the difficulty
+// of words
+type ScoringRepository interface {
+	word.Repository
+	word.DifficultyRepository
+}
+
+// DifficultyRepository is a WordRepository around a ScoringRepository,
+// which also invalidates the entries of the words it scores. ListUnscored is
+// not cached.
+type DifficultyRepository struct {
+	*WordRepository
+	next ScoringRepository
+}
+
+// Ensure DifficultyRepository implements word.DifficultyRepository
+var _ word.DifficultyRepository = (*DifficultyRepository)(nil)
+
+// NewDifficultyRepository creates a caching decorator around a scoring repository
+func NewDifficultyRepository(next ScoringRepository, store Store, ttls TTLs, logger zerolog.Logger) *DifficultyRepository {
+	return &DifficultyRepository{
+		WordRepository: NewWordRepository(next, store, ttls, logger),
+		next:           next,
+	}
+}
+
+// ListUnscored retrieves a batch of words without difficulty, uncached
+func (r *DifficultyRepository) ListUnscored(ctx context.Context, afterID string, limit int) ([]*word.Word, error) {
+	return r.next.ListUnscored(ctx, afterID, limit)
+}
+
+// UpdateDifficulty stores the difficulty of a word and invalidates its cache
+// entries. The word is read first, uncached, to find the entries of its forms.
+func (r *DifficultyRepository) UpdateDifficulty(ctx context.Context, id string, rank int, difficulty word.Difficulty) error {
+	w, err := r.next.FindByID(ctx, id)
+	if err != nil && !errors.Is(err, word.ErrWordNotFound) {
+		return err
+	}
+
+	if err := r.next.UpdateDifficulty(ctx, id, rank, difficulty); err != nil {
+		return err
+	}
+
+	if w != nil {
+		r.invalidate(ctx, w)
+	} else {
+		r.delete(ctx, idKey(id))
+	}
+	return nil
+}
+
+// idKey returns the key of a word looked up by ID
+func idKey(id string) string {
+	return "word:id:" + id
+}
+
+// textKey returns the key of a word looked up by text
+func textKey(text, language string) string {
+	return "word:text:" + language + ":" + text
+}
+
+// formKey returns the key of a word looked up by any form
+func formKey(text, language string) string {
+	return "word:form:" + language + ":" + text
+}
@@ -0,0 +1,380 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"voconsteroid/internal/domain/event"
+	"voconsteroid/internal/domain/word"
+)
+
+// MockWordRepository is a mock implementation of the word.MaintenanceRepository
+// and word.DifficultyRepository interfaces
+type MockWordRepository struct {
+	mock.Mock
+}
+
+func (m *MockWordRepository) FindByID(ctx context.Context, id string) (*word.Word, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) FindByText(ctx context.Context, text, language string) (*word.Word, error) {
+	args := m.Called(ctx, text, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) FindByAnyForm(ctx context.Context, text, language string) (*word.Word, error) {
+	args := m.Called(ctx, text, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) Save(ctx context.Context, w *word.Word, emit event.Emit) error {
+	args := m.Called(ctx, w)
+	return args.Error(0)
+}
+
+func (m *MockWordRepository) List(ctx context.Context, filter map[string]interface{}, limit, offset int) ([]*word.Word, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) FindByPrefix(ctx context.Context, prefix, language string, limit int) ([]*word.Word, error) {
+	args := m.Called(ctx, prefix, language, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) FindSuggestions(ctx context.Context, prefix, language string, limit int) ([]string, error) {
+	args := m.Called(ctx, prefix, language, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockWordRepository) ListBatch(ctx context.Context, afterID string, updatedBefore time.Time, limit int) ([]*word.Word, error) {
+	args := m.Called(ctx, afterID, updatedBefore, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockWordRepository) Merge(ctx context.Context, source, target *word.Word) error {
+	args := m.Called(ctx, source, target)
+	return args.Error(0)
+}
+
+func (m *MockWordRepository) Stats(ctx context.Context) (*word.TableStats, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.TableStats), args.Error(1)
+}
+
+func (m *MockWordRepository) ListUnscored(ctx context.Context, afterID string, limit int) ([]*word.Word, error) {
+	args := m.Called(ctx, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*word.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) UpdateDifficulty(ctx context.Context, id string, rank int, difficulty word.Difficulty) error {
+	args := m.Called(ctx, id, rank, difficulty)
+	return args.Error(0)
+}
+
+// MockDictionaryAPI is a mock implementation of the word.DictionaryAPI interface
+type MockDictionaryAPI struct {
+	mock.Mock
+}
+
+func (m *MockDictionaryAPI) FetchWord(ctx context.Context, text, language string) (*word.Word, error) {
+	args := m.Called(ctx, text, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.Word), args.Error(1)
+}
+
+func (m *MockDictionaryAPI) FetchRelatedWords(ctx context.Context, w *word.Word) (*word.RelatedWords, error) {
+	args := m.Called(ctx, w)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.RelatedWords), args.Error(1)
+}
+
+func (m *MockDictionaryAPI) FetchSuggestions(ctx context.Context, prefix, language string) ([]string, error) {
+	args := m.Called(ctx, prefix, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// setupTestRepository creates a caching repository backed by miniredis
+func setupTestRepository(t *testing.T) (*MockWordRepository, *WordRepository) {
+	_, store := newTestRedisStore(t)
+	next := new(MockWordRepository)
+	return next, NewWordRepository(next, store, DefaultTTLs(), zerolog.New(zerolog.NewTestWriter(t)))
+}
+
+func TestWordRepository_FindByText(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("caches hits", func(t *testing.T) {
+		// Setup
+		next, repo := setupTestRepository(t)
+		maison := word.NewWord("maison", "fr")
+		maison.Provenance = &word.Provenance{Source: "frwiktionary", ParserVersion: 2}
+		next.On("FindByText", ctx, "maison", "fr").Return(maison, nil).Once()
+
+		// Execute
+		first, err1 := repo.FindByText(ctx, "maison", "fr")
+		second, err2 := repo.FindByText(ctx, "maison", "fr")
+
+		// Assert
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		assert.Equal(t, maison.ID, first.ID)
+		assert.Equal(t, maison.ID, second.ID)
+		assert.Equal(t, maison.SearchTerms, second.SearchTerms)
+		assert.Equal(t, maison.Provenance, second.Provenance, "the provenance is cached along with the word")
+		next.AssertExpectations(t)
+	})
+
+	t.Run("caches not found", func(t *testing.T) {
+		// Setup
+		next, repo := setupTestRepository(t)
+		next.On("FindByText", ctx, "chat", "fr").Return(nil, word.ErrWordNotFound).Once()
+
+		// Execute
+		_, err1 := repo.FindByText(ctx, "chat", "fr")
+		_, err2 := repo.FindByText(ctx, "chat", "fr")
+
+		// Assert
+		assert.ErrorIs(t, err1, word.ErrWordNotFound)
+		assert.ErrorIs(t, err2, word.ErrWordNotFound)
+		next.AssertExpectations(t)
+	})
+
+	t.Run("does not cache errors", func(t *testing.T) {
+		// Setup
+		next, repo := setupTestRepository(t)
+		next.On("FindByText", ctx, "chat", "fr").Return(nil, errors.New("db down")).Twice()
+
+		// Execute
+		_, err1 := repo.FindByText(ctx, "chat", "fr")
+		_, err2 := repo.FindByText(ctx, "chat", "fr")
+
+		// Assert
+		assert.Error(t, err1)
+		assert.Error(t, err2)
+		next.AssertExpectations(t)
+	})
+}
+
+func TestWordRepository_SaveInvalidates(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	next, repo := setupTestRepository(t)
+	chat := word.NewWord("chat", "fr")
+	chat.SearchTerms = []string{"chat", "chats"}
+
+	next.On("FindByText", ctx, "chat", "fr").Return(nil, word.ErrWordNotFound).Once()
+	next.On("FindByAnyForm", ctx, "chats", "fr").Return(nil, word.ErrWordNotFound).Once()
+	next.On("FindSuggestions", ctx, "ch", "fr", 10).Return([]string{}, nil).Once()
+	_, _ = repo.FindByText(ctx, "chat", "fr")
+	_, _ = repo.FindByAnyForm(ctx, "chats", "fr")
+	_, _ = repo.FindSuggestions(ctx, "ch", "fr", 10)
+
+	next.On("Save", ctx, chat).Return(nil)
+	next.On("FindByText", ctx, "chat", "fr").Return(chat, nil).Once()
+	next.On("FindByAnyForm", ctx, "chats", "fr").Return(chat, nil).Once()
+	next.On("FindSuggestions", ctx, "ch", "fr", 10).Return([]string{"chat"}, nil).Once()
+
+	// Execute
+	require.NoError(t, repo.Save(ctx, chat, nil))
+	byText, errText := repo.FindByText(ctx, "chat", "fr")
+	byForm, errForm := repo.FindByAnyForm(ctx, "chats", "fr")
+	suggestions, errSuggestions := repo.FindSuggestions(ctx, "ch", "fr", 10)
+
+	// Assert
+	require.NoError(t, errText)
+	require.NoError(t, errForm)
+	require.NoError(t, errSuggestions)
+	assert.Equal(t, chat.ID, byText.ID)
+	assert.Equal(t, chat.ID, byForm.ID)
+	assert.Equal(t, []string{"chat"}, suggestions)
+	next.AssertExpectations(t)
+}
+
+func TestWordRepository_SaveErrorKeepsEntries(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	next, repo := setupTestRepository(t)
+	chat := word.NewWord("chat", "fr")
+	next.On("FindByID", ctx, chat.ID).Return(chat, nil).Once()
+	next.On("Save", ctx, chat).Return(errors.New("db down"))
+	_, _ = repo.FindByID(ctx, chat.ID)
+
+	// Execute
+	err := repo.Save(ctx, chat, nil)
+	_, errFind := repo.FindByID(ctx, chat.ID)
+
+	// Assert
+	assert.Error(t, err)
+	assert.NoError(t, errFind)
+	next.AssertExpectations(t)
+}
+
+// setupTestMaintenanceRepository creates a caching maintenance repository backed by miniredis
+func setupTestMaintenanceRepository(t *testing.T) (*MockWordRepository, *MaintenanceRepository) {
+	_, store := newTestRedisStore(t)
+	next := new(MockWordRepository)
+	return next, NewMaintenanceRepository(next, store, DefaultTTLs(), zerolog.New(zerolog.NewTestWriter(t)))
+}
+
+func TestMaintenanceRepository_DeleteInvalidates(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	next, repo := setupTestMaintenanceRepository(t)
+	chat := word.NewWord("chat", "fr")
+	chat.SearchTerms = []string{"chat", "chats"}
+
+	next.On("FindByID", ctx, chat.ID).Return(chat, nil)
+	next.On("FindByAnyForm", ctx, "chats", "fr").Return(chat, nil).Once()
+	_, _ = repo.FindByID(ctx, chat.ID)
+	_, _ = repo.FindByAnyForm(ctx, "chats", "fr")
+
+	next.On("Delete", ctx, chat.ID).Return(nil)
+	next.On("FindByAnyForm", ctx, "chats", "fr").Return(nil, word.ErrWordNotFound).Once()
+
+	// Execute
+	require.NoError(t, repo.Delete(ctx, chat.ID))
+	_, errForm := repo.FindByAnyForm(ctx, "chats", "fr")
+
+	// Assert
+	assert.ErrorIs(t, errForm, word.ErrWordNotFound)
+	next.AssertExpectations(t)
+}
+
+func TestMaintenanceRepository_MergeInvalidates(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	next, repo := setupTestMaintenanceRepository(t)
+	source := word.NewWord("chats", "fr")
+	target := word.NewWord("chat", "fr")
+
+	next.On("FindByText", ctx, "chats", "fr").Return(source, nil).Once()
+	next.On("FindByText", ctx, "chat", "fr").Return(target, nil).Once()
+	_, _ = repo.FindByText(ctx, "chats", "fr")
+	_, _ = repo.FindByText(ctx, "chat", "fr")
+
+	merged := *target
+	merged.SearchTerms = []string{"chat", "chats"}
+	next.On("Merge", ctx, source, &merged).Return(nil)
+	next.On("FindByText", ctx, "chats", "fr").Return(nil, word.ErrWordNotFound).Once()
+	next.On("FindByText", ctx, "chat", "fr").Return(&merged, nil).Once()
+
+	// Execute
+	require.NoError(t, repo.Merge(ctx, source, &merged))
+	_, errSource := repo.FindByText(ctx, "chats", "fr")
+	byText, errTarget := repo.FindByText(ctx, "chat", "fr")
+
+	// Assert
+	assert.ErrorIs(t, errSource, word.ErrWordNotFound)
+	require.NoError(t, errTarget)
+	assert.Equal(t, merged.SearchTerms, byText.SearchTerms)
+	next.AssertExpectations(t)
+}
+
+func TestDifficultyRepository_UpdateDifficultyInvalidates(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	_, store := newTestRedisStore(t)
+	next := new(MockWordRepository)
+	repo := NewDifficultyRepository(next, store, DefaultTTLs(), zerolog.New(zerolog.NewTestWriter(t)))
+	chat := word.NewWord("chat", "fr")
+	chat.SearchTerms = []string{"chat", "chats"}
+	scored := *chat
+	scored.FrequencyRank = 812
+	scored.Difficulty = word.DifficultyA2
+
+	next.On("FindByAnyForm", ctx, "chats", "fr").Return(chat, nil).Once()
+	_, _ = repo.FindByAnyForm(ctx, "chats", "fr")
+
+	next.On("FindByID", ctx, chat.ID).Return(chat, nil).Once()
+	next.On("UpdateDifficulty", ctx, chat.ID, 812, word.DifficultyA2).Return(nil)
+	next.On("FindByAnyForm", ctx, "chats", "fr").Return(&scored, nil).Once()
+
+	// Execute
+	require.NoError(t, repo.UpdateDifficulty(ctx, chat.ID, 812, word.DifficultyA2))
+	found, err := repo.FindByAnyForm(ctx, "chats", "fr")
+
+	// Assert: the form is read again with its difficulty
+	require.NoError(t, err)
+	assert.Equal(t, word.DifficultyA2, found.Difficulty)
+	next.AssertExpectations(t)
+}
+
+func TestDictionaryAPI(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	store, err := NewMemoryStore(100, nil)
+	require.NoError(t, err)
+	next := new(MockDictionaryAPI)
+	api := NewDictionaryAPI(next, store, DefaultTTLs(), zerolog.New(zerolog.NewTestWriter(t)))
+
+	maison := word.NewWord("maison", "fr")
+	next.On("FetchWord", ctx, "maison", "fr").Return(maison, nil).Once()
+	next.On("FetchWord", ctx, "zzzz", "fr").Return(nil, word.ErrWordNotFound).Once()
+	next.On("FetchSuggestions", ctx, "mai", "fr").Return([]string{"maison", "maire"}, nil).Once()
+
+	// Execute
+	for i := 0; i < 2; i++ {
+		fetched, err := api.FetchWord(ctx, "maison", "fr")
+		require.NoError(t, err)
+		assert.Equal(t, maison.ID, fetched.ID)
+
+		_, err = api.FetchWord(ctx, "zzzz", "fr")
+		assert.ErrorIs(t, err, word.ErrWordNotFound)
+
+		suggestions, err := api.FetchSuggestions(ctx, "mai", "fr")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"maison", "maire"}, suggestions)
+	}
+
+	// Assert
+	next.AssertExpectations(t)
+}
@@ -0,0 +1,83 @@
+package coalesce
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	languageDomain "voconsteroid/internal/domain/language"
+	"voconsteroid/internal/domain/word"
+)
+
+// DictionaryAPI coalesces concurrent fetches of the same word, related
+// words or suggestions made through a word.DictionaryAPI. Placed in front
+// of a shared cache, replicas waiting for each other are answered by it.
+type DictionaryAPI struct {
+	next  word.DictionaryAPI
+	group *Group
+}
+
+// Ensure DictionaryAPI implements word.DictionaryAPI
+var _ word.DictionaryAPI = (*DictionaryAPI)(nil)
+
+// NewDictionaryAPI creates a coalescing decorator around a dictionary API
+func NewDictionaryAPI(next word.DictionaryAPI, group *Group) *DictionaryAPI {
+	return &DictionaryAPI{next: next, group: group}
+}
+
+// FetchWord retrieves word information from the dictionary. The text is
+// normalized, so that every caller sharing a fetch gets the same answer.
+func (d *DictionaryAPI) FetchWord(ctx context.Context, text, language string) (*word.Word, error) {
+	text = normalize(text, language)
+	return do(ctx, d.group, "dict:word:"+language+":"+text, func(ctx context.Context) (*word.Word, error) {
+		return d.next.FetchWord(ctx, text, language)
+	})
+}
+
+// FetchRelatedWords retrieves words related to the given word
+func (d *DictionaryAPI) FetchRelatedWords(ctx context.Context, w *word.Word) (*word.RelatedWords, error) {
+	return do(ctx, d.group, "dict:related:"+w.Language+":"+normalize(w.Text, w.Language), func(ctx context.Context) (*word.RelatedWords, error) {
+		return d.next.FetchRelatedWords(ctx, w)
+	})
+}
+
+// FetchSuggestions retrieves suggestions for a given prefix and language.
+// The prefix is normalized like the text of FetchWord.
+func (d *DictionaryAPI) FetchSuggestions(ctx context.Context, prefix, language string) ([]string, error) {
+	prefix = normalize(prefix, language)
+	return do(ctx, d.group, "dict:suggest:"+language+":"+prefix, func(ctx context.Context) ([]string, error) {
+		return d.next.FetchSuggestions(ctx, prefix, language)
+	})
+}
+
+// normalize normalizes a text by the rules of its language, as the word
+// service does
+func normalize(text, language string) string {
+	return languageDomain.Default.Normalize(language, text)
+}
+
+// do runs fn through the group. A result shared between callers is copied
+// for each of them, as callers such as the word service modify it.
+func do[T any](ctx context.Context, group *Group, key string, fn func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+	v, shared, err := group.Do(ctx, key, func(ctx context.Context) (any, error) {
+		return fn(ctx)
+	})
+	if err != nil {
+		return zero, err
+	}
+	value := v.(T)
+	if !shared {
+		return value, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return zero, fmt.Errorf("failed to copy shared result: %w", err)
+	}
+	var copied T
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return zero, fmt.Errorf("failed to copy shared result: %w", err)
+	}
+	return copied, nil
+}
@@ -0,0 +1,114 @@
+package coalesce
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"voconsteroid/internal/domain/word"
+)
+
+// MockDictionaryAPI is a mock implementation of the word.DictionaryAPI interface
+type MockDictionaryAPI struct {
+	mock.Mock
+}
+
+func (m *MockDictionaryAPI) FetchWord(ctx context.Context, text, language string) (*word.Word, error) {
+	args := m.Called(ctx, text, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.Word), args.Error(1)
+}
+
+func (m *MockDictionaryAPI) FetchRelatedWords(ctx context.Context, w *word.Word) (*word.RelatedWords, error) {
+	args := m.Called(ctx, w)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.RelatedWords), args.Error(1)
+}
+
+func (m *MockDictionaryAPI) FetchSuggestions(ctx context.Context, prefix, language string) ([]string, error) {
+	args := m.Called(ctx, prefix, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func TestDictionaryAPI_FetchWord(t *testing.T) {
+	// Setup
+	group := NewGroup(nil, testConfig(), zerolog.New(zerolog.NewTestWriter(t)))
+	next := new(MockDictionaryAPI)
+	api := NewDictionaryAPI(next, group)
+	maison := word.NewWord("maison", "fr")
+	maison.Provenance = &word.Provenance{Source: "frwiktionary", ParserVersion: 2}
+	release := make(chan struct{})
+	next.On("FetchWord", mock.Anything, "maison", "fr").
+		Run(func(mock.Arguments) { <-release }).
+		Return(maison, nil).
+		Once()
+
+	// Execute
+	const callers = 5
+	results := make([]*word.Word, callers)
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		text := "maison"
+		if i > 0 {
+			text = " Maison " // Normalized to the same key
+		}
+		go func(i int, text string) {
+			defer wg.Done()
+			w, err := api.FetchWord(context.Background(), text, "fr")
+			assert.NoError(t, err)
+			results[i] = w
+		}(i, text)
+	}
+	require.Eventually(t, func() bool { return group.Stats().Calls == callers }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	// Assert
+	next.AssertExpectations(t)
+	for i, w := range results {
+		require.NotNil(t, w)
+		assert.Equal(t, maison.ID, w.ID)
+		assert.Equal(t, maison.Provenance, w.Provenance)
+		for _, other := range results[:i] {
+			assert.NotSame(t, other, w, "shared results are copied for each caller")
+		}
+	}
+	assert.Equal(t, int64(callers-1), group.Stats().Saved)
+}
+
+func TestDictionaryAPI_KeysByKindAndLanguage(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	group := NewGroup(nil, testConfig(), zerolog.New(zerolog.NewTestWriter(t)))
+	next := new(MockDictionaryAPI)
+	api := NewDictionaryAPI(next, group)
+	next.On("FetchWord", mock.Anything, "chat", "fr").Return(word.NewWord("chat", "fr"), nil).Once()
+	next.On("FetchWord", mock.Anything, "chat", "en").Return(nil, word.ErrWordNotFound).Once()
+	next.On("FetchSuggestions", mock.Anything, "chat", "fr").Return([]string{"chaton"}, nil).Once()
+
+	// Execute
+	_, errFr := api.FetchWord(ctx, "chat", "fr")
+	_, errEn := api.FetchWord(ctx, "chat", "en")
+	suggestions, errSuggestions := api.FetchSuggestions(ctx, "chat", "fr")
+
+	// Assert
+	assert.NoError(t, errFr)
+	assert.ErrorIs(t, errEn, word.ErrWordNotFound)
+	assert.NoError(t, errSuggestions)
+	assert.Equal(t, []string{"chaton"}, suggestions)
+	next.AssertExpectations(t)
+}
@@ -0,0 +1,128 @@
+// Package coalesce deduplicates concurrent calls doing the same work, within
+// the process and optionally across replicas through a Redis lock.
+package coalesce
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/singleflight"
+)
+
+// Locker takes short-lived locks shared between processes
+type Locker interface {
+	// TryLock takes the lock on a key for at most ttl. It returns a function
+	// releasing it, or ok false when another process holds the lock.
+	TryLock(ctx context.Context, key string, ttl time.Duration) (release func(), ok bool, err error)
+}
+
+// Config tunes how long a call waits for another replica doing the same work
+type Config struct {
+	LockTTL      time.Duration // Longest a replica holds a lock, and a caller waits for it
+	PollInterval time.Duration // Delay between attempts to take a held lock
+}
+
+// DefaultConfig returns the default configuration, sized for a dictionary scrape
+func DefaultConfig() Config {
+	return Config{
+		LockTTL:      30 * time.Second,
+		PollInterval: 100 * time.Millisecond,
+	}
+}
+
+// Stats counts the calls made through a Group
+type Stats struct {
+	Calls    int64 `json:"calls"`    // Calls made by callers
+	Executed int64 `json:"executed"` // Calls actually run
+	Saved    int64 `json:"saved"`    // Calls answered by a call already in flight
+	Waited   int64 `json:"waited"`   // Executed calls that first waited for another replica
+}
+
+// Group runs at most one call per key at a time, sharing its result with
+// the callers asking for the same key meanwhile. With a Locker, a call
+// whose key is locked by another replica waits for it to finish before
+// running, so that it can be answered by a shared cache.
+type Group struct {
+	flights singleflight.Group
+	locker  Locker
+	cfg     Config
+	logger  zerolog.Logger
+
+	calls    atomic.Int64
+	executed atomic.Int64
+	waited   atomic.Int64
+}
+
+// NewGroup creates a group; a nil locker coalesces within the process only
+func NewGroup(locker Locker, cfg Config, logger zerolog.Logger) *Group {
+	return &Group{
+		locker: locker,
+		cfg:    cfg,
+		logger: logger.With().Str("component", "coalesce").Logger(),
+	}
+}
+
+// Do runs fn, or waits for the call already running for key and returns
+// its result. shared reports whether the result went to several callers,
+// which must then not modify it. The call outlives the cancellation of the
+// caller that started it, as other callers may still wait for it.
+func (g *Group) Do(ctx context.Context, key string, fn func(ctx context.Context) (any, error)) (v any, shared bool, err error) {
+	g.calls.Add(1)
+	runCtx := context.WithoutCancel(ctx)
+	result := g.flights.DoChan(key, func() (any, error) {
+		g.executed.Add(1)
+		return g.run(runCtx, key, fn)
+	})
+
+	select {
+	case r := <-result:
+		return r.Val, r.Shared, r.Err
+	case <-ctx.Done():
+		return nil, false, ctx.Err()
+	}
+}
+
+// Stats returns the counts of calls made so far
+func (g *Group) Stats() Stats {
+	calls, executed := g.calls.Load(), g.executed.Load()
+	return Stats{
+		Calls:    calls,
+		Executed: executed,
+		Saved:    calls - executed,
+		Waited:   g.waited.Load(),
+	}
+}
+
+// run calls fn under the distributed lock of key when there is a locker.
+// Lock failures are logged and never prevent the call.
+func (g *Group) run(ctx context.Context, key string, fn func(ctx context.Context) (any, error)) (any, error) {
+	if g.locker == nil {
+		return fn(ctx)
+	}
+
+	deadline := time.Now().Add(g.cfg.LockTTL)
+	waited := false
+	for {
+		release, ok, err := g.locker.TryLock(ctx, key, g.cfg.LockTTL)
+		if err != nil {
+			g.logger.Warn().Err(err).Str("key", key).Msg("Failed to take lock, running unlocked")
+			return fn(ctx)
+		}
+		if ok {
+			defer release()
+			return fn(ctx)
+		}
+		if !waited {
+			waited = true
+			g.waited.Add(1)
+			g.logger.Debug().Str("key", key).Msg("Waiting for another replica")
+		}
+		if time.Now().After(deadline) {
+			g.logger.Warn().Str("key", key).Msg("Lock still held after its TTL, running unlocked")
+			return fn(ctx)
+		}
+		time.Sleep(g.cfg.PollInterval)
+	}
+}
@@ -0,0 +1,183 @@
+package coalesce
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestLocker creates a locker backed by an in-process Redis server
+func newTestLocker(t *testing.T) (*miniredis.Miniredis, *RedisLocker) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { client.Close() })
+	return server, NewRedisLocker(client, "lock:")
+}
+
+// testConfig keeps waits short
+func testConfig() Config {
+	return Config{LockTTL: 2 * time.Second, PollInterval: 5 * time.Millisecond}
+}
+
+// callConcurrently runs n calls of the same key once they have all joined
+// the call in flight, returning their results
+func callConcurrently(t *testing.T, group *Group, n int, fn func(ctx context.Context) (any, error)) []any {
+	t.Helper()
+	release := make(chan struct{})
+	results := make([]any, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, _, err := group.Do(context.Background(), "k", func(ctx context.Context) (any, error) {
+				<-release
+				return fn(ctx)
+			})
+			assert.NoError(t, err)
+			results[i] = v
+		}(i)
+	}
+	require.Eventually(t, func() bool { return group.Stats().Calls == int64(n) }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+	return results
+}
+
+func TestGroup_Do(t *testing.T) {
+	// Setup
+	group := NewGroup(nil, testConfig(), zerolog.New(zerolog.NewTestWriter(t)))
+	var runs atomic.Int64
+
+	// Execute
+	results := callConcurrently(t, group, 10, func(ctx context.Context) (any, error) {
+		runs.Add(1)
+		return "maison", nil
+	})
+
+	// Assert
+	assert.Equal(t, int64(1), runs.Load())
+	for _, v := range results {
+		assert.Equal(t, "maison", v)
+	}
+	assert.Equal(t, Stats{Calls: 10, Executed: 1, Saved: 9}, group.Stats())
+}
+
+func TestGroup_DoCallerCanceled(t *testing.T) {
+	// Setup
+	group := NewGroup(nil, testConfig(), zerolog.New(zerolog.NewTestWriter(t)))
+	release := make(chan struct{})
+	fn := func(ctx context.Context) (any, error) {
+		<-release
+		return "maison", ctx.Err()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	leader := make(chan error, 1)
+	go func() {
+		_, _, err := group.Do(ctx, "k", fn)
+		leader <- err
+	}()
+	require.Eventually(t, func() bool { return group.Stats().Executed == 1 }, time.Second, time.Millisecond)
+
+	// Execute
+	cancel()
+	leaderErr := <-leader
+	follower := make(chan any, 1)
+	go func() {
+		v, _, err := group.Do(context.Background(), "k", fn)
+		assert.NoError(t, err)
+		follower <- v
+	}()
+	require.Eventually(t, func() bool { return group.Stats().Calls == 2 }, time.Second, time.Millisecond)
+	close(release)
+
+	// Assert
+	assert.ErrorIs(t, leaderErr, context.Canceled)
+	assert.Equal(t, "maison", <-follower)
+	assert.Equal(t, int64(1), group.Stats().Executed)
+}
+
+func TestGroup_DoWaitsForOtherReplica(t *testing.T) {
+	// Setup
+	_, locker := newTestLocker(t)
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	replicaA := NewGroup(locker, testConfig(), logger)
+	replicaB := NewGroup(locker, testConfig(), logger)
+
+	var running atomic.Int64
+	var overlapped atomic.Bool
+	fn := func(ctx context.Context) (any, error) {
+		if running.Add(1) > 1 {
+			overlapped.Store(true)
+		}
+		time.Sleep(50 * time.Millisecond)
+		running.Add(-1)
+		return "maison", nil
+	}
+
+	// Execute
+	var wg sync.WaitGroup
+	for _, group := range []*Group{replicaA, replicaB} {
+		wg.Add(1)
+		go func(group *Group) {
+			defer wg.Done()
+			_, _, err := group.Do(context.Background(), "k", fn)
+			assert.NoError(t, err)
+		}(group)
+	}
+	wg.Wait()
+
+	// Assert
+	assert.False(t, overlapped.Load())
+	assert.Equal(t, int64(1), replicaA.Stats().Waited+replicaB.Stats().Waited)
+}
+
+func TestGroup_DoRunsUnlockedWhenRedisFails(t *testing.T) {
+	// Setup
+	server, locker := newTestLocker(t)
+	group := NewGroup(locker, testConfig(), zerolog.New(zerolog.NewTestWriter(t)))
+	server.Close()
+
+	// Execute
+	v, _, err := group.Do(context.Background(), "k", func(ctx context.Context) (any, error) {
+		return "maison", nil
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "maison", v)
+}
+
+func TestRedisLocker(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	server, locker := newTestLocker(t)
+
+	// Execute
+	release, ok, err := locker.TryLock(ctx, "k", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+	_, okHeld, err := locker.TryLock(ctx, "k", time.Minute)
+	require.NoError(t, err)
+
+	// Assert
+	assert.False(t, okHeld)
+	assert.Equal(t, time.Minute, server.TTL("lock:k"))
+
+	// A lock taken over after expiry is not released by its former holder
+	server.FastForward(time.Minute)
+	_, okTaken, err := locker.TryLock(ctx, "k", time.Minute)
+	require.NoError(t, err)
+	require.True(t, okTaken)
+	release()
+	assert.True(t, server.Exists("lock:k"))
+}
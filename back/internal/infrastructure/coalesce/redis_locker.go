@@ -0,0 +1,54 @@
+package coalesce
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Ensure RedisLocker implements Locker
+var _ Locker = (*RedisLocker)(nil)
+
+// releaseScript deletes a lock only if it is still held with the given token,
+// so that a lock that expired and was taken by another replica is kept
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// RedisLocker is a Locker backed by Redis keys set with NX
+type RedisLocker struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisLocker creates a locker; every lock key is prefixed with prefix
+func NewRedisLocker(client redis.UniversalClient, prefix string) *RedisLocker {
+	return &RedisLocker{client: client, prefix: prefix}
+}
+
+// TryLock takes the lock on a key for at most ttl
+func (l *RedisLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (func(), bool, error) {
+	lockKey := l.prefix + key
+	token := uuid.New().String()
+	ok, err := l.client.SetNX(ctx, lockKey, token, ttl).Result()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to take lock %q: %w", lockKey, err)
+	}
+	if !ok {
+		return nil, false, nil
+	}
+
+	release := func() {
+		// Released even when the caller gave up, so that waiting replicas go on
+		releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Second)
+		defer cancel()
+		_ = releaseScript.Run(releaseCtx, l.client, []string{lockKey}, token).Err()
+	}
+	return release, true, nil
+}
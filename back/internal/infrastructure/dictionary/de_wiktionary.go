@@ -0,0 +1,55 @@
+package dictionary
+
+import (
+	"voconsteroid/internal/domain/word/languages/german"
+)
+
+// German is the German edition of Wiktionary. Its language headings
+// qualify the word ("Haus (Deutsch)") and its word type headings carry
+// the gender of nouns ("Substantiv, n"). A word type section lists its
+// meanings, pronunciation and examples in labelled blocks rather than in
+// subsections, so only the meanings and pronunciation are read from them.
+var German = Edition{
+	Language: "de",
+	Source:   "dewiktionary",
+	BaseURL:  "https://de.wiktionary.org",
+
+	LanguageHeading: "Deutsch",
+	WordTypes: map[string]string{
+		"Substantiv":   string(german.Noun),
+		"Eigenname":    string(german.Noun),
+		"Verb":         string(german.Verb),
+		"Adjektiv":     string(german.Adjective),
+		"Adverb":       string(german.Adverb),
+		"Pronomen":     string(german.Pronoun),
+		"Präposition":  string(german.Preposition),
+		"Konjunktion":  string(german.Conjunction),
+		"Interjektion": string(german.Interjection),
+	},
+	TranslationsHeading: "Übersetzungen",
+
+	DefinitionListSelector: `p:contains("Bedeutungen:") + dl`,
+	DefinitionSelector:     "dd",
+	PronunciationSelector:  ".ipa",
+	PluralSelector:         ".inflection-table tr:nth-of-type(2) > td:nth-of-type(2) > :first-child",
+	InflectionRows: map[string]string{
+		"Nominativ": string(german.Nominative),
+		"Genitiv":   string(german.Genitive),
+		"Dativ":     string(german.Dative),
+		"Akkusativ": string(german.Accusative),
+	},
+
+	TranslationLanguages: map[string]string{
+		"Englisch":      "en",
+		"Französisch":   "fr",
+		"Spanisch":      "es",
+		"Italienisch":   "it",
+		"Portugiesisch": "pt",
+		"Rumänisch":     "ro",
+	},
+	Genders: map[string]string{
+		"m": string(german.Masculine),
+		"f": string(german.Feminine),
+		"n": string(german.Neuter),
+	},
+}
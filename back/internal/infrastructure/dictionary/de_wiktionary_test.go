@@ -0,0 +1,87 @@
+package dictionary
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"voconsteroid/internal/domain/snapshot"
+	"voconsteroid/internal/domain/word/languages/german"
+)
+
+func TestGermanWiktionary_ParsePage(t *testing.T) {
+	// Setup
+	page := []byte(`<html about="https://de.wiktionary.org/wiki/Special:Redirect/revision/9968532"><body>
+<section data-mw-section-id="0"><p>Haus</p></section>
+<section data-mw-section-id="1"><h2 id="Haus_(Deutsch)">Haus (<span>Deutsch</span>)</h2>
+<section data-mw-section-id="2"><h3 id="Substantiv,_n">Substantiv, <span>n</span></h3>
+<table class="wikitable inflection-table">
+<tr><th>Kasus</th><th>Singular</th><th>Plural</th></tr>
+<tr><th>Nominativ</th><td>das <a>Haus</a></td><td>die <a>Häuser</a></td></tr>
+<tr><th>Genitiv</th><td>des <a>Hauses</a></td><td>der <a>Häuser</a></td></tr>
+<tr><th>Dativ</th><td>dem <a>Haus</a></td><td>den <a>Häusern</a></td></tr>
+<tr><th>Akkusativ</th><td>das <a>Haus</a></td><td>die <a>Häuser</a></td></tr>
+</table>
+<p><span>Worttrennung:</span></p>
+<dl><dd>Haus, Plural: Häu·ser</dd></dl>
+<p><span>Aussprache:</span></p>
+<dl><dd>IPA: [<span class="ipa">haʊ̯s</span>]</dd></dl>
+<p><span>Bedeutungen:</span></p>
+<dl><dd>[1] Gebäude, das Menschen als Wohnung dient</dd><dd>[2] Familie, Dynastie</dd></dl>
+<p><span>Beispiele:</span></p>
+<dl><dd>[1] Das Haus steht am Waldrand.</dd></dl>
+<section data-mw-section-id="3"><h4 id="Übersetzungen">Übersetzungen</h4>
+<ul><li>Englisch: [1] house</li><li>Französisch: [1] maison</li></ul>
+</section>
+</section>
+</section>
+</body></html>`)
+	api := NewWiktionaryScraper(German, nil, zerolog.New(zerolog.NewTestWriter(t)))
+	snap := &snapshot.Snapshot{Text: "Haus", Language: "de", SourceURL: "https://de.wiktionary.org/w/rest.php/v1/page/Haus/html"}
+
+	// Execute
+	word, report, err := api.ParsePageReport(context.Background(), snap, page)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, report.Errors)
+	assert.Equal(t, "dewiktionary", word.Provenance.Source)
+	require.Len(t, word.Definitions, 2, "the examples block should not be read as definitions")
+	def := word.Definitions[0]
+	assert.Equal(t, "Gebäude, das Menschen als Wohnung dient", def.Text)
+	assert.Equal(t, string(german.Noun), def.WordType)
+	assert.Equal(t, string(german.Neuter), def.Gender)
+	assert.Equal(t, "haʊ̯s", def.Pronunciation)
+	assert.Equal(t, "Häuser", def.LanguageSpecifics["plural"])
+	assert.Equal(t, "Haus", def.LanguageSpecifics[string(german.Nominative)])
+	assert.Equal(t, "Hauses", def.LanguageSpecifics[string(german.Genitive)])
+	assert.Equal(t, "Haus", def.LanguageSpecifics[string(german.Dative)])
+	assert.Equal(t, "Haus", def.LanguageSpecifics[string(german.Accusative)])
+	assert.Equal(t, "Familie, Dynastie", word.Definitions[1].Text)
+	assert.NoError(t, word.ValidateDefinition(def))
+	assert.Equal(t, "[1] house", word.Translations["en"])
+}
+
+func TestGerman_WordType(t *testing.T) {
+	tests := []struct {
+		heading        string
+		expectedType   string
+		expectedGender string
+	}{
+		{"Substantiv, m", string(german.Noun), string(german.Masculine)},
+		{"Substantiv, f", string(german.Noun), string(german.Feminine)},
+		{"Substantiv, n", string(german.Noun), string(german.Neuter)},
+		{"Verb", string(german.Verb), ""},
+		{"Übersetzungen", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.heading, func(t *testing.T) {
+			assert.Equal(t, tt.expectedType, German.wordType(tt.heading))
+			assert.Equal(t, tt.expectedGender, German.gender(tt.heading))
+		})
+	}
+}
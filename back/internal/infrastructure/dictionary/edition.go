@@ -0,0 +1,107 @@
+package dictionary
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Edition describes a Wiktionary edition to the scraper engine: where it
+// lives, and how it names and marks up the sections of a word. An edition
+// is scraped for the words of its own language only; the lemmas of the
+// language come from the language registry.
+type Edition struct {
+	Language string // Code of the language of the edition and its words
+	Source   string // Name of the edition in word provenance, as MediaWiki names its databases
+	BaseURL  string // Root of the site, serving the REST API under /w/rest.php
+
+	// Headings of the sections, matched on their first words so that
+	// "Nom commun 2" is a "Nom commun" section, or on a qualifier between
+	// parentheses so that "Haus (Deutsch)" is a "Deutsch" section
+	LanguageHeading      string
+	WordTypes            map[string]string // Headings of the word type sections, to their word type
+	EtymologyHeading     string
+	PronunciationHeading string
+	SynonymsHeading      string
+	AntonymsHeading      string
+	TranslationsHeading  string
+
+	// Selectors of the markup of the edition
+	DefinitionListSelector string            // List of definitions, among the elements of a word type section
+	DefinitionSelector     string            // Definitions, among the children of the list
+	PronunciationSelector  string            // Pronunciation, in its section, the lead of the language section or the line of form
+	GenderSelector         string            // Gender, among the children of the line of form
+	PluralSelector         string            // Plural, in a word type section
+	InflectionRows         map[string]string // Headers of inflection table cells, to the specific the next cell holds
+	ExampleSelector        string            // Examples, in a definition
+	RelatedSelector        string            // Related words, in the list of a synonyms or antonyms section
+
+	// Text of the edition
+	TranslationLanguages map[string]string // Names of languages in translation lists, to their code
+	Genders              map[string]string // Gender marks of word type headings and lines of form, to their gender; nil keeps marks as they are
+	QuoteMarks           []string          // Quote marks removed from examples
+	MissingEtymology     string            // Placeholder of a missing etymology
+	MissingExample       string            // Placeholder of a missing example
+}
+
+// Editions are the Wiktionary editions the scraper engine knows. A language
+// of the registry is scraped from those among its dictionary sources.
+var Editions = []Edition{French, English, Spanish, German, Italian}
+
+// editionOf returns the edition of a dictionary source and whether the
+// engine knows it
+func editionOf(source string) (Edition, bool) {
+	for _, edition := range Editions {
+		if edition.Source == source {
+			return edition, true
+		}
+	}
+	return Edition{}, false
+}
+
+// wordType returns the word type of a section heading, "" when it is none
+func (e Edition) wordType(heading string) string {
+	for name, wordType := range e.WordTypes {
+		if headingIs(heading, name) {
+			return wordType
+		}
+	}
+	return ""
+}
+
+// translationLanguage returns the code of a language named in a
+// translation list, "" when the edition does not keep it
+func (e Edition) translationLanguage(name string) string {
+	return e.TranslationLanguages[strings.TrimSpace(name)]
+}
+
+// gender returns the gender of a mark, the first of its words the edition
+// knows, "" when it knows none of them
+func (e Edition) gender(mark string) string {
+	if e.Genders == nil {
+		return strings.TrimSpace(mark)
+	}
+	for _, word := range strings.FieldsFunc(mark, isHeadingSeparator) {
+		if gender, ok := e.Genders[word]; ok {
+			return gender
+		}
+	}
+	return ""
+}
+
+// headingIs reports whether a section heading is name, possibly numbered
+// or qualified after it ("Etymology 2", "Traductions à trier", "Substantiv,
+// m"), or whether it qualifies the heading ("Haus (Deutsch)")
+func headingIs(heading, name string) bool {
+	if name == "" {
+		return false
+	}
+	return heading == name ||
+		strings.HasPrefix(heading, name+" ") ||
+		strings.HasPrefix(heading, name+",") ||
+		strings.HasSuffix(heading, " ("+name+")")
+}
+
+// isHeadingSeparator reports whether r separates the words of a heading
+func isHeadingSeparator(r rune) bool {
+	return unicode.IsSpace(r) || r == ','
+}
@@ -0,0 +1,48 @@
+package dictionary
+
+import (
+	"voconsteroid/internal/domain/word/languages/english"
+)
+
+// English is the English edition of Wiktionary. Words with several
+// etymologies nest their word types under "Etymology N" sections.
+var English = Edition{
+	Language: "en",
+	Source:   "enwiktionary",
+	BaseURL:  "https://en.wiktionary.org",
+
+	LanguageHeading: "English",
+	WordTypes: map[string]string{
+		"Noun":         string(english.Noun),
+		"Proper noun":  string(english.Noun),
+		"Verb":         string(english.Verb),
+		"Adjective":    string(english.Adjective),
+		"Adverb":       string(english.Adverb),
+		"Pronoun":      string(english.Pronoun),
+		"Preposition":  string(english.Preposition),
+		"Conjunction":  string(english.Conjunction),
+		"Interjection": string(english.Interjection),
+	},
+	EtymologyHeading:     "Etymology",
+	PronunciationHeading: "Pronunciation",
+	SynonymsHeading:      "Synonyms",
+	AntonymsHeading:      "Antonyms",
+	TranslationsHeading:  "Translations",
+
+	DefinitionListSelector: "ol",
+	DefinitionSelector:     "li",
+	PronunciationSelector:  ".IPA",
+	PluralSelector:         ".headword-line .p-form-of",
+	ExampleSelector:        ".e-example",
+	RelatedSelector:        "li > span[lang]",
+
+	TranslationLanguages: map[string]string{
+		"French":     "fr",
+		"German":     "de",
+		"Spanish":    "es",
+		"Italian":    "it",
+		"Portuguese": "pt",
+		"Romanian":   "ro",
+	},
+	MissingEtymology: "This etymology is missing or incomplete",
+}
@@ -0,0 +1,94 @@
+package dictionary
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"voconsteroid/internal/domain/snapshot"
+	"voconsteroid/internal/domain/word/languages/english"
+)
+
+func TestEnglishWiktionary_ParsePage(t *testing.T) {
+	// Setup
+	page, err := os.ReadFile("en_html/en_wiktionary_test_rest.html")
+	require.NoError(t, err)
+	api := NewWiktionaryScraper(English, nil, zerolog.New(zerolog.NewTestWriter(t)))
+	snap := &snapshot.Snapshot{Text: "test", Language: "en", SourceURL: "https://en.wiktionary.org/w/rest.php/v1/page/test/html"}
+
+	// Execute
+	word, report, err := api.ParsePageReport(context.Background(), snap, page)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "en", word.Language)
+	assert.Equal(t, "enwiktionary", word.Provenance.Source)
+	assert.Equal(t, int64(84076433), word.Provenance.RevisionID)
+	assert.NotEmpty(t, word.Etymology)
+
+	require.NotEmpty(t, word.Definitions)
+	first := word.Definitions[0]
+	assert.Equal(t, "A challenge, trial.", first.Text)
+	assert.Equal(t, string(english.Noun), first.WordType)
+	assert.Equal(t, "/tɛst/", first.Pronunciation)
+	assert.Equal(t, "tests", first.LanguageSpecifics["plural"])
+	verbs := 0
+	for _, def := range word.Definitions {
+		assert.NoError(t, word.ValidateDefinition(def))
+		if def.WordType == string(english.Verb) {
+			verbs++
+		}
+	}
+	assert.Positive(t, verbs, "the verb section of the first etymology should be extracted")
+
+	assert.Contains(t, word.Synonyms, "examination")
+	assert.Contains(t, word.Antonyms, "recess")
+	assert.Contains(t, word.Translations, "fr")
+	assert.Equal(t, 1, report.Extracted["pronunciation"])
+}
+
+func TestEnglishWiktionary_ParsePage_Captured(t *testing.T) {
+	// Setup
+	page, err := os.ReadFile("en_html/en_wiktionary_test.html")
+	require.NoError(t, err)
+	api := NewWiktionaryScraper(English, nil, zerolog.New(zerolog.NewTestWriter(t)))
+	snap := &snapshot.Snapshot{Text: "test", Language: "en", SourceURL: "https://en.wiktionary.org/wiki/test"}
+
+	// Execute
+	word, report, err := api.ParsePageReport(context.Background(), snap, page)
+
+	// Assert
+	require.NoError(t, err)
+	require.NotEmpty(t, word.Definitions)
+	first := word.Definitions[0]
+	assert.Equal(t, "A challenge, trial.", first.Text)
+	assert.Equal(t, string(english.Noun), first.WordType)
+	assert.Equal(t, "/tɛst/", first.Pronunciation)
+	assert.Contains(t, word.Synonyms, "examination")
+	assert.Contains(t, word.Translations, "fr")
+	assert.Equal(t, 1, report.Extracted["pronunciation"])
+	assert.Equal(t, 5, report.Extracted["definitions"])
+}
+
+func TestEnglish_WordType(t *testing.T) {
+	tests := []struct {
+		heading  string
+		expected string
+	}{
+		{"Noun", string(english.Noun)},
+		{"Proper noun", string(english.Noun)},
+		{"Verb", string(english.Verb)},
+		{"Etymology 2", ""},
+		{"Nouns", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.heading, func(t *testing.T) {
+			assert.Equal(t, tt.expected, English.wordType(tt.heading))
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package dictionary
+
+import (
+	"voconsteroid/internal/domain/word/languages/spanish"
+)
+
+// Spanish is the Spanish edition of Wiktionary, the Wikcionario. Its word
+// type headings carry the gender of nouns ("Sustantivo femenino"), the
+// pronunciation table sits at the top of the language section, and each
+// definition is a list of its own, numbered in a term before it.
+var Spanish = Edition{
+	Language: "es",
+	Source:   "eswiktionary",
+	BaseURL:  "https://es.wiktionary.org",
+
+	LanguageHeading: "Español",
+	WordTypes: map[string]string{
+		"Sustantivo":   string(spanish.Noun),
+		"Verbo":        string(spanish.Verb),
+		"Adjetivo":     string(spanish.Adjective),
+		"Adverbio":     string(spanish.Adverb),
+		"Pronombre":    string(spanish.Pronoun),
+		"Preposición":  string(spanish.Preposition),
+		"Conjunción":   string(spanish.Conjunction),
+		"Interjección": string(spanish.Interjection),
+	},
+	EtymologyHeading:    "Etimología",
+	TranslationsHeading: "Traducciones",
+
+	DefinitionListSelector: "dl",
+	DefinitionSelector:     "dd",
+	PronunciationSelector:  ".pron-graf .ipa",
+	PluralSelector:         ".inflection-table tr:nth-of-type(2) > td:nth-of-type(2)",
+
+	TranslationLanguages: map[string]string{
+		"Alemán":    "de",
+		"Francés":   "fr",
+		"Inglés":    "en",
+		"Italiano":  "it",
+		"Portugués": "pt",
+		"Rumano":    "ro",
+	},
+	Genders: map[string]string{
+		"masculino": string(spanish.Masculine),
+		"femenino":  string(spanish.Feminine),
+	},
+	MissingEtymology: "Si puedes, incorpórala",
+}
@@ -0,0 +1,55 @@
+package dictionary
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"voconsteroid/internal/domain/snapshot"
+	"voconsteroid/internal/domain/word/languages/spanish"
+)
+
+func TestSpanishWiktionary_ParsePage(t *testing.T) {
+	// Setup
+	page := []byte(`<html about="https://es.wiktionary.org/wiki/Special:Redirect/revision/6543210"><body>
+<section data-mw-section-id="1"><h2 id="Español">Español</h2>
+<table class="pron-graf"><tr><td>pronunciación (AFI)</td><td><span class="ipa">[ˈka.sa]</span></td></tr></table>
+<section data-mw-section-id="2"><h3 id="Etimología">Etimología</h3>
+<p>Del latín casa, «choza».</p>
+</section>
+<section data-mw-section-id="3"><h3 id="Sustantivo_femenino">Sustantivo femenino</h3>
+<table class="inflection-table"><tr><th>Singular</th><th>Plural</th></tr><tr><td>casa</td><td>casas</td></tr></table>
+<dl><dt>1 Arquitectura</dt><dd>Edificio para habitar.</dd></dl>
+<ul><li>Sinónimos: hogar, vivienda.</li></ul>
+<dl><dt>2</dt><dd>Familia, linaje.</dd></dl>
+</section>
+<section data-mw-section-id="4"><h3 id="Traducciones">Traducciones</h3>
+<ul><li>Inglés: house</li><li>Francés: maison</li></ul>
+</section>
+</section>
+</body></html>`)
+	api := NewWiktionaryScraper(Spanish, nil, zerolog.New(zerolog.NewTestWriter(t)))
+	snap := &snapshot.Snapshot{Text: "casa", Language: "es", SourceURL: "https://es.wiktionary.org/w/rest.php/v1/page/casa/html"}
+
+	// Execute
+	word, report, err := api.ParsePageReport(context.Background(), snap, page)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, report.Errors)
+	assert.Equal(t, "eswiktionary", word.Provenance.Source)
+	assert.Equal(t, "Del latín casa, «choza».", word.Etymology)
+	require.Len(t, word.Definitions, 2, "each definition is a list of its own")
+	def := word.Definitions[0]
+	assert.Equal(t, "Edificio para habitar.", def.Text)
+	assert.Equal(t, string(spanish.Noun), def.WordType)
+	assert.Equal(t, string(spanish.Feminine), def.Gender)
+	assert.Equal(t, "[ˈka.sa]", def.Pronunciation, "the pronunciation table tops the language section")
+	assert.Equal(t, "casas", def.LanguageSpecifics["plural"])
+	assert.Equal(t, "Familia, linaje.", word.Definitions[1].Text)
+	assert.NoError(t, word.ValidateDefinition(def))
+	assert.Equal(t, "house", word.Translations["en"])
+}
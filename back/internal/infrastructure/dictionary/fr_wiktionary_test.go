@@ -4,20 +4,26 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"voconsteroid/internal/domain/snapshot"
 	wordDomain "voconsteroid/internal/domain/word"
 	"voconsteroid/internal/domain/word/languages/french"
+	"voconsteroid/internal/infrastructure/httpclient"
 )
 
 // Helper function to create a test API with real Wiktionary URL
-func createTestAPI(t *testing.T) *FrenchWiktionaryAPI {
+func createTestAPI(t *testing.T) *WiktionaryScraper {
 	logger := zerolog.New(zerolog.NewConsoleWriter()).With().Timestamp().Logger()
-	return NewFrenchWiktionaryAPI(logger)
+	return NewWiktionaryScraper(French, nil, logger)
 }
 
 func TestFrenchWiktionaryAPI_FetchWord(t *testing.T) {
@@ -127,7 +133,7 @@ func TestFrenchWiktionaryAPI_FetchWord_EmptyHTML(t *testing.T) {
 	logger := zerolog.New(zerolog.NewConsoleWriter()).With().Timestamp().Logger()
 
 	// Create a French Wiktionary API with a custom getBaseURL function
-	api := NewFrenchWiktionaryAPI(logger)
+	api := NewWiktionaryScraper(French, nil, logger)
 	api.getBaseURL = func() string {
 		return server.URL
 	}
@@ -242,8 +248,9 @@ func TestFrenchWiktionaryAPI_FetchSuggestions_MockServer(t *testing.T) {
 	logger := zerolog.New(zerolog.NewConsoleWriter()).With().Timestamp().Logger()
 
 	// Create a French Wiktionary API with a custom HTTP client
-	api := &FrenchWiktionaryAPI{
-		logger: logger.With().Str("component", "fr_wiktionary_scraper").Logger(),
+	api := &WiktionaryScraper{
+		edition: French,
+		logger:  logger.With().Str("component", "fr_wiktionary_scraper").Logger(),
 		getBaseURL: func() string {
 			return server.URL
 		},
@@ -272,8 +279,9 @@ func TestFrenchWiktionaryAPI_FetchSuggestions_InvalidJSON(t *testing.T) {
 	logger := zerolog.New(zerolog.NewConsoleWriter()).With().Timestamp().Logger()
 
 	// Create a French Wiktionary API with a custom HTTP client
-	api := &FrenchWiktionaryAPI{
-		logger: logger.With().Str("component", "fr_wiktionary_scraper").Logger(),
+	api := &WiktionaryScraper{
+		edition: French,
+		logger:  logger.With().Str("component", "fr_wiktionary_scraper").Logger(),
 		getBaseURL: func() string {
 			return server.URL
 		},
@@ -300,8 +308,9 @@ func TestFrenchWiktionaryAPI_FetchSuggestions_ServerError(t *testing.T) {
 	logger := zerolog.New(zerolog.NewConsoleWriter()).With().Timestamp().Logger()
 
 	// Create a French Wiktionary API with a custom HTTP client
-	api := &FrenchWiktionaryAPI{
-		logger: logger.With().Str("component", "fr_wiktionary_scraper").Logger(),
+	api := &WiktionaryScraper{
+		edition: French,
+		logger:  logger.With().Str("component", "fr_wiktionary_scraper").Logger(),
 		getBaseURL: func() string {
 			return server.URL
 		},
@@ -316,6 +325,37 @@ func TestFrenchWiktionaryAPI_FetchSuggestions_ServerError(t *testing.T) {
 	assert.Nil(t, suggestions)
 	assert.Contains(t, err.Error(), "received non-OK status code")
 }
+func TestFrenchWiktionaryAPI_SourceUnavailable(t *testing.T) {
+	// Setup
+	var calls atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	client := httpclient.New(httpclient.Config{FailureThreshold: 2, Cooldown: time.Minute}, logger)
+	api := NewWiktionaryScraper(French, client, logger)
+	api.getBaseURL = func() string {
+		return server.URL
+	}
+	ctx := context.Background()
+
+	// Execute
+	_, errWord := api.FetchWord(ctx, "maison", "fr")
+	_, errSuggestions := api.FetchSuggestions(ctx, "mai", "fr")
+	_, errOpen := api.FetchWord(ctx, "chat", "fr")
+
+	// Assert
+	assert.ErrorIs(t, errWord, wordDomain.ErrDictionaryUnavailable)
+	assert.NotErrorIs(t, errWord, wordDomain.ErrWordNotFound, "an unavailable source must not look like a missing word")
+	assert.ErrorIs(t, errSuggestions, wordDomain.ErrDictionaryUnavailable)
+	assert.ErrorIs(t, errOpen, wordDomain.ErrDictionaryUnavailable)
+	assert.ErrorIs(t, errOpen, httpclient.ErrCircuitOpen)
+	assert.Equal(t, int64(2), calls.Load(), "the open circuit fails fast without calling the source")
+}
+
 func TestFrenchWiktionaryAPI_RealFetchWord(t *testing.T) {
 	// Create a test API with real Wiktionary URL
 	api := createTestAPI(t)
@@ -374,8 +414,7 @@ func TestFrenchWiktionaryAPI_RealFetchWord_NotFound(t *testing.T) {
 	assert.Error(t, err)
 	assert.ErrorIs(t, err, wordDomain.ErrWordNotFound)
 }
-func TestFrenchWiktionaryAPI_DetermineWordType(t *testing.T) {
-	api := &FrenchWiktionaryAPI{}
+func TestFrench_WordType(t *testing.T) {
 
 	testCases := []struct {
 		sectionTitle string
@@ -394,13 +433,12 @@ func TestFrenchWiktionaryAPI_DetermineWordType(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.sectionTitle, func(t *testing.T) {
-			result := api.determineWordType(tc.sectionTitle)
+			result := French.wordType(tc.sectionTitle)
 			assert.Equal(t, tc.expectedType, result)
 		})
 	}
 }
-func TestFrenchWiktionaryAPI_MapLanguageNameToCode(t *testing.T) {
-	api := &FrenchWiktionaryAPI{}
+func TestFrench_TranslationLanguage(t *testing.T) {
 
 	testCases := []struct {
 		langName string
@@ -417,7 +455,7 @@ func TestFrenchWiktionaryAPI_MapLanguageNameToCode(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.langName, func(t *testing.T) {
-			result := api.mapLanguageNameToCode(tc.langName)
+			result := French.translationLanguage(tc.langName)
 			assert.Equal(t, tc.expected, result)
 		})
 	}
@@ -557,3 +595,211 @@ func TestWord_EntityMethods(t *testing.T) {
 	assert.Error(t, err)
 	assert.ErrorIs(t, err, wordDomain.ErrInvalidGender)
 }
+
+func TestFrenchWiktionaryAPI_FetchWord_Provenance(t *testing.T) {
+	// Setup
+	page, err := os.ReadFile("fr_html/fr_wiktionary_irenologie_rest.html")
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(page)
+	}))
+	defer server.Close()
+
+	api := NewWiktionaryScraper(French, server.Client(), zerolog.New(zerolog.NewTestWriter(t)))
+	api.getBaseURL = func() string { return server.URL }
+	recorder := &fakeRecorder{}
+	api.SetRecorder(recorder)
+
+	// Execute
+	word, err := api.FetchWord(context.Background(), "irénologie", "fr")
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, word.Provenance)
+	assert.Equal(t, "frwiktionary", word.Provenance.Source)
+	assert.Equal(t, server.URL+"/w/rest.php/v1/page/ir%C3%A9nologie/html", word.Provenance.SourceURL)
+	assert.Equal(t, int64(34803495), word.Provenance.RevisionID)
+	assert.Equal(t, ParserVersion, word.Provenance.ParserVersion)
+	assert.False(t, word.Provenance.FetchedAt.IsZero())
+	assert.Equal(t, page, recorder.page)
+	assert.Equal(t, *word.Provenance, recorder.provenance)
+}
+
+func TestFrenchWiktionaryAPI_ParsePage(t *testing.T) {
+	// Setup
+	page, err := os.ReadFile("fr_html/fr_wiktionary_irenologie_rest.html")
+	require.NoError(t, err)
+	api := NewWiktionaryScraper(French, nil, zerolog.New(zerolog.NewTestWriter(t)))
+	api.getBaseURL = func() string { panic("no network access expected") }
+	recorder := &fakeRecorder{}
+	api.SetRecorder(recorder)
+	fetchedAt := time.Date(2025, 3, 8, 17, 4, 29, 0, time.UTC)
+	snap := &snapshot.Snapshot{
+		Text:      "irénologie",
+		Language:  "fr",
+		Source:    "frwiktionary",
+		SourceURL: "https://fr.wiktionary.org/wiki/ir%C3%A9nologie",
+		FetchedAt: fetchedAt,
+	}
+
+	// Execute
+	word, err := api.ParsePage(context.Background(), snap, page)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEmpty(t, word.Definitions)
+	assert.Equal(t, snap.SourceURL, word.Provenance.SourceURL)
+	assert.Equal(t, int64(34803495), word.Provenance.RevisionID)
+	assert.Equal(t, fetchedAt, word.Provenance.FetchedAt)
+	assert.Nil(t, recorder.page, "parsed pages should not be recorded again")
+}
+
+func TestFrenchWiktionaryAPI_ParsePage_Captured(t *testing.T) {
+	// Setup
+	page, err := os.ReadFile("fr_html/fr_wiktionary_irenologie.html")
+	require.NoError(t, err)
+	api := NewWiktionaryScraper(French, nil, zerolog.New(zerolog.NewTestWriter(t)))
+	snap := &snapshot.Snapshot{Text: "irénologie", Language: "fr", SourceURL: "https://fr.wiktionary.org/wiki/ir%C3%A9nologie"}
+
+	// Execute
+	word, report, err := api.ParsePageReport(context.Background(), snap, page)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, word.Definitions, 1)
+	assert.Equal(t, "Science de la paix.", word.Definitions[0].Text)
+	assert.Equal(t, "féminin", word.Definitions[0].Gender)
+	assert.Equal(t, `\i.ʁe.nɔ.lɔ.ʒi\`, word.Definitions[0].Pronunciation)
+	assert.Contains(t, word.Translations["en"], "irenology")
+	assert.Empty(t, report.Errors)
+	assert.Zero(t, word.Provenance.RevisionID, "pages rendered at /wiki/ do not tell their revision")
+}
+
+func TestFrenchWiktionaryAPI_FetchWord_Sections(t *testing.T) {
+	// Setup
+	page, err := os.ReadFile("fr_html/fr_wiktionary_test_rest.html")
+	require.NoError(t, err)
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(page)
+	}))
+	defer server.Close()
+
+	api := NewWiktionaryScraper(French, server.Client(), zerolog.New(zerolog.NewTestWriter(t)))
+	api.getBaseURL = func() string { return server.URL }
+
+	// Execute
+	word, err := api.FetchWord(context.Background(), "test", "fr")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "/w/rest.php/v1/page/test/html", path)
+	require.Len(t, word.Definitions, 6, "both common nouns and the verb, but no other language")
+	assert.Equal(t, string(french.Noun), word.Definitions[0].WordType)
+	assert.Equal(t, "masculin", word.Definitions[0].Gender)
+	assert.Equal(t, "tests", word.Definitions[0].LanguageSpecifics["plural"])
+	assert.Contains(t, word.Definitions[2].Text, "Essai")
+	assert.Equal(t, string(french.Verb), word.Definitions[5].WordType)
+	assert.Contains(t, word.Synonyms, "coque", "synonyms of the first noun")
+	assert.Contains(t, word.Synonyms, "examen", "synonyms of the second noun")
+	assert.Contains(t, word.Translations["en"], "test")
+	assert.True(t, strings.HasPrefix(word.Etymology, "(Nom 1)"))
+	assert.Equal(t, int64(37409928), word.Provenance.RevisionID)
+}
+
+func TestFrenchWiktionaryAPI_ParsePageReport(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      string
+		sourceURL string
+	}{
+		{
+			name:      "page of the REST API",
+			file:      "fr_html/fr_wiktionary_test_rest.html",
+			sourceURL: "https://fr.wiktionary.org/w/rest.php/v1/page/test/html",
+		},
+		{
+			name:      "page captured at /wiki/",
+			file:      "fr_html/fr_wiktionary_test.html",
+			sourceURL: "https://fr.wiktionary.org/wiki/test",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			page, err := os.ReadFile(tt.file)
+			require.NoError(t, err)
+			api := NewWiktionaryScraper(French, nil, zerolog.New(zerolog.NewTestWriter(t)))
+			snap := &snapshot.Snapshot{Text: "test", Language: "fr", SourceURL: tt.sourceURL}
+
+			// Execute
+			word, report, err := api.ParsePageReport(context.Background(), snap, page)
+
+			// Assert
+			require.NoError(t, err)
+			require.Len(t, word.Definitions, 6)
+			assert.Equal(t, "masculin", word.Definitions[0].Gender)
+			assert.Contains(t, word.Definitions[2].Text, "Essai")
+			assert.Equal(t, string(french.Verb), word.Definitions[5].WordType)
+			assert.Contains(t, word.Synonyms, "examen")
+			assert.Empty(t, report.Errors, "the pronunciation section only holds recordings, which are skipped")
+			assert.Equal(t, map[string]int{
+				"etymology":     1,
+				"pronunciation": 1,
+				"definitions":   3,
+				"synonyms":      2,
+				"translations":  1,
+			}, report.Extracted)
+		})
+	}
+}
+
+func TestFrenchWiktionaryAPI_ParsePageReport_ExtractorError(t *testing.T) {
+	// Setup
+	page := []byte(`<html about="https://fr.wiktionary.org/wiki/Special:Redirect/revision/7"><body>
+<section data-mw-section-id="1"><h2 id="Français">Français</h2>
+<section data-mw-section-id="2"><h3 id="Adjectif">Adjectif</h3><p><b>vert</b></p></section>
+<section data-mw-section-id="3"><h3 id="Nom_commun">Nom commun</h3><ol><li>Couleur.</li></ol>
+<section data-mw-section-id="4"><h4 id="Synonymes">Synonymes</h4></section>
+</section>
+</section>
+</body></html>`)
+	api := NewWiktionaryScraper(French, nil, zerolog.New(zerolog.NewTestWriter(t)))
+	snap := &snapshot.Snapshot{Text: "vert", Language: "fr", SourceURL: "https://fr.wiktionary.org/w/rest.php/v1/page/vert/html"}
+
+	// Execute
+	word, report, err := api.ParsePageReport(context.Background(), snap, page)
+
+	// Assert
+	require.NoError(t, err, "a failing extractor should not fail the parse")
+	require.Len(t, word.Definitions, 1)
+	assert.Equal(t, "Couleur.", word.Definitions[0].Text)
+	require.Len(t, report.Errors, 2)
+	assert.Equal(t, "definitions", report.Errors[0].Extractor)
+	assert.Equal(t, "Adjectif", report.Errors[0].Section)
+	assert.ErrorIs(t, report.Errors[0], ErrNoDefinitions)
+	assert.Equal(t, "synonyms", report.Errors[1].Extractor)
+	assert.ErrorIs(t, report.Errors[1], ErrEmptySection)
+	assert.Equal(t, 1, report.Extracted["definitions"])
+}
+
+// fakeRecorder keeps the last recorded page
+type fakeRecorder struct {
+	provenance wordDomain.Provenance
+	page       []byte
+}
+
+func (f *fakeRecorder) Record(_ context.Context, _, _ string, provenance wordDomain.Provenance, page []byte) error {
+	f.provenance, f.page = provenance, page
+	return nil
+}
+
+func TestRevisionID(t *testing.T) {
+	assert.Equal(t, int64(34803495), revisionID("https://fr.wiktionary.org/wiki/Special:Redirect/revision/34803495"))
+	assert.Equal(t, int64(0), revisionID("https://fr.wiktionary.org/wiki/maison"))
+	assert.Equal(t, int64(0), revisionID("https://fr.wiktionary.org/wiki/Special:Redirect/revision/latest"))
+}
@@ -0,0 +1,51 @@
+package dictionary
+
+import (
+	"voconsteroid/internal/domain/word/languages/italian"
+)
+
+// Italian is the Italian edition of Wiktionary, the Wikizionario. The line
+// of form of a noun marks its gender in italics ("f sing").
+var Italian = Edition{
+	Language: "it",
+	Source:   "itwiktionary",
+	BaseURL:  "https://it.wiktionary.org",
+
+	LanguageHeading: "Italiano",
+	WordTypes: map[string]string{
+		"Sostantivo":   string(italian.Noun),
+		"Verbo":        string(italian.Verb),
+		"Aggettivo":    string(italian.Adjective),
+		"Avverbio":     string(italian.Adverb),
+		"Pronome":      string(italian.Pronoun),
+		"Preposizione": string(italian.Preposition),
+		"Congiunzione": string(italian.Conjunction),
+		"Interiezione": string(italian.Interjection),
+	},
+	EtymologyHeading:     "Etimologia",
+	PronunciationHeading: "Pronuncia",
+	SynonymsHeading:      "Sinonimi",
+	AntonymsHeading:      "Contrari",
+	TranslationsHeading:  "Traduzione",
+
+	DefinitionListSelector: "ol",
+	DefinitionSelector:     "li",
+	PronunciationSelector:  ".IPA",
+	GenderSelector:         "i",
+	ExampleSelector:        "dd",
+	RelatedSelector:        "li",
+
+	TranslationLanguages: map[string]string{
+		"Inglese":    "en",
+		"Francese":   "fr",
+		"Tedesco":    "de",
+		"Spagnolo":   "es",
+		"Portoghese": "pt",
+		"Rumeno":     "ro",
+	},
+	Genders: map[string]string{
+		"m": string(italian.Masculine),
+		"f": string(italian.Feminine),
+	},
+	MissingEtymology: "Etimologia mancante",
+}
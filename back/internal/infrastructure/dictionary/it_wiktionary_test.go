@@ -0,0 +1,58 @@
+package dictionary
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"voconsteroid/internal/domain/snapshot"
+	"voconsteroid/internal/domain/word/languages/italian"
+)
+
+func TestItalianWiktionary_ParsePage(t *testing.T) {
+	// Setup
+	page := []byte(`<html about="https://it.wiktionary.org/wiki/Special:Redirect/revision/1234567"><body>
+<section data-mw-section-id="1"><h2 id="Italiano">Italiano</h2>
+<section data-mw-section-id="2"><h3 id="Sostantivo">Sostantivo</h3>
+<p><b>casa</b> <i>f sing</i> (pl.: <i>case</i>)</p>
+<ol><li>edificio adibito ad abitazione<dl><dd>abito in una casa in campagna</dd></dl></li><li>famiglia</li></ol>
+</section>
+<section data-mw-section-id="3"><h3 id="Pronuncia">Pronuncia</h3>
+<p><span class="IPA">/ˈkasa/</span></p>
+</section>
+<section data-mw-section-id="4"><h3 id="Etimologia_/_Derivazione">Etimologia / Derivazione</h3>
+<p>dal latino casa, «capanna»</p>
+</section>
+<section data-mw-section-id="5"><h3 id="Sinonimi">Sinonimi</h3>
+<ul><li>abitazione</li><li>dimora</li></ul>
+</section>
+<section data-mw-section-id="6"><h3 id="Traduzione">Traduzione</h3>
+<ul><li>Inglese: house</li><li>Tedesco: Haus</li></ul>
+</section>
+</section>
+</body></html>`)
+	api := NewWiktionaryScraper(Italian, nil, zerolog.New(zerolog.NewTestWriter(t)))
+	snap := &snapshot.Snapshot{Text: "casa", Language: "it", SourceURL: "https://it.wiktionary.org/w/rest.php/v1/page/casa/html"}
+
+	// Execute
+	word, report, err := api.ParsePageReport(context.Background(), snap, page)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, report.Errors)
+	assert.Equal(t, "itwiktionary", word.Provenance.Source)
+	assert.Equal(t, "dal latino casa, «capanna»", word.Etymology)
+	require.Len(t, word.Definitions, 2)
+	def := word.Definitions[0]
+	assert.Equal(t, "edificio adibito ad abitazione", def.Text)
+	assert.Equal(t, string(italian.Noun), def.WordType)
+	assert.Equal(t, string(italian.Feminine), def.Gender, "the plural after the gender is no gender mark")
+	assert.Equal(t, []string{"abito in una casa in campagna"}, def.Examples)
+	assert.NoError(t, word.ValidateDefinition(def))
+	assert.Equal(t, []string{"abitazione", "dimora"}, word.Synonyms)
+	assert.Equal(t, "house", word.Translations["en"])
+	assert.Equal(t, "Haus", word.Translations["de"])
+}
@@ -0,0 +1,45 @@
+package dictionary
+
+import (
+	"fmt"
+
+	"github.com/aaaton/golem/v4"
+
+	languageDomain "voconsteroid/internal/domain/language"
+	wordDomain "voconsteroid/internal/domain/word"
+)
+
+// Lemmatizer implements word.Lemmatizer with the golem dictionaries, the
+// ones the scrapers use when fetching words
+type Lemmatizer struct {
+	lemmatizers map[string]*golem.Lemmatizer
+}
+
+// Ensure Lemmatizer implements word.Lemmatizer
+var _ wordDomain.Lemmatizer = (*Lemmatizer)(nil)
+
+// NewLemmatizer loads the dictionaries of the registered languages having lemmas
+func NewLemmatizer() (*Lemmatizer, error) {
+	lemmatizers := make(map[string]*golem.Lemmatizer)
+	for _, lang := range languageDomain.Default.All() {
+		if lang.Lemmas == nil {
+			continue
+		}
+		lemmatizer, err := golem.New(lang.Lemmas)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s lemmas: %w", lang.Name, err)
+		}
+		lemmatizers[lang.Code] = lemmatizer
+	}
+
+	return &Lemmatizer{lemmatizers: lemmatizers}, nil
+}
+
+// Lemma returns the lemma of a form and whether the form is known
+func (l *Lemmatizer) Lemma(text, language string) (string, bool) {
+	lemmatizer, ok := l.lemmatizers[language]
+	if !ok || !lemmatizer.InDict(text) {
+		return "", false
+	}
+	return lemmatizer.Lemma(text), true
+}
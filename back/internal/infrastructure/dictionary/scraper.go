@@ -0,0 +1,671 @@
+package dictionary
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/aaaton/golem/v4"
+	"github.com/gocolly/colly/v2"
+	"github.com/rs/zerolog"
+
+	languageDomain "voconsteroid/internal/domain/language"
+	"voconsteroid/internal/domain/snapshot"
+	wordDomain "voconsteroid/internal/domain/word"
+	"voconsteroid/internal/infrastructure/httpclient"
+)
+
+// WiktionaryScraper implements the word.DictionaryAPI interface for a
+// Wiktionary edition, reading its pages as its Edition describes them
+type WiktionaryScraper struct {
+	edition    Edition
+	logger     zerolog.Logger
+	getBaseURL func() string
+	lemmatizer *golem.Lemmatizer
+	client     *http.Client
+	recorder   snapshot.Recorder
+}
+
+// NewWiktionaryScraper creates a new scraper of a Wiktionary edition sending
+// its requests through the given client
+func NewWiktionaryScraper(edition Edition, client *http.Client, logger zerolog.Logger) *WiktionaryScraper {
+	var lemmatizer *golem.Lemmatizer
+	if lang, ok := languageDomain.Default.Get(edition.Language); ok && lang.Lemmas != nil {
+		lemmatizer, _ = golem.New(lang.Lemmas)
+	}
+
+	return &WiktionaryScraper{
+		edition: edition,
+		client:  client,
+		logger:  logger.With().Str("component", edition.Language+"_wiktionary_scraper").Logger(),
+		getBaseURL: func() string {
+			return edition.BaseURL
+		},
+		lemmatizer: lemmatizer,
+	}
+}
+
+// SetRecorder makes the scraper record the raw pages it fetches
+func (w *WiktionaryScraper) SetRecorder(recorder snapshot.Recorder) {
+	w.recorder = recorder
+}
+
+// FetchRelatedWords retrieves words related to the given word from the edition
+func (w *WiktionaryScraper) FetchRelatedWords(ctx context.Context, word *wordDomain.Word) (*wordDomain.RelatedWords, error) {
+	w.logger.Debug().Str("word", word.Text).Str("language", word.Language).Msg("Fetching related words from Wiktionary")
+
+	// Create a new RelatedWords object with the source word
+	relatedWords := &wordDomain.RelatedWords{
+		SourceWord: word,
+		Synonyms:   []*wordDomain.Word{},
+		Antonyms:   []*wordDomain.Word{},
+	}
+
+	// Track which words we've already processed to avoid duplicates
+	processedSynonyms := make(map[string]bool)
+	processedAntonyms := make(map[string]bool)
+
+	// If the word already has synonyms or antonyms, fetch them
+	for _, synonym := range word.Synonyms {
+		if synonym == "" || processedSynonyms[synonym] {
+			continue
+		}
+		processedSynonyms[synonym] = true
+
+		// Check context cancellation
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+			// Fetch the synonym word
+			synonymWord, err := w.FetchWord(ctx, synonym, word.Language)
+			if err == nil && synonymWord != nil {
+				relatedWords.Synonyms = append(relatedWords.Synonyms, synonymWord)
+			} else {
+				// If we can't fetch the full word, create a minimal one
+				minimalWord := wordDomain.NewWord(synonym, word.Language)
+				relatedWords.Synonyms = append(relatedWords.Synonyms, minimalWord)
+			}
+		}
+	}
+
+	// Do the same for antonyms
+	for _, antonym := range word.Antonyms {
+		if antonym == "" || processedAntonyms[antonym] {
+			continue
+		}
+		processedAntonyms[antonym] = true
+
+		// Check context cancellation
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+			// Fetch the antonym word
+			antonymWord, err := w.FetchWord(ctx, antonym, word.Language)
+			if err == nil && antonymWord != nil {
+				relatedWords.Antonyms = append(relatedWords.Antonyms, antonymWord)
+			} else {
+				// If we can't fetch the full word, create a minimal one
+				minimalWord := wordDomain.NewWord(antonym, word.Language)
+				relatedWords.Antonyms = append(relatedWords.Antonyms, minimalWord)
+			}
+		}
+	}
+
+	w.logger.Debug().
+		Str("word", word.Text).
+		Int("synonyms", len(relatedWords.Synonyms)).
+		Int("antonyms", len(relatedWords.Antonyms)).
+		Msg("Successfully fetched related words")
+
+	return relatedWords, nil
+}
+
+func (w *WiktionaryScraper) FetchSuggestions(ctx context.Context, prefix, language string) ([]string, error) {
+	w.logger.Debug().Str("prefix", prefix).Str("language", language).Msg("Fetching suggestions from Wiktionary")
+
+	// Validate language
+	if language != w.edition.Language {
+		w.logger.Warn().Str("language", language).Msg("Unsupported language for the Wiktionary edition")
+		return nil, fmt.Errorf("unsupported language %s", language)
+	}
+
+	// URL encode the prefix
+	encodedPrefix := url.QueryEscape(prefix)
+
+	// Build the API URL
+	apiURL := fmt.Sprintf("%s/w/rest.php/v1/search/title?q=%s&limit=10", w.getBaseURL(), encodedPrefix)
+
+	// Create request
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		w.logger.Error().Err(err).Str("url", apiURL).Msg("Failed to create request")
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Execute request
+	resp, err := w.httpClient().Do(req)
+	if err != nil {
+		w.logger.Error().Err(err).Str("url", apiURL).Msg("Failed to execute request")
+		if sourceUnavailable(err, 0) {
+			return nil, fmt.Errorf("failed to execute request: %w: %w", wordDomain.ErrDictionaryUnavailable, err)
+		}
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Check status code
+	if resp.StatusCode != http.StatusOK {
+		w.logger.Error().Int("status", resp.StatusCode).Str("url", apiURL).Msg("Received non-OK status code")
+		if sourceUnavailable(nil, resp.StatusCode) {
+			return nil, fmt.Errorf("received non-OK status code: %d: %w", resp.StatusCode, wordDomain.ErrDictionaryUnavailable)
+		}
+		return nil, fmt.Errorf("received non-OK status code: %d", resp.StatusCode)
+	}
+
+	// Parse response
+	var searchResponse struct {
+		Pages []struct {
+			ID      int    `json:"id"`
+			Key     string `json:"key"`
+			Title   string `json:"title"`
+			Excerpt string `json:"excerpt"`
+		} `json:"pages"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&searchResponse); err != nil {
+		w.logger.Error().Err(err).Str("url", apiURL).Msg("Failed to decode response")
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	// Convert to Word objects
+	suggestions := make([]string, 0, len(searchResponse.Pages))
+	for _, page := range searchResponse.Pages {
+		suggestions = append(suggestions, page.Title)
+	}
+
+	w.logger.Debug().Int("count", len(suggestions)).Msg("Found suggestions")
+	return suggestions, nil
+}
+
+// FetchWord retrieves word information from the edition by parsing the
+// HTML of its page, as the MediaWiki REST API renders it
+func (w *WiktionaryScraper) FetchWord(ctx context.Context, text, language string) (*wordDomain.Word, error) {
+	newWord, _, err := w.FetchWordReport(ctx, text, language)
+	return newWord, err
+}
+
+// FetchWordReport retrieves word information from the edition, along
+// with the report of the parse of its page. The report is also returned
+// when the page was parsed but held no word.
+func (w *WiktionaryScraper) FetchWordReport(ctx context.Context, text, language string) (*wordDomain.Word, *ParseReport, error) {
+	w.logger.Debug().Str("text", text).Str("language", language).Msg("Fetching word from Wiktionary")
+
+	var transport http.RoundTripper
+	if w.client != nil {
+		transport = w.client.Transport
+	}
+	pageURL := fmt.Sprintf("%s/w/rest.php/v1/page/%s/html", w.getBaseURL(), url.PathEscape(text))
+	return w.scrape(ctx, text, language, pageURL, transport, w.recorder)
+}
+
+// ParsePage parses a recorded page of the edition, without network
+// access; the word keeps the fetch time of the snapshot
+func (w *WiktionaryScraper) ParsePage(ctx context.Context, snap *snapshot.Snapshot, page []byte) (*wordDomain.Word, error) {
+	newWord, _, err := w.ParsePageReport(ctx, snap, page)
+	return newWord, err
+}
+
+// ParsePageReport parses a recorded page of the edition like
+// ParsePage, along with the report of the parse
+func (w *WiktionaryScraper) ParsePageReport(ctx context.Context, snap *snapshot.Snapshot, page []byte) (*wordDomain.Word, *ParseReport, error) {
+	newWord, report, err := w.scrape(ctx, snap.Text, snap.Language, snap.SourceURL, pageTransport(page), nil)
+	if err != nil {
+		return nil, report, err
+	}
+	newWord.Provenance.FetchedAt = snap.FetchedAt
+	return newWord, report, nil
+}
+
+// scrape visits a page through transport, nil for the default one, and
+// parses the word of the page with the extractors of the edition. The page is
+// recorded when recorder is set and the page was fetched, even if no word
+// could be parsed from it.
+func (w *WiktionaryScraper) scrape(ctx context.Context, text, language, pageURL string, transport http.RoundTripper, recorder snapshot.Recorder) (*wordDomain.Word, *ParseReport, error) {
+	// Create a new word with validation
+	newWord := wordDomain.NewWord(text, language)
+
+	// Validate language
+	if language != w.edition.Language {
+		w.logger.Warn().Str("language", language).Msg("Unsupported language for the Wiktionary edition")
+		return nil, nil, fmt.Errorf("unsupported language %s: %w", language, wordDomain.ErrWordNotFound)
+	}
+
+	// Create a single collector for the entire operation
+	c := colly.NewCollector(
+		colly.MaxDepth(1),
+	)
+	if transport != nil {
+		c.WithTransport(transport)
+	}
+
+	// Set timeout
+	c.SetRequestTimeout(10 * time.Second)
+
+	// Keep the status of a failed response, to tell a missing page from an unavailable source
+	failedStatus := 0
+	c.OnError(func(r *colly.Response, _ error) {
+		failedStatus = r.StatusCode
+	})
+
+	// Record the page the word comes from, after redirects
+	provenance := &wordDomain.Provenance{
+		Source:        w.edition.Source,
+		ParserVersion: ParserVersion,
+	}
+	var page []byte
+	c.OnResponse(func(r *colly.Response) {
+		provenance.SourceURL = r.Request.URL.String()
+		provenance.FetchedAt = time.Now()
+		page = r.Body
+	})
+	c.OnHTML("html[about]", func(e *colly.HTMLElement) {
+		provenance.RevisionID = revisionID(e.Attr("about"))
+	})
+
+	// Run the extractors over the section of the language, parsed once
+	var languageSection *pageSection
+	report := newParseReport()
+	c.OnHTML("body", func(e *colly.HTMLElement) {
+		languageSection = findSection(buildSections(e.DOM), func(s *pageSection) bool {
+			return s.level == 2 && headingIs(s.title, w.edition.LanguageHeading)
+		})
+		if languageSection == nil {
+			return
+		}
+		w.logger.Debug().Int("subsections", len(languageSection.children)).Msg("Found language section")
+		runExtractors(languageSection, newWord, w.extractors(languageSection), report)
+	})
+
+	// Check if context is done
+	select {
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	default:
+		// Visit the page (single HTTP request)
+		err := c.Visit(pageURL)
+		if err != nil {
+			w.logger.Error().Err(err).Str("url", pageURL).Msg("Failed to visit page")
+
+			if sourceUnavailable(err, failedStatus) {
+				return nil, nil, fmt.Errorf("failed to visit page: %w: %w", wordDomain.ErrDictionaryUnavailable, err)
+			}
+			return nil, nil, fmt.Errorf("failed to visit page: %w, %w", err, wordDomain.ErrWordNotFound)
+		}
+	}
+
+	// Wait until scraping is finished
+	c.Wait()
+
+	// Keep the raw page for parsing it again offline
+	if recorder != nil && page != nil {
+		if err := recorder.Record(ctx, text, language, *provenance, page); err != nil {
+			w.logger.Error().Err(err).Str("text", text).Msg("Failed to record page")
+		}
+	}
+
+	for _, extractErr := range report.Errors {
+		w.logger.Debug().Err(extractErr).Str("text", text).Msg("Failed to extract section")
+	}
+
+	// If no section of the language was found, return an error
+	if languageSection == nil {
+		w.logger.Warn().Str("text", text).Str("language", language).Msg("No language section found")
+		return nil, report, fmt.Errorf("no %s section found: %w", w.edition.LanguageHeading, wordDomain.ErrWordNotFound)
+	}
+
+	// If still no definitions, return error
+	if len(newWord.Definitions) == 0 {
+		w.logger.Warn().Str("text", text).Str("language", language).Msg("No word data found")
+		return nil, report, fmt.Errorf("no word data found: %w", wordDomain.ErrWordNotFound)
+	}
+
+	// Set primary word type from first definition
+	if len(newWord.Definitions) > 0 {
+		newWord.Definitions[0].WordType = newWord.GetPrimaryWordType()
+	}
+
+	// Add lemma
+	if w.lemmatizer != nil && w.lemmatizer.InDict(text) {
+		newWord.Lemma = w.lemmatizer.Lemma(text)
+	}
+	newWord.Provenance = provenance
+
+	w.logger.Debug().
+		Str("text", text).
+		Str("language", language).
+		Int("definitions", len(newWord.Definitions)).
+		Int("synonyms", len(newWord.Synonyms)).
+		Int("antonyms", len(newWord.Antonyms)).
+		Str("etymology", newWord.Etymology).
+		Str("lemma", newWord.Lemma).
+		Int("extractErrors", len(report.Errors)).
+		Msg("Successfully fetched word data from Wiktionary")
+
+	return newWord, report, nil
+}
+
+// pageTransport serves page as the response to every request
+type pageTransport []byte
+
+// RoundTrip answers the request with the page
+func (p pageTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"text/html; charset=UTF-8"}},
+		Body:          io.NopCloser(bytes.NewReader(p)),
+		ContentLength: int64(len(p)),
+		Request:       req,
+	}, nil
+}
+
+// revisionID returns the revision a Parsoid page is about
+// (https://.../wiki/Special:Redirect/revision/N), 0 when it has none
+func revisionID(about string) int64 {
+	_, revision, ok := strings.Cut(about, "/revision/")
+	if !ok {
+		return 0
+	}
+	id, err := strconv.ParseInt(revision, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// httpClient returns the client of the scraper, or a plain one when none was given
+func (w *WiktionaryScraper) httpClient() *http.Client {
+	if w.client != nil {
+		return w.client
+	}
+	return &http.Client{Timeout: 5 * time.Second}
+}
+
+// sourceUnavailable reports whether a request failed because Wiktionary is
+// down or throttling us, given its error and the status of the failed
+// response. Network errors count toward opening the circuit breaker.
+func sourceUnavailable(err error, status int) bool {
+	return errors.Is(err, httpclient.ErrCircuitOpen) ||
+		status == http.StatusTooManyRequests ||
+		status >= http.StatusInternalServerError
+}
+
+// definitionNumber matches the number written before a definition
+var definitionNumber = regexp.MustCompile(`^\[\d+\]\s*`)
+
+func cleanEtymology(etymologyText string) string {
+	etymologyText = strings.TrimSpace(etymologyText)
+	regex := regexp.MustCompile(`\[\d+\] `)
+	etymologyText = regex.ReplaceAllString(etymologyText, "")
+
+	return etymologyText
+}
+
+// extractors returns the extractors of the section of the language of a
+// page. They share the examples already seen, to avoid duplicates, and the
+// pronunciation of the word for the definitions whose line of form has none,
+// which some editions give at the top of the language section.
+func (w *WiktionaryScraper) extractors(language *pageSection) []sectionExtractor {
+	seenExamples := make(map[string]bool)
+	pronunciation := strings.TrimSpace(language.content.Find(w.edition.PronunciationSelector).First().Text())
+
+	return []sectionExtractor{
+		{
+			name: "etymology",
+			match: func(s *pageSection) bool {
+				return headingIs(s.title, w.edition.EtymologyHeading)
+			},
+			extract: w.extractEtymology,
+		},
+		{
+			name: "pronunciation",
+			match: func(s *pageSection) bool {
+				return headingIs(s.title, w.edition.PronunciationHeading)
+			},
+			extract: func(s *pageSection, _ *wordDomain.Word) error {
+				// Sections holding only recordings leave the pronunciation as it is
+				if found := strings.TrimSpace(s.content.Find(w.edition.PronunciationSelector).First().Text()); found != "" {
+					pronunciation = found
+				}
+				return nil
+			},
+		},
+		{
+			name: "definitions",
+			match: func(s *pageSection) bool {
+				return w.edition.wordType(s.title) != ""
+			},
+			extract: func(s *pageSection, newWord *wordDomain.Word) error {
+				return w.extractDefinitions(s, newWord, pronunciation, seenExamples)
+			},
+		},
+		{
+			// Related words and translations belong to the word types
+			name: "synonyms",
+			match: func(s *pageSection) bool {
+				return headingIs(s.title, w.edition.SynonymsHeading)
+			},
+			extract: func(s *pageSection, newWord *wordDomain.Word) error {
+				return w.extractRelated(s, newWord.AddSynonym)
+			},
+		},
+		{
+			name: "antonyms",
+			match: func(s *pageSection) bool {
+				return headingIs(s.title, w.edition.AntonymsHeading)
+			},
+			extract: func(s *pageSection, newWord *wordDomain.Word) error {
+				return w.extractRelated(s, newWord.AddAntonym)
+			},
+		},
+		{
+			name: "translations",
+			match: func(s *pageSection) bool {
+				return headingIs(s.title, w.edition.TranslationsHeading)
+			},
+			extract: w.extractTranslations,
+		},
+	}
+}
+
+// extractEtymology sets the etymology of the word from its section, the
+// first one when the word has several
+func (w *WiktionaryScraper) extractEtymology(section *pageSection, newWord *wordDomain.Word) error {
+	etymology := section.content.Filter("dl, p").First()
+	if etymology.Length() == 0 {
+		return ErrEmptySection
+	}
+	if newWord.Etymology != "" {
+		return nil
+	}
+	etymologyText := strings.TrimSpace(etymology.Text())
+
+	// Remove the placeholder of a missing etymology and anything after it
+	if idx := strings.Index(etymologyText, w.edition.MissingEtymology); w.edition.MissingEtymology != "" && idx >= 0 {
+		// If there's content before the message, keep only that part
+		etymologyText = strings.TrimSpace(etymologyText[:idx])
+	}
+
+	// Only set etymology if there's still content after filtering
+	if etymologyText == "" {
+		w.logger.Debug().Msg("No etymology besides the 'missing' message, ignoring")
+		return nil
+	}
+	w.logger.Debug().Str("etymology", etymologyText).Msg("Found etymology")
+	newWord.Etymology = cleanEtymology(etymologyText)
+	return nil
+}
+
+// extractDefinitions adds the definitions of a word type section to the
+// word, with the given pronunciation unless its line of form has one
+func (w *WiktionaryScraper) extractDefinitions(section *pageSection, newWord *wordDomain.Word, pronunciation string, seenExamples map[string]bool) error {
+	wordType := w.edition.wordType(section.title)
+	foundDefinition := wordDomain.NewDefinition()
+	foundDefinition.WordType = wordType
+	foundDefinition.Pronunciation = pronunciation
+	if w.edition.Genders != nil {
+		foundDefinition.Gender = w.edition.gender(section.title)
+	}
+	w.logger.Debug().Str("title", section.title).Str("wordType", wordType).Msg("Found word type section")
+
+	// The line of form and the inflection table precede the lists of
+	// definitions, one for the section or one per definition
+	definitionLists := section.content.Filter(w.edition.DefinitionListSelector)
+	section.content.Each(func(_ int, elem *goquery.Selection) {
+		if elem.Is(w.edition.DefinitionListSelector) {
+			return
+		}
+		switch {
+		case elem.Is("p, dl"):
+			if found := strings.TrimSpace(elem.Find(w.edition.PronunciationSelector).First().Text()); found != "" {
+				foundDefinition.Pronunciation = found
+			}
+			if w.edition.GenderSelector != "" {
+				elem.ChildrenFiltered(w.edition.GenderSelector).Each(func(_ int, mark *goquery.Selection) {
+					if gender := w.edition.gender(mark.Text()); gender != "" {
+						foundDefinition.Gender = gender
+					}
+				})
+			}
+		case elem.Is("table"):
+			elem.Find("th, td").Each(func(_ int, cell *goquery.Selection) {
+				if specific, ok := w.edition.InflectionRows[strings.TrimSpace(cell.Text())]; ok {
+					foundDefinition.LanguageSpecifics[specific] = cell.Next().Children().First().Text()
+				}
+			})
+		}
+	})
+	if plural := strings.TrimSpace(section.content.Find(w.edition.PluralSelector).First().Text()); plural != "" {
+		foundDefinition.LanguageSpecifics["plural"] = plural
+	}
+	if definitionLists.Length() == 0 {
+		return ErrNoDefinitions
+	}
+
+	// Process each list item as a definition
+	definitionLists.ChildrenFiltered(w.edition.DefinitionSelector).Each(func(_ int, liSelection *goquery.Selection) {
+		// Get the main definition text, without the nested lists of examples
+		// nor the number some editions write before it
+		definitionText := strings.TrimSpace(liSelection.Contents().Not("ul, ol, dl").Text())
+		definitionText = definitionNumber.ReplaceAllString(definitionText, "")
+
+		// If empty, try getting the full text and cleaning it
+		if definitionText == "" {
+			fullText := strings.TrimSpace(liSelection.Text())
+
+			// Try to separate definition from examples
+			if idx := strings.Index(fullText, " : "); idx > 0 {
+				definitionText = strings.TrimSpace(fullText[:idx])
+			} else if idx := strings.Index(fullText, " — "); idx > 0 {
+				definitionText = strings.TrimSpace(fullText[:idx])
+			} else if idx := strings.Index(fullText, "« "); idx > 0 {
+				definitionText = strings.TrimSpace(fullText[:idx])
+			} else {
+				definitionText = fullText
+			}
+		}
+
+		// Skip empty definitions
+		if definitionText == "" {
+			return
+		}
+
+		// Collect examples for this definition
+		examples := []string{}
+		liSelection.Find(w.edition.ExampleSelector).Each(func(_ int, exampleSpan *goquery.Selection) {
+			exampleText := strings.TrimSpace(exampleSpan.Text())
+			if exampleText == "" {
+				return
+			}
+
+			// Clean up the example text
+			for _, mark := range w.edition.QuoteMarks {
+				exampleText = strings.ReplaceAll(exampleText, mark, "")
+			}
+
+			// Skip the placeholder of a missing example
+			if w.edition.MissingExample != "" && strings.Contains(exampleText, w.edition.MissingExample) {
+				w.logger.Debug().Str("example", exampleText).Msg("Skipping 'missing example' message")
+				return
+			}
+
+			// Only add if we haven't seen this example before
+			if !seenExamples[exampleText] {
+				w.logger.Debug().Str("example", exampleText).Msg("Found example")
+				examples = append(examples, exampleText)
+				seenExamples[exampleText] = true
+			}
+		})
+
+		foundDefinition.Text = definitionText
+		foundDefinition.Examples = examples
+
+		// Add the definition using the entity method
+		newWord.AddDefinition(foundDefinition)
+		w.logger.Debug().Int("index", len(newWord.Definitions)-1).Str("definition", definitionText).Msg("Found definition")
+	})
+	return nil
+}
+
+// extractRelated calls add with the related words listed in a section
+func (w *WiktionaryScraper) extractRelated(section *pageSection, add func(text string)) error {
+	if section.listItems(w.edition.RelatedSelector, add) == 0 {
+		return ErrEmptySection
+	}
+	return nil
+}
+
+// extractTranslations adds the translations of a section to the word, the
+// first one of each language. Each item names its language before a colon.
+func (w *WiktionaryScraper) extractTranslations(section *pageSection, newWord *wordDomain.Word) error {
+	items := section.content.Find("li")
+	if items.Length() == 0 {
+		return ErrEmptySection
+	}
+	items.Each(func(_ int, liSelection *goquery.Selection) {
+		langName, translationText, ok := strings.Cut(liSelection.Text(), ":")
+		if !ok {
+			return
+		}
+
+		// Skip if we've already got this language
+		langCode := w.edition.translationLanguage(langName)
+		if langCode == "" {
+			return
+		}
+		if _, ok := newWord.Translations[langCode]; ok {
+			return
+		}
+
+		if translationText = strings.TrimSpace(translationText); translationText != "" {
+			w.logger.Debug().Str("language", langCode).Str("translation", translationText).Msg("Found translation")
+			newWord.Translations[langCode] = translationText
+		}
+	})
+	return nil
+}
@@ -0,0 +1,214 @@
+package dictionary
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+
+	wordDomain "voconsteroid/internal/domain/word"
+)
+
+// Section extraction errors
+var (
+	ErrEmptySection  = errors.New("section has no content to extract")
+	ErrNoDefinitions = errors.New("word type section has no definition list")
+)
+
+// pageSection is a section of a page as the REST API renders it: a
+// <section> element holding its heading, its content and its subsections
+type pageSection struct {
+	title    string
+	level    int                // Level of the heading, 2 for a language, 0 for the lead
+	content  *goquery.Selection // Elements of the section besides its heading and subsections
+	children []*pageSection
+}
+
+// headings selects the headings of sections
+const headings = "h1, h2, h3, h4, h5, h6"
+
+// buildSections builds the tree of the sections of a page. Pages rendered
+// for reading at /wiki/, such as the ones captured before the REST API was
+// used, have no <section> elements: their sections are built from the
+// headings of their parser output instead.
+func buildSections(body *goquery.Selection) []*pageSection {
+	if body.Find("section").Length() == 0 {
+		if output := body.Find(".mw-parser-output").First(); output.Length() > 0 {
+			return buildFlatSections(output)
+		}
+	}
+	return buildNestedSections(body)
+}
+
+// buildNestedSections builds the tree of the sections nested in parent
+func buildNestedSections(parent *goquery.Selection) []*pageSection {
+	var sections []*pageSection
+	parent.ChildrenFiltered("section").Each(func(_ int, s *goquery.Selection) {
+		heading := s.ChildrenFiltered(headings).First()
+		section := &pageSection{
+			title:    strings.TrimSpace(heading.Text()),
+			content:  s.Children().Not("section").Not(headings),
+			children: buildNestedSections(s),
+		}
+		if heading.Length() > 0 {
+			section.level = headingLevel(heading)
+		}
+		sections = append(sections, section)
+	})
+	return sections
+}
+
+// buildFlatSections builds the tree of the sections of a parser output
+// whose headings are siblings of their content. A section holds the
+// elements up to the next heading, and the sections of deeper headings
+// up to the next heading of its level or above.
+func buildFlatSections(output *goquery.Selection) []*pageSection {
+	lead := &pageSection{}
+	sections := []*pageSection{lead}
+	open := []*pageSection{lead}
+
+	// The section of each element, by index
+	elements := output.Children()
+	owners := make([]*pageSection, elements.Length())
+	elements.Each(func(i int, element *goquery.Selection) {
+		// Headings are wrapped in a <div class="mw-heading"> since 2024
+		heading := element
+		if element.HasClass("mw-heading") {
+			heading = element.ChildrenFiltered(headings).First()
+		}
+		if !heading.Is(headings) {
+			owners[i] = open[len(open)-1]
+			return
+		}
+
+		// Older headings hold their edit links next to a headline
+		title := heading
+		if headline := heading.Find(".mw-headline"); headline.Length() > 0 {
+			title = headline
+		}
+		section := &pageSection{title: strings.TrimSpace(title.Text()), level: headingLevel(heading)}
+		for len(open) > 1 && open[len(open)-1].level >= section.level {
+			open = open[:len(open)-1]
+		}
+		if parent := open[len(open)-1]; parent == lead {
+			sections = append(sections, section)
+		} else {
+			parent.children = append(parent.children, section)
+		}
+		open = append(open, section)
+	})
+
+	for _, section := range allSections(sections) {
+		section.content = elements.FilterFunction(func(i int, _ *goquery.Selection) bool {
+			return owners[i] == section
+		})
+	}
+	return sections
+}
+
+// allSections returns the sections of the trees, in page order
+func allSections(sections []*pageSection) []*pageSection {
+	var all []*pageSection
+	for _, s := range sections {
+		all = append(all, s)
+		all = append(all, allSections(s.children)...)
+	}
+	return all
+}
+
+// headingLevel returns the level of a heading element, 2 for an <h2>
+func headingLevel(heading *goquery.Selection) int {
+	level, _ := strconv.Atoi(strings.TrimPrefix(goquery.NodeName(heading), "h"))
+	return level
+}
+
+// findSection returns the first section of the trees, in page order,
+// matching match, nil when none does
+func findSection(sections []*pageSection, match func(s *pageSection) bool) *pageSection {
+	for _, s := range sections {
+		if match(s) {
+			return s
+		}
+		if found := findSection(s.children, match); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// walk calls fn on every subsection of s, in page order
+func (s *pageSection) walk(fn func(sub *pageSection)) {
+	for _, sub := range s.children {
+		fn(sub)
+		sub.walk(fn)
+	}
+}
+
+// listItems calls fn with the text of the elements matching selector in
+// the first list of the section, and returns how many it found
+func (s *pageSection) listItems(selector string, fn func(text string)) int {
+	count := 0
+	s.content.Filter("ul").First().Find(selector).Each(func(_ int, item *goquery.Selection) {
+		if text := strings.TrimSpace(item.Text()); text != "" {
+			fn(text)
+			count++
+		}
+	})
+	return count
+}
+
+// sectionExtractor fills part of a word from the sections it matches
+type sectionExtractor struct {
+	name    string
+	match   func(s *pageSection) bool
+	extract func(s *pageSection, word *wordDomain.Word) error
+}
+
+// ExtractError is the failure of an extractor on a section of a page
+type ExtractError struct {
+	Extractor string
+	Section   string
+	Err       error
+}
+
+// Error implements the error interface
+func (e ExtractError) Error() string {
+	return fmt.Sprintf("%s extractor on section %q: %v", e.Extractor, e.Section, e.Err)
+}
+
+// Unwrap returns the error of the extractor
+func (e ExtractError) Unwrap() error {
+	return e.Err
+}
+
+// ParseReport tells how the sections of a page were extracted. An extractor
+// failing on a section does not fail the parse; the word keeps what the
+// other extractors found.
+type ParseReport struct {
+	Extracted map[string]int // Sections extracted without error, by extractor
+	Errors    []ExtractError
+}
+
+// newParseReport creates an empty parse report
+func newParseReport() *ParseReport {
+	return &ParseReport{Extracted: make(map[string]int)}
+}
+
+// runExtractors runs the extractors on the subsections of a language
+// section, in page order, reporting how each of them went
+func runExtractors(language *pageSection, word *wordDomain.Word, extractors []sectionExtractor, report *ParseReport) {
+	language.walk(func(s *pageSection) {
+		for _, e := range extractors {
+			if !e.match(s) {
+				continue
+			}
+			if err := e.extract(s, word); err != nil {
+				report.Errors = append(report.Errors, ExtractError{Extractor: e.name, Section: s.title, Err: err})
+				continue
+			}
+			report.Extracted[e.name]++
+		}
+	})
+}
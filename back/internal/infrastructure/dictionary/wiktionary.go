@@ -3,12 +3,20 @@ package dictionary
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/rs/zerolog"
 
+	languageDomain "voconsteroid/internal/domain/language"
+	"voconsteroid/internal/domain/snapshot"
 	wordDomain "voconsteroid/internal/domain/word"
 )
 
+// ParserVersion is the version of the Wiktionary parsers, recorded on the
+// words they fetch. Bump it when a parser change improves fetched words, so
+// that the refresh worker fetches the stored ones again.
+const ParserVersion = 2
+
 // WiktionaryAPI implements the word.DictionaryAPI interface for Wiktionary
 // It acts as a router to language-specific scrapers
 type WiktionaryAPI struct {
@@ -17,8 +25,9 @@ type WiktionaryAPI struct {
 	scrapers map[string]wordDomain.DictionaryAPI
 }
 
-// NewWiktionaryAPI creates a new Wiktionary API router
-func NewWiktionaryAPI(logger zerolog.Logger) *WiktionaryAPI {
+// NewWiktionaryAPI creates a new Wiktionary API router; the scrapers send
+// their requests through the given client
+func NewWiktionaryAPI(client *http.Client, logger zerolog.Logger) *WiktionaryAPI {
 	baseLogger := logger.With().Str("component", "wiktionary_api").Logger()
 
 	// Create the API instance
@@ -27,15 +36,48 @@ func NewWiktionaryAPI(logger zerolog.Logger) *WiktionaryAPI {
 		scrapers: make(map[string]wordDomain.DictionaryAPI),
 	}
 
-	// Register language-specific scrapers
-	api.scrapers["fr"] = NewFrenchWiktionaryAPI(baseLogger)
-	// Add more language scrapers as they are implemented
-	// api.scrapers["en"] = NewEnglishWiktionaryAPI(baseLogger)
-	// api.scrapers["es"] = NewSpanishWiktionaryAPI(baseLogger)
+	// Register a scraper per language of the registry, for its Wiktionary edition
+	for _, lang := range languageDomain.Default.All() {
+		for _, source := range lang.Sources {
+			edition, ok := editionOf(source)
+			if !ok {
+				baseLogger.Warn().Str("language", lang.Code).Str("source", source).Msg("No Wiktionary edition for the dictionary source")
+				continue
+			}
+			api.scrapers[lang.Code] = NewWiktionaryScraper(edition, client, baseLogger)
+		}
+	}
 
 	return api
 }
 
+// Ensure WiktionaryAPI can parse recorded pages
+var _ snapshot.Parser = (*WiktionaryAPI)(nil)
+
+// pageRecorder is implemented by the scrapers able to record the pages
+// they fetch
+type pageRecorder interface {
+	SetRecorder(recorder snapshot.Recorder)
+}
+
+// SetRecorder makes the scrapers record the raw pages they fetch
+func (w *WiktionaryAPI) SetRecorder(recorder snapshot.Recorder) {
+	for _, scraper := range w.scrapers {
+		if r, ok := scraper.(pageRecorder); ok {
+			r.SetRecorder(recorder)
+		}
+	}
+}
+
+// ParsePage routes a recorded page to the scraper of its language
+func (w *WiktionaryAPI) ParsePage(ctx context.Context, snap *snapshot.Snapshot, page []byte) (*wordDomain.Word, error) {
+	parser, ok := w.scrapers[snap.Language].(snapshot.Parser)
+	if !ok {
+		return nil, fmt.Errorf("unsupported language %s: %w", snap.Language, wordDomain.ErrWordNotFound)
+	}
+	return parser.ParsePage(ctx, snap, page)
+}
+
 // FetchWord routes the request to the appropriate language-specific scraper
 func (w *WiktionaryAPI) FetchWord(ctx context.Context, text, language string) (*wordDomain.Word, error) {
 	w.logger.Debug().Str("text", text).Str("language", language).Msg("Routing word fetch request")
@@ -46,12 +46,16 @@ func TestNewWiktionaryAPI(t *testing.T) {
 	logger := zerolog.New(zerolog.NewTestWriter(t))
 
 	// Execute
-	api := NewWiktionaryAPI(logger)
+	api := NewWiktionaryAPI(nil, logger)
 
 	// Assert
 	assert.NotNil(t, api)
 	assert.NotNil(t, api.scrapers)
 	assert.NotNil(t, api.scrapers["fr"]) // French scraper should be registered
+	assert.NotNil(t, api.scrapers["en"]) // English scraper should be registered
+	assert.NotNil(t, api.scrapers["es"]) // Spanish scraper should be registered
+	assert.NotNil(t, api.scrapers["de"]) // German scraper should be registered
+	assert.NotNil(t, api.scrapers["it"]) // Italian scraper should be registered
 }
 
 func TestWiktionaryAPI_FetchWord_SupportedLanguage(t *testing.T) {
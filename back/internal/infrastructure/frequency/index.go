@@ -0,0 +1,240 @@
+// Package frequency loads word frequency lists from local files.
+package frequency
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/word"
+)
+
+// fileExtensions are the extensions tried, in order, for a language's file
+var fileExtensions = []string{".tsv", ".txt"}
+
+// wordColumns and frequencyColumns are the header names recognized in
+// tabular files, in order of preference: Lexique (ortho, freqfilms2,
+// freqlivres) and SUBTLEX (Word, SUBTLWF, FREQcount)
+var (
+	wordColumns      = []string{"ortho", "word", "lemme", "lemma"}
+	frequencyColumns = []string{"freqfilms2", "subtlwf", "freqcount", "freqlivres", "frequency", "freq", "count"}
+)
+
+// Index holds the frequency rank of words per language
+type Index struct {
+	ranks map[string]map[string]int
+}
+
+// Ensure Index implements word.FrequencyIndex
+var _ word.FrequencyIndex = (*Index)(nil)
+
+// NewIndex creates an empty index
+func NewIndex() *Index {
+	return &Index{ranks: make(map[string]map[string]int)}
+}
+
+// ErrNoLists is returned when a frequency directory holds no list for any
+// language
+var ErrNoLists = errors.New("no frequency list found")
+
+// Load reads the frequency list of each language from dir, named after the
+// language code (fr.tsv, en.txt, ...). An empty dir loads no list, so that
+// words are not scored. Otherwise dir must exist and hold the list of at
+// least one language; a language without a file is left without a list.
+func Load(dir string, languages []string, logger zerolog.Logger) (*Index, error) {
+	log := logger.With().Str("component", "frequency_index").Logger()
+	index := NewIndex()
+
+	if dir == "" {
+		log.Warn().Msg("No frequency directory configured, words will not be scored")
+		return index, nil
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open frequency directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("frequency directory %s is not a directory", dir)
+	}
+
+	for _, language := range languages {
+		path, err := findFile(dir, language)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				log.Warn().Str("dir", dir).Str("language", language).Msg("No frequency list found, words will not be scored")
+				continue
+			}
+			return nil, err
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open frequency list %s: %w", path, err)
+		}
+		ranks, err := Parse(file)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse frequency list %s: %w", path, err)
+		}
+
+		index.Set(language, ranks)
+		log.Info().Str("language", language).Str("path", path).Int("words", len(ranks)).Msg("Loaded frequency list")
+	}
+
+	if len(index.ranks) == 0 {
+		return nil, fmt.Errorf("%w in %s", ErrNoLists, dir)
+	}
+	return index, nil
+}
+
+// findFile returns the path of the language's frequency list in dir
+func findFile(dir, language string) (string, error) {
+	for _, ext := range fileExtensions {
+		path := filepath.Join(dir, language+ext)
+		info, err := os.Stat(path)
+		if err == nil && !info.IsDir() {
+			return path, nil
+		}
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("failed to stat frequency list %s: %w", path, err)
+		}
+	}
+	return "", fs.ErrNotExist
+}
+
+// Set replaces the frequency ranks of a language
+func (i *Index) Set(language string, ranks map[string]int) {
+	i.ranks[language] = ranks
+}
+
+// HasLanguage reports whether a frequency list is loaded for the language
+func (i *Index) HasLanguage(language string) bool {
+	return len(i.ranks[language]) > 0
+}
+
+// Rank returns the rank of a form in the language's frequency list
+func (i *Index) Rank(text, language string) (int, bool) {
+	rank, ok := i.ranks[language][normalize(text)]
+	return rank, ok
+}
+
+// Parse reads a frequency list and returns the rank of each word, 1 being the
+// most frequent. Two layouts are accepted:
+//   - a tab-separated table whose header names a word column and a frequency
+//     column, as in Lexique or SUBTLEX exports; rows are ranked by decreasing
+//     frequency, and the frequencies of a word listed several times (one row
+//     per part of speech) are summed
+//   - one word per line, most frequent first, optionally followed by a tab
+//     and a count which is ignored
+//
+// Empty lines and lines starting with # are skipped.
+func Parse(r io.Reader) (map[string]int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read frequency list: %w", err)
+	}
+	if len(lines) == 0 {
+		return map[string]int{}, nil
+	}
+
+	header := strings.Split(lines[0], "\t")
+	wordCol, freqCol := columnIndex(header, wordColumns), columnIndex(header, frequencyColumns)
+	if wordCol >= 0 && freqCol >= 0 {
+		return parseTable(lines[1:], wordCol, freqCol)
+	}
+
+	return parseRanked(lines), nil
+}
+
+// parseTable ranks the rows of a table by decreasing frequency
+func parseTable(rows []string, wordCol, freqCol int) (map[string]int, error) {
+	frequencies := make(map[string]float64)
+	for n, row := range rows {
+		fields := strings.Split(row, "\t")
+		if len(fields) <= wordCol || len(fields) <= freqCol {
+			continue
+		}
+		text := normalize(fields[wordCol])
+		if text == "" {
+			continue
+		}
+		freq, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(fields[freqCol]), ",", "."), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid frequency on row %d: %w", n+2, err)
+		}
+		frequencies[text] += freq
+	}
+
+	words := make([]string, 0, len(frequencies))
+	for text := range frequencies {
+		words = append(words, text)
+	}
+	sort.Slice(words, func(a, b int) bool {
+		if frequencies[words[a]] != frequencies[words[b]] {
+			return frequencies[words[a]] > frequencies[words[b]]
+		}
+		return words[a] < words[b]
+	})
+
+	ranks := make(map[string]int, len(words))
+	for i, text := range words {
+		ranks[text] = i + 1
+	}
+	return ranks, nil
+}
+
+// parseRanked ranks words by their line, keeping the first occurrence
+func parseRanked(lines []string) map[string]int {
+	ranks := make(map[string]int, len(lines))
+	rank := 0
+	for _, line := range lines {
+		text, _, _ := strings.Cut(line, "\t")
+		text = normalize(text)
+		if text == "" {
+			continue
+		}
+		if _, seen := ranks[text]; seen {
+			continue
+		}
+		rank++
+		ranks[text] = rank
+	}
+	return ranks
+}
+
+// columnIndex returns the index of the first header matching one of names,
+// ignoring case, or -1
+func columnIndex(header []string, names []string) int {
+	for _, name := range names {
+		for i, column := range header {
+			if strings.EqualFold(strings.TrimSpace(column), name) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// normalize returns the lookup key of a form
+func normalize(text string) string {
+	return strings.ToLower(strings.TrimSpace(text))
+}
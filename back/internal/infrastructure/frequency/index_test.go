@@ -0,0 +1,112 @@
+package frequency
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected map[string]int
+	}{
+		{
+			name:     "ranked list",
+			input:    "# most frequent first\nthe\t5000\nof\nThe\n\nand\n",
+			expected: map[string]int{"the": 1, "of": 2, "and": 3},
+		},
+		{
+			name: "lexique table",
+			input: "ortho\tcgram\tfreqfilms2\tfreqlivres\n" +
+				"maison\tNOM\t500,5\t600\n" +
+				"être\tVER\t9000\t8000\n" +
+				"être\tNOM\t10\t5\n" +
+				"flâner\tVER\t2.1\t3\n",
+			expected: map[string]int{"être": 1, "maison": 2, "flâner": 3},
+		},
+		{
+			name:     "subtlex table",
+			input:    "Word\tFREQcount\tCDcount\tSUBTLWF\nyou\t2134713\t8381\t41857\nhouse\t15393\t3901\t301\n",
+			expected: map[string]int{"you": 1, "house": 2},
+		},
+		{
+			name:     "empty",
+			input:    "# nothing\n",
+			expected: map[string]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Execute
+			ranks, err := Parse(strings.NewReader(tt.input))
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ranks)
+		})
+	}
+}
+
+func TestParse_InvalidFrequency(t *testing.T) {
+	// Execute
+	_, err := Parse(strings.NewReader("ortho\tfreqfilms2\nmaison\tlots\n"))
+
+	// Assert
+	assert.ErrorContains(t, err, "row 2")
+}
+
+func TestLoad(t *testing.T) {
+	// Setup
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "fr.tsv"), []byte("ortho\tfreqfilms2\nmaison\t500\nchat\t900\n"), 0o600))
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+
+	// Execute
+	index, err := Load(dir, []string{"fr", "en"}, logger)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, index.HasLanguage("fr"))
+	assert.False(t, index.HasLanguage("en"))
+
+	rank, ok := index.Rank(" Maison ", "fr")
+	assert.True(t, ok)
+	assert.Equal(t, 2, rank)
+
+	_, ok = index.Rank("maison", "en")
+	assert.False(t, ok)
+}
+
+func TestLoad_NoDirectory(t *testing.T) {
+	// Execute
+	index, err := Load("", []string{"fr"}, zerolog.Nop())
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, index.HasLanguage("fr"))
+}
+
+func TestLoad_MissingDirectory(t *testing.T) {
+	// Execute
+	_, err := Load(filepath.Join(t.TempDir(), "missing"), []string{"fr"}, zerolog.Nop())
+
+	// Assert
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestLoad_EmptyDirectory(t *testing.T) {
+	// Execute
+	_, err := Load(t.TempDir(), []string{"fr", "en"}, zerolog.Nop())
+
+	// Assert
+	assert.ErrorIs(t, err, ErrNoLists)
+}
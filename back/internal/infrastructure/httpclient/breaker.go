@@ -0,0 +1,68 @@
+package httpclient
+
+import (
+	"sync"
+	"time"
+)
+
+// breaker is a circuit breaker. It opens after threshold consecutive
+// failures, rejecting requests until the cooldown is over; then a single
+// trial request closes it on success or opens it again on failure.
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+	clock     func() time.Time
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time // Zero while closed
+	trial     bool      // Whether the trial request is in flight
+}
+
+// newBreaker creates a breaker; a threshold of zero never opens it
+func newBreaker(threshold int, cooldown time.Duration, clock func() time.Time) *breaker {
+	return &breaker{threshold: threshold, cooldown: cooldown, clock: clock}
+}
+
+// allow reports whether a request may be sent, and whether it is the trial
+// request, which must be released once done
+func (b *breaker) allow() (allowed, trial bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.openUntil.IsZero() {
+		return true, false
+	}
+	if b.trial || b.clock().Before(b.openUntil) {
+		return false, false
+	}
+	b.trial = true
+	return true, true
+}
+
+// release ends the trial request. A trial canceled before its outcome was
+// recorded leaves the breaker open, for the next request to try again.
+func (b *breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+}
+
+// success records a request that reached a working host, closing the breaker
+func (b *breaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.openUntil = time.Time{}
+	b.trial = false
+}
+
+// failure records a failed request, opening the breaker at the threshold
+func (b *breaker) failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	b.trial = false
+	if b.threshold > 0 && b.failures >= b.threshold {
+		b.openUntil = b.clock().Add(b.cooldown)
+	}
+}
@@ -0,0 +1,234 @@
+// Package httpclient provides the outbound HTTP client shared by the
+// dictionary scrapers. It identifies itself honestly, rate limits requests
+// per host, retries throttled and failed requests with backoff, and stops
+// calling a host that keeps failing.
+package httpclient
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/time/rate"
+)
+
+// ErrCircuitOpen is returned without calling a host that failed repeatedly,
+// until its cooldown is over
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// Config tunes the client. A zero field disables the matching behavior.
+type Config struct {
+	UserAgent string        // Sent with every request, with a way to contact the operator
+	Timeout   time.Duration // Limit for a whole request, retries included
+
+	Rate  float64 // Requests per second allowed to each host
+	Burst int     // Requests allowed at once to each host
+
+	MaxRetries int           // Retries of a request answered 429 or 5xx, or failing
+	BaseDelay  time.Duration // Delay before the first retry, doubled for each following one
+	MaxDelay   time.Duration // Longest delay before a retry; a longer Retry-After stops retrying
+
+	FailureThreshold int           // Consecutive failures opening the circuit of a host
+	Cooldown         time.Duration // Time the circuit stays open before a trial request
+}
+
+// DefaultConfig returns the default configuration. Its User-Agent only names
+// the product: build one with UserAgent and a contact before scraping.
+func DefaultConfig() Config {
+	return Config{
+		UserAgent:        "voconsteroid/1.0",
+		Timeout:          30 * time.Second,
+		Rate:             5,
+		Burst:            10,
+		MaxRetries:       3,
+		BaseDelay:        500 * time.Millisecond,
+		MaxDelay:         10 * time.Second,
+		FailureThreshold: 5,
+		Cooldown:         30 * time.Second,
+	}
+}
+
+// UserAgent builds an honest User-Agent from a product and a contact, such
+// as an email address or a URL, as Wikimedia asks of automated clients
+func UserAgent(product, contact string) string {
+	if contact == "" {
+		return product
+	}
+	return fmt.Sprintf("%s (+%s)", product, contact)
+}
+
+// New creates a client sending requests through a Transport
+func New(cfg Config, logger zerolog.Logger) *http.Client {
+	return &http.Client{
+		Transport: NewTransport(http.DefaultTransport, cfg, logger),
+		Timeout:   cfg.Timeout,
+	}
+}
+
+// host holds the limiter and circuit breaker of a host
+type host struct {
+	limiter *rate.Limiter
+	breaker *breaker
+}
+
+// Transport is an http.RoundTripper applying the Config to requests sent
+// through the next round tripper. Colly collectors use it too.
+type Transport struct {
+	next   http.RoundTripper
+	cfg    Config
+	clock  func() time.Time
+	sleep  func(d time.Duration, req *http.Request) error
+	logger zerolog.Logger
+
+	mu    sync.Mutex
+	hosts map[string]*host
+}
+
+// Ensure Transport implements http.RoundTripper
+var _ http.RoundTripper = (*Transport)(nil)
+
+// NewTransport creates a transport sending requests through next
+func NewTransport(next http.RoundTripper, cfg Config, logger zerolog.Logger) *Transport {
+	return &Transport{
+		next:   next,
+		cfg:    cfg,
+		clock:  time.Now,
+		sleep:  sleepContext,
+		logger: logger.With().Str("component", "http_client").Logger(),
+		hosts:  make(map[string]*host),
+	}
+}
+
+// RoundTrip sends a request, waiting for the host's rate limit and retrying
+// it when throttled or failing. A response still failing after the last
+// retry is returned as is, for the caller to report its status.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	h := t.host(req.URL.Host)
+	allowed, trial := h.breaker.allow()
+	if !allowed {
+		return nil, fmt.Errorf("%s: %w", req.URL.Host, ErrCircuitOpen)
+	}
+	if trial {
+		defer h.breaker.release()
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := h.limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+
+		resp, err := t.next.RoundTrip(t.prepare(req))
+		failed := err != nil || retryable(resp.StatusCode)
+		if !failed {
+			h.breaker.success()
+			return resp, nil
+		}
+		if req.Context().Err() != nil {
+			return resp, err
+		}
+		delay, ok := t.delay(attempt, resp)
+		if !ok || attempt >= t.cfg.MaxRetries || (req.Body != nil && req.GetBody == nil) {
+			h.breaker.failure()
+			return resp, err
+		}
+
+		event := t.logger.Debug().Str("url", req.URL.String()).Int("attempt", attempt+1).Dur("delay", delay)
+		if err != nil {
+			event.Err(err).Msg("Request failed, retrying")
+		} else {
+			event.Int("status", resp.StatusCode).Msg("Request failed, retrying")
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if err := t.sleep(delay, req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// host returns the limiter and breaker of a host, creating them on first use
+func (t *Transport) host(name string) *host {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	h, ok := t.hosts[name]
+	if !ok {
+		limit := rate.Limit(t.cfg.Rate)
+		if t.cfg.Rate <= 0 {
+			limit = rate.Inf
+		}
+		h = &host{
+			limiter: rate.NewLimiter(limit, max(t.cfg.Burst, 1)),
+			breaker: newBreaker(t.cfg.FailureThreshold, t.cfg.Cooldown, t.clock),
+		}
+		t.hosts[name] = h
+	}
+	return h
+}
+
+// prepare returns a copy of the request ready to be sent, with the
+// User-Agent set and a fresh body for retries
+func (t *Transport) prepare(req *http.Request) *http.Request {
+	out := req.Clone(req.Context())
+	if t.cfg.UserAgent != "" {
+		out.Header.Set("User-Agent", t.cfg.UserAgent)
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			out.Body = body
+		}
+	}
+	return out
+}
+
+// delay returns how long to wait before a retry: the Retry-After of the
+// response when there is one, an exponential backoff capped at MaxDelay
+// otherwise. It reports false when the Retry-After is beyond MaxDelay, as
+// retrying sooner would be refused again.
+func (t *Transport) delay(attempt int, resp *http.Response) (time.Duration, bool) {
+	if resp != nil {
+		if after, ok := retryAfter(resp.Header.Get("Retry-After"), t.clock()); ok {
+			return after, t.cfg.MaxDelay <= 0 || after <= t.cfg.MaxDelay
+		}
+	}
+	delay := t.cfg.BaseDelay << attempt
+	if t.cfg.MaxDelay > 0 && delay > t.cfg.MaxDelay {
+		delay = t.cfg.MaxDelay
+	}
+	return delay, true
+}
+
+// retryable reports whether a status asks to try again later
+func retryable(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// retryAfter parses a Retry-After header, given in seconds or as a date
+func retryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// sleepContext waits for d, or until the request is canceled
+func sleepContext(d time.Duration, req *http.Request) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
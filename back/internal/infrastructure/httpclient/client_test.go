@@ -0,0 +1,263 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+// setupTestTransport creates a transport recording its delays instead of sleeping
+func setupTestTransport(t *testing.T, cfg Config) (*Transport, *[]time.Duration) {
+	transport := NewTransport(http.DefaultTransport, cfg, zerolog.New(zerolog.NewTestWriter(t)))
+	delays := &[]time.Duration{}
+	transport.sleep = func(d time.Duration, _ *http.Request) error {
+		*delays = append(*delays, d)
+		return nil
+	}
+	return transport, delays
+}
+
+// get sends a GET request through a transport
+func get(t *testing.T, transport *Transport, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	require.NoError(t, err)
+	resp, err := transport.RoundTrip(req)
+	if resp != nil {
+		t.Cleanup(func() { resp.Body.Close() })
+	}
+	return resp, err
+}
+
+func TestTransport_UserAgent(t *testing.T) {
+	// Setup
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.UserAgent()
+	}))
+	defer server.Close()
+	transport, _ := setupTestTransport(t, Config{UserAgent: UserAgent("voconsteroid/1.0", "admin@example.org")})
+
+	// Execute
+	_, err := get(t, transport, server.URL)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "voconsteroid/1.0 (+admin@example.org)", userAgent)
+}
+
+func TestTransport_Retries(t *testing.T) {
+	t.Run("backs off on server errors", func(t *testing.T) {
+		// Setup
+		var calls atomic.Int64
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) < 3 {
+				w.WriteHeader(http.StatusBadGateway)
+			}
+		}))
+		defer server.Close()
+		transport, delays := setupTestTransport(t, Config{MaxRetries: 3, BaseDelay: time.Second, MaxDelay: time.Minute})
+
+		// Execute
+		resp, err := get(t, transport, server.URL)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, *delays)
+	})
+
+	t.Run("honors Retry-After", func(t *testing.T) {
+		// Setup
+		var calls atomic.Int64
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				w.Header().Set("Retry-After", "7")
+				w.WriteHeader(http.StatusTooManyRequests)
+			}
+		}))
+		defer server.Close()
+		transport, delays := setupTestTransport(t, Config{MaxRetries: 3, BaseDelay: time.Second, MaxDelay: time.Minute})
+
+		// Execute
+		resp, err := get(t, transport, server.URL)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []time.Duration{7 * time.Second}, *delays)
+	})
+
+	t.Run("does not wait for a Retry-After beyond the longest delay", func(t *testing.T) {
+		// Setup
+		var calls atomic.Int64
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+		transport, delays := setupTestTransport(t, Config{MaxRetries: 3, BaseDelay: time.Second, MaxDelay: 10 * time.Second})
+
+		// Execute
+		resp, err := get(t, transport, server.URL)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(t, int64(1), calls.Load())
+		assert.Empty(t, *delays)
+	})
+
+	t.Run("returns the last response", func(t *testing.T) {
+		// Setup
+		var calls atomic.Int64
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		transport, delays := setupTestTransport(t, Config{MaxRetries: 2, BaseDelay: time.Second, MaxDelay: 1500 * time.Millisecond})
+
+		// Execute
+		resp, err := get(t, transport, server.URL)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, int64(3), calls.Load())
+		assert.Equal(t, []time.Duration{time.Second, 1500 * time.Millisecond}, *delays)
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		// Setup
+		var calls atomic.Int64
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+		transport, _ := setupTestTransport(t, Config{MaxRetries: 3})
+
+		// Execute
+		resp, err := get(t, transport, server.URL)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		assert.Equal(t, int64(1), calls.Load())
+	})
+}
+
+func TestTransport_CircuitBreaker(t *testing.T) {
+	// Setup
+	var down atomic.Bool
+	down.Store(true)
+	var calls atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if down.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	transport, _ := setupTestTransport(t, Config{FailureThreshold: 2, Cooldown: time.Minute})
+	transport.clock = func() time.Time { return now }
+
+	// Execute
+	_, _ = get(t, transport, server.URL)
+	_, _ = get(t, transport, server.URL)
+	_, errOpen := get(t, transport, server.URL)
+
+	now = now.Add(time.Minute)
+	down.Store(false)
+	resp, errTrial := get(t, transport, server.URL)
+
+	// Assert
+	assert.True(t, errors.Is(errOpen, ErrCircuitOpen))
+	require.NoError(t, errTrial)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int64(3), calls.Load())
+}
+
+func TestTransport_CircuitBreakerCanceledTrial(t *testing.T) {
+	// Setup
+	var down atomic.Bool
+	down.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	transport, _ := setupTestTransport(t, Config{Rate: 1, Burst: 1, FailureThreshold: 1, Cooldown: time.Minute})
+	transport.clock = func() time.Time { return now }
+	_, _ = get(t, transport, server.URL)
+	now = now.Add(time.Minute)
+	down.Store(false)
+
+	// Execute: the trial request is canceled while waiting for the rate limit
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	_, errCanceled := transport.RoundTrip(req)
+
+	transport.hosts[req.URL.Host].limiter.SetLimit(rate.Inf)
+	resp, errNext := get(t, transport, server.URL)
+
+	// Assert
+	assert.ErrorIs(t, errCanceled, context.Canceled)
+	require.NoError(t, errNext, "a canceled trial lets the next request try")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestTransport_RateLimit(t *testing.T) {
+	// Setup
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	transport, _ := setupTestTransport(t, Config{Rate: 20, Burst: 1})
+
+	// Execute
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err := get(t, transport, server.URL)
+		require.NoError(t, err)
+	}
+
+	// Assert
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"120", 2 * time.Minute, true},
+		{"Fri, 01 Mar 2024 09:00:30 GMT", 30 * time.Second, true},
+		{"Fri, 01 Mar 2024 08:00:00 GMT", 0, true},
+		{"soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok := retryAfter(tt.value, now)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+-- Create saved_words table
+CREATE TABLE IF NOT EXISTS saved_words (
+    id UUID PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    word_id UUID NOT NULL REFERENCES words(id) ON DELETE CASCADE,
+    context_sentence TEXT NOT NULL DEFAULT '',
+    book_title TEXT NOT NULL DEFAULT '',
+    book_author TEXT NOT NULL DEFAULT '',
+    location TEXT NOT NULL DEFAULT '',
+    note TEXT NOT NULL DEFAULT '',
+    suggested_definition INTEGER,
+    search_vector TSVECTOR GENERATED ALWAYS AS (
+        to_tsvector('simple', context_sentence || ' ' || book_title || ' ' || book_author || ' ' || note)
+    ) STORED,
+    saved_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_interacted_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    UNIQUE(user_id, word_id)
+);
+
+-- Create index on user_id and last interaction for listing a user's words
+CREATE INDEX IF NOT EXISTS idx_saved_words_user_interacted ON saved_words(user_id, last_interacted_at DESC);
+
+-- Create index on the reading context for full-text search
+CREATE INDEX IF NOT EXISTS idx_saved_words_search_vector ON saved_words USING GIN(search_vector);
+
+COMMENT ON COLUMN saved_words.context_sentence IS 'Sentence the word was met in';
+COMMENT ON COLUMN saved_words.location IS 'Page, chapter or e-reader location';
+COMMENT ON COLUMN saved_words.suggested_definition IS 'Index of the definition matching the sentence';
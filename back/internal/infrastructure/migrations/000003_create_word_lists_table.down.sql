@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS word_list_items;
+DROP TABLE IF EXISTS word_lists;
@@ -0,0 +1,25 @@
+-- Create word_lists table
+CREATE TABLE IF NOT EXISTS word_lists (
+    id UUID PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    share_token TEXT UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    UNIQUE(user_id, name)
+);
+
+-- Create word_list_items table holding the ordered saved words of each list
+CREATE TABLE IF NOT EXISTS word_list_items (
+    list_id UUID NOT NULL REFERENCES word_lists(id) ON DELETE CASCADE,
+    saved_word_id UUID NOT NULL REFERENCES saved_words(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (list_id, saved_word_id)
+);
+
+-- Create index on saved_word_id so deleting a saved word stays cheap
+CREATE INDEX IF NOT EXISTS idx_word_list_items_saved_word ON word_list_items(saved_word_id);
+
+COMMENT ON COLUMN word_lists.share_token IS 'Public read-only link token, NULL when the list is private';
+COMMENT ON COLUMN word_list_items.position IS 'Manual order of the saved word within the list';
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS trg_word_lists_delete_tag_assignments ON word_lists;
+DROP TRIGGER IF EXISTS trg_saved_words_delete_tag_assignments ON saved_words;
+DROP FUNCTION IF EXISTS delete_tag_assignments();
+DROP TABLE IF EXISTS tag_assignments;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,44 @@
+-- Create tags table
+CREATE TABLE IF NOT EXISTS tags (
+    id UUID PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Tag names are unique per user regardless of case; the index also serves prefix autocomplete
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_user_name ON tags(user_id, lower(name) text_pattern_ops);
+
+-- Create tag_assignments table linking tags to saved words and word lists
+CREATE TABLE IF NOT EXISTS tag_assignments (
+    id UUID PRIMARY KEY,
+    tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    target_id UUID NOT NULL,
+    target_type TEXT NOT NULL CHECK (target_type IN ('saved_word', 'word_list')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    UNIQUE(tag_id, target_id, target_type)
+);
+
+-- Create index on the target for tag filters and target cleanup
+CREATE INDEX IF NOT EXISTS idx_tag_assignments_target ON tag_assignments(target_type, target_id);
+
+-- Targets are polymorphic, so assignments are removed by triggers instead of foreign keys
+CREATE OR REPLACE FUNCTION delete_tag_assignments() RETURNS TRIGGER AS $$
+BEGIN
+    DELETE FROM tag_assignments WHERE target_type = TG_ARGV[0] AND target_id = OLD.id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_saved_words_delete_tag_assignments ON saved_words;
+CREATE TRIGGER trg_saved_words_delete_tag_assignments
+    AFTER DELETE ON saved_words
+    FOR EACH ROW EXECUTE FUNCTION delete_tag_assignments('saved_word');
+
+DROP TRIGGER IF EXISTS trg_word_lists_delete_tag_assignments ON word_lists;
+CREATE TRIGGER trg_word_lists_delete_tag_assignments
+    AFTER DELETE ON word_lists
+    FOR EACH ROW EXECUTE FUNCTION delete_tag_assignments('word_list');
+
+COMMENT ON COLUMN tag_assignments.target_type IS 'Kind of tagged object: saved_word or word_list';
@@ -0,0 +1,40 @@
+-- Create review_cards table holding the spaced repetition state of each saved word
+CREATE TABLE IF NOT EXISTS review_cards (
+    saved_word_id UUID PRIMARY KEY REFERENCES saved_words(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    state TEXT NOT NULL DEFAULT 'new',
+    ease DOUBLE PRECISION NOT NULL DEFAULT 2.5,
+    stability DOUBLE PRECISION NOT NULL DEFAULT 0,
+    difficulty DOUBLE PRECISION NOT NULL DEFAULT 0,
+    interval_days INTEGER NOT NULL DEFAULT 0,
+    repetitions INTEGER NOT NULL DEFAULT 0,
+    lapses INTEGER NOT NULL DEFAULT 0,
+    due_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Create index on user_id and due date for the review queue
+CREATE INDEX IF NOT EXISTS idx_review_cards_user_due ON review_cards(user_id, due_at);
+
+-- Create review_logs table recording every graded review
+CREATE TABLE IF NOT EXISTS review_logs (
+    id UUID PRIMARY KEY,
+    saved_word_id UUID NOT NULL REFERENCES saved_words(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    grade SMALLINT NOT NULL CHECK (grade BETWEEN 1 AND 4),
+    state TEXT NOT NULL,
+    elapsed_days INTEGER NOT NULL,
+    scheduled_days INTEGER NOT NULL,
+    duration_ms INTEGER NOT NULL DEFAULT 0,
+    reviewed_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Create index on user_id and review time for history and statistics
+CREATE INDEX IF NOT EXISTS idx_review_logs_user_reviewed ON review_logs(user_id, reviewed_at);
+
+COMMENT ON COLUMN review_cards.ease IS 'SM-2 easiness factor';
+COMMENT ON COLUMN review_cards.stability IS 'FSRS memory stability in days';
+COMMENT ON COLUMN review_cards.difficulty IS 'FSRS difficulty from 1 to 10';
+COMMENT ON COLUMN review_logs.state IS 'Card state before the review';
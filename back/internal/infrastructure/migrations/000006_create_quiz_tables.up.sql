@@ -0,0 +1,53 @@
+-- Create quiz_sessions table holding the progress and score of each quiz
+CREATE TABLE IF NOT EXISTS quiz_sessions (
+    id UUID PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    source TEXT NOT NULL CHECK (source IN ('list', 'tag', 'due')),
+    source_ref TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'in_progress',
+    question_count INTEGER NOT NULL,
+    answered_count INTEGER NOT NULL DEFAULT 0,
+    correct_count INTEGER NOT NULL DEFAULT 0,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Create index on user_id and start time for a user's quiz history
+CREATE INDEX IF NOT EXISTS idx_quiz_sessions_user_started ON quiz_sessions(user_id, started_at DESC);
+
+-- Create quiz_questions table holding the questions of a session and their answer keys
+CREATE TABLE IF NOT EXISTS quiz_questions (
+    id UUID PRIMARY KEY,
+    session_id UUID NOT NULL REFERENCES quiz_sessions(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    saved_word_id UUID REFERENCES saved_words(id) ON DELETE SET NULL,
+    word_id UUID NOT NULL,
+    language VARCHAR(10) NOT NULL,
+    type TEXT NOT NULL,
+    prompt TEXT NOT NULL,
+    target_language VARCHAR(10) NOT NULL DEFAULT '',
+    choices TEXT[] NOT NULL DEFAULT '{}',
+    answers TEXT[] NOT NULL,
+    served_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE(session_id, position)
+);
+
+-- Create quiz_attempts table recording the answer given to each question
+CREATE TABLE IF NOT EXISTS quiz_attempts (
+    id UUID PRIMARY KEY,
+    session_id UUID NOT NULL REFERENCES quiz_sessions(id) ON DELETE CASCADE,
+    question_id UUID NOT NULL UNIQUE REFERENCES quiz_questions(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    answer TEXT NOT NULL,
+    correct BOOLEAN NOT NULL,
+    latency_ms INTEGER NOT NULL DEFAULT 0,
+    answered_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Create index on user_id and answer time for statistics
+CREATE INDEX IF NOT EXISTS idx_quiz_attempts_user_answered ON quiz_attempts(user_id, answered_at);
+
+COMMENT ON COLUMN quiz_sessions.source_ref IS 'Word list ID or tag expression the session was built from';
+COMMENT ON COLUMN quiz_questions.answers IS 'Accepted answers, never sent to clients';
+COMMENT ON COLUMN quiz_attempts.latency_ms IS 'Time between serving the question and receiving the answer';
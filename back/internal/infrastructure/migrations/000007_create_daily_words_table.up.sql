@@ -0,0 +1,13 @@
+-- Create daily_words table recording the word of the day per language, either
+-- global (empty user_id) or personal
+CREATE TABLE IF NOT EXISTS daily_words (
+    date DATE NOT NULL,
+    language VARCHAR(10) NOT NULL,
+    user_id TEXT NOT NULL DEFAULT '',
+    word_id UUID NOT NULL REFERENCES words(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (date, language, user_id)
+);
+
+-- Create index on language, user_id and date for history and repeat checks
+CREATE INDEX IF NOT EXISTS idx_daily_words_language_user_date ON daily_words(language, user_id, date DESC);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_words_language_frequency_rank;
+DROP INDEX IF EXISTS idx_words_language_difficulty;
+ALTER TABLE words DROP COLUMN IF EXISTS difficulty;
+ALTER TABLE words DROP COLUMN IF EXISTS frequency_rank;
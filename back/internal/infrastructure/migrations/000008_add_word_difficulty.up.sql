@@ -0,0 +1,12 @@
+-- Add the frequency rank and CEFR-like difficulty band of each word; both are
+-- NULL until the word is scored, and the rank stays NULL for unlisted words
+ALTER TABLE words ADD COLUMN IF NOT EXISTS frequency_rank INTEGER;
+ALTER TABLE words ADD COLUMN IF NOT EXISTS difficulty VARCHAR(2)
+    CHECK (difficulty IN ('A1', 'A2', 'B1', 'B2', 'C1', 'C2'));
+
+-- Create indexes for browsing words by difficulty or frequency
+CREATE INDEX IF NOT EXISTS idx_words_language_difficulty ON words(language, difficulty);
+CREATE INDEX IF NOT EXISTS idx_words_language_frequency_rank ON words(language, frequency_rank);
+
+COMMENT ON COLUMN words.frequency_rank IS 'Rank in the language frequency list, 1 being the most frequent';
+COMMENT ON COLUMN words.difficulty IS 'CEFR-like band derived from the frequency rank';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS user_badges;
+DROP TABLE IF EXISTS xp_events;
+DROP TABLE IF EXISTS user_progress;
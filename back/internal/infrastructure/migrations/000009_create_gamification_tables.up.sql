@@ -0,0 +1,35 @@
+-- Create user_progress table holding the XP, level, streak and activity counters of each user
+CREATE TABLE IF NOT EXISTS user_progress (
+    user_id TEXT PRIMARY KEY,
+    xp INTEGER NOT NULL DEFAULT 0,
+    level INTEGER NOT NULL DEFAULT 1,
+    current_streak INTEGER NOT NULL DEFAULT 0,
+    longest_streak INTEGER NOT NULL DEFAULT 0,
+    last_active_day DATE,
+    freeze_tokens INTEGER NOT NULL DEFAULT 0,
+    timezone TEXT NOT NULL DEFAULT 'UTC',
+    counters JSONB NOT NULL DEFAULT '{}'::jsonb,
+    day_xp JSONB NOT NULL DEFAULT '{}'::jsonb,
+    version INTEGER NOT NULL DEFAULT 1,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Create xp_events table recording the XP awarded for each event
+CREATE TABLE IF NOT EXISTS xp_events (
+    id BIGSERIAL PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    event_type TEXT NOT NULL,
+    xp INTEGER NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Create index on user_id and occurrence time for a user's XP history
+CREATE INDEX IF NOT EXISTS idx_xp_events_user_occurred ON xp_events(user_id, occurred_at DESC);
+
+-- Create user_badges table recording the badges unlocked by each user
+CREATE TABLE IF NOT EXISTS user_badges (
+    user_id TEXT NOT NULL,
+    badge_id TEXT NOT NULL,
+    unlocked_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (user_id, badge_id)
+);
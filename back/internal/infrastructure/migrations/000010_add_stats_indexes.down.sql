@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_review_logs_user_scheduled;
+DROP INDEX IF EXISTS idx_saved_words_user_saved;
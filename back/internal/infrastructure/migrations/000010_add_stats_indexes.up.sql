@@ -0,0 +1,5 @@
+-- Create index on user_id and save time for the saved words time series
+CREATE INDEX IF NOT EXISTS idx_saved_words_user_saved ON saved_words(user_id, saved_at);
+
+-- Create index on user_id and interval for the words mastered time series
+CREATE INDEX IF NOT EXISTS idx_review_logs_user_scheduled ON review_logs(user_id, scheduled_days);
@@ -0,0 +1,22 @@
+-- Create event_outbox table holding domain events awaiting delivery to an
+-- asynchronous subscriber, one row per event and subscriber
+CREATE TABLE IF NOT EXISTS event_outbox (
+    id UUID PRIMARY KEY,
+    event_name TEXT NOT NULL,
+    subscriber TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    failed_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Create partial index on the pending events for the dispatcher
+CREATE INDEX IF NOT EXISTS idx_event_outbox_pending ON event_outbox(next_attempt_at)
+    WHERE delivered_at IS NULL AND failed_at IS NULL;
+
+COMMENT ON COLUMN event_outbox.subscriber IS 'Name of the asynchronous subscriber the event is delivered to';
+COMMENT ON COLUMN event_outbox.next_attempt_at IS 'Next delivery attempt, or end of the lease while being delivered';
+COMMENT ON COLUMN event_outbox.failed_at IS 'Set when the event was given up after repeated failures';
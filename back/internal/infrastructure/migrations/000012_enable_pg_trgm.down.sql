@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_words_text_trgm;
+DROP EXTENSION IF EXISTS pg_trgm;
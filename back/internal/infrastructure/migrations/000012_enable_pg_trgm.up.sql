@@ -0,0 +1,5 @@
+-- Enable trigram matching, used to rank autocomplete results by similarity
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Create trigram index on text for ordering by distance to the prefix
+CREATE INDEX IF NOT EXISTS idx_words_text_trgm ON words USING GIST (text gist_trgm_ops);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS job_controls;
+DROP TABLE IF EXISTS refresh_jobs;
+DROP INDEX IF EXISTS idx_words_last_checked;
+ALTER TABLE words DROP COLUMN IF EXISTS checked_at;
+ALTER TABLE words DROP COLUMN IF EXISTS parser_version;
@@ -0,0 +1,36 @@
+-- Add the version of the parser each word was fetched with, and when the
+-- refresh worker last compared it with the dictionary
+ALTER TABLE words ADD COLUMN IF NOT EXISTS parser_version INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE words ADD COLUMN IF NOT EXISTS checked_at TIMESTAMP WITH TIME ZONE;
+
+-- Create index on the last update or check for selecting stale words
+CREATE INDEX IF NOT EXISTS idx_words_last_checked ON words((GREATEST(checked_at, updated_at)));
+
+-- Create refresh_jobs table recording each run of the refresh worker
+CREATE TABLE IF NOT EXISTS refresh_jobs (
+    id UUID PRIMARY KEY,
+    status TEXT NOT NULL CHECK (status IN ('running', 'completed', 'paused', 'failed', 'interrupted')),
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE,
+    selected INTEGER NOT NULL DEFAULT 0,
+    refreshed INTEGER NOT NULL DEFAULT 0,
+    unchanged INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT ''
+);
+
+-- Only one job runs at a time, across replicas
+CREATE UNIQUE INDEX IF NOT EXISTS idx_refresh_jobs_running ON refresh_jobs((TRUE)) WHERE status = 'running';
+
+-- Create index on start time for the job history
+CREATE INDEX IF NOT EXISTS idx_refresh_jobs_started_at ON refresh_jobs(started_at DESC);
+
+-- Create job_controls table holding the pause switch of background jobs
+CREATE TABLE IF NOT EXISTS job_controls (
+    name TEXT PRIMARY KEY,
+    paused BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+COMMENT ON COLUMN words.parser_version IS 'Version of the dictionary parser the word was fetched with';
+COMMENT ON COLUMN words.checked_at IS 'Last time the refresh worker fetched the word again';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_words_source_parser_version;
+ALTER TABLE words DROP COLUMN IF EXISTS fetched_at;
+ALTER TABLE words DROP COLUMN IF EXISTS source_revision;
+ALTER TABLE words DROP COLUMN IF EXISTS source_url;
+ALTER TABLE words DROP COLUMN IF EXISTS source;
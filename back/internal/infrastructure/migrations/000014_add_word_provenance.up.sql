@@ -0,0 +1,13 @@
+-- Add where each word was fetched from, next to its parser version
+ALTER TABLE words ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT '';
+ALTER TABLE words ADD COLUMN IF NOT EXISTS source_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE words ADD COLUMN IF NOT EXISTS source_revision BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE words ADD COLUMN IF NOT EXISTS fetched_at TIMESTAMP WITH TIME ZONE;
+
+-- Create index on source and parser version for finding words to process again
+CREATE INDEX IF NOT EXISTS idx_words_source_parser_version ON words(source, parser_version);
+
+COMMENT ON COLUMN words.source IS 'Dictionary the word was fetched from, empty when unknown';
+COMMENT ON COLUMN words.source_url IS 'URL of the page the word was fetched from';
+COMMENT ON COLUMN words.source_revision IS 'Revision ID of the source page, 0 when unknown';
+COMMENT ON COLUMN words.fetched_at IS 'Time the word was fetched from its source';
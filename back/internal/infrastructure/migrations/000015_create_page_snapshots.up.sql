@@ -0,0 +1,20 @@
+-- Create page_snapshots table referencing the raw dictionary pages words
+-- were parsed from, kept in the blob store under their digest
+CREATE TABLE IF NOT EXISTS page_snapshots (
+    id UUID PRIMARY KEY,
+    text TEXT NOT NULL,
+    language VARCHAR(10) NOT NULL,
+    source TEXT NOT NULL,
+    source_url TEXT NOT NULL,
+    revision_id BIGINT NOT NULL DEFAULT 0,
+    digest CHAR(64) NOT NULL,
+    size INTEGER NOT NULL,
+    fetched_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    UNIQUE (language, text, digest)
+);
+
+-- Create index on word and fetch time for finding the last page of a word
+CREATE INDEX IF NOT EXISTS idx_page_snapshots_word ON page_snapshots(language, text, fetched_at DESC);
+
+COMMENT ON COLUMN page_snapshots.revision_id IS 'Revision ID of the page, 0 when unknown';
+COMMENT ON COLUMN page_snapshots.digest IS 'SHA-256 of the uncompressed page, in hex';
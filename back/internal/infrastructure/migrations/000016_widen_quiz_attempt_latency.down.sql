@@ -0,0 +1,2 @@
+UPDATE quiz_attempts SET latency_ms = LEAST(latency_ms, 2147483647);
+ALTER TABLE quiz_attempts ALTER COLUMN latency_ms TYPE INTEGER;
@@ -0,0 +1,3 @@
+-- Widen the answer latency, which an abandoned question can push past the
+-- range of an INTEGER of milliseconds
+ALTER TABLE quiz_attempts ALTER COLUMN latency_ms TYPE BIGINT;
@@ -0,0 +1 @@
+ALTER TABLE word_lists DROP COLUMN IF EXISTS version;
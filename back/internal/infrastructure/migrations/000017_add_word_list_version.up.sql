@@ -0,0 +1,3 @@
+-- Add a version to word lists for optimistic concurrency control, so that
+-- concurrent edits of a list are retried instead of overwriting each other
+ALTER TABLE word_lists ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_xp_events_user_event_key;
+ALTER TABLE xp_events DROP COLUMN IF EXISTS event_key;
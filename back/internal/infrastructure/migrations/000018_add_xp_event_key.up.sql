@@ -0,0 +1,5 @@
+-- Identify the occurrence behind each XP event, so that an event delivered
+-- again by the outbox is not awarded twice
+ALTER TABLE xp_events ADD COLUMN IF NOT EXISTS event_key TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_xp_events_user_event_key ON xp_events(user_id, event_key) WHERE event_key IS NOT NULL;
@@ -0,0 +1,76 @@
+package migrations
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbedded(t *testing.T) {
+	// Execute
+	migrations, err := Embedded()
+
+	// Assert
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	for i, m := range migrations {
+		assert.Equal(t, uint(i+1), m.Version, "versions should follow each other")
+		assert.NotEmpty(t, m.Up)
+		assert.NotEmpty(t, m.Down)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	// Setup
+	fsys := fstest.MapFS{
+		"000002_add_lemma.up.sql":        {Data: []byte("ALTER TABLE words ADD COLUMN lemma TEXT;")},
+		"000002_add_lemma.down.sql":      {Data: []byte("ALTER TABLE words DROP COLUMN lemma;")},
+		"000001_create_words.up.sql":     {Data: []byte("CREATE TABLE words (id UUID);")},
+		"000001_create_words.down.sql":   {Data: []byte("DROP TABLE words;")},
+		"README.md":                      {Data: []byte("Not a migration")},
+		"fixtures/000003_ignored.up.sql": {Data: []byte("SELECT 1;")},
+	}
+
+	// Execute
+	migrations, err := Load(fsys)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []Migration{
+		{Version: 1, Name: "create_words", Up: "CREATE TABLE words (id UUID);", Down: "DROP TABLE words;"},
+		{Version: 2, Name: "add_lemma", Up: "ALTER TABLE words ADD COLUMN lemma TEXT;", Down: "ALTER TABLE words DROP COLUMN lemma;"},
+	}, migrations)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	testCases := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{"unexpected name", fstest.MapFS{
+			"create_words.sql": {Data: []byte("SELECT 1;")},
+		}},
+		{"missing down", fstest.MapFS{
+			"000001_create_words.up.sql": {Data: []byte("SELECT 1;")},
+		}},
+		{"version zero", fstest.MapFS{
+			"000000_create_words.up.sql":   {Data: []byte("SELECT 1;")},
+			"000000_create_words.down.sql": {Data: []byte("SELECT 1;")},
+		}},
+		{"duplicate version", fstest.MapFS{
+			"000001_create_words.up.sql":   {Data: []byte("SELECT 1;")},
+			"000001_create_words.down.sql": {Data: []byte("SELECT 1;")},
+			"000001_create_tags.up.sql":    {Data: []byte("SELECT 1;")},
+			"000001_create_tags.down.sql":  {Data: []byte("SELECT 1;")},
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Load(tc.fsys)
+			assert.ErrorIs(t, err, ErrInvalidMigration)
+		})
+	}
+}
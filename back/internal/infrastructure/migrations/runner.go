@@ -0,0 +1,242 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+)
+
+// Runner errors
+var (
+	ErrDirty          = errors.New("database is dirty, fix it and force a version")
+	ErrUnknownVersion = errors.New("database version is not a known migration")
+)
+
+// lockID is the advisory lock held while migrating, so that replicas
+// starting together apply each migration once
+const lockID int64 = 0x766f6373 // "vocs"
+
+// The version table is compatible with golang-migrate, which applied the
+// migrations before they were built in
+const createVersionTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version BIGINT NOT NULL PRIMARY KEY,
+	dirty BOOLEAN NOT NULL
+)`
+
+// Status is the state of a database's schema
+type Status struct {
+	Version uint // Last applied migration, 0 when none was
+	Dirty   bool // golang-migrate left a migration half applied
+	Latest  uint // Last known migration
+	Pending []Migration
+}
+
+// Runner applies migrations to a database
+type Runner struct {
+	db         *pgxpool.Pool
+	migrations []Migration
+	logger     zerolog.Logger
+}
+
+// NewRunner creates a runner of migrations, sorted by version
+func NewRunner(db *pgxpool.Pool, migrations []Migration, logger zerolog.Logger) *Runner {
+	return &Runner{
+		db:         db,
+		migrations: migrations,
+		logger:     logger.With().Str("component", "migrations").Logger(),
+	}
+}
+
+// Up applies up to steps pending migrations, all of them when steps is 0,
+// and returns how many were applied
+func (r *Runner) Up(ctx context.Context, steps int) (int, error) {
+	applied := 0
+	err := r.withLock(ctx, func(conn *pgxpool.Conn) error {
+		next, err := r.position(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range r.migrations[next:] {
+			if steps > 0 && applied == steps {
+				break
+			}
+			r.logger.Info().Uint("version", m.Version).Str("name", m.Name).Msg("Applying migration")
+			if err := r.apply(ctx, conn, m.Up, m.Version); err != nil {
+				return fmt.Errorf("failed to apply migration %d_%s: %w", m.Version, m.Name, err)
+			}
+			applied++
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts up to steps applied migrations, all of them when steps is 0,
+// and returns how many were reverted
+func (r *Runner) Down(ctx context.Context, steps int) (int, error) {
+	reverted := 0
+	err := r.withLock(ctx, func(conn *pgxpool.Conn) error {
+		next, err := r.position(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := next - 1; i >= 0; i-- {
+			if steps > 0 && reverted == steps {
+				break
+			}
+			m := r.migrations[i]
+			previous := uint(0)
+			if i > 0 {
+				previous = r.migrations[i-1].Version
+			}
+			r.logger.Info().Uint("version", m.Version).Str("name", m.Name).Msg("Reverting migration")
+			if err := r.apply(ctx, conn, m.Down, previous); err != nil {
+				return fmt.Errorf("failed to revert migration %d_%s: %w", m.Version, m.Name, err)
+			}
+			reverted++
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status returns the version of the database and the pending migrations
+func (r *Runner) Status(ctx context.Context) (*Status, error) {
+	status := &Status{}
+	if len(r.migrations) > 0 {
+		status.Latest = r.migrations[len(r.migrations)-1].Version
+	}
+
+	err := r.withLock(ctx, func(conn *pgxpool.Conn) error {
+		var err error
+		status.Version, status.Dirty, err = r.version(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, m := range r.migrations {
+			if m.Version > status.Version {
+				status.Pending = append(status.Pending, m)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// Force records version as applied and clears the dirty flag without
+// running any migration, after a database was fixed by hand or created
+// from another schema source. Version 0 records that none was applied.
+func (r *Runner) Force(ctx context.Context, version uint) error {
+	if version != 0 && r.index(version) < 0 {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	return r.withLock(ctx, func(conn *pgxpool.Conn) error {
+		r.logger.Warn().Uint("version", version).Msg("Forcing migration version")
+		return r.apply(ctx, conn, "", version)
+	})
+}
+
+// withLock runs fn on a connection holding the migration lock, waiting for
+// other runners to release it
+func (r *Runner) withLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", lockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.Exec(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", lockID); err != nil {
+			r.logger.Error().Err(err).Msg("Failed to release migration lock")
+		}
+	}()
+
+	if _, err := conn.Exec(ctx, createVersionTable); err != nil {
+		return fmt.Errorf("failed to create version table: %w", err)
+	}
+	return fn(conn)
+}
+
+// version reads the version recorded in the database
+func (r *Runner) version(ctx context.Context, conn *pgxpool.Conn) (uint, bool, error) {
+	var version int64
+	var dirty bool
+	err := conn.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read migration version: %w", err)
+	}
+	return uint(version), dirty, nil
+}
+
+// position returns the index of the first migration not applied to a
+// clean database
+func (r *Runner) position(ctx context.Context, conn *pgxpool.Conn) (int, error) {
+	version, dirty, err := r.version(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+	if dirty {
+		return 0, fmt.Errorf("%w (version %d)", ErrDirty, version)
+	}
+	if version == 0 {
+		return 0, nil
+	}
+
+	i := r.index(version)
+	if i < 0 {
+		return 0, fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+	return i + 1, nil
+}
+
+// index returns the position of a version among the migrations, -1 if unknown
+func (r *Runner) index(version uint) int {
+	for i, m := range r.migrations {
+		if m.Version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+// apply runs sql and records version in one transaction, so that a failing
+// migration leaves the database as it was
+func (r *Runner) apply(ctx context.Context, conn *pgxpool.Conn, sql string, version uint) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if sql != "" {
+		if _, err := tx.Exec(ctx, sql); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec(ctx, "DELETE FROM schema_migrations"); err != nil {
+		return fmt.Errorf("failed to record migration version: %w", err)
+	}
+	if version > 0 {
+		if _, err := tx.Exec(ctx, "INSERT INTO schema_migrations (version, dirty) VALUES ($1, FALSE)", int64(version)); err != nil {
+			return fmt.Errorf("failed to record migration version: %w", err)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
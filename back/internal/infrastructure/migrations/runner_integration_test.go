@@ -0,0 +1,132 @@
+package migrations_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"voconsteroid/internal/infrastructure/migrations"
+)
+
+// setupTestDatabase creates an empty PostgreSQL container and returns a
+// connection pool
+func setupTestDatabase(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	ctx := context.Background()
+
+	postgresContainer, err := postgres.Run(ctx,
+		"postgres:16-alpine",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(5*time.Second),
+		),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := testcontainers.TerminateContainer(postgresContainer); err != nil {
+			t.Logf("failed to terminate container: %s", err)
+		}
+	})
+
+	connString, err := postgresContainer.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+	dbpool, err := pgxpool.New(ctx, connString)
+	require.NoError(t, err)
+	t.Cleanup(dbpool.Close)
+
+	return dbpool
+}
+
+func TestRunner_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	dbpool := setupTestDatabase(t)
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	embedded, err := migrations.Embedded()
+	require.NoError(t, err)
+	latest := embedded[len(embedded)-1].Version
+
+	t.Run("concurrent runners apply each migration once", func(t *testing.T) {
+		var wg sync.WaitGroup
+		applied := make([]int, 3)
+		errs := make([]error, 3)
+		for i := range applied {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				applied[i], errs[i] = migrations.NewRunner(dbpool, embedded, logger).Up(ctx, 0)
+			}()
+		}
+		wg.Wait()
+
+		for _, err := range errs {
+			require.NoError(t, err)
+		}
+		assert.Equal(t, len(embedded), applied[0]+applied[1]+applied[2])
+
+		status, err := migrations.NewRunner(dbpool, embedded, logger).Status(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, latest, status.Version)
+		assert.Empty(t, status.Pending)
+	})
+
+	runner := migrations.NewRunner(dbpool, embedded, logger)
+
+	t.Run("down reverts the last migrations", func(t *testing.T) {
+		reverted, err := runner.Down(ctx, 2)
+		require.NoError(t, err)
+		assert.Equal(t, 2, reverted)
+
+		status, err := runner.Status(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, latest-2, status.Version)
+		assert.Len(t, status.Pending, 2)
+	})
+
+	t.Run("down and up of every migration", func(t *testing.T) {
+		_, err := runner.Down(ctx, 0)
+		require.NoError(t, err)
+		var tables int
+		require.NoError(t, dbpool.QueryRow(ctx, `SELECT COUNT(*) FROM pg_tables
+			WHERE schemaname = 'public' AND tablename <> 'schema_migrations'`).Scan(&tables))
+		assert.Zero(t, tables)
+
+		applied, err := runner.Up(ctx, 0)
+		require.NoError(t, err)
+		assert.Equal(t, len(embedded), applied)
+	})
+
+	t.Run("dirty database", func(t *testing.T) {
+		_, err := dbpool.Exec(ctx, "UPDATE schema_migrations SET dirty = TRUE")
+		require.NoError(t, err)
+
+		_, err = runner.Up(ctx, 0)
+		assert.ErrorIs(t, err, migrations.ErrDirty)
+
+		require.NoError(t, runner.Force(ctx, latest))
+		status, err := runner.Status(ctx)
+		require.NoError(t, err)
+		assert.False(t, status.Dirty)
+	})
+
+	t.Run("force unknown version", func(t *testing.T) {
+		err := runner.Force(ctx, latest+1)
+		assert.ErrorIs(t, err, migrations.ErrUnknownVersion)
+	})
+}
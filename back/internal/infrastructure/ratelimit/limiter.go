@@ -0,0 +1,140 @@
+// Package ratelimit counts requests per key in fixed windows, in memory or
+// in Redis so that replicas share their counts.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Limit allows a number of requests per window. A limit without requests
+// is unlimited.
+type Limit struct {
+	Requests int           `yaml:"requests"`
+	Window   time.Duration `yaml:"window"`
+}
+
+// Unlimited reports whether the limit lets every request through
+func (l Limit) Unlimited() bool {
+	return l.Requests <= 0 || l.Window <= 0
+}
+
+// Result is the state of a key's window after a request
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	Reset     time.Duration // Time until the window starts over
+}
+
+// Limiter counts requests against limits
+type Limiter interface {
+	// Allow counts a request for key and reports whether it is within limit
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// Ensure both backends implement Limiter
+var (
+	_ Limiter = (*MemoryLimiter)(nil)
+	_ Limiter = (*RedisLimiter)(nil)
+)
+
+// result builds the result of the count-th request of a window
+func result(count int, limit Limit, reset time.Duration) Result {
+	return Result{
+		Allowed:   count <= limit.Requests,
+		Limit:     limit.Requests,
+		Remaining: max(limit.Requests-count, 0),
+		Reset:     reset,
+	}
+}
+
+// window is a key's count in the memory limiter
+type window struct {
+	count int
+	ends  time.Time
+}
+
+// MemoryLimiter is a Limiter local to the process
+type MemoryLimiter struct {
+	clock func() time.Time
+
+	mu        sync.Mutex
+	windows   map[string]*window
+	nextSweep time.Time
+}
+
+// NewMemoryLimiter creates an in-memory limiter; a nil clock uses time.Now
+func NewMemoryLimiter(clock func() time.Time) *MemoryLimiter {
+	if clock == nil {
+		clock = time.Now
+	}
+	return &MemoryLimiter{clock: clock, windows: make(map[string]*window)}
+}
+
+// Allow counts a request for key and reports whether it is within limit
+func (l *MemoryLimiter) Allow(_ context.Context, key string, limit Limit) (Result, error) {
+	now := l.clock()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+	w, ok := l.windows[key]
+	if !ok || !now.Before(w.ends) {
+		w = &window{ends: now.Add(limit.Window)}
+		l.windows[key] = w
+	}
+	w.count++
+	return result(w.count, limit, w.ends.Sub(now)), nil
+}
+
+// sweep drops ended windows, at most once a minute
+func (l *MemoryLimiter) sweep(now time.Time) {
+	if now.Before(l.nextSweep) {
+		return
+	}
+	for key, w := range l.windows {
+		if !now.Before(w.ends) {
+			delete(l.windows, key)
+		}
+	}
+	l.nextSweep = now.Add(time.Minute)
+}
+
+// allowScript counts a request and starts the window on the first one,
+// returning the count and the time left in the window in milliseconds
+var allowScript = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if count == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return {count, redis.call("PTTL", KEYS[1])}
+`)
+
+// RedisLimiter is a Limiter shared by every replica using the same Redis
+type RedisLimiter struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisLimiter creates a limiter; every key is prefixed with prefix
+func NewRedisLimiter(client redis.UniversalClient, prefix string) *RedisLimiter {
+	return &RedisLimiter{client: client, prefix: prefix}
+}
+
+// Allow counts a request for key and reports whether it is within limit
+func (l *RedisLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	values, err := allowScript.Run(ctx, l.client, []string{l.prefix + key}, limit.Window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to count request: %w", err)
+	}
+	if len(values) != 2 {
+		return Result{}, fmt.Errorf("failed to count request: unexpected reply %v", values)
+	}
+	reset := time.Duration(max(values[1], 0)) * time.Millisecond
+	return result(int(values[0]), limit, reset), nil
+}
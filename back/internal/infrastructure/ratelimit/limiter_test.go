@@ -0,0 +1,98 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiters(t *testing.T) {
+	ctx := context.Background()
+	limit := Limit{Requests: 2, Window: time.Minute}
+
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	memory := NewMemoryLimiter(func() time.Time { return now })
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	redisLimiter := NewRedisLimiter(client, "test:")
+
+	for name, tc := range map[string]struct {
+		limiter Limiter
+		advance func(time.Duration)
+	}{
+		"memory": {memory, func(d time.Duration) { now = now.Add(d) }},
+		"redis":  {redisLimiter, server.FastForward},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// Execute
+			first, err := tc.limiter.Allow(ctx, "words:ip:1.2.3.4", limit)
+			require.NoError(t, err)
+			second, err := tc.limiter.Allow(ctx, "words:ip:1.2.3.4", limit)
+			require.NoError(t, err)
+			third, err := tc.limiter.Allow(ctx, "words:ip:1.2.3.4", limit)
+			require.NoError(t, err)
+			other, err := tc.limiter.Allow(ctx, "words:ip:5.6.7.8", limit)
+			require.NoError(t, err)
+
+			tc.advance(time.Minute)
+			next, err := tc.limiter.Allow(ctx, "words:ip:1.2.3.4", limit)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, Result{Allowed: true, Limit: 2, Remaining: 1, Reset: time.Minute}, first)
+			assert.True(t, second.Allowed)
+			assert.Equal(t, 0, second.Remaining)
+			assert.False(t, third.Allowed)
+			assert.Equal(t, 0, third.Remaining)
+			assert.True(t, other.Allowed)
+			assert.True(t, next.Allowed)
+			assert.Equal(t, 1, next.Remaining)
+		})
+	}
+}
+
+func TestMemoryLimiter_SweepsEndedWindows(t *testing.T) {
+	// Setup
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	limiter := NewMemoryLimiter(func() time.Time { return now })
+	_, _ = limiter.Allow(context.Background(), "a", Limit{Requests: 1, Window: time.Second})
+
+	// Execute
+	now = now.Add(2 * time.Minute)
+	_, _ = limiter.Allow(context.Background(), "b", Limit{Requests: 1, Window: time.Second})
+
+	// Assert
+	assert.Len(t, limiter.windows, 1)
+	assert.Contains(t, limiter.windows, "b")
+}
+
+func TestParseRules(t *testing.T) {
+	t.Run("default rules", func(t *testing.T) {
+		rules, err := DefaultRules()
+		require.NoError(t, err)
+		assert.Equal(t, Limit{Requests: 60, Window: time.Minute}, rules["words"].Limit(false))
+		assert.Equal(t, Limit{Requests: 30, Window: time.Minute}, rules["fetch"].Limit(true))
+		assert.True(t, rules["unknown"].Limit(true).Unlimited())
+	})
+
+	invalid := []struct {
+		name string
+		data string
+	}{
+		{"not yaml", "words: ["},
+		{"missing window", "words:\n  user: {requests: 10}\n"},
+		{"negative requests", "words:\n  user: {requests: -1, window: 1m}\n"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRules([]byte(tt.data))
+			assert.ErrorIs(t, err, ErrInvalidRules)
+		})
+	}
+}
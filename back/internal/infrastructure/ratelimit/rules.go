@@ -0,0 +1,74 @@
+package ratelimit
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultRules is the rules file used when none is configured
+//
+//go:embed rules.yaml
+var defaultRules []byte
+
+// ErrInvalidRules is returned when a rules file cannot be used
+var ErrInvalidRules = errors.New("invalid rate limit rules")
+
+// Policy holds the limits of a group of routes: per user for authenticated
+// requests, per client IP otherwise
+type Policy struct {
+	Anonymous Limit `yaml:"anonymous"`
+	User      Limit `yaml:"user"`
+}
+
+// Limit returns the limit applying to a request
+func (p Policy) Limit(authenticated bool) Limit {
+	if authenticated {
+		return p.User
+	}
+	return p.Anonymous
+}
+
+// Rules map route groups to their policy. Groups without a policy are not
+// limited.
+type Rules map[string]Policy
+
+// DefaultRules returns the built-in rules
+func DefaultRules() (Rules, error) {
+	return ParseRules(defaultRules)
+}
+
+// LoadRules reads the rules file at path, or the built-in rules if path is empty
+func LoadRules(path string) (Rules, error) {
+	if path == "" {
+		return DefaultRules()
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rate limit rules: %w", err)
+	}
+
+	return ParseRules(data)
+}
+
+// ParseRules parses and validates a YAML rules file
+func ParseRules(data []byte) (Rules, error) {
+	var rules Rules
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRules, err)
+	}
+
+	for group, policy := range rules {
+		for _, limit := range []Limit{policy.Anonymous, policy.User} {
+			if limit.Requests < 0 || limit.Window < 0 || (limit.Requests > 0 && limit.Window == 0) {
+				return nil, fmt.Errorf("%w: group %q needs positive requests and a window", ErrInvalidRules, group)
+			}
+		}
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/dailyword"
+	"voconsteroid/internal/domain/word"
+)
+
+// dailyWordColumns lists the columns selected for a daily word, in scan order,
+// followed by the columns of its word
+const dailyWordColumns = `to_char(d.date, 'YYYY-MM-DD'), d.language, d.user_id, d.word_id, d.created_at,` + wordColumns
+
+// DailyWordRepository implements the dailyword.Repository interface using PostgreSQL
+type DailyWordRepository struct {
+	db     DBInterface
+	logger zerolog.Logger
+}
+
+// Ensure DailyWordRepository implements dailyword.Repository
+var _ dailyword.Repository = (*DailyWordRepository)(nil)
+
+// NewDailyWordRepository creates a new daily word repository
+func NewDailyWordRepository(db DBInterface, logger zerolog.Logger) *DailyWordRepository {
+	return &DailyWordRepository{
+		db:     db,
+		logger: logger.With().Str("component", "daily_word_repository").Logger(),
+	}
+}
+
+// FindByDate retrieves the word of the day recorded for a date, with its word
+func (r *DailyWordRepository) FindByDate(ctx context.Context, date, language, userID string) (*dailyword.DailyWord, error) {
+	query := `SELECT ` + dailyWordColumns + `
+		FROM daily_words d
+		JOIN words w ON w.id = d.word_id
+		WHERE d.date = $1::date AND d.language = $2 AND d.user_id = $3
+	`
+
+	daily, err := scanDailyWord(r.db.QueryRow(ctx, query, date, language, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, dailyword.ErrDailyWordNotFound
+		}
+		return nil, fmt.Errorf("failed to query daily word: %w", err)
+	}
+
+	return daily, nil
+}
+
+// Pick retrieves the first word of the language meeting the criteria in the
+// order given by seed. Hashing the word ID with the seed shuffles the words
+// differently every day while keeping the pick stable for a given day.
+func (r *DailyWordRepository) Pick(ctx context.Context, language, userID, seed string, criteria dailyword.Criteria, since string) (*word.Word, error) {
+	r.logger.Debug().Str("language", language).Str("userID", userID).Str("seed", seed).Msg("Picking daily word")
+
+	query := `SELECT ` + wordColumns + `
+		FROM words w
+		WHERE w.language = $1
+		  AND jsonb_array_length(w.definitions) >= $3
+		  AND (NOT $4 OR COALESCE(w.etymology, '') <> '')
+		  AND (NOT $5 OR EXISTS (
+		      SELECT 1 FROM jsonb_array_elements(w.definitions) def
+		      WHERE jsonb_array_length(COALESCE(def->'examples', '[]'::jsonb)) > 0
+		  ))
+		  AND NOT EXISTS (
+		      SELECT 1 FROM daily_words d
+		      WHERE d.language = $1 AND d.user_id = $2 AND d.word_id = w.id AND d.date >= $6::date
+		  )
+		  AND ($2 = '' OR NOT EXISTS (
+		      SELECT 1 FROM saved_words sw WHERE sw.user_id = $2 AND sw.word_id = w.id
+		  ))
+		  AND ($8 = '' OR w.difficulty >= $8)
+		  AND ($9 = '' OR w.difficulty <= $9)
+		ORDER BY md5(w.id::text || $7), w.id
+		LIMIT 1
+	`
+
+	w, err := scanWord(r.db.QueryRow(ctx, query,
+		language,
+		userID,
+		criteria.MinDefinitions,
+		criteria.RequireEtymology,
+		criteria.RequireExamples,
+		since,
+		seed,
+		string(criteria.MinDifficulty),
+		string(criteria.MaxDifficulty),
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, word.ErrWordNotFound
+		}
+		return nil, fmt.Errorf("failed to pick daily word: %w", err)
+	}
+
+	return w, nil
+}
+
+// Save records a word of the day; a word already recorded for the date is kept
+func (r *DailyWordRepository) Save(ctx context.Context, daily *dailyword.DailyWord) error {
+	query := `
+		INSERT INTO daily_words (date, language, user_id, word_id, created_at)
+		VALUES ($1::date, $2, $3, $4, $5)
+		ON CONFLICT (date, language, user_id) DO NOTHING
+	`
+
+	if _, err := r.db.Exec(ctx, query, daily.Date, daily.Language, daily.UserID, daily.WordID, daily.CreatedAt); err != nil {
+		return fmt.Errorf("failed to save daily word: %w", err)
+	}
+
+	return nil
+}
+
+// ListHistory retrieves the words of the day up to a date, most recent first
+func (r *DailyWordRepository) ListHistory(ctx context.Context, language, userID, until string, limit int) ([]*dailyword.DailyWord, error) {
+	query := `SELECT ` + dailyWordColumns + `
+		FROM daily_words d
+		JOIN words w ON w.id = d.word_id
+		WHERE d.language = $1 AND d.user_id = $2 AND d.date <= $3::date
+		ORDER BY d.date DESC
+		LIMIT $4
+	`
+
+	rows, err := r.db.Query(ctx, query, language, userID, until, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query daily word history: %w", err)
+	}
+	defer rows.Close()
+
+	history := make([]*dailyword.DailyWord, 0)
+	for rows.Next() {
+		daily, err := scanDailyWord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan daily word row: %w", err)
+		}
+		history = append(history, daily)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating daily word rows: %w", err)
+	}
+
+	return history, nil
+}
+
+// PreferredLanguage returns the language of most of the user's saved words, or "" if none
+func (r *DailyWordRepository) PreferredLanguage(ctx context.Context, userID string) (string, error) {
+	query := `
+		SELECT w.language
+		FROM saved_words sw
+		JOIN words w ON w.id = sw.word_id
+		WHERE sw.user_id = $1
+		GROUP BY w.language
+		ORDER BY count(*) DESC, w.language
+		LIMIT 1
+	`
+
+	var language string
+	if err := r.db.QueryRow(ctx, query, userID).Scan(&language); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to query preferred language: %w", err)
+	}
+
+	return language, nil
+}
+
+// Level returns the median difficulty of the user's scored saved words in the language
+func (r *DailyWordRepository) Level(ctx context.Context, userID, language string) (word.Difficulty, error) {
+	query := `
+		SELECT percentile_disc(0.5) WITHIN GROUP (ORDER BY w.difficulty)
+		FROM saved_words sw
+		JOIN words w ON w.id = sw.word_id
+		WHERE sw.user_id = $1 AND w.language = $2 AND w.difficulty IS NOT NULL
+	`
+
+	var level *string
+	if err := r.db.QueryRow(ctx, query, userID, language).Scan(&level); err != nil {
+		return "", fmt.Errorf("failed to query user level: %w", err)
+	}
+	if level == nil {
+		return "", nil
+	}
+
+	return word.Difficulty(*level), nil
+}
+
+// dailyWordRow adapts a row so that scanWord reads the word columns
+// following the daily word columns
+type dailyWordRow struct {
+	row   pgx.Row
+	daily *dailyword.DailyWord
+}
+
+func (r dailyWordRow) Scan(dest ...interface{}) error {
+	return r.row.Scan(append([]interface{}{
+		&r.daily.Date,
+		&r.daily.Language,
+		&r.daily.UserID,
+		&r.daily.WordID,
+		&r.daily.CreatedAt,
+	}, dest...)...)
+}
+
+// scanDailyWord scans a row selected with dailyWordColumns
+func scanDailyWord(row pgx.Row) (*dailyword.DailyWord, error) {
+	daily := &dailyword.DailyWord{}
+
+	w, err := scanWord(dailyWordRow{row: row, daily: daily})
+	if err != nil {
+		return nil, err
+	}
+
+	daily.Word = w
+	return daily, nil
+}
@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/gamification"
+)
+
+// GamificationRepository implements the gamification.Repository interface using PostgreSQL
+type GamificationRepository struct {
+	db     DBInterface
+	logger zerolog.Logger
+}
+
+// Ensure GamificationRepository implements gamification.Repository
+var _ gamification.Repository = (*GamificationRepository)(nil)
+
+// NewGamificationRepository creates a new gamification repository
+func NewGamificationRepository(db DBInterface, logger zerolog.Logger) *GamificationRepository {
+	return &GamificationRepository{
+		db:     db,
+		logger: logger.With().Str("component", "gamification_repository").Logger(),
+	}
+}
+
+// FindProgress retrieves the progress of a user
+func (r *GamificationRepository) FindProgress(ctx context.Context, userID string) (*gamification.Progress, error) {
+	query := `
+		SELECT user_id, xp, level, current_streak, longest_streak,
+		       COALESCE(to_char(last_active_day, 'YYYY-MM-DD'), ''), freeze_tokens, timezone,
+		       counters, day_xp, version, updated_at
+		FROM user_progress
+		WHERE user_id = $1
+	`
+
+	p := &gamification.Progress{}
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&p.UserID,
+		&p.XP,
+		&p.Level,
+		&p.CurrentStreak,
+		&p.LongestStreak,
+		&p.LastActiveDay,
+		&p.FreezeTokens,
+		&p.Timezone,
+		&p.Counters,
+		&p.DayXP,
+		&p.Version,
+		&p.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, gamification.ErrProgressNotFound
+		}
+		return nil, fmt.Errorf("failed to query progress: %w", err)
+	}
+
+	if p.Counters == nil {
+		p.Counters = make(map[string]int)
+	}
+	if p.DayXP == nil {
+		p.DayXP = make(map[string]int)
+	}
+
+	return p, nil
+}
+
+// SaveProgress stores the progress of a user if its version is unchanged,
+// with the XP and badges of the award. The XP event of an award key already
+// recorded breaks the unique index on event keys, rolling back the progress.
+func (r *GamificationRepository) SaveProgress(ctx context.Context, p *gamification.Progress, award *gamification.Award) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // No-op once committed
+
+	args := []interface{}{
+		p.UserID,
+		p.XP,
+		p.Level,
+		p.CurrentStreak,
+		p.LongestStreak,
+		p.LastActiveDay,
+		p.FreezeTokens,
+		p.Timezone,
+		p.Counters,
+		p.DayXP,
+		p.UpdatedAt,
+		p.Version,
+	}
+
+	var query string
+	if p.Version == 0 {
+		query = `
+			INSERT INTO user_progress (
+				user_id, xp, level, current_streak, longest_streak, last_active_day,
+				freeze_tokens, timezone, counters, day_xp, updated_at, version
+			)
+			VALUES ($1, $2, $3, $4, $5, NULLIF($6, '')::date, $7, $8, $9, $10, $11, $12 + 1)
+		`
+	} else {
+		query = `
+			UPDATE user_progress
+			SET xp = $2,
+				level = $3,
+				current_streak = $4,
+				longest_streak = $5,
+				last_active_day = NULLIF($6, '')::date,
+				freeze_tokens = $7,
+				timezone = $8,
+				counters = $9,
+				day_xp = $10,
+				updated_at = $11,
+				version = version + 1
+			WHERE user_id = $1 AND version = $12
+		`
+	}
+
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			// Another request created the progress first
+			return gamification.ErrVersionConflict
+		}
+		return fmt.Errorf("failed to save progress: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return gamification.ErrVersionConflict
+	}
+
+	if award != nil {
+		if err := r.saveAward(ctx, tx, p.UserID, award); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	p.Version++
+	return nil
+}
+
+// saveAward records the XP and the badges of an award
+func (r *GamificationRepository) saveAward(ctx context.Context, tx pgx.Tx, userID string, award *gamification.Award) error {
+	xpQuery := `
+		INSERT INTO xp_events (user_id, event_type, event_key, xp, occurred_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5)
+	`
+	if _, err := tx.Exec(ctx, xpQuery, userID, string(award.Event), award.EventKey, award.XP, award.OccurredAt); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return gamification.ErrDuplicateEvent
+		}
+		return fmt.Errorf("failed to save xp event: %w", err)
+	}
+
+	badgeQuery := `
+		INSERT INTO user_badges (user_id, badge_id, unlocked_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, badge_id) DO NOTHING
+	`
+	for _, badge := range award.Badges {
+		if _, err := tx.Exec(ctx, badgeQuery, userID, badge.ID, award.OccurredAt); err != nil {
+			return fmt.Errorf("failed to save badge: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ListBadges retrieves the badges unlocked by a user
+func (r *GamificationRepository) ListBadges(ctx context.Context, userID string) ([]*gamification.UserBadge, error) {
+	query := `
+		SELECT badge_id, unlocked_at
+		FROM user_badges
+		WHERE user_id = $1
+		ORDER BY unlocked_at, badge_id
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query badges: %w", err)
+	}
+	defer rows.Close()
+
+	badges := make([]*gamification.UserBadge, 0)
+	for rows.Next() {
+		var b gamification.UserBadge
+		if err := rows.Scan(&b.BadgeID, &b.UnlockedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan badge row: %w", err)
+		}
+		badges = append(badges, &b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating badge rows: %w", err)
+	}
+
+	return badges, nil
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/event"
+)
+
+// OutboxRepository implements the event.Outbox interface using PostgreSQL
+type OutboxRepository struct {
+	db     DBInterface
+	logger zerolog.Logger
+}
+
+// Ensure OutboxRepository implements event.Outbox
+var _ event.Outbox = (*OutboxRepository)(nil)
+
+// NewOutboxRepository creates a new event outbox repository. Given a
+// transaction, it appends records as part of it.
+func NewOutboxRepository(db DBInterface, logger zerolog.Logger) *OutboxRepository {
+	return &OutboxRepository{
+		db:     db,
+		logger: logger.With().Str("component", "outbox_repository").Logger(),
+	}
+}
+
+// emitIn publishes the events of a write in its transaction, so that they are
+// queued if and only if the write commits
+func emitIn(ctx context.Context, tx pgx.Tx, emit event.Emit, logger zerolog.Logger) error {
+	if emit == nil {
+		return nil
+	}
+	if err := emit(ctx, NewOutboxRepository(tx, logger)); err != nil {
+		return fmt.Errorf("failed to publish events: %w", err)
+	}
+	return nil
+}
+
+// writeWithEvents runs a write and publishes its events in a single
+// transaction; a write without events runs on db directly
+func writeWithEvents(ctx context.Context, db DBInterface, emit event.Emit, logger zerolog.Logger, write func(db DBInterface) error) error {
+	if emit == nil {
+		return write(db)
+	}
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // No-op once committed
+
+	if err := write(tx); err != nil {
+		return err
+	}
+	if err := emitIn(ctx, tx, emit, logger); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Append stores records for delivery
+func (r *OutboxRepository) Append(ctx context.Context, records []*event.Record) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // No-op once committed
+
+	query := `
+		INSERT INTO event_outbox (id, event_name, subscriber, payload, occurred_at, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+	`
+	for _, rec := range records {
+		if _, err := tx.Exec(ctx, query, rec.ID, rec.Event, rec.Subscriber, rec.Payload, rec.OccurredAt); err != nil {
+			return fmt.Errorf("failed to insert outbox record: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Claim leases up to limit records due for delivery at now, incrementing
+// their attempts. Records locked by another dispatcher are skipped.
+func (r *OutboxRepository) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*event.Record, error) {
+	query := `
+		UPDATE event_outbox
+		SET attempts = attempts + 1,
+			next_attempt_at = $2
+		WHERE id IN (
+			SELECT id
+			FROM event_outbox
+			WHERE delivered_at IS NULL AND failed_at IS NULL AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, event_name, subscriber, payload, attempts, occurred_at
+	`
+
+	rows, err := r.db.Query(ctx, query, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox records: %w", err)
+	}
+	defer rows.Close()
+
+	records := make([]*event.Record, 0)
+	for rows.Next() {
+		var rec event.Record
+		if err := rows.Scan(&rec.ID, &rec.Event, &rec.Subscriber, &rec.Payload, &rec.Attempts, &rec.OccurredAt); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox row: %w", err)
+		}
+		records = append(records, &rec)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating outbox rows: %w", err)
+	}
+
+	return records, nil
+}
+
+// MarkDelivered marks a record as delivered
+func (r *OutboxRepository) MarkDelivered(ctx context.Context, id string, at time.Time) error {
+	query := `
+		UPDATE event_outbox
+		SET delivered_at = $2, last_error = ''
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, id, at); err != nil {
+		return fmt.Errorf("failed to mark outbox record delivered: %w", err)
+	}
+
+	return nil
+}
+
+// MarkFailed records a delivery failure. The record is retried at retryAt,
+// or never again if retryAt is zero.
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id, reason string, retryAt time.Time) error {
+	query := `
+		UPDATE event_outbox
+		SET last_error = $2,
+			next_attempt_at = COALESCE($3, next_attempt_at),
+			failed_at = CASE WHEN $3::timestamptz IS NULL THEN now() END
+		WHERE id = $1
+	`
+
+	var next *time.Time
+	if !retryAt.IsZero() {
+		next = &retryAt
+	}
+
+	if _, err := r.db.Exec(ctx, query, id, reason, next); err != nil {
+		return fmt.Errorf("failed to mark outbox record failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"voconsteroid/internal/domain/event"
+)
+
+func TestWriteWithEvents(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	record := &event.Record{ID: "rec-1", Event: "word.saved", Subscriber: "stats", Payload: []byte(`{}`), OccurredAt: time.Now()}
+	emit := func(ctx context.Context, outbox event.Outbox) error {
+		return outbox.Append(ctx, []*event.Record{record})
+	}
+	write := func(db DBInterface) error {
+		_, err := db.Exec(ctx, `UPDATE words SET text = $1`, "maison")
+		return err
+	}
+
+	t.Run("appends the events in the transaction of the write", func(t *testing.T) {
+		// Setup
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE words`).WithArgs("maison").WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO event_outbox`).
+			WithArgs(record.ID, record.Event, record.Subscriber, record.Payload, record.OccurredAt).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mock.ExpectCommit()
+		mock.ExpectCommit()
+
+		// Execute
+		err = writeWithEvents(ctx, mock, emit, logger, write)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rolls the write back when the events fail", func(t *testing.T) {
+		// Setup
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE words`).WithArgs("maison").WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mock.ExpectRollback()
+		failing := func(context.Context, event.Outbox) error { return errors.New("outbox full") }
+
+		// Execute
+		err = writeWithEvents(ctx, mock, failing, logger, write)
+
+		// Assert
+		assert.ErrorContains(t, err, "outbox full")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("writes without a transaction when there are no events", func(t *testing.T) {
+		// Setup
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		mock.ExpectExec(`UPDATE words`).WithArgs("maison").WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		// Execute
+		err = writeWithEvents(ctx, mock, nil, logger, write)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
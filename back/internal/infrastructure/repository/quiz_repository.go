@@ -0,0 +1,320 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/event"
+	"voconsteroid/internal/domain/quiz"
+	"voconsteroid/internal/domain/word"
+)
+
+// quizSessionColumns lists the columns selected for a quiz session, in scan order
+const quizSessionColumns = `
+	id, user_id, source, source_ref, status, question_count, answered_count,
+	correct_count, started_at, completed_at, updated_at`
+
+// quizQuestionColumns lists the columns selected for a quiz question, in scan order
+const quizQuestionColumns = `
+	id, session_id, position, saved_word_id, word_id, language, type, prompt,
+	target_language, choices, answers, served_at`
+
+// QuizRepository implements the quiz.Repository interface using PostgreSQL
+type QuizRepository struct {
+	db     DBInterface
+	logger zerolog.Logger
+}
+
+// Ensure QuizRepository implements quiz.Repository
+var _ quiz.Repository = (*QuizRepository)(nil)
+
+// NewQuizRepository creates a new quiz repository
+func NewQuizRepository(db DBInterface, logger zerolog.Logger) *QuizRepository {
+	return &QuizRepository{
+		db:     db,
+		logger: logger.With().Str("component", "quiz_repository").Logger(),
+	}
+}
+
+// FindDistractors retrieves random words of a language with a definition of
+// the given word type (any type when empty), skipping the excluded IDs
+func (r *QuizRepository) FindDistractors(ctx context.Context, language, wordType string, excludeIDs []string, limit int) ([]*word.Word, error) {
+	r.logger.Debug().Str("language", language).Str("wordType", wordType).Int("limit", limit).Msg("Finding distractors")
+
+	query := `SELECT ` + wordColumns + `
+		FROM words w
+		WHERE w.language = $1
+		  AND jsonb_array_length(w.definitions) > 0
+		  AND ($2 = '' OR w.definitions @> jsonb_build_array(jsonb_build_object('word_type', $2::text)))
+		  AND NOT (w.id = ANY($3::uuid[]))
+		ORDER BY random()
+		LIMIT $4
+	`
+
+	rows, err := r.db.Query(ctx, query, language, wordType, excludeIDs, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query distractors: %w", err)
+	}
+	defer rows.Close()
+
+	words := make([]*word.Word, 0)
+	for rows.Next() {
+		w, err := scanWord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan distractor row: %w", err)
+		}
+		words = append(words, w)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating distractor rows: %w", err)
+	}
+
+	return words, nil
+}
+
+// FindSession retrieves a quiz session by its ID
+func (r *QuizRepository) FindSession(ctx context.Context, id string) (*quiz.Session, error) {
+	query := `SELECT ` + quizSessionColumns + `
+		FROM quiz_sessions
+		WHERE id = $1
+	`
+
+	session, err := scanQuizSession(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || isInvalidID(err) {
+			return nil, quiz.ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to query quiz session: %w", err)
+	}
+
+	return session, nil
+}
+
+// FindQuestion retrieves the question of a session at the given position
+func (r *QuizRepository) FindQuestion(ctx context.Context, sessionID string, position int) (*quiz.SessionQuestion, error) {
+	query := `SELECT ` + quizQuestionColumns + `
+		FROM quiz_questions
+		WHERE session_id = $1 AND position = $2
+	`
+
+	q, err := scanQuizQuestion(r.db.QueryRow(ctx, query, sessionID, position))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, quiz.ErrQuestionNotFound
+		}
+		return nil, fmt.Errorf("failed to query quiz question: %w", err)
+	}
+
+	return q, nil
+}
+
+// CreateSession stores a new session together with its questions and
+// answer keys in a single transaction
+func (r *QuizRepository) CreateSession(ctx context.Context, session *quiz.Session, questions []*quiz.SessionQuestion) error {
+	r.logger.Debug().Str("id", session.ID).Int("questions", len(questions)).Msg("Creating quiz session")
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // No-op once committed
+
+	sessionQuery := `
+		INSERT INTO quiz_sessions (` + quizSessionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err = tx.Exec(ctx, sessionQuery,
+		session.ID,
+		session.UserID,
+		string(session.Source),
+		session.SourceRef,
+		string(session.Status),
+		session.QuestionCount,
+		session.AnsweredCount,
+		session.CorrectCount,
+		session.StartedAt,
+		session.CompletedAt,
+		session.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save quiz session: %w", err)
+	}
+
+	questionQuery := `
+		INSERT INTO quiz_questions (` + quizQuestionColumns + `)
+		VALUES ($1, $2, $3, NULLIF($4, '')::uuid, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	for _, q := range questions {
+		choices := q.Choices
+		if choices == nil {
+			choices = []string{}
+		}
+
+		_, err = tx.Exec(ctx, questionQuery,
+			q.ID,
+			q.SessionID,
+			q.Position,
+			q.SavedWordID,
+			q.WordID,
+			q.Language,
+			string(q.Type),
+			q.Prompt,
+			q.TargetLanguage,
+			choices,
+			q.Answers,
+			q.ServedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save quiz question: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit quiz session: %w", err)
+	}
+
+	return nil
+}
+
+// MarkServed records when a question was first shown
+func (r *QuizRepository) MarkServed(ctx context.Context, questionID string, servedAt time.Time) error {
+	query := `UPDATE quiz_questions SET served_at = $2 WHERE id = $1 AND served_at IS NULL`
+
+	if _, err := r.db.Exec(ctx, query, questionID, servedAt); err != nil {
+		return fmt.Errorf("failed to mark quiz question served: %w", err)
+	}
+
+	return nil
+}
+
+// SaveAttempt stores an attempt and the session counters it updated in a
+// single transaction, along with the events of the attempt. A second attempt
+// at the same question is rejected.
+func (r *QuizRepository) SaveAttempt(ctx context.Context, session *quiz.Session, attempt *quiz.Attempt, emit event.Emit) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // No-op once committed
+
+	attemptQuery := `
+		INSERT INTO quiz_attempts (id, session_id, question_id, user_id, answer, correct, latency_ms, answered_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err = tx.Exec(ctx, attemptQuery,
+		attempt.ID,
+		attempt.SessionID,
+		attempt.QuestionID,
+		attempt.UserID,
+		attempt.Answer,
+		attempt.Correct,
+		attempt.Latency.Milliseconds(),
+		attempt.AnsweredAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return quiz.ErrAlreadyAnswered
+		}
+		return fmt.Errorf("failed to save quiz attempt: %w", err)
+	}
+
+	sessionQuery := `
+		UPDATE quiz_sessions
+		SET status = $2,
+			answered_count = $3,
+			correct_count = $4,
+			completed_at = $5,
+			updated_at = $6
+		WHERE id = $1
+	`
+
+	_, err = tx.Exec(ctx, sessionQuery,
+		session.ID,
+		string(session.Status),
+		session.AnsweredCount,
+		session.CorrectCount,
+		session.CompletedAt,
+		session.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update quiz session: %w", err)
+	}
+
+	if err := emitIn(ctx, tx, emit, r.logger); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit quiz attempt: %w", err)
+	}
+
+	return nil
+}
+
+// scanQuizSession scans a row selected with quizSessionColumns
+func scanQuizSession(row pgx.Row) (*quiz.Session, error) {
+	var session quiz.Session
+	var source, status string
+
+	if err := row.Scan(
+		&session.ID,
+		&session.UserID,
+		&source,
+		&session.SourceRef,
+		&status,
+		&session.QuestionCount,
+		&session.AnsweredCount,
+		&session.CorrectCount,
+		&session.StartedAt,
+		&session.CompletedAt,
+		&session.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	session.Source = quiz.Source(source)
+	session.Status = quiz.SessionStatus(status)
+	return &session, nil
+}
+
+// scanQuizQuestion scans a row selected with quizQuestionColumns
+func scanQuizQuestion(row pgx.Row) (*quiz.SessionQuestion, error) {
+	q := &quiz.SessionQuestion{Question: &quiz.Question{}}
+	var savedWordID *string
+	var questionType string
+
+	if err := row.Scan(
+		&q.ID,
+		&q.SessionID,
+		&q.Position,
+		&savedWordID,
+		&q.WordID,
+		&q.Language,
+		&questionType,
+		&q.Prompt,
+		&q.TargetLanguage,
+		&q.Choices,
+		&q.Answers,
+		&q.ServedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	// The saved word is unset once deleted; the question remains answerable
+	if savedWordID != nil {
+		q.SavedWordID = *savedWordID
+	}
+	q.Type = quiz.QuestionType(questionType)
+	return q, nil
+}
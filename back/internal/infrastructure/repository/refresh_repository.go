@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/refresh"
+	"voconsteroid/internal/domain/word"
+)
+
+// refreshControl is the job_controls row of the refresh worker
+const refreshControl = "refresh"
+
+// RefreshRepository implements the refresh.Repository interface using PostgreSQL
+type RefreshRepository struct {
+	db     DBInterface
+	logger zerolog.Logger
+}
+
+// Ensure RefreshRepository implements refresh.Repository
+var _ refresh.Repository = (*RefreshRepository)(nil)
+
+// NewRefreshRepository creates a new refresh repository
+func NewRefreshRepository(db DBInterface, logger zerolog.Logger) *RefreshRepository {
+	return &RefreshRepository{
+		db:     db,
+		logger: logger.With().Str("component", "refresh_repository").Logger(),
+	}
+}
+
+// ListCandidates retrieves up to limit words without definitions, fetched
+// with an older parser or stale, skipping those checked recently. Words
+// without definitions come first, then older parsers, then the stalest.
+func (r *RefreshRepository) ListCandidates(ctx context.Context, criteria refresh.Criteria, limit int) ([]*word.Word, error) {
+	query := `SELECT ` + wordColumns + `
+		FROM words w
+		WHERE (w.definitions IS NULL OR w.definitions IN ('null'::jsonb, '[]'::jsonb)
+		       OR w.parser_version < $1
+		       OR GREATEST(w.checked_at, w.updated_at) < $2)
+		  AND (w.checked_at IS NULL OR w.checked_at < $3)
+		ORDER BY (w.definitions IS NULL OR w.definitions IN ('null'::jsonb, '[]'::jsonb)) DESC,
+		         w.parser_version,
+		         GREATEST(w.checked_at, w.updated_at)
+		LIMIT $4
+	`
+
+	rows, err := r.db.Query(ctx, query, criteria.ParserVersion, criteria.StaleBefore, criteria.CheckedBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query refresh candidates: %w", err)
+	}
+
+	return collectWords(rows)
+}
+
+// MarkChecked records when a word was last compared with the dictionary
+func (r *RefreshRepository) MarkChecked(ctx context.Context, wordID string, at time.Time) error {
+	tag, err := r.db.Exec(ctx, `UPDATE words SET checked_at = $2 WHERE id = $1`, wordID, at)
+	if err != nil {
+		return fmt.Errorf("failed to mark word checked: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return word.ErrWordNotFound
+	}
+
+	return nil
+}
+
+// StartJob interrupts the expired running jobs and inserts job
+func (r *RefreshRepository) StartJob(ctx context.Context, job *refresh.Job, expiredBefore time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // No-op once committed
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE refresh_jobs
+		SET status = $1, finished_at = $2
+		WHERE status = $3 AND started_at < $2
+	`, string(refresh.JobInterrupted), expiredBefore, string(refresh.JobRunning))
+	if err != nil {
+		return fmt.Errorf("failed to interrupt expired jobs: %w", err)
+	}
+	if tag.RowsAffected() > 0 {
+		r.logger.Warn().Int64("jobs", tag.RowsAffected()).Msg("Marked expired refresh jobs as interrupted")
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO refresh_jobs (id, status, started_at)
+		VALUES ($1, $2, $3)
+	`, job.ID, string(job.Status), job.StartedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return refresh.ErrJobRunning
+		}
+		return fmt.Errorf("failed to insert refresh job: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// FinishJob stores the status and counters of a job
+func (r *RefreshRepository) FinishJob(ctx context.Context, job *refresh.Job) error {
+	query := `
+		UPDATE refresh_jobs
+		SET status = $2, finished_at = $3, selected = $4, refreshed = $5,
+		    unchanged = $6, failed = $7, error = $8
+		WHERE id = $1
+	`
+
+	tag, err := r.db.Exec(ctx, query,
+		job.ID, string(job.Status), job.FinishedAt, job.Selected, job.Refreshed,
+		job.Unchanged, job.Failed, job.Error,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update refresh job: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return refresh.ErrJobNotFound
+	}
+
+	return nil
+}
+
+// ListJobs retrieves up to limit jobs, the most recent first
+func (r *RefreshRepository) ListJobs(ctx context.Context, limit int) ([]*refresh.Job, error) {
+	query := `
+		SELECT id, status, started_at, finished_at, selected, refreshed, unchanged, failed, error
+		FROM refresh_jobs
+		ORDER BY started_at DESC
+		LIMIT $1
+	`
+
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query refresh jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*refresh.Job
+	for rows.Next() {
+		var job refresh.Job
+		var status string
+		if err := rows.Scan(
+			&job.ID,
+			&status,
+			&job.StartedAt,
+			&job.FinishedAt,
+			&job.Selected,
+			&job.Refreshed,
+			&job.Unchanged,
+			&job.Failed,
+			&job.Error,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan refresh job row: %w", err)
+		}
+		job.Status = refresh.JobStatus(status)
+		jobs = append(jobs, &job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating refresh job rows: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// IsPaused reports whether the refresh worker is paused
+func (r *RefreshRepository) IsPaused(ctx context.Context) (bool, error) {
+	var paused bool
+	err := r.db.QueryRow(ctx, `SELECT paused FROM job_controls WHERE name = $1`, refreshControl).Scan(&paused)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to query pause state: %w", err)
+	}
+
+	return paused, nil
+}
+
+// SetPaused pauses or resumes the refresh worker
+func (r *RefreshRepository) SetPaused(ctx context.Context, paused bool, at time.Time) error {
+	query := `
+		INSERT INTO job_controls (name, paused, updated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (name) DO UPDATE SET paused = EXCLUDED.paused, updated_at = EXCLUDED.updated_at
+	`
+
+	if _, err := r.db.Exec(ctx, query, refreshControl, paused, at); err != nil {
+		return fmt.Errorf("failed to update pause state: %w", err)
+	}
+
+	return nil
+}
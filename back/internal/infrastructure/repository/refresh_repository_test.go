@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"voconsteroid/internal/domain/refresh"
+)
+
+// setupRefreshMockDB sets up a mock database for testing the refresh repository
+func setupRefreshMockDB(t *testing.T) (pgxmock.PgxPoolIface, *RefreshRepository) {
+	t.Helper()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	return mock, NewRefreshRepository(mock, zerolog.New(zerolog.NewTestWriter(t)))
+}
+
+func TestRefreshRepository_StartJob_Running(t *testing.T) {
+	mock, repo := setupRefreshMockDB(t)
+	defer mock.Close()
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	job := refresh.NewJob(now)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE refresh_jobs`).
+		WithArgs("interrupted", now.Add(-time.Hour), "running").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectExec(`INSERT INTO refresh_jobs`).
+		WithArgs(job.ID, "running", now).
+		WillReturnError(&pgconn.PgError{Code: uniqueViolation})
+	mock.ExpectRollback()
+
+	err := repo.StartJob(context.Background(), job, now.Add(-time.Hour))
+
+	assert.ErrorIs(t, err, refresh.ErrJobRunning)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRefreshRepository_IsPaused_NoControl(t *testing.T) {
+	mock, repo := setupRefreshMockDB(t)
+	defer mock.Close()
+
+	mock.ExpectQuery(`SELECT paused FROM job_controls WHERE name = \$1`).
+		WithArgs("refresh").
+		WillReturnError(pgx.ErrNoRows)
+
+	paused, err := repo.IsPaused(context.Background())
+
+	require.NoError(t, err)
+	assert.False(t, paused)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
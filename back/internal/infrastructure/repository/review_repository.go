@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/event"
+	"voconsteroid/internal/domain/review"
+)
+
+// reviewCardColumns lists the columns selected for a review card, in scan order
+const reviewCardColumns = `
+	saved_word_id, user_id, state, ease, stability, difficulty, interval_days,
+	repetitions, lapses, due_at, last_reviewed_at, created_at, updated_at`
+
+// ReviewRepository implements the review.Repository interface using PostgreSQL
+type ReviewRepository struct {
+	db     DBInterface
+	logger zerolog.Logger
+}
+
+// Ensure ReviewRepository implements review.Repository
+var _ review.Repository = (*ReviewRepository)(nil)
+
+// NewReviewRepository creates a new review repository
+func NewReviewRepository(db DBInterface, logger zerolog.Logger) *ReviewRepository {
+	return &ReviewRepository{
+		db:     db,
+		logger: logger.With().Str("component", "review_repository").Logger(),
+	}
+}
+
+// FindBySavedWord retrieves the card of a saved word
+func (r *ReviewRepository) FindBySavedWord(ctx context.Context, savedWordID string) (*review.Card, error) {
+	query := `SELECT ` + reviewCardColumns + `
+		FROM review_cards
+		WHERE saved_word_id = $1
+	`
+
+	card, err := scanReviewCard(r.db.QueryRow(ctx, query, savedWordID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || isInvalidID(err) {
+			return nil, review.ErrCardNotFound
+		}
+		return nil, fmt.Errorf("failed to query review card: %w", err)
+	}
+
+	return card, nil
+}
+
+// CreateMissingCards creates new cards for the user's saved words that have none.
+// New cards are due from the moment the word was saved.
+func (r *ReviewRepository) CreateMissingCards(ctx context.Context, userID string, now time.Time) error {
+	query := `
+		INSERT INTO review_cards (saved_word_id, user_id, state, ease, due_at, created_at, updated_at)
+		SELECT sw.id, sw.user_id, $2, $3, sw.saved_at, $4, $4
+		FROM saved_words sw
+		WHERE sw.user_id = $1
+		  AND NOT EXISTS (SELECT 1 FROM review_cards rc WHERE rc.saved_word_id = sw.id)
+		ON CONFLICT (saved_word_id) DO NOTHING
+	`
+
+	tag, err := r.db.Exec(ctx, query, userID, string(review.StateNew), review.DefaultEase, now)
+	if err != nil {
+		return fmt.Errorf("failed to create review cards: %w", err)
+	}
+
+	if tag.RowsAffected() > 0 {
+		r.logger.Debug().Str("userID", userID).Int64("count", tag.RowsAffected()).Msg("Created review cards")
+	}
+
+	return nil
+}
+
+// ListDue retrieves the user's cards due at the given time, earliest first
+func (r *ReviewRepository) ListDue(ctx context.Context, userID string, now time.Time, limit int) ([]*review.Card, error) {
+	query := `SELECT ` + reviewCardColumns + `
+		FROM review_cards
+		WHERE user_id = $1 AND due_at <= $2
+		ORDER BY due_at, saved_word_id
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query due cards: %w", err)
+	}
+	defer rows.Close()
+
+	cards := make([]*review.Card, 0)
+	for rows.Next() {
+		card, err := scanReviewCard(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan review card row: %w", err)
+		}
+		cards = append(cards, card)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating review card rows: %w", err)
+	}
+
+	return cards, nil
+}
+
+// Save stores the state of a card and, when given, the log of the review
+// that produced it in a single transaction, along with the events of the review
+func (r *ReviewRepository) Save(ctx context.Context, card *review.Card, log *review.Log, emit event.Emit) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // No-op once committed
+
+	cardQuery := `
+		INSERT INTO review_cards (` + reviewCardColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (saved_word_id)
+		DO UPDATE SET
+			state = $3,
+			ease = $4,
+			stability = $5,
+			difficulty = $6,
+			interval_days = $7,
+			repetitions = $8,
+			lapses = $9,
+			due_at = $10,
+			last_reviewed_at = $11,
+			updated_at = $13
+	`
+
+	_, err = tx.Exec(ctx, cardQuery,
+		card.SavedWordID,
+		card.UserID,
+		string(card.State),
+		card.Ease,
+		card.Stability,
+		card.Difficulty,
+		card.IntervalDays,
+		card.Repetitions,
+		card.Lapses,
+		card.Due,
+		card.LastReviewedAt,
+		card.CreatedAt,
+		card.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save review card: %w", err)
+	}
+
+	if log != nil {
+		logQuery := `
+			INSERT INTO review_logs (
+				id, saved_word_id, user_id, grade, state, elapsed_days,
+				scheduled_days, duration_ms, reviewed_at
+			)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		`
+
+		_, err = tx.Exec(ctx, logQuery,
+			log.ID,
+			log.SavedWordID,
+			log.UserID,
+			int(log.Grade),
+			string(log.State),
+			log.ElapsedDays,
+			log.ScheduledDays,
+			log.Duration.Milliseconds(),
+			log.ReviewedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save review log: %w", err)
+		}
+	}
+
+	if err := emitIn(ctx, tx, emit, r.logger); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit review: %w", err)
+	}
+
+	return nil
+}
+
+// scanReviewCard scans a row selected with reviewCardColumns
+func scanReviewCard(row pgx.Row) (*review.Card, error) {
+	var card review.Card
+	var state string
+
+	if err := row.Scan(
+		&card.SavedWordID,
+		&card.UserID,
+		&state,
+		&card.Ease,
+		&card.Stability,
+		&card.Difficulty,
+		&card.IntervalDays,
+		&card.Repetitions,
+		&card.Lapses,
+		&card.Due,
+		&card.LastReviewedAt,
+		&card.CreatedAt,
+		&card.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	card.State = review.State(state)
+	return &card, nil
+}
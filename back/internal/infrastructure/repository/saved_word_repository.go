@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/event"
+	"voconsteroid/internal/domain/savedword"
+	"voconsteroid/internal/domain/tag"
+)
+
+// uniqueViolation is the PostgreSQL error code for unique constraint violations
+const uniqueViolation = "23505"
+
+// invalidTextRepresentation is the PostgreSQL error code for values that
+// cannot be parsed, such as malformed UUIDs
+const invalidTextRepresentation = "22P02"
+
+// isInvalidID reports whether a query failed because an ID was malformed;
+// a malformed ID names nothing, so lookups treat it as not found
+func isInvalidID(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == invalidTextRepresentation
+}
+
+// savedWordColumns lists the columns selected for a saved word, in scan order
+const savedWordColumns = `
+	sw.id, sw.user_id, sw.word_id, sw.context_sentence, sw.book_title, sw.book_author,
+	sw.location, sw.note, sw.suggested_definition, sw.saved_at, sw.last_interacted_at`
+
+// SavedWordRepository implements the savedword.Repository interface using PostgreSQL
+type SavedWordRepository struct {
+	db     DBInterface
+	logger zerolog.Logger
+}
+
+// Ensure SavedWordRepository implements savedword.Repository
+var _ savedword.Repository = (*SavedWordRepository)(nil)
+
+// NewSavedWordRepository creates a new saved word repository
+func NewSavedWordRepository(db DBInterface, logger zerolog.Logger) *SavedWordRepository {
+	return &SavedWordRepository{
+		db:     db,
+		logger: logger.With().Str("component", "saved_word_repository").Logger(),
+	}
+}
+
+// FindByID retrieves a saved word by its ID
+func (r *SavedWordRepository) FindByID(ctx context.Context, id string) (*savedword.SavedWord, error) {
+	r.logger.Debug().Str("id", id).Msg("Finding saved word by ID")
+
+	query := `SELECT ` + savedWordColumns + `
+		FROM saved_words sw
+		WHERE sw.id = $1
+	`
+
+	sw, err := scanSavedWord(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || isInvalidID(err) {
+			return nil, savedword.ErrSavedWordNotFound
+		}
+		return nil, fmt.Errorf("failed to query saved word by ID: %w", err)
+	}
+
+	return sw, nil
+}
+
+// FindByIDs retrieves the saved words matching the given IDs; unknown IDs are skipped
+func (r *SavedWordRepository) FindByIDs(ctx context.Context, ids []string) ([]*savedword.SavedWord, error) {
+	if len(ids) == 0 {
+		return []*savedword.SavedWord{}, nil
+	}
+
+	query := `SELECT ` + savedWordColumns + `
+		FROM saved_words sw
+		WHERE sw.id = ANY($1::uuid[])
+	`
+
+	rows, err := r.db.Query(ctx, query, ids)
+	if err != nil {
+		if isInvalidID(err) {
+			return []*savedword.SavedWord{}, nil
+		}
+		return nil, fmt.Errorf("failed to query saved words by IDs: %w", err)
+	}
+	defer rows.Close()
+
+	savedWords, err := collectSavedWords(rows)
+	if isInvalidID(err) {
+		return []*savedword.SavedWord{}, nil
+	}
+	return savedWords, err
+}
+
+// FindByUserAndWord retrieves the saved word a user created for a word
+func (r *SavedWordRepository) FindByUserAndWord(ctx context.Context, userID, wordID string) (*savedword.SavedWord, error) {
+	query := `SELECT ` + savedWordColumns + `
+		FROM saved_words sw
+		WHERE sw.user_id = $1 AND sw.word_id = $2
+	`
+
+	sw, err := scanSavedWord(r.db.QueryRow(ctx, query, userID, wordID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, savedword.ErrSavedWordNotFound
+		}
+		return nil, fmt.Errorf("failed to query saved word: %w", err)
+	}
+
+	return sw, nil
+}
+
+// Save stores a saved word in the repository, publishing the events of the
+// write in its transaction
+func (r *SavedWordRepository) Save(ctx context.Context, sw *savedword.SavedWord, emit event.Emit) error {
+	return writeWithEvents(ctx, r.db, emit, r.logger, func(db DBInterface) error {
+		return saveSavedWord(ctx, db, sw)
+	})
+}
+
+// saveSavedWord inserts or updates a saved word
+func saveSavedWord(ctx context.Context, db DBInterface, sw *savedword.SavedWord) error {
+	query := `
+		INSERT INTO saved_words (
+			id, user_id, word_id, context_sentence, book_title, book_author,
+			location, note, suggested_definition, saved_at, last_interacted_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (id)
+		DO UPDATE SET
+			context_sentence = $4,
+			book_title = $5,
+			book_author = $6,
+			location = $7,
+			note = $8,
+			suggested_definition = $9,
+			last_interacted_at = $11
+	`
+
+	_, err := db.Exec(ctx, query,
+		sw.ID,
+		sw.UserID,
+		sw.WordID,
+		sw.Context.Sentence,
+		sw.Context.BookTitle,
+		sw.Context.BookAuthor,
+		sw.Context.Location,
+		sw.Context.Note,
+		sw.SuggestedDefinition,
+		sw.SavedAt,
+		sw.LastInteractedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return savedword.ErrDuplicateSavedWord
+		}
+		return fmt.Errorf("failed to save saved word: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a saved word from the repository
+func (r *SavedWordRepository) Delete(ctx context.Context, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM saved_words WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete saved word: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return savedword.ErrSavedWordNotFound
+	}
+	return nil
+}
+
+// ListByUser retrieves the saved words of a user, most recently used first.
+// A non-empty filter query is matched against the reading context with
+// full-text search and against the beginning of the word text. A tag
+// expression keeps only the saved words whose tags satisfy it.
+func (r *SavedWordRepository) ListByUser(ctx context.Context, userID string, filter savedword.Filter, limit, offset int) ([]*savedword.SavedWord, error) {
+	r.logger.Debug().Str("userID", userID).Str("query", filter.Query).Msg("Listing saved words")
+
+	args := []interface{}{userID, filter.Query, limit, offset}
+
+	tagCondition := "TRUE"
+	if filter.Tags != nil {
+		tagCondition = tagExpressionSQL(filter.Tags, tag.TargetSavedWord, "sw.id", &args)
+	}
+
+	query := `SELECT ` + savedWordColumns + `
+		FROM saved_words sw
+		JOIN words w ON w.id = sw.word_id
+		WHERE sw.user_id = $1
+		  AND ($2 = '' OR sw.search_vector @@ websearch_to_tsquery('simple', $2) OR w.text ILIKE $2 || '%')
+		  AND ` + tagCondition + `
+		ORDER BY sw.last_interacted_at DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query saved words: %w", err)
+	}
+	defer rows.Close()
+
+	return collectSavedWords(rows)
+}
+
+// collectSavedWords scans every row selected with savedWordColumns
+func collectSavedWords(rows pgx.Rows) ([]*savedword.SavedWord, error) {
+	savedWords := make([]*savedword.SavedWord, 0)
+	for rows.Next() {
+		sw, err := scanSavedWord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan saved word row: %w", err)
+		}
+		savedWords = append(savedWords, sw)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating saved word rows: %w", err)
+	}
+
+	return savedWords, nil
+}
+
+// scanSavedWord scans a row selected with savedWordColumns
+func scanSavedWord(row pgx.Row) (*savedword.SavedWord, error) {
+	var sw savedword.SavedWord
+	var suggested *int32
+
+	if err := row.Scan(
+		&sw.ID,
+		&sw.UserID,
+		&sw.WordID,
+		&sw.Context.Sentence,
+		&sw.Context.BookTitle,
+		&sw.Context.BookAuthor,
+		&sw.Context.Location,
+		&sw.Context.Note,
+		&suggested,
+		&sw.SavedAt,
+		&sw.LastInteractedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if suggested != nil {
+		idx := int(*suggested)
+		sw.SuggestedDefinition = &idx
+	}
+
+	return &sw, nil
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/snapshot"
+)
+
+// SnapshotRepository implements the snapshot.Repository interface using PostgreSQL
+type SnapshotRepository struct {
+	db     DBInterface
+	logger zerolog.Logger
+}
+
+// Ensure SnapshotRepository implements snapshot.Repository
+var _ snapshot.Repository = (*SnapshotRepository)(nil)
+
+// NewSnapshotRepository creates a new page snapshot repository
+func NewSnapshotRepository(db DBInterface, logger zerolog.Logger) *SnapshotRepository {
+	return &SnapshotRepository{
+		db:     db,
+		logger: logger.With().Str("component", "snapshot_repository").Logger(),
+	}
+}
+
+// Save inserts a snapshot, or updates the fetch time and revision of the
+// stored snapshot of the same page
+func (r *SnapshotRepository) Save(ctx context.Context, s *snapshot.Snapshot) error {
+	query := `
+		INSERT INTO page_snapshots (id, text, language, source, source_url, revision_id, digest, size, fetched_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (language, text, digest)
+		DO UPDATE SET
+			source_url = EXCLUDED.source_url,
+			revision_id = EXCLUDED.revision_id,
+			fetched_at = EXCLUDED.fetched_at
+		RETURNING id
+	`
+
+	if s.ID == "" {
+		s.ID = uuid.New().String()
+	}
+
+	err := r.db.QueryRow(ctx, query,
+		s.ID, s.Text, s.Language, s.Source, s.SourceURL, s.RevisionID, s.Digest, s.Size, s.FetchedAt,
+	).Scan(&s.ID)
+	if err != nil {
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// FindLatest retrieves the last snapshot of a word
+func (r *SnapshotRepository) FindLatest(ctx context.Context, text, language string) (*snapshot.Snapshot, error) {
+	query := `SELECT ` + snapshotColumns + `
+		FROM page_snapshots
+		WHERE language = $1 AND text = $2
+		ORDER BY fetched_at DESC
+		LIMIT 1
+	`
+
+	s, err := scanSnapshot(r.db.QueryRow(ctx, query, language, text))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, snapshot.ErrSnapshotNotFound
+		}
+		return nil, fmt.Errorf("failed to query snapshot: %w", err)
+	}
+
+	return s, nil
+}
+
+// ListLatest retrieves the last snapshot of each word after
+// (afterLanguage, afterText), optionally of a single language
+func (r *SnapshotRepository) ListLatest(ctx context.Context, language, afterLanguage, afterText string, limit int) ([]*snapshot.Snapshot, error) {
+	query := `SELECT DISTINCT ON (language, text) ` + snapshotColumns + `
+		FROM page_snapshots
+		WHERE ($1 = '' OR language = $1)
+		  AND (language, text) > ($2, $3)
+		ORDER BY language, text, fetched_at DESC
+		LIMIT $4
+	`
+
+	rows, err := r.db.Query(ctx, query, language, afterLanguage, afterText, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	var snapshots []*snapshot.Snapshot
+	for rows.Next() {
+		s, err := scanSnapshot(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan snapshot row: %w", err)
+		}
+		snapshots = append(snapshots, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating snapshot rows: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// snapshotColumns lists the columns selected for a snapshot, in scanSnapshot order
+const snapshotColumns = `id, text, language, source, source_url, revision_id, digest, size, fetched_at`
+
+// scanSnapshot scans a row selected with snapshotColumns
+func scanSnapshot(row pgx.Row) (*snapshot.Snapshot, error) {
+	var s snapshot.Snapshot
+	if err := row.Scan(
+		&s.ID,
+		&s.Text,
+		&s.Language,
+		&s.Source,
+		&s.SourceURL,
+		&s.RevisionID,
+		&s.Digest,
+		&s.Size,
+		&s.FetchedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"voconsteroid/internal/domain/snapshot"
+)
+
+func TestSnapshotRepository_FindLatest_NotFound(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	repo := NewSnapshotRepository(mock, zerolog.New(zerolog.NewTestWriter(t)))
+
+	mock.ExpectQuery(`SELECT (.+) FROM page_snapshots WHERE language = \$1 AND text = \$2`).
+		WithArgs("fr", "maison").
+		WillReturnError(pgx.ErrNoRows)
+
+	_, err = repo.FindLatest(context.Background(), "maison", "fr")
+
+	assert.ErrorIs(t, err, snapshot.ErrSnapshotNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSnapshotRepository_Save(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	repo := NewSnapshotRepository(mock, zerolog.New(zerolog.NewTestWriter(t)))
+
+	s := &snapshot.Snapshot{Text: "maison", Language: "fr", Source: "frwiktionary", Digest: "abc", Size: 3}
+	mock.ExpectQuery(`INSERT INTO page_snapshots`).
+		WithArgs(pgxmock.AnyArg(), "maison", "fr", "frwiktionary", "", int64(0), "abc", 3, s.FetchedAt).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow("stored-id"))
+
+	err = repo.Save(context.Background(), s)
+
+	require.NoError(t, err)
+	assert.Equal(t, "stored-id", s.ID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
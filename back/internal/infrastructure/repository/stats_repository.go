@@ -0,0 +1,248 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/review"
+	"voconsteroid/internal/domain/stats"
+)
+
+// StatsRepository implements the stats.Repository interface using PostgreSQL.
+// Every series is aggregated in the database, bucketed in the query timezone.
+type StatsRepository struct {
+	db     DBInterface
+	logger zerolog.Logger
+}
+
+// Ensure StatsRepository implements stats.Repository
+var _ stats.Repository = (*StatsRepository)(nil)
+
+// NewStatsRepository creates a new statistics repository
+func NewStatsRepository(db DBInterface, logger zerolog.Logger) *StatsRepository {
+	return &StatsRepository{
+		db:     db,
+		logger: logger.With().Str("component", "stats_repository").Logger(),
+	}
+}
+
+// bucketExpr returns the SQL expression of the bucket of a timestamp column,
+// given the interval as $4 and the timezone as $5
+func bucketExpr(column string) string {
+	return `date_trunc($4::text, ` + column + ` AT TIME ZONE $5::text)::date`
+}
+
+// cumulativeBucketExpr is like bucketExpr, with NULL for the timestamps
+// before the range start given as $2
+func cumulativeBucketExpr(column string) string {
+	return `CASE WHEN ` + column + ` < $2 THEN NULL ELSE ` + bucketExpr(column) + ` END`
+}
+
+// queryArgs returns the query parameters shared by the time series queries
+func queryArgs(userID string, q stats.Query, extra ...interface{}) []interface{} {
+	return append([]interface{}{userID, q.From, q.To, string(q.Interval), q.Timezone}, extra...)
+}
+
+// CountSaved counts the words saved per bucket, with a zero bucket for the
+// words saved before the range
+func (r *StatsRepository) CountSaved(ctx context.Context, userID string, q stats.Query) ([]stats.Count, error) {
+	query := `
+		SELECT ` + cumulativeBucketExpr("saved_at") + ` AS bucket, count(*)
+		FROM saved_words
+		WHERE user_id = $1 AND saved_at < $3
+		GROUP BY bucket
+	`
+
+	return r.queryCounts(ctx, "saved words", query, queryArgs(userID, q)...)
+}
+
+// CountMastered counts the saved words that first reached the mastered
+// interval per bucket, with a zero bucket for those mastered before the range
+func (r *StatsRepository) CountMastered(ctx context.Context, userID string, q stats.Query, masteredInterval int) ([]stats.Count, error) {
+	query := `
+		SELECT ` + cumulativeBucketExpr("mastered_at") + ` AS bucket, count(*)
+		FROM (
+			SELECT min(reviewed_at) AS mastered_at
+			FROM review_logs
+			WHERE user_id = $1 AND scheduled_days >= $6
+			GROUP BY saved_word_id
+		) m
+		WHERE mastered_at < $3
+		GROUP BY bucket
+	`
+
+	return r.queryCounts(ctx, "mastered words", query, queryArgs(userID, q, masteredInterval)...)
+}
+
+// CountReviews aggregates the reviews per bucket
+func (r *StatsRepository) CountReviews(ctx context.Context, userID string, q stats.Query) ([]stats.ReviewCount, error) {
+	query := `
+		SELECT ` + bucketExpr("reviewed_at") + ` AS bucket,
+		       count(*),
+		       count(*) FILTER (WHERE grade > $6),
+		       COALESCE(sum(duration_ms), 0)
+		FROM review_logs
+		WHERE user_id = $1 AND reviewed_at >= $2 AND reviewed_at < $3
+		GROUP BY bucket
+	`
+
+	rows, err := r.db.Query(ctx, query, queryArgs(userID, q, int(review.GradeAgain))...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reviews: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make([]stats.ReviewCount, 0)
+	for rows.Next() {
+		var c stats.ReviewCount
+		if err := rows.Scan(&c.Bucket, &c.Reviews, &c.Recalled, &c.DurationMs); err != nil {
+			return nil, fmt.Errorf("failed to scan review count row: %w", err)
+		}
+		counts = append(counts, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating review count rows: %w", err)
+	}
+
+	return counts, nil
+}
+
+// SumQuizTime sums the quiz answer latencies per bucket, in milliseconds
+func (r *StatsRepository) SumQuizTime(ctx context.Context, userID string, q stats.Query) ([]stats.Count, error) {
+	query := `
+		SELECT ` + bucketExpr("answered_at") + ` AS bucket, COALESCE(sum(latency_ms), 0)
+		FROM quiz_attempts
+		WHERE user_id = $1 AND answered_at >= $2 AND answered_at < $3
+		GROUP BY bucket
+	`
+
+	return r.queryCounts(ctx, "quiz time", query, queryArgs(userID, q)...)
+}
+
+// CountDue counts the cards falling due per bucket; overdue cards count in
+// the first bucket
+func (r *StatsRepository) CountDue(ctx context.Context, userID string, q stats.Query) ([]stats.Count, error) {
+	query := `
+		SELECT ` + bucketExpr("GREATEST(due_at, $2)") + ` AS bucket, count(*)
+		FROM review_cards
+		WHERE user_id = $1 AND due_at < $3
+		GROUP BY bucket
+	`
+
+	return r.queryCounts(ctx, "due cards", query, queryArgs(userID, q)...)
+}
+
+// CountByState counts the saved words per review state
+func (r *StatsRepository) CountByState(ctx context.Context, userID string, masteredInterval int) ([]stats.Share, error) {
+	query := `
+		SELECT CASE
+		           WHEN rc.saved_word_id IS NULL OR rc.state = 'new' THEN 'new'
+		           WHEN rc.state = 'relearning' THEN 'relearning'
+		           WHEN rc.interval_days >= $2 THEN 'mastered'
+		           ELSE 'learning'
+		       END AS state,
+		       count(*)
+		FROM saved_words sw
+		LEFT JOIN review_cards rc ON rc.saved_word_id = sw.id
+		WHERE sw.user_id = $1
+		GROUP BY state
+		ORDER BY count(*) DESC, state
+	`
+
+	return r.queryShares(ctx, "states", query, userID, masteredInterval)
+}
+
+// CountByLanguage counts the saved words per language
+func (r *StatsRepository) CountByLanguage(ctx context.Context, userID string) ([]stats.Share, error) {
+	query := `
+		SELECT w.language, count(*)
+		FROM saved_words sw
+		JOIN words w ON w.id = sw.word_id
+		WHERE sw.user_id = $1
+		GROUP BY w.language
+		ORDER BY count(*) DESC, w.language
+	`
+
+	return r.queryShares(ctx, "languages", query, userID)
+}
+
+// CountByWordType counts the saved words per definition word type; a word
+// with several word types counts once for each
+func (r *StatsRepository) CountByWordType(ctx context.Context, userID string) ([]stats.Share, error) {
+	query := `
+		SELECT t.word_type, count(*)
+		FROM saved_words sw
+		JOIN words w ON w.id = sw.word_id
+		CROSS JOIN LATERAL (
+			SELECT DISTINCT NULLIF(d->>'word_type', '') AS word_type
+			FROM jsonb_array_elements(w.definitions) d
+		) t
+		WHERE sw.user_id = $1 AND t.word_type IS NOT NULL
+		GROUP BY t.word_type
+		ORDER BY count(*) DESC, t.word_type
+	`
+
+	return r.queryShares(ctx, "word types", query, userID)
+}
+
+// queryCounts runs a query returning a nullable bucket and a value
+func (r *StatsRepository) queryCounts(ctx context.Context, what, query string, args ...interface{}) ([]stats.Count, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", what, err)
+	}
+	defer rows.Close()
+
+	return collectCounts(rows)
+}
+
+// collectCounts scans bucket counts, mapping a NULL bucket to the zero time
+func collectCounts(rows pgx.Rows) ([]stats.Count, error) {
+	counts := make([]stats.Count, 0)
+	for rows.Next() {
+		var bucket *time.Time
+		var c stats.Count
+		if err := rows.Scan(&bucket, &c.Value); err != nil {
+			return nil, fmt.Errorf("failed to scan count row: %w", err)
+		}
+		if bucket != nil {
+			c.Bucket = *bucket
+		}
+		counts = append(counts, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating count rows: %w", err)
+	}
+
+	return counts, nil
+}
+
+// queryShares runs a query returning a key and a count
+func (r *StatsRepository) queryShares(ctx context.Context, what, query string, args ...interface{}) ([]stats.Share, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", what, err)
+	}
+	defer rows.Close()
+
+	shares := make([]stats.Share, 0)
+	for rows.Next() {
+		var s stats.Share
+		if err := rows.Scan(&s.Key, &s.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan %s row: %w", what, err)
+		}
+		shares = append(shares, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating %s rows: %w", what, err)
+	}
+
+	return shares, nil
+}
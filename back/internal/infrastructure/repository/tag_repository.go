@@ -0,0 +1,339 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/tag"
+)
+
+// tagColumns lists the columns selected for a tag, in scan order
+const tagColumns = `t.id, t.user_id, t.name, t.created_at, t.updated_at`
+
+// TagRepository implements the tag.Repository interface using PostgreSQL
+type TagRepository struct {
+	db     DBInterface
+	logger zerolog.Logger
+}
+
+// Ensure TagRepository implements tag.Repository
+var _ tag.Repository = (*TagRepository)(nil)
+
+// NewTagRepository creates a new tag repository
+func NewTagRepository(db DBInterface, logger zerolog.Logger) *TagRepository {
+	return &TagRepository{
+		db:     db,
+		logger: logger.With().Str("component", "tag_repository").Logger(),
+	}
+}
+
+// FindByID retrieves a tag by its ID
+func (r *TagRepository) FindByID(ctx context.Context, id string) (*tag.Tag, error) {
+	r.logger.Debug().Str("id", id).Msg("Finding tag by ID")
+
+	query := `SELECT ` + tagColumns + `
+		FROM tags t
+		WHERE t.id = $1
+	`
+
+	t, err := scanTag(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || isInvalidID(err) {
+			return nil, tag.ErrTagNotFound
+		}
+		return nil, fmt.Errorf("failed to query tag by ID: %w", err)
+	}
+
+	return t, nil
+}
+
+// FindByUserAndName retrieves a user's tag by its name, ignoring case
+func (r *TagRepository) FindByUserAndName(ctx context.Context, userID, name string) (*tag.Tag, error) {
+	query := `SELECT ` + tagColumns + `
+		FROM tags t
+		WHERE t.user_id = $1 AND lower(t.name) = lower($2)
+	`
+
+	t, err := scanTag(r.db.QueryRow(ctx, query, userID, name))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, tag.ErrTagNotFound
+		}
+		return nil, fmt.Errorf("failed to query tag by name: %w", err)
+	}
+
+	return t, nil
+}
+
+// ListByUser retrieves the tags of a user, ordered by name
+func (r *TagRepository) ListByUser(ctx context.Context, userID string) ([]*tag.Tag, error) {
+	query := `SELECT ` + tagColumns + `
+		FROM tags t
+		WHERE t.user_id = $1
+		ORDER BY lower(t.name)
+	`
+
+	return r.queryTags(ctx, query, userID)
+}
+
+// ListByTarget retrieves the tags assigned to an object
+func (r *TagRepository) ListByTarget(ctx context.Context, targetType tag.TargetType, targetID string) ([]*tag.Tag, error) {
+	query := `SELECT ` + tagColumns + `
+		FROM tags t
+		JOIN tag_assignments ta ON ta.tag_id = t.id
+		WHERE ta.target_type = $1 AND ta.target_id = $2
+		ORDER BY lower(t.name)
+	`
+
+	return r.queryTags(ctx, query, string(targetType), targetID)
+}
+
+// FindSuggestions retrieves the names of a user's tags starting with a prefix
+func (r *TagRepository) FindSuggestions(ctx context.Context, userID, prefix string, limit int) ([]string, error) {
+	r.logger.Debug().Str("prefix", prefix).Int("limit", limit).Msg("Finding tag suggestions")
+
+	query := `
+		SELECT name
+		FROM tags
+		WHERE user_id = $1 AND lower(name) LIKE lower($2) || '%'
+		ORDER BY updated_at DESC
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, prefix, limit)
+	if err != nil {
+		r.logger.Error().Err(err).Msg("Error finding tag suggestions")
+		return nil, fmt.Errorf("error finding tag suggestions: %w", err)
+	}
+	defer rows.Close()
+
+	suggestions := make([]string, 0)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			r.logger.Error().Err(err).Msg("Error scanning tag suggestion")
+			continue
+		}
+		suggestions = append(suggestions, name)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error().Err(err).Msg("Error iterating tag suggestions")
+		return nil, fmt.Errorf("error iterating tag suggestions: %w", err)
+	}
+
+	return suggestions, nil
+}
+
+// Save stores a tag in the repository
+func (r *TagRepository) Save(ctx context.Context, t *tag.Tag) error {
+	query := `
+		INSERT INTO tags (id, user_id, name, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (id)
+		DO UPDATE SET
+			name = $3,
+			updated_at = $5
+	`
+
+	_, err := r.db.Exec(ctx, query, t.ID, t.UserID, t.Name, t.CreatedAt, t.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return tag.ErrDuplicateTagName
+		}
+		return fmt.Errorf("failed to save tag: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a tag and its assignments from the repository
+func (r *TagRepository) Delete(ctx context.Context, id string) error {
+	cmd, err := r.db.Exec(ctx, `DELETE FROM tags WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return tag.ErrTagNotFound
+	}
+	return nil
+}
+
+// Merge moves the assignments of the source tag to the target tag and
+// deletes the source tag in a single transaction
+func (r *TagRepository) Merge(ctx context.Context, sourceID, targetID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // No-op once committed
+
+	// Objects already carrying the target tag keep their assignment; the
+	// source assignment is dropped with the source tag
+	moveQuery := `
+		UPDATE tag_assignments src
+		SET tag_id = $2
+		WHERE src.tag_id = $1
+		  AND NOT EXISTS (
+		      SELECT 1 FROM tag_assignments dst
+		      WHERE dst.tag_id = $2 AND dst.target_id = src.target_id AND dst.target_type = src.target_type
+		  )
+	`
+	if _, err := tx.Exec(ctx, moveQuery, sourceID, targetID); err != nil {
+		return fmt.Errorf("failed to move tag assignments: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM tags WHERE id = $1`, sourceID); err != nil {
+		return fmt.Errorf("failed to delete merged tag: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE tags SET updated_at = $2 WHERE id = $1`, targetID, time.Now()); err != nil {
+		return fmt.Errorf("failed to update tag: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit tag merge: %w", err)
+	}
+
+	return nil
+}
+
+// Assign stores tag assignments; assignments that already exist are skipped
+func (r *TagRepository) Assign(ctx context.Context, assignments []*tag.Assignment) error {
+	if len(assignments) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(assignments))
+	tagIDs := make([]string, len(assignments))
+	targetIDs := make([]string, len(assignments))
+	targetTypes := make([]string, len(assignments))
+	createdAts := make([]time.Time, len(assignments))
+	for i, a := range assignments {
+		ids[i] = a.ID
+		tagIDs[i] = a.TagID
+		targetIDs[i] = a.TargetID
+		targetTypes[i] = string(a.TargetType)
+		createdAts[i] = a.CreatedAt
+	}
+
+	query := `
+		INSERT INTO tag_assignments (id, tag_id, target_id, target_type, created_at)
+		SELECT * FROM unnest($1::uuid[], $2::uuid[], $3::uuid[], $4::text[], $5::timestamptz[])
+		ON CONFLICT (tag_id, target_id, target_type) DO NOTHING
+	`
+
+	if _, err := r.db.Exec(ctx, query, ids, tagIDs, targetIDs, targetTypes, createdAts); err != nil {
+		return fmt.Errorf("failed to assign tag: %w", err)
+	}
+
+	return nil
+}
+
+// Unassign removes a tag from the given objects
+func (r *TagRepository) Unassign(ctx context.Context, tagID string, targetType tag.TargetType, targetIDs []string) error {
+	query := `
+		DELETE FROM tag_assignments
+		WHERE tag_id = $1 AND target_type = $2 AND target_id = ANY($3::uuid[])
+	`
+
+	if _, err := r.db.Exec(ctx, query, tagID, string(targetType), targetIDs); err != nil {
+		// Malformed IDs name no object, so there is nothing to unassign
+		if isInvalidID(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to unassign tag: %w", err)
+	}
+
+	return nil
+}
+
+// CountOwnedTargets counts how many of the given objects exist and belong to the user
+func (r *TagRepository) CountOwnedTargets(ctx context.Context, userID string, targetType tag.TargetType, targetIDs []string) (int, error) {
+	var table string
+	switch targetType {
+	case tag.TargetSavedWord:
+		table = "saved_words"
+	case tag.TargetWordList:
+		table = "word_lists"
+	default:
+		return 0, tag.ErrInvalidTargetType
+	}
+
+	query := `SELECT count(*) FROM ` + table + ` WHERE user_id = $1 AND id = ANY($2::uuid[])`
+
+	var count int
+	if err := r.db.QueryRow(ctx, query, userID, targetIDs).Scan(&count); err != nil {
+		// Malformed IDs name no object, so none of them is owned
+		if isInvalidID(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to count tag targets: %w", err)
+	}
+
+	return count, nil
+}
+
+// queryTags runs a query selecting tagColumns
+func (r *TagRepository) queryTags(ctx context.Context, query string, args ...interface{}) ([]*tag.Tag, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := make([]*tag.Tag, 0)
+	for rows.Next() {
+		t, err := scanTag(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tag row: %w", err)
+		}
+		tags = append(tags, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tag rows: %w", err)
+	}
+
+	return tags, nil
+}
+
+// scanTag scans a row selected with tagColumns
+func scanTag(row pgx.Row) (*tag.Tag, error) {
+	var t tag.Tag
+	if err := row.Scan(&t.ID, &t.UserID, &t.Name, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// tagExpressionSQL compiles a tag expression into a SQL condition on the
+// object whose ID is in idColumn. Tag names and the target type are
+// appended to args and referenced as positional parameters.
+func tagExpressionSQL(expr tag.Expr, targetType tag.TargetType, idColumn string, args *[]interface{}) string {
+	switch e := expr.(type) {
+	case tag.NameExpr:
+		*args = append(*args, string(targetType), e.Name)
+		return fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM tag_assignments ta
+			JOIN tags t ON t.id = ta.tag_id
+			WHERE ta.target_type = $%d AND ta.target_id = %s AND lower(t.name) = lower($%d)
+		)`, len(*args)-1, idColumn, len(*args))
+	case tag.AndExpr:
+		return "(" + tagExpressionSQL(e.Left, targetType, idColumn, args) + " AND " +
+			tagExpressionSQL(e.Right, targetType, idColumn, args) + ")"
+	case tag.OrExpr:
+		return "(" + tagExpressionSQL(e.Left, targetType, idColumn, args) + " OR " +
+			tagExpressionSQL(e.Right, targetType, idColumn, args) + ")"
+	case tag.NotExpr:
+		return "NOT " + tagExpressionSQL(e.Expr, targetType, idColumn, args)
+	}
+	return "FALSE"
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"voconsteroid/internal/domain/tag"
+)
+
+func TestTagExpressionSQL(t *testing.T) {
+	expr, err := tag.ParseExpression(`verbs AND NOT (food OR "to review")`)
+	require.NoError(t, err)
+
+	args := []interface{}{"user-1"}
+	condition := tagExpressionSQL(expr, tag.TargetSavedWord, "sw.id", &args)
+
+	// Every tag name gets its own target type and name parameters, numbered after existing args
+	assert.Equal(t, []interface{}{"user-1", "saved_word", "verbs", "saved_word", "food", "saved_word", "to review"}, args)
+	assert.Contains(t, condition, "lower(t.name) = lower($3)")
+	assert.Contains(t, condition, "lower(t.name) = lower($7)")
+	assert.Equal(t, 3, strings.Count(condition, "ta.target_id = sw.id"))
+	assert.True(t, strings.HasPrefix(condition, "(EXISTS"))
+	assert.Contains(t, condition, "AND NOT (EXISTS")
+}
+
+func TestTagRepository_CountOwnedTargets_MalformedIDs(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	repo := NewTagRepository(mock, zerolog.New(zerolog.NewTestWriter(t)))
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM saved_words`).
+		WithArgs("user-1", []string{"not-a-uuid"}).
+		WillReturnError(&pgconn.PgError{Code: invalidTextRepresentation})
+
+	count, err := repo.CountOwnedTargets(context.Background(), "user-1", tag.TargetSavedWord, []string{"not-a-uuid"})
+
+	require.NoError(t, err)
+	assert.Zero(t, count, "malformed IDs are not owned")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
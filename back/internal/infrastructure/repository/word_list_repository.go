@@ -0,0 +1,278 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/event"
+	"voconsteroid/internal/domain/savedword"
+	"voconsteroid/internal/domain/wordlist"
+)
+
+// wordListSelect selects a word list with its saved word IDs in list order
+const wordListSelect = `
+	SELECT l.id, l.user_id, l.name, l.description, l.share_token, l.created_at, l.updated_at, l.version,
+	       COALESCE(
+	           array_agg(i.saved_word_id::text ORDER BY i.position) FILTER (WHERE i.saved_word_id IS NOT NULL),
+	           '{}'
+	       )
+	FROM word_lists l
+	LEFT JOIN word_list_items i ON i.list_id = l.id`
+
+// WordListRepository implements the wordlist.Repository interface using PostgreSQL
+type WordListRepository struct {
+	db     DBInterface
+	logger zerolog.Logger
+}
+
+// Ensure WordListRepository implements wordlist.Repository
+var _ wordlist.Repository = (*WordListRepository)(nil)
+
+// NewWordListRepository creates a new word list repository
+func NewWordListRepository(db DBInterface, logger zerolog.Logger) *WordListRepository {
+	return &WordListRepository{
+		db:     db,
+		logger: logger.With().Str("component", "word_list_repository").Logger(),
+	}
+}
+
+// FindByID retrieves a word list by its ID
+func (r *WordListRepository) FindByID(ctx context.Context, id string) (*wordlist.WordList, error) {
+	r.logger.Debug().Str("id", id).Msg("Finding word list by ID")
+
+	query := wordListSelect + `
+		WHERE l.id = $1
+		GROUP BY l.id
+	`
+
+	return r.findOne(ctx, query, id)
+}
+
+// FindByShareToken retrieves a shared word list by its public token
+func (r *WordListRepository) FindByShareToken(ctx context.Context, token string) (*wordlist.WordList, error) {
+	query := wordListSelect + `
+		WHERE l.share_token = $1
+		GROUP BY l.id
+	`
+
+	return r.findOne(ctx, query, token)
+}
+
+// FindByUserAndName retrieves a user's word list by its name
+func (r *WordListRepository) FindByUserAndName(ctx context.Context, userID, name string) (*wordlist.WordList, error) {
+	query := wordListSelect + `
+		WHERE l.user_id = $1 AND l.name = $2
+		GROUP BY l.id
+	`
+
+	return r.findOne(ctx, query, userID, name)
+}
+
+// ListByUser retrieves the word lists of a user, ordered by name
+func (r *WordListRepository) ListByUser(ctx context.Context, userID string, limit, offset int) ([]*wordlist.WordList, error) {
+	r.logger.Debug().Str("userID", userID).Msg("Listing word lists")
+
+	query := wordListSelect + `
+		WHERE l.user_id = $1
+		GROUP BY l.id
+		ORDER BY l.name
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query word lists: %w", err)
+	}
+	defer rows.Close()
+
+	lists := make([]*wordlist.WordList, 0)
+	for rows.Next() {
+		list, err := scanWordList(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan word list row: %w", err)
+		}
+		lists = append(lists, list)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating word list rows: %w", err)
+	}
+
+	return lists, nil
+}
+
+// Save stores a word list and replaces its items in a single transaction.
+// A list read from the repository is only updated if it was not changed
+// since it was read (ErrVersionConflict otherwise).
+func (r *WordListRepository) Save(ctx context.Context, list *wordlist.WordList) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // No-op once committed
+
+	if err := saveWordList(ctx, tx, list); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit word list: %w", err)
+	}
+
+	list.Version++
+	return nil
+}
+
+// CreateWithSavedWords stores a new word list together with the saved words
+// created for it, and appends the events of these writes to the outbox, in a
+// single transaction
+func (r *WordListRepository) CreateWithSavedWords(ctx context.Context, list *wordlist.WordList, savedWords []*savedword.SavedWord, emit event.Emit) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // No-op once committed
+
+	// The saved words come first, as the items of the list refer to them
+	for _, sw := range savedWords {
+		if err := saveSavedWord(ctx, tx, sw); err != nil {
+			return err
+		}
+	}
+	if err := saveWordList(ctx, tx, list); err != nil {
+		return err
+	}
+	if err := emitIn(ctx, tx, emit, r.logger); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit word list: %w", err)
+	}
+
+	list.Version++
+	return nil
+}
+
+// saveWordList inserts or updates a word list and replaces its items
+// within a transaction
+func saveWordList(ctx context.Context, tx pgx.Tx, list *wordlist.WordList) error {
+	var tag pgconn.CommandTag
+	var err error
+	if list.Version == 0 {
+		query := `
+			INSERT INTO word_lists (id, user_id, name, description, share_token, created_at, updated_at, version)
+			VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, 1)
+		`
+		tag, err = tx.Exec(ctx, query,
+			list.ID,
+			list.UserID,
+			list.Name,
+			list.Description,
+			list.ShareToken,
+			list.CreatedAt,
+			list.UpdatedAt,
+		)
+	} else {
+		query := `
+			UPDATE word_lists
+			SET name = $2,
+				description = $3,
+				share_token = NULLIF($4, ''),
+				updated_at = $5,
+				version = version + 1
+			WHERE id = $1 AND version = $6
+		`
+		tag, err = tx.Exec(ctx, query,
+			list.ID,
+			list.Name,
+			list.Description,
+			list.ShareToken,
+			list.UpdatedAt,
+			list.Version,
+		)
+	}
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return wordlist.ErrDuplicateListName
+		}
+		return fmt.Errorf("failed to save word list: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return wordlist.ErrVersionConflict
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM word_list_items WHERE list_id = $1`, list.ID); err != nil {
+		return fmt.Errorf("failed to clear word list items: %w", err)
+	}
+
+	if len(list.SavedWordIDs) > 0 {
+		// Positions follow the order of the IDs in the array
+		itemsQuery := `
+			INSERT INTO word_list_items (list_id, saved_word_id, position)
+			SELECT $1, item.id, item.position
+			FROM unnest($2::uuid[]) WITH ORDINALITY AS item(id, position)
+		`
+		if _, err := tx.Exec(ctx, itemsQuery, list.ID, list.SavedWordIDs); err != nil {
+			return fmt.Errorf("failed to save word list items: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Delete removes a word list from the repository
+func (r *WordListRepository) Delete(ctx context.Context, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM word_lists WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete word list: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return wordlist.ErrListNotFound
+	}
+	return nil
+}
+
+// findOne runs a query selecting at most one word list
+func (r *WordListRepository) findOne(ctx context.Context, query string, args ...interface{}) (*wordlist.WordList, error) {
+	list, err := scanWordList(r.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || isInvalidID(err) {
+			return nil, wordlist.ErrListNotFound
+		}
+		return nil, fmt.Errorf("failed to query word list: %w", err)
+	}
+	return list, nil
+}
+
+// scanWordList scans a row selected with wordListSelect
+func scanWordList(row pgx.Row) (*wordlist.WordList, error) {
+	var list wordlist.WordList
+	var shareToken *string
+
+	if err := row.Scan(
+		&list.ID,
+		&list.UserID,
+		&list.Name,
+		&list.Description,
+		&shareToken,
+		&list.CreatedAt,
+		&list.UpdatedAt,
+		&list.Version,
+		&list.SavedWordIDs,
+	); err != nil {
+		return nil, err
+	}
+
+	if shareToken != nil {
+		list.ShareToken = *shareToken
+	}
+
+	return &list, nil
+}
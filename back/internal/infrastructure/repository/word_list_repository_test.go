@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"voconsteroid/internal/domain/event"
+	"voconsteroid/internal/domain/savedword"
+	"voconsteroid/internal/domain/wordlist"
+)
+
+func anyArgs(n int) []interface{} {
+	args := make([]interface{}, n)
+	for i := range args {
+		args[i] = pgxmock.AnyArg()
+	}
+	return args
+}
+
+func TestWordListRepository_CreateWithSavedWords(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	emitted := false
+	emit := func(context.Context, event.Outbox) error {
+		emitted = true
+		return nil
+	}
+
+	t.Run("saves the saved words, the list and the events together", func(t *testing.T) {
+		// Setup
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		repo := NewWordListRepository(mock, logger)
+		list := wordlist.NewWordList("user-1", "Verbs (copy)", "")
+		sw := savedword.NewSavedWord("user-1", "word-1")
+		list.AddWords(sw.ID)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO saved_words`).WithArgs(anyArgs(11)...).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mock.ExpectExec(`INSERT INTO word_lists`).WithArgs(anyArgs(7)...).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mock.ExpectExec(`DELETE FROM word_list_items`).WithArgs(list.ID).WillReturnResult(pgxmock.NewResult("DELETE", 0))
+		mock.ExpectExec(`INSERT INTO word_list_items`).WithArgs(list.ID, list.SavedWordIDs).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mock.ExpectCommit()
+
+		// Execute
+		err = repo.CreateWithSavedWords(ctx, list, []*savedword.SavedWord{sw}, emit)
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, emitted)
+		assert.Equal(t, 1, list.Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rolls the saved words back when the list cannot be saved", func(t *testing.T) {
+		// Setup
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		repo := NewWordListRepository(mock, logger)
+		list := wordlist.NewWordList("user-1", "Verbs (copy)", "")
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO saved_words`).WithArgs(anyArgs(11)...).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mock.ExpectExec(`INSERT INTO word_lists`).WithArgs(anyArgs(7)...).WillReturnError(&pgconn.PgError{Code: uniqueViolation})
+		mock.ExpectRollback()
+
+		// Execute
+		err = repo.CreateWithSavedWords(ctx, list, []*savedword.SavedWord{savedword.NewSavedWord("user-1", "word-1")}, emit)
+
+		// Assert
+		assert.ErrorIs(t, err, wordlist.ErrDuplicateListName)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
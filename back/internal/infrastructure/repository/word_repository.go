@@ -9,8 +9,10 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/rs/zerolog"
 
+	"voconsteroid/internal/domain/event"
 	"voconsteroid/internal/domain/word"
 )
 
@@ -18,6 +20,8 @@ import (
 type DBInterface interface {
 	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
 	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Begin(ctx context.Context) (pgx.Tx, error)
 }
 
 // WordRepository implements the word.Repository interface using PostgreSQL
@@ -26,8 +30,12 @@ type WordRepository struct {
 	logger zerolog.Logger
 }
 
-// Ensure WordRepository implements word.Repository
-var _ word.Repository = (*WordRepository)(nil)
+// Ensure WordRepository implements the word repository interfaces
+var (
+	_ word.Repository            = (*WordRepository)(nil)
+	_ word.DifficultyRepository  = (*WordRepository)(nil)
+	_ word.MaintenanceRepository = (*WordRepository)(nil)
+)
 
 // NewWordRepository creates a new word repository
 func NewWordRepository(db DBInterface, logger zerolog.Logger) *WordRepository {
@@ -41,91 +49,30 @@ func NewWordRepository(db DBInterface, logger zerolog.Logger) *WordRepository {
 func (r *WordRepository) FindByID(ctx context.Context, id string) (*word.Word, error) {
 	r.logger.Debug().Str("id", id).Msg("Finding word by ID")
 
-	query := `
-		SELECT id, text, language, definitions, etymology, translations, 
-		       synonyms, antonyms, search_terms, lemma, usage_notes, created_at, updated_at
-		FROM words
-		WHERE id = $1
+	query := `SELECT ` + wordColumns + `
+		FROM words w
+		WHERE w.id = $1
 	`
 
-	var w word.Word
-	var definitionsJSON []byte
-	var searchTerms []string
-	var translations map[string]string
-	var synonyms []string
-	var antonyms []string
-	var usageNotes []string
-
-	err := r.db.QueryRow(ctx, query, id).Scan(
-		&w.ID,
-		&w.Text,
-		&w.Language,
-		&definitionsJSON,
-		&w.Etymology,
-		&translations,
-		&synonyms,
-		&antonyms,
-		&searchTerms,
-		&w.Lemma,
-		&usageNotes,
-		&w.CreatedAt,
-		&w.UpdatedAt,
-	)
-
+	w, err := scanWord(r.db.QueryRow(ctx, query, id))
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
+		if errors.Is(err, pgx.ErrNoRows) || isInvalidID(err) {
 			return nil, word.ErrWordNotFound
 		}
 		return nil, fmt.Errorf("failed to query word by ID: %w", err)
 	}
 
-	// Parse definitions JSON
-	if err := json.Unmarshal(definitionsJSON, &w.Definitions); err != nil {
-		return nil, fmt.Errorf("failed to parse definitions: %w", err)
-	}
-
-	w.SearchTerms = searchTerms
-	w.Translations = translations
-	w.Synonyms = synonyms
-	w.Antonyms = antonyms
-	w.UsageNotes = usageNotes
-
-	return &w, nil
+	return w, nil
 }
 
 // FindByText retrieves a word by its text and language
 func (r *WordRepository) FindByText(ctx context.Context, text, language string) (*word.Word, error) {
-	query := `
-		SELECT id, text, language, definitions, etymology, translations, 
-		       synonyms, antonyms, search_terms, lemma, usage_notes, created_at, updated_at
-		FROM words
-		WHERE text = $1 AND language = $2
+	query := `SELECT ` + wordColumns + `
+		FROM words w
+		WHERE w.text = $1 AND w.language = $2
 	`
 
-	var w word.Word
-	var definitionsJSON []byte
-	var searchTerms []string
-	var translations map[string]string
-	var synonyms []string
-	var antonyms []string
-	var usageNotes []string
-
-	err := r.db.QueryRow(ctx, query, text, language).Scan(
-		&w.ID,
-		&w.Text,
-		&w.Language,
-		&definitionsJSON,
-		&w.Etymology,
-		&translations,
-		&synonyms,
-		&antonyms,
-		&searchTerms,
-		&w.Lemma,
-		&usageNotes,
-		&w.CreatedAt,
-		&w.UpdatedAt,
-	)
-
+	w, err := scanWord(r.db.QueryRow(ctx, query, text, language))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, word.ErrWordNotFound
@@ -133,53 +80,17 @@ func (r *WordRepository) FindByText(ctx context.Context, text, language string)
 		return nil, fmt.Errorf("failed to query word: %w", err)
 	}
 
-	// Parse definitions JSON
-	if err := json.Unmarshal(definitionsJSON, &w.Definitions); err != nil {
-		return nil, fmt.Errorf("failed to parse definitions: %w", err)
-	}
-
-	w.SearchTerms = searchTerms
-	w.Translations = translations
-	w.Synonyms = synonyms
-	w.Antonyms = antonyms
-	w.UsageNotes = usageNotes
-
-	return &w, nil
+	return w, nil
 }
 
 // FindByAnyForm retrieves a word by any of its forms (using search terms)
 func (r *WordRepository) FindByAnyForm(ctx context.Context, text, language string) (*word.Word, error) {
-	query := `
-		SELECT id, text, language, definitions, etymology, translations, 
-		        synonyms, antonyms, search_terms, lemma, usage_notes, created_at, updated_at
-		FROM words
-		WHERE language = $1 AND $2 = ANY(search_terms)
+	query := `SELECT ` + wordColumns + `
+		FROM words w
+		WHERE w.language = $1 AND $2 = ANY(w.search_terms)
 	`
 
-	var w word.Word
-	var definitionsJSON []byte
-	var searchTerms []string
-	var translations map[string]string
-	var synonyms []string
-	var antonyms []string
-	var usageNotes []string
-
-	err := r.db.QueryRow(ctx, query, language, text).Scan(
-		&w.ID,
-		&w.Text,
-		&w.Language,
-		&definitionsJSON,
-		&w.Etymology,
-		&translations,
-		&synonyms,
-		&antonyms,
-		&searchTerms,
-		&w.Lemma,
-		&usageNotes,
-		&w.CreatedAt,
-		&w.UpdatedAt,
-	)
-
+	w, err := scanWord(r.db.QueryRow(ctx, query, language, text))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, word.ErrWordNotFound
@@ -187,28 +98,34 @@ func (r *WordRepository) FindByAnyForm(ctx context.Context, text, language strin
 		return nil, fmt.Errorf("failed to query word by form: %w", err)
 	}
 
-	// Parse definitions JSON
-	if err := json.Unmarshal(definitionsJSON, &w.Definitions); err != nil {
-		return nil, fmt.Errorf("failed to parse definitions: %w", err)
-	}
+	return w, nil
+}
 
-	w.SearchTerms = searchTerms
-	w.Translations = translations
-	w.Synonyms = synonyms
-	w.Antonyms = antonyms
-	w.UsageNotes = usageNotes
+// Save stores a word in the repository, publishing the events of the write
+// in its transaction
+func (r *WordRepository) Save(ctx context.Context, w *word.Word, emit event.Emit) error {
+	return writeWithEvents(ctx, r.db, emit, r.logger, func(db DBInterface) error {
+		return saveWord(ctx, db, w)
+	})
+}
 
-	return &w, nil
+// rowQuerier is implemented by both the pool and transactions
+type rowQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
 }
 
-// Save stores a word in the repository
-func (r *WordRepository) Save(ctx context.Context, w *word.Word) error {
+// saveWord inserts or updates a word, matching stored words by text and language
+func saveWord(ctx context.Context, db rowQuerier, w *word.Word) error {
 	query := `
 		INSERT INTO words (
 			id, text, language, definitions, etymology, translations, 
-			synonyms, antonyms, search_terms, lemma, usage_notes, created_at, updated_at
+			synonyms, antonyms, search_terms, lemma, usage_notes, created_at, updated_at,
+			frequency_rank, difficulty, parser_version, source, source_url, source_revision, fetched_at
+		)
+		VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, NULLIF($14, 0), NULLIF($15, ''),
+			COALESCE($16::int, 0), COALESCE($17::text, ''), COALESCE($18::text, ''), COALESCE($19::bigint, 0), $20
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 		ON CONFLICT (text, language) 
 		DO UPDATE SET 
 			definitions = $4,
@@ -220,7 +137,14 @@ func (r *WordRepository) Save(ctx context.Context, w *word.Word) error {
 			lemma = $10,
 			usage_notes = $11,
 			created_at = $12,
-			updated_at = $13
+			updated_at = $13,
+			frequency_rank = CASE WHEN $15 = '' THEN words.frequency_rank ELSE NULLIF($14, 0) END,
+			difficulty = COALESCE(NULLIF($15, ''), words.difficulty),
+			parser_version = COALESCE($16::int, words.parser_version),
+			source = COALESCE($17::text, words.source),
+			source_url = COALESCE($18::text, words.source_url),
+			source_revision = COALESCE($19::bigint, words.source_revision),
+			fetched_at = COALESCE($20, words.fetched_at)
 		RETURNING id
 	`
 
@@ -241,7 +165,19 @@ func (r *WordRepository) Save(ctx context.Context, w *word.Word) error {
 		return fmt.Errorf("failed to marshal definitions: %w", err)
 	}
 
-	return r.db.QueryRow(ctx, query,
+	// Words without provenance keep the stored one
+	var parserVersion *int
+	var source, sourceURL *string
+	var revision *int64
+	var fetchedAt *time.Time
+	if p := w.Provenance; p != nil {
+		parserVersion, source, sourceURL, revision = &p.ParserVersion, &p.Source, &p.SourceURL, &p.RevisionID
+		if !p.FetchedAt.IsZero() {
+			fetchedAt = &p.FetchedAt
+		}
+	}
+
+	return db.QueryRow(ctx, query,
 		w.ID,
 		w.Text,
 		w.Language,
@@ -255,97 +191,76 @@ func (r *WordRepository) Save(ctx context.Context, w *word.Word) error {
 		w.UsageNotes,
 		w.CreatedAt,
 		w.UpdatedAt,
+		w.FrequencyRank,
+		string(w.Difficulty),
+		parserVersion,
+		source,
+		sourceURL,
+		revision,
+		fetchedAt,
 	).Scan(&w.ID)
 }
 
 // FindByPrefix retrieves words by prefix and language
 func (r *WordRepository) FindByPrefix(ctx context.Context, prefix, language string, limit int) ([]*word.Word, error) {
-	query := `
-		SELECT id, text, language, definitions, etymology, 
-		       translations, synonyms, antonyms, search_terms, lemma, usage_notes, created_at, updated_at
-		FROM words
-		WHERE language = $1 
-		  AND EXISTS (SELECT 1 FROM unnest(search_terms) AS term 
+	query := `SELECT ` + wordColumns + `
+		FROM words w
+		WHERE w.language = $1 
+		  AND EXISTS (SELECT 1 FROM unnest(w.search_terms) AS term 
 		         WHERE term ILIKE $2 || '%')
-		ORDER BY text <-> $2  -- Use pg_trgm similarity operator
+		ORDER BY w.text <-> $2  -- Use pg_trgm similarity operator
 		LIMIT $3
 	`
 
-	var words []*word.Word
 	rows, err := r.db.Query(ctx, query, language, prefix, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query words by prefix: %w", err)
 	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var w word.Word
-		var definitionsJSON []byte
-		var searchTerms []string
-		var translations map[string]string
-		var synonyms []string
-		var antonyms []string
-		var usageNotes []string
-
-		if err := rows.Scan(
-			&w.ID,
-			&w.Text,
-			&w.Language,
-			&definitionsJSON,
-			&w.Etymology,
-			&translations,
-			&synonyms,
-			&antonyms,
-			&searchTerms,
-			&w.Lemma,
-			&usageNotes,
-			&w.CreatedAt,
-			&w.UpdatedAt,
-		); err != nil {
-			return nil, fmt.Errorf("failed to scan word row: %w", err)
-		}
 
-		if err := json.Unmarshal(definitionsJSON, &w.Definitions); err != nil {
-			return nil, fmt.Errorf("failed to parse definitions: %w", err)
-		}
-
-		w.SearchTerms = searchTerms
-		w.Translations = translations
-		w.Synonyms = synonyms
-		w.Antonyms = antonyms
-		w.UsageNotes = usageNotes
-
-		words = append(words, &w)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating word rows: %w", err)
-	}
-
-	return words, nil
+	return collectWords(rows)
 }
 
+// List retrieves words with optional filtering and sorting. The
+// word.FilterMinDifficulty, word.FilterMaxDifficulty and word.FilterSort keys
+// are handled specially; any other key matches its column exactly.
 func (r *WordRepository) List(ctx context.Context, filter map[string]interface{}, limit, offset int) ([]*word.Word, error) {
 	// Build query with filters
-	query := `
-		SELECT id, text, language, definitions, etymology, translations, 
-		        search_terms, lemma, created_at, updated_at
-		FROM words
+	query := `SELECT ` + wordColumns + `
+		FROM words w
 		WHERE 1=1
 	`
 
 	args := []interface{}{}
 	argIndex := 1
+	orderBy := "w.updated_at DESC"
 
 	// Add filters
 	for key, value := range filter {
-		query += fmt.Sprintf(" AND %s = $%d", key, argIndex)
+		switch key {
+		case word.FilterSort:
+			switch value {
+			case word.SortFrequency:
+				orderBy = "w.frequency_rank ASC NULLS LAST, w.text"
+			case word.SortDifficulty:
+				orderBy = "w.difficulty ASC NULLS LAST, w.frequency_rank ASC NULLS LAST, w.text"
+			}
+			continue
+		case word.FilterMinDifficulty:
+			// Bands sort alphabetically from A1 to C2
+			query += fmt.Sprintf(" AND w.difficulty >= $%d", argIndex)
+			value = fmt.Sprint(value)
+		case word.FilterMaxDifficulty:
+			query += fmt.Sprintf(" AND w.difficulty <= $%d", argIndex)
+			value = fmt.Sprint(value)
+		default:
+			query += fmt.Sprintf(" AND w.%s = $%d", key, argIndex)
+		}
 		args = append(args, value)
 		argIndex++
 	}
 
 	// Add ordering and pagination
-	query += " ORDER BY updated_at DESC LIMIT $" + fmt.Sprintf("%d", argIndex)
+	query += " ORDER BY " + orderBy + " LIMIT $" + fmt.Sprintf("%d", argIndex)
 	args = append(args, limit)
 	argIndex++
 
@@ -359,50 +274,202 @@ func (r *WordRepository) List(ctx context.Context, filter map[string]interface{}
 	if err != nil {
 		return nil, fmt.Errorf("failed to query words: %w", err)
 	}
-	defer rows.Close()
 
-	// Process results
-	var words []*word.Word
-	for rows.Next() {
-		var w word.Word
-		var definitionsJSON []byte
-		var searchTerms []string
-		var translations map[string]string
-
-		err := rows.Scan(
-			&w.ID,
-			&w.Text,
-			&w.Language,
-			&definitionsJSON,
-
-			&w.Etymology,
-			&translations,
-			&searchTerms,
-			&w.Lemma,
-			&w.CreatedAt,
-			&w.UpdatedAt,
-		)
+	return collectWords(rows)
+}
 
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan word row: %w", err)
+// ListUnscored retrieves words without difficulty whose ID sorts after afterID
+func (r *WordRepository) ListUnscored(ctx context.Context, afterID string, limit int) ([]*word.Word, error) {
+	query := `SELECT ` + wordColumns + `
+		FROM words w
+		WHERE w.difficulty IS NULL AND ($1 = '' OR w.id > $1::uuid)
+		ORDER BY w.id
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unscored words: %w", err)
+	}
+
+	return collectWords(rows)
+}
+
+// UpdateDifficulty stores the frequency rank and difficulty of a word
+func (r *WordRepository) UpdateDifficulty(ctx context.Context, id string, rank int, difficulty word.Difficulty) error {
+	query := `
+		UPDATE words
+		SET frequency_rank = NULLIF($2, 0), difficulty = $3
+		WHERE id = $1
+	`
+
+	tag, err := r.db.Exec(ctx, query, id, rank, string(difficulty))
+	if err != nil {
+		return fmt.Errorf("failed to update word difficulty: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return word.ErrWordNotFound
+	}
+
+	return nil
+}
+
+// ListBatch retrieves words whose ID sorts after afterID, in ID order, only
+// those updated before updatedBefore unless it is zero
+func (r *WordRepository) ListBatch(ctx context.Context, afterID string, updatedBefore time.Time, limit int) ([]*word.Word, error) {
+	query := `SELECT ` + wordColumns + `
+		FROM words w
+		WHERE ($1 = '' OR w.id > $1::uuid)
+		  AND ($2::timestamptz IS NULL OR w.updated_at < $2)
+		ORDER BY w.id
+		LIMIT $3
+	`
+
+	var before *time.Time
+	if !updatedBefore.IsZero() {
+		before = &updatedBefore
+	}
+
+	rows, err := r.db.Query(ctx, query, afterID, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query words: %w", err)
+	}
+
+	return collectWords(rows)
+}
+
+// Delete removes a word; saved and daily words pointing to it are removed
+// by their foreign keys
+func (r *WordRepository) Delete(ctx context.Context, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM words WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete word: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return word.ErrWordNotFound
+	}
+
+	return nil
+}
+
+// Merge saves target and moves the saved words, daily words and quiz
+// questions of source onto it before deleting source. A user who saved
+// both words keeps the saved target.
+func (r *WordRepository) Merge(ctx context.Context, source, target *word.Word) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // No-op once committed
+
+	if err := saveWord(ctx, tx, target); err != nil {
+		return fmt.Errorf("failed to save merged word: %w", err)
+	}
+
+	moves := []struct {
+		table string
+		query string
+	}{
+		{"saved words", `
+			UPDATE saved_words s SET word_id = $2
+			WHERE s.word_id = $1
+			  AND NOT EXISTS (SELECT 1 FROM saved_words t WHERE t.user_id = s.user_id AND t.word_id = $2)`},
+		{"daily words", `UPDATE daily_words SET word_id = $2 WHERE word_id = $1`},
+		{"quiz questions", `UPDATE quiz_questions SET word_id = $2 WHERE word_id = $1`},
+	}
+	for _, move := range moves {
+		if _, err := tx.Exec(ctx, move.query, source.ID, target.ID); err != nil {
+			return fmt.Errorf("failed to move %s: %w", move.table, err)
 		}
+	}
+
+	tag, err := tx.Exec(ctx, `DELETE FROM words WHERE id = $1`, source.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete merged word: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return word.ErrWordNotFound
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Stats summarizes the stored words
+func (r *WordRepository) Stats(ctx context.Context) (*word.TableStats, error) {
+	stats := &word.TableStats{
+		ByLanguage:      make(map[string]int),
+		ByDifficulty:    make(map[string]int),
+		BySource:        make(map[string]int),
+		ByParserVersion: make(map[string]int),
+	}
+
+	query := `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE definitions IS NULL OR definitions IN ('null'::jsonb, '[]'::jsonb)),
+			COUNT(*) FILTER (WHERE lemma IS NULL OR lemma = ''),
+			MIN(updated_at),
+			MAX(updated_at)
+		FROM words
+	`
+
+	var oldest, newest *time.Time
+	if err := r.db.QueryRow(ctx, query).Scan(
+		&stats.Total,
+		&stats.WithoutDefinitions,
+		&stats.WithoutLemma,
+		&oldest,
+		&newest,
+	); err != nil {
+		return nil, fmt.Errorf("failed to count words: %w", err)
+	}
+	if oldest != nil {
+		stats.OldestUpdate = *oldest
+	}
+	if newest != nil {
+		stats.NewestUpdate = *newest
+	}
 
-		// Parse definitions JSON
-		if err := json.Unmarshal(definitionsJSON, &w.Definitions); err != nil {
-			return nil, fmt.Errorf("failed to parse definitions: %w", err)
+	groups := []struct {
+		counts map[string]int
+		query  string
+	}{
+		{stats.ByLanguage, `SELECT language, COUNT(*) FROM words GROUP BY language`},
+		{stats.ByDifficulty, `SELECT COALESCE(difficulty, ''), COUNT(*) FROM words GROUP BY 1`},
+		{stats.BySource, `SELECT source, COUNT(*) FROM words GROUP BY source`},
+		{stats.ByParserVersion, `SELECT parser_version::text, COUNT(*) FROM words GROUP BY parser_version`},
+	}
+	for _, group := range groups {
+		if err := r.queryGroupCounts(ctx, group.query, group.counts); err != nil {
+			return nil, err
 		}
+	}
 
-		w.SearchTerms = searchTerms
-		w.Translations = translations
+	return stats, nil
+}
 
-		words = append(words, &w)
+// queryGroupCounts scans the counts of a query selecting a key and a count
+func (r *WordRepository) queryGroupCounts(ctx context.Context, query string, counts map[string]int) error {
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to group words: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key string
+		var count int
+		if err := rows.Scan(&key, &count); err != nil {
+			return fmt.Errorf("failed to scan word group: %w", err)
+		}
+		counts[key] = count
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating word rows: %w", err)
+		return fmt.Errorf("error iterating word groups: %w", err)
 	}
 
-	return words, nil
+	return nil
 }
 
 // FindSuggestions retrieves word suggestions based on a prefix
@@ -441,3 +508,77 @@ func (r *WordRepository) FindSuggestions(ctx context.Context, prefix, language s
 
 	return suggestions, nil
 }
+
+// wordColumns lists the columns selected for a full word, in scanWord order
+const wordColumns = `
+	w.id, w.text, w.language, w.definitions, w.etymology, w.translations,
+	w.synonyms, w.antonyms, w.search_terms, w.lemma, w.usage_notes, w.created_at, w.updated_at,
+	COALESCE(w.frequency_rank, 0), COALESCE(w.difficulty, ''),
+	w.parser_version, w.source, w.source_url, w.source_revision, w.fetched_at`
+
+// scanWord scans a row selected with wordColumns
+func scanWord(row pgx.Row) (*word.Word, error) {
+	var w word.Word
+	var definitionsJSON []byte
+	var difficulty string
+	var provenance word.Provenance
+	var fetchedAt *time.Time
+
+	if err := row.Scan(
+		&w.ID,
+		&w.Text,
+		&w.Language,
+		&definitionsJSON,
+		&w.Etymology,
+		&w.Translations,
+		&w.Synonyms,
+		&w.Antonyms,
+		&w.SearchTerms,
+		&w.Lemma,
+		&w.UsageNotes,
+		&w.CreatedAt,
+		&w.UpdatedAt,
+		&w.FrequencyRank,
+		&difficulty,
+		&provenance.ParserVersion,
+		&provenance.Source,
+		&provenance.SourceURL,
+		&provenance.RevisionID,
+		&fetchedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(definitionsJSON, &w.Definitions); err != nil {
+		return nil, fmt.Errorf("failed to parse definitions: %w", err)
+	}
+	w.Difficulty = word.Difficulty(difficulty)
+	if provenance.Source != "" || provenance.ParserVersion > 0 {
+		if fetchedAt != nil {
+			provenance.FetchedAt = *fetchedAt
+		}
+		w.Provenance = &provenance
+	}
+
+	return &w, nil
+}
+
+// collectWords scans and closes rows selected with wordColumns
+func collectWords(rows pgx.Rows) ([]*word.Word, error) {
+	defer rows.Close()
+
+	var words []*word.Word
+	for rows.Next() {
+		w, err := scanWord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan word row: %w", err)
+		}
+		words = append(words, w)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating word rows: %w", err)
+	}
+
+	return words, nil
+}
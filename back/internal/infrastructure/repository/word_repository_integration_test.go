@@ -3,9 +3,6 @@ package repository_test
 import (
 	"context"
 	"fmt"
-	"os"
-	"path/filepath"
-	"sort"
 	"strings"
 	"sync"
 	"testing"
@@ -20,6 +17,7 @@ import (
 	"github.com/testcontainers/testcontainers-go/wait"
 
 	"voconsteroid/internal/domain/word"
+	"voconsteroid/internal/infrastructure/migrations"
 	"voconsteroid/internal/infrastructure/repository"
 )
 
@@ -70,41 +68,14 @@ func setupTestDatabase(t *testing.T) (*pgxpool.Pool, func()) {
 	return dbpool, cleanup
 }
 
-// runMigrations executes all SQL migration scripts against the database
+// runMigrations applies the embedded migrations to the database
 func runMigrations(ctx context.Context, db *pgxpool.Pool) error {
-	// Path to migration files
-	migrationsPath := "../migrations"
-
-	// Get absolute path to migrations directory
-	absPath, err := filepath.Abs(migrationsPath)
+	embedded, err := migrations.Embedded()
 	if err != nil {
-		return fmt.Errorf("failed to get absolute path to migrations: %w", err)
-	}
-
-	// Read all SQL files from the migrations directory
-	entries, err := filepath.Glob(filepath.Join(absPath, "*.sql"))
-	if err != nil {
-		return fmt.Errorf("failed to read migration files: %w", err)
-	}
-
-	// Sort migration files to ensure they're executed in the correct order
-	// This assumes migration files are named with a numeric prefix like "001_create_tables.sql"
-	sort.Strings(entries)
-
-	// Execute each migration file
-	for _, entry := range entries {
-		content, err := os.ReadFile(entry)
-		if err != nil {
-			return fmt.Errorf("failed to read migration file %s: %w", entry, err)
-		}
-
-		_, err = db.Exec(ctx, string(content))
-		if err != nil {
-			return fmt.Errorf("failed to execute migration %s: %w", entry, err)
-		}
+		return err
 	}
-
-	return nil
+	_, err = migrations.NewRunner(db, embedded, zerolog.Nop()).Up(ctx, 0)
+	return err
 }
 
 // createTestWord creates a test word for use in tests
@@ -150,7 +121,7 @@ func TestWordRepository_Integration(t *testing.T) {
 		testWord := createTestWord()
 
 		// Save word
-		err := repo.Save(ctx, testWord)
+		err := repo.Save(ctx, testWord, nil)
 		require.NoError(t, err)
 		assert.NotEmpty(t, testWord.ID)
 
@@ -164,6 +135,35 @@ func TestWordRepository_Integration(t *testing.T) {
 		assert.Equal(t, testWord.Definitions[0].Text, found.Definitions[0].Text)
 	})
 
+	t.Run("Provenance", func(t *testing.T) {
+		ctx := context.Background()
+		fetchedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+		// Save a fetched word
+		testWord := createTestWord()
+		testWord.Text = "provenance"
+		testWord.Provenance = &word.Provenance{
+			Source:        "enwiktionary",
+			SourceURL:     "https://en.wiktionary.org/wiki/provenance",
+			RevisionID:    81234567,
+			ParserVersion: 2,
+			FetchedAt:     fetchedAt,
+		}
+		require.NoError(t, repo.Save(ctx, testWord, nil))
+
+		// Saving it again without provenance keeps the stored one
+		testWord.Provenance = nil
+		require.NoError(t, repo.Save(ctx, testWord, nil))
+
+		found, err := repo.FindByID(ctx, testWord.ID)
+		require.NoError(t, err)
+		require.NotNil(t, found.Provenance)
+		assert.Equal(t, "enwiktionary", found.Provenance.Source)
+		assert.Equal(t, int64(81234567), found.Provenance.RevisionID)
+		assert.Equal(t, 2, found.Provenance.ParserVersion)
+		assert.True(t, fetchedAt.Equal(found.Provenance.FetchedAt))
+	})
+
 	t.Run("FindByAnyForm", func(t *testing.T) {
 		ctx := context.Background()
 
@@ -171,7 +171,7 @@ func TestWordRepository_Integration(t *testing.T) {
 		testWord := createTestWord()
 
 		// Save word
-		err := repo.Save(ctx, testWord)
+		err := repo.Save(ctx, testWord, nil)
 		require.NoError(t, err)
 
 		// Find word by form
@@ -194,10 +194,10 @@ func TestWordRepository_Integration(t *testing.T) {
 		testWord2.SearchTerms = []string{"banana", "bananas"}
 
 		// Save words
-		err := repo.Save(ctx, testWord1)
+		err := repo.Save(ctx, testWord1, nil)
 		require.NoError(t, err)
 
-		err = repo.Save(ctx, testWord2)
+		err = repo.Save(ctx, testWord2, nil)
 		require.NoError(t, err)
 
 		// List words
@@ -236,7 +236,7 @@ func TestWordRepository_Integration(t *testing.T) {
 		testWord := createTestWord()
 		testWord.Text = "update-test"
 
-		err := repo.Save(ctx, testWord)
+		err := repo.Save(ctx, testWord, nil)
 		require.NoError(t, err)
 		assert.NotEmpty(t, testWord.ID)
 
@@ -250,7 +250,7 @@ func TestWordRepository_Integration(t *testing.T) {
 		})
 
 		// Save the updated word
-		err = repo.Save(ctx, testWord)
+		err = repo.Save(ctx, testWord, nil)
 		require.NoError(t, err)
 
 		// ID should remain the same
@@ -282,9 +282,9 @@ func TestWordRepository_Integration(t *testing.T) {
 		esWord.Language = "es"
 
 		// Save all words
-		require.NoError(t, repo.Save(ctx, enWord))
-		require.NoError(t, repo.Save(ctx, frWord))
-		require.NoError(t, repo.Save(ctx, esWord))
+		require.NoError(t, repo.Save(ctx, enWord, nil))
+		require.NoError(t, repo.Save(ctx, frWord, nil))
+		require.NoError(t, repo.Save(ctx, esWord, nil))
 
 		// Filter by language
 		filter := map[string]interface{}{"language": "fr"}
@@ -329,7 +329,7 @@ func TestWordRepository_Integration(t *testing.T) {
 					testWord.Text = fmt.Sprintf("concurrent-test-%d-%d", routineID, j)
 
 					// Save the word
-					err := repo.Save(ctx, testWord)
+					err := repo.Save(ctx, testWord, nil)
 					assert.NoError(t, err)
 
 					// Find the word
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/pashagolub/pgxmock/v4"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
@@ -39,10 +40,7 @@ func TestWordRepository_ErrorHandling(t *testing.T) {
 	ctx := context.Background()
 
 	// Test database error handling
-	mock.ExpectQuery(`SELECT id, text, language, definitions, etymology, translations, 
-		       search_terms, lemma, created_at, updated_at
-		FROM words
-		WHERE text = \$1 AND language = \$2`).
+	mock.ExpectQuery(`SELECT (.+) FROM words w WHERE w\.text = \$1 AND w\.language = \$2`).
 		WithArgs("error-test", "en").
 		WillReturnError(pgx.ErrNoRows)
 
@@ -54,10 +52,7 @@ func TestWordRepository_ErrorHandling(t *testing.T) {
 	assert.ErrorIs(t, err, word.ErrWordNotFound)
 
 	// Test with a different error
-	mock.ExpectQuery(`SELECT id, text, language, definitions, etymology, translations, 
-		       search_terms, lemma, created_at, updated_at
-		FROM words
-		WHERE text = \$1 AND language = \$2`).
+	mock.ExpectQuery(`SELECT (.+) FROM words w WHERE w\.text = \$1 AND w\.language = \$2`).
 		WithArgs("db-error", "en").
 		WillReturnError(context.DeadlineExceeded)
 
@@ -73,3 +68,68 @@ func TestWordRepository_ErrorHandling(t *testing.T) {
 	err = mock.ExpectationsWereMet()
 	require.NoError(t, err)
 }
+
+func TestWordRepository_FindByID_MalformedID(t *testing.T) {
+	mock, repo := setupMockDB(t)
+	defer mock.Close()
+
+	mock.ExpectQuery(`SELECT (.+) FROM words w WHERE w\.id = \$1`).
+		WithArgs("not-a-uuid").
+		WillReturnError(&pgconn.PgError{Code: invalidTextRepresentation})
+
+	result, err := repo.FindByID(context.Background(), "not-a-uuid")
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, word.ErrWordNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWordRepository_Delete_NotFound(t *testing.T) {
+	mock, repo := setupMockDB(t)
+	defer mock.Close()
+
+	mock.ExpectExec(`DELETE FROM words WHERE id = \$1`).
+		WithArgs("missing-id").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	err := repo.Delete(context.Background(), "missing-id")
+
+	assert.ErrorIs(t, err, word.ErrWordNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWordRepository_Merge(t *testing.T) {
+	mock, repo := setupMockDB(t)
+	defer mock.Close()
+
+	source := &word.Word{ID: "source-id", Text: "chevaux", Language: "fr"}
+	target := &word.Word{ID: "target-id", Text: "cheval", Language: "fr"}
+
+	saveArgs := make([]interface{}, 20)
+	for i := range saveArgs {
+		saveArgs[i] = pgxmock.AnyArg()
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO words`).
+		WithArgs(saveArgs...).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow("target-id"))
+	mock.ExpectExec(`UPDATE saved_words s SET word_id = \$2`).
+		WithArgs("source-id", "target-id").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec(`UPDATE daily_words SET word_id = \$2`).
+		WithArgs("source-id", "target-id").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectExec(`UPDATE quiz_questions SET word_id = \$2`).
+		WithArgs("source-id", "target-id").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+	mock.ExpectExec(`DELETE FROM words WHERE id = \$1`).
+		WithArgs("source-id").
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mock.ExpectCommit()
+
+	err := repo.Merge(context.Background(), source, target)
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
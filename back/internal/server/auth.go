@@ -0,0 +1,118 @@
+package server
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rs/zerolog"
+)
+
+// userIDKey is the gin context key holding the authenticated user ID
+const userIDKey = "userID"
+
+// errMissingToken is returned when a request carries no bearer token
+var errMissingToken = errors.New("missing bearer token")
+
+// errInvalidAdminToken is returned when a request to an operator endpoint
+// does not carry the admin token, or when no admin token is configured
+var errInvalidAdminToken = errors.New("invalid admin token")
+
+// requireUser is a middleware that authenticates requests with a bearer JWT
+// signed with the configured secret. The token subject is used as the user ID.
+func (s *Server) requireUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := s.authenticate(c.GetHeader("Authorization"))
+		if err != nil {
+			log := c.MustGet("logger").(zerolog.Logger)
+			log.Debug().Err(err).Msg("Unauthorized request")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorResponse{
+				Status:  http.StatusUnauthorized,
+				Message: "Unauthorized",
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		c.Set(userIDKey, userID)
+		c.Next()
+	}
+}
+
+// optionalUser is a middleware that authenticates requests carrying a bearer
+// JWT and lets anonymous requests through. An invalid token is still rejected.
+func (s *Server) optionalUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := s.authenticate(c.GetHeader("Authorization"))
+		if errors.Is(err, errMissingToken) {
+			c.Next()
+			return
+		}
+		if err != nil {
+			log := c.MustGet("logger").(zerolog.Logger)
+			log.Debug().Err(err).Msg("Unauthorized request")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorResponse{
+				Status:  http.StatusUnauthorized,
+				Message: "Unauthorized",
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		c.Set(userIDKey, userID)
+		c.Next()
+	}
+}
+
+// requireAdmin is a middleware that restricts operator endpoints to requests
+// carrying the configured admin token as a bearer token
+func (s *Server) requireAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, _ := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if s.cfg.AdminToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.cfg.AdminToken)) != 1 {
+			log := c.MustGet("logger").(zerolog.Logger)
+			log.Debug().Msg("Unauthorized admin request")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorResponse{
+				Status:  http.StatusUnauthorized,
+				Message: "Unauthorized",
+				Error:   errInvalidAdminToken.Error(),
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// authenticate validates the Authorization header and returns the user ID
+func (s *Server) authenticate(header string) (string, error) {
+	tokenString, found := strings.CutPrefix(header, "Bearer ")
+	if !found || strings.TrimSpace(tokenString) == "" {
+		return "", errMissingToken
+	}
+
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		return []byte(s.cfg.JWTSecret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Name}))
+	if err != nil {
+		return "", err
+	}
+
+	subject, err := token.Claims.GetSubject()
+	if err != nil {
+		return "", err
+	}
+	if subject == "" {
+		return "", errors.New("token has no subject")
+	}
+
+	return subject, nil
+}
+
+// currentUserID returns the user ID stored by the requireUser middleware
+func currentUserID(c *gin.Context) string {
+	return c.GetString(userIDKey)
+}
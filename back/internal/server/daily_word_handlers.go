@@ -0,0 +1,190 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/dailyword"
+)
+
+// Daily word scopes
+const (
+	dailyScopeGlobal   = "global"
+	dailyScopePersonal = "personal"
+)
+
+// DailyWordRequest represents a request for the word of the day
+type DailyWordRequest struct {
+	Language string `form:"lang" binding:"omitempty,language"`
+	Date     string `form:"date" binding:"omitempty,datetime=2006-01-02"`
+	Scope    string `form:"scope" binding:"omitempty,oneof=global personal"`
+}
+
+// DailyWordHistoryRequest represents a request for previous words of the day
+type DailyWordHistoryRequest struct {
+	Language string `form:"lang" binding:"omitempty,language"`
+	Scope    string `form:"scope" binding:"omitempty,oneof=global personal"`
+	Limit    int    `form:"limit" binding:"omitempty,min=1,max=60"`
+}
+
+// DailyWordResponse represents the response for the word of the day
+type DailyWordResponse struct {
+	DailyWord *DailyWordView `json:"daily_word"`
+}
+
+// DailyWordHistoryResponse represents the response for previous words of the day
+type DailyWordHistoryResponse struct {
+	History []*DailyWordView `json:"history"`
+}
+
+// DailyWordView is a word of the day with the public form of its word
+type DailyWordView struct {
+	*dailyword.DailyWord
+	Word *WordResponse `json:"word,omitempty"`
+}
+
+// newDailyWordView returns the public form of a word of the day
+func newDailyWordView(d *dailyword.DailyWord) *DailyWordView {
+	return &DailyWordView{DailyWord: d, Word: newWordResponse(d.Word)}
+}
+
+// GetDailyWord handles requests for the word of the day
+// @Summary Get the word of the day
+// @Description Get the word of the day of a language. Authenticated users get a personal pick among words they have not saved, in the language they save most words in by default; anonymous users and scope=global get the word shared by everyone.
+// @Tags words
+// @Produce json
+// @Security BearerAuth
+// @Param lang query string false "Language code (fr, en, es, de, it)"
+// @Param date query string false "Date (YYYY-MM-DD), defaults to today in UTC"
+// @Param scope query string false "global or personal, defaults to personal when authenticated"
+// @Success 200 {object} DailyWordResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/words/daily [get]
+func (s *Server) GetDailyWord(c *gin.Context) {
+	log := c.MustGet("logger").(zerolog.Logger)
+
+	var req DailyWordRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Debug().Err(err).Msg("Invalid daily word request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	userID, ok := s.dailyWordUser(c, req.Scope)
+	if !ok {
+		return
+	}
+
+	date := time.Now().UTC()
+	if req.Date != "" {
+		// Already validated by the binding
+		date, _ = time.Parse(dailyword.DateLayout, req.Date)
+	}
+
+	daily, err := s.dailyWordService.GetDailyWord(c.Request.Context(), userID, req.Language, date)
+	if err != nil {
+		s.respondDailyWordError(c, err, "Failed to get daily word")
+		return
+	}
+
+	c.JSON(http.StatusOK, DailyWordResponse{DailyWord: newDailyWordView(daily)})
+}
+
+// GetDailyWordHistory handles requests for previous words of the day
+// @Summary Get previous words of the day
+// @Description Get the previous words of the day of a language, most recent first
+// @Tags words
+// @Produce json
+// @Security BearerAuth
+// @Param lang query string false "Language code (fr, en, es, de, it)"
+// @Param scope query string false "global or personal, defaults to personal when authenticated"
+// @Param limit query int false "Maximum number of words (1-60, default 20)"
+// @Success 200 {object} DailyWordHistoryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/words/daily/history [get]
+func (s *Server) GetDailyWordHistory(c *gin.Context) {
+	log := c.MustGet("logger").(zerolog.Logger)
+
+	var req DailyWordHistoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Debug().Err(err).Msg("Invalid daily word history request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	userID, ok := s.dailyWordUser(c, req.Scope)
+	if !ok {
+		return
+	}
+
+	history, err := s.dailyWordService.GetHistory(c.Request.Context(), userID, req.Language, req.Limit)
+	if err != nil {
+		s.respondDailyWordError(c, err, "Failed to get daily word history")
+		return
+	}
+
+	views := make([]*DailyWordView, len(history))
+	for i, d := range history {
+		views[i] = newDailyWordView(d)
+	}
+	c.JSON(http.StatusOK, DailyWordHistoryResponse{History: views})
+}
+
+// dailyWordUser returns the user the word of the day is picked for, "" for
+// the global word. A personal scope without authentication is rejected.
+func (s *Server) dailyWordUser(c *gin.Context, scope string) (string, bool) {
+	userID := currentUserID(c)
+
+	switch scope {
+	case dailyScopeGlobal:
+		return "", true
+	case dailyScopePersonal:
+		if userID == "" {
+			c.JSON(http.StatusUnauthorized, ErrorResponse{
+				Status:  http.StatusUnauthorized,
+				Message: "Unauthorized",
+				Error:   errMissingToken.Error(),
+			})
+			return "", false
+		}
+	}
+
+	return userID, true
+}
+
+// respondDailyWordError maps daily word domain errors to HTTP responses
+func (s *Server) respondDailyWordError(c *gin.Context, err error, message string) {
+	log := c.MustGet("logger").(zerolog.Logger)
+
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, dailyword.ErrNoCandidate), errors.Is(err, dailyword.ErrDailyWordNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, dailyword.ErrInvalidDate):
+		status = http.StatusBadRequest
+	}
+
+	log.Debug().Err(err).Int("status", status).Msg(message)
+	c.JSON(status, ErrorResponse{
+		Status:  status,
+		Message: message,
+		Error:   err.Error(),
+	})
+}
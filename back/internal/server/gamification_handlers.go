@@ -0,0 +1,99 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/gamification"
+)
+
+// UpdateProgressRequest represents a request to update progress settings
+type UpdateProgressRequest struct {
+	Timezone string `json:"timezone" binding:"required"` // IANA name, e.g. Europe/Paris
+}
+
+// ProgressResponse represents the response for the current user's progress
+type ProgressResponse struct {
+	Overview *gamification.Overview `json:"overview"`
+}
+
+// UpdateProgressResponse represents the response for updated progress settings
+type UpdateProgressResponse struct {
+	Progress *gamification.Progress `json:"progress"`
+}
+
+// GetProgress handles requests for the current user's progress
+// @Summary Get progress
+// @Description Get the XP, level, streak, freeze tokens and badges of the current user. The streak is reported as of today in the user's timezone.
+// @Tags progress
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} ProgressResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/me/progress [get]
+func (s *Server) GetProgress(c *gin.Context) {
+	overview, err := s.gamificationService.GetOverview(c.Request.Context(), currentUserID(c))
+	if err != nil {
+		s.respondGamificationError(c, err, "Failed to get progress")
+		return
+	}
+
+	c.JSON(http.StatusOK, ProgressResponse{Overview: overview})
+}
+
+// UpdateProgress handles requests to update progress settings
+// @Summary Update progress settings
+// @Description Set the timezone used for the day boundaries of the current user's streak
+// @Tags progress
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body UpdateProgressRequest true "Progress settings"
+// @Success 200 {object} UpdateProgressResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/me/progress [patch]
+func (s *Server) UpdateProgress(c *gin.Context) {
+	log := c.MustGet("logger").(zerolog.Logger)
+
+	var req UpdateProgressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Debug().Err(err).Msg("Invalid update progress request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	progress, err := s.gamificationService.SetTimezone(c.Request.Context(), currentUserID(c), req.Timezone)
+	if err != nil {
+		s.respondGamificationError(c, err, "Failed to update progress")
+		return
+	}
+
+	c.JSON(http.StatusOK, UpdateProgressResponse{Progress: progress})
+}
+
+// respondGamificationError maps gamification domain errors to HTTP responses
+func (s *Server) respondGamificationError(c *gin.Context, err error, message string) {
+	log := c.MustGet("logger").(zerolog.Logger)
+
+	status := http.StatusInternalServerError
+	if errors.Is(err, gamification.ErrInvalidTimezone) {
+		status = http.StatusBadRequest
+	}
+
+	log.Debug().Err(err).Int("status", status).Msg(message)
+	c.JSON(status, ErrorResponse{
+		Status:  status,
+		Message: message,
+		Error:   err.Error(),
+	})
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/language"
+)
+
+// LanguageResponse describes a supported language and what the application
+// can do with it
+type LanguageResponse struct {
+	Code          string   `json:"code"`
+	Name          string   `json:"name"`
+	NativeName    string   `json:"native_name"`
+	WordTypes     []string `json:"word_types"`
+	Genders       []string `json:"genders"`
+	Sources       []string `json:"sources"`
+	Lemmatization bool     `json:"lemmatization"`
+	Primary       bool     `json:"primary"` // Used when a request names no language
+}
+
+// LanguagesResponse represents the response for the supported languages
+type LanguagesResponse struct {
+	Languages []LanguageResponse `json:"languages"`
+}
+
+// ListLanguages handles requests for the supported languages
+// @Summary List supported languages
+// @Description List the languages words can be looked up in, with their word types, genders, dictionary sources and whether their words are lemmatized
+// @Tags words
+// @Produce json
+// @Success 200 {object} LanguagesResponse
+// @Failure 429 {object} ErrorResponse
+// @Router /api/v1/languages [get]
+func (s *Server) ListLanguages(c *gin.Context) {
+	log := c.MustGet("logger").(zerolog.Logger)
+	log.Debug().Msg("Listing languages")
+
+	primary := language.Default.Primary().Code
+	languages := make([]LanguageResponse, 0)
+	for _, l := range language.Default.All() {
+		genders := l.Genders
+		if genders == nil {
+			genders = []string{}
+		}
+		languages = append(languages, LanguageResponse{
+			Code:          l.Code,
+			Name:          l.Name,
+			NativeName:    l.NativeName,
+			WordTypes:     l.WordTypes,
+			Genders:       genders,
+			Sources:       l.Sources,
+			Lemmatization: l.Lemmas != nil,
+			Primary:       l.Code == primary,
+		})
+	}
+
+	c.JSON(http.StatusOK, LanguagesResponse{Languages: languages})
+}
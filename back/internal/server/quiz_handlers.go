@@ -0,0 +1,228 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/quiz"
+	"voconsteroid/internal/domain/tag"
+	"voconsteroid/internal/domain/wordlist"
+)
+
+// StartQuizRequest represents a request to start a quiz session
+type StartQuizRequest struct {
+	Source string   `json:"source" binding:"required,oneof=list tag due"`
+	ListID string   `json:"list_id" binding:"required_if=Source list"`
+	Tags   string   `json:"tags" binding:"required_if=Source tag"`
+	Types  []string `json:"types" binding:"omitempty,dive,oneof=definition_to_word word_to_definition fill_blank synonym antonym gender translation"`
+	Count  int      `json:"count" binding:"omitempty,min=1,max=50"`
+}
+
+// QuizAnswerRequest represents the answer given to a quiz question
+type QuizAnswerRequest struct {
+	QuestionID string `json:"question_id" binding:"required"`
+	Answer     string `json:"answer"`
+}
+
+// QuizSessionResponse represents the response for a quiz session
+type QuizSessionResponse struct {
+	Session  *quiz.Session         `json:"session"`
+	Score    int                   `json:"score"`              // Percentage of correct answers so far
+	Question *quiz.SessionQuestion `json:"question,omitempty"` // First question, when the session was just started
+}
+
+// QuizQuestionResponse represents the response for a quiz question
+type QuizQuestionResponse struct {
+	Question *quiz.SessionQuestion `json:"question"`
+}
+
+// QuizAnswerResponse represents the outcome of answering a quiz question
+type QuizAnswerResponse struct {
+	Correct        bool          `json:"correct"`
+	ExpectedAnswer string        `json:"expected_answer"`
+	Attempt        *quiz.Attempt `json:"attempt"`
+	Session        *quiz.Session `json:"session"`
+	Score          int           `json:"score"`
+}
+
+// StartQuiz handles requests to start a quiz session
+// @Summary Start a quiz
+// @Description Generate a quiz over the saved words of a word list, those matching a tag expression, or those due for review, and return its first question. Answer keys stay on the server.
+// @Tags quizzes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body StartQuizRequest true "Quiz source and options"
+// @Success 201 {object} QuizSessionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/quizzes [post]
+func (s *Server) StartQuiz(c *gin.Context) {
+	log := c.MustGet("logger").(zerolog.Logger)
+
+	var req StartQuizRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Debug().Err(err).Msg("Invalid start quiz request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	options := quiz.SessionOptions{
+		Source: quiz.Source(req.Source),
+		Count:  req.Count,
+	}
+	switch options.Source {
+	case quiz.SourceList:
+		options.SourceRef = req.ListID
+	case quiz.SourceTag:
+		options.SourceRef = req.Tags
+	}
+	for _, t := range req.Types {
+		options.Types = append(options.Types, quiz.QuestionType(t))
+	}
+
+	userID := currentUserID(c)
+	session, err := s.quizService.StartSession(c.Request.Context(), userID, options)
+	if err != nil {
+		s.respondQuizError(c, err, "Failed to start quiz")
+		return
+	}
+
+	question, err := s.quizService.CurrentQuestion(c.Request.Context(), userID, session.ID)
+	if err != nil {
+		s.respondQuizError(c, err, "Failed to get quiz question")
+		return
+	}
+
+	c.JSON(http.StatusCreated, QuizSessionResponse{Session: session, Score: session.Score(), Question: question})
+}
+
+// GetQuiz handles requests for a quiz session
+// @Summary Get a quiz
+// @Description Get the progress and score of a quiz session of the current user
+// @Tags quizzes
+// @Produce json
+// @Security BearerAuth
+// @Param quizId path string true "Quiz session ID"
+// @Success 200 {object} QuizSessionResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/quizzes/{quizId} [get]
+func (s *Server) GetQuiz(c *gin.Context) {
+	session, err := s.quizService.GetSession(c.Request.Context(), currentUserID(c), c.Param("quizId"))
+	if err != nil {
+		s.respondQuizError(c, err, "Failed to get quiz")
+		return
+	}
+
+	c.JSON(http.StatusOK, QuizSessionResponse{Session: session, Score: session.Score()})
+}
+
+// GetQuizQuestion handles requests for the current question of a quiz
+// @Summary Get the current quiz question
+// @Description Get the next unanswered question of a quiz session. Answer latency is measured from the first time a question is served.
+// @Tags quizzes
+// @Produce json
+// @Security BearerAuth
+// @Param quizId path string true "Quiz session ID"
+// @Success 200 {object} QuizQuestionResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/quizzes/{quizId}/question [get]
+func (s *Server) GetQuizQuestion(c *gin.Context) {
+	question, err := s.quizService.CurrentQuestion(c.Request.Context(), currentUserID(c), c.Param("quizId"))
+	if err != nil {
+		s.respondQuizError(c, err, "Failed to get quiz question")
+		return
+	}
+
+	c.JSON(http.StatusOK, QuizQuestionResponse{Question: question})
+}
+
+// AnswerQuiz handles the answer to the current question of a quiz
+// @Summary Answer a quiz question
+// @Description Grade the answer to the current question, ignoring case and accents, and update the spaced repetition schedule of the word
+// @Tags quizzes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param quizId path string true "Quiz session ID"
+// @Param request body QuizAnswerRequest true "Answer"
+// @Success 200 {object} QuizAnswerResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/quizzes/{quizId}/answers [post]
+func (s *Server) AnswerQuiz(c *gin.Context) {
+	log := c.MustGet("logger").(zerolog.Logger)
+
+	var req QuizAnswerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Debug().Err(err).Msg("Invalid quiz answer request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	result, err := s.quizService.Answer(c.Request.Context(), currentUserID(c), c.Param("quizId"), req.QuestionID, req.Answer)
+	if err != nil {
+		s.respondQuizError(c, err, "Failed to answer quiz question")
+		return
+	}
+
+	c.JSON(http.StatusOK, QuizAnswerResponse{
+		Correct:        result.Attempt.Correct,
+		ExpectedAnswer: result.ExpectedAnswer,
+		Attempt:        result.Attempt,
+		Session:        result.Session,
+		Score:          result.Session.Score(),
+	})
+}
+
+// respondQuizError maps quiz domain errors to HTTP responses
+func (s *Server) respondQuizError(c *gin.Context, err error, message string) {
+	log := c.MustGet("logger").(zerolog.Logger)
+
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, quiz.ErrSessionNotFound),
+		errors.Is(err, quiz.ErrQuestionNotFound),
+		errors.Is(err, wordlist.ErrListNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, quiz.ErrSessionCompleted),
+		errors.Is(err, quiz.ErrQuestionMismatch),
+		errors.Is(err, quiz.ErrAlreadyAnswered):
+		status = http.StatusConflict
+	case errors.Is(err, quiz.ErrInvalidSource),
+		errors.Is(err, quiz.ErrInvalidQuestionType),
+		errors.Is(err, tag.ErrInvalidExpression):
+		status = http.StatusBadRequest
+	case errors.Is(err, quiz.ErrNoQuestions):
+		status = http.StatusUnprocessableEntity
+	}
+
+	log.Debug().Err(err).Int("status", status).Msg(message)
+	c.JSON(status, ErrorResponse{
+		Status:  status,
+		Message: message,
+		Error:   err.Error(),
+	})
+}
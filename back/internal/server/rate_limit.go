@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/word"
+	"voconsteroid/internal/infrastructure/ratelimit"
+)
+
+// Rate limit groups of the default rules
+const (
+	rateLimitWords   = "words"
+	rateLimitAccount = "account"
+	rateLimitShared  = "shared"
+	rateLimitFetch   = "fetch" // Requests fetching from the external dictionary
+)
+
+// SetRateLimiter limits requests with the given limiter and rules. Without
+// a limiter, requests are not limited.
+func (s *Server) SetRateLimiter(limiter ratelimit.Limiter, rules ratelimit.Rules) {
+	s.limiter = limiter
+	s.rateLimits = rules
+}
+
+// rateLimit is a middleware limiting the requests of a route group, per
+// user when the request carries a valid token and per client IP otherwise.
+// Requests are let through when the limiter fails.
+func (s *Server) rateLimit(group string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if s.limiter == nil {
+			c.Next()
+			return
+		}
+
+		result, ok := s.countRequest(c, group)
+		if ok && !result.Allowed {
+			log := c.MustGet("logger").(zerolog.Logger)
+			log.Debug().Str("group", group).Str("ip", c.ClientIP()).Msg("Rate limit exceeded")
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, ErrorResponse{
+				Status:  http.StatusTooManyRequests,
+				Message: "Too many requests",
+				Error:   fmt.Sprintf("rate limit of %d requests exceeded", result.Limit),
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// fetchBudget is a middleware charging the external dictionary fetches of
+// a request to the fetch group, on top of the route group's limit
+func (s *Server) fetchBudget() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if s.limiter == nil {
+			c.Next()
+			return
+		}
+
+		ctx := word.WithFetchBudget(c.Request.Context(), func(context.Context) error {
+			result, ok := s.countRequest(c, rateLimitFetch)
+			if ok && !result.Allowed {
+				return fmt.Errorf("%w: %d fetches allowed", word.ErrFetchLimited, result.Limit)
+			}
+			return nil
+		})
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// countRequest counts a request against a group's limit and sets the
+// RateLimit headers. ok is false when the group is not limited for this
+// request or the limiter failed.
+func (s *Server) countRequest(c *gin.Context, group string) (ratelimit.Result, bool) {
+	userID, err := s.authenticate(c.GetHeader("Authorization"))
+	authenticated := err == nil
+	limit := s.rateLimits[group].Limit(authenticated)
+	if limit.Unlimited() {
+		return ratelimit.Result{}, false
+	}
+
+	key := group + ":ip:" + c.ClientIP()
+	if authenticated {
+		key = group + ":user:" + userID
+	}
+
+	result, err := s.limiter.Allow(c.Request.Context(), key, limit)
+	if err != nil {
+		log := c.MustGet("logger").(zerolog.Logger)
+		log.Warn().Err(err).Str("group", group).Msg("Failed to check rate limit, letting request through")
+		return ratelimit.Result{}, false
+	}
+
+	reset := strconv.Itoa(int(math.Ceil(result.Reset.Seconds())))
+	header := c.Writer.Header()
+	header.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+	header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	header.Set("RateLimit-Reset", reset)
+	header.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", limit.Requests, int(limit.Window.Seconds())))
+	if !result.Allowed {
+		header.Set("Retry-After", reset)
+	}
+	return result, true
+}
@@ -0,0 +1,142 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/review"
+)
+
+// DueReviewsRequest represents a request for the reviews due now
+type DueReviewsRequest struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// DueReviewsResponse represents the response for the reviews due now
+type DueReviewsResponse struct {
+	Reviews []*DueItemView `json:"reviews"`
+}
+
+// DueItemView is a card due for review with the public form of its word
+type DueItemView struct {
+	*review.DueItem
+	Word *WordResponse `json:"word"`
+}
+
+// GradeReviewRequest represents the answer given to a review
+type GradeReviewRequest struct {
+	Grade      int `json:"grade" binding:"required,min=1,max=4"`
+	DurationMS int `json:"duration_ms" binding:"omitempty,min=0"`
+}
+
+// ReviewCardResponse represents the response for a review card
+type ReviewCardResponse struct {
+	Card *review.Card `json:"card"`
+}
+
+// GetDueReviews handles requests for the saved words due for review
+// @Summary Get due reviews
+// @Description Get the current user's saved words due for review, earliest first. Newly saved words are due immediately.
+// @Tags reviews
+// @Produce json
+// @Security BearerAuth
+// @Param limit query integer false "Maximum number of reviews to return" minimum(1) maximum(100) default(20)
+// @Success 200 {object} DueReviewsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/reviews/due [get]
+func (s *Server) GetDueReviews(c *gin.Context) {
+	log := c.MustGet("logger").(zerolog.Logger)
+
+	var req DueReviewsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Debug().Err(err).Msg("Invalid due reviews request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	items, err := s.reviewService.GetDue(c.Request.Context(), currentUserID(c), req.Limit)
+	if err != nil {
+		s.respondReviewError(c, err, "Failed to get due reviews")
+		return
+	}
+
+	c.JSON(http.StatusOK, DueReviewsResponse{Reviews: newDueItemViews(items)})
+}
+
+// GradeReview handles the answer to a review
+// @Summary Grade a review
+// @Description Record how well a saved word was recalled (1 again, 2 hard, 3 good, 4 easy) and schedule its next review
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param savedWordId path string true "Saved word ID"
+// @Param request body GradeReviewRequest true "Review answer"
+// @Success 200 {object} ReviewCardResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/reviews/{savedWordId}/grade [post]
+func (s *Server) GradeReview(c *gin.Context) {
+	log := c.MustGet("logger").(zerolog.Logger)
+
+	var req GradeReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Debug().Err(err).Msg("Invalid grade review request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	duration := time.Duration(req.DurationMS) * time.Millisecond
+	card, err := s.reviewService.Grade(c.Request.Context(), currentUserID(c), c.Param("savedWordId"), review.Grade(req.Grade), duration, review.SourceQueue)
+	if err != nil {
+		s.respondReviewError(c, err, "Failed to grade review")
+		return
+	}
+
+	c.JSON(http.StatusOK, ReviewCardResponse{Card: card})
+}
+
+// respondReviewError maps review domain errors to HTTP responses
+func (s *Server) respondReviewError(c *gin.Context, err error, message string) {
+	log := c.MustGet("logger").(zerolog.Logger)
+
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, review.ErrCardNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, review.ErrInvalidGrade):
+		status = http.StatusBadRequest
+	}
+
+	log.Debug().Err(err).Int("status", status).Msg(message)
+	c.JSON(status, ErrorResponse{
+		Status:  status,
+		Message: message,
+		Error:   err.Error(),
+	})
+}
+
+// newDueItemViews returns the public form of cards due for review
+func newDueItemViews(items []*review.DueItem) []*DueItemView {
+	views := make([]*DueItemView, len(items))
+	for i, item := range items {
+		views[i] = &DueItemView{DueItem: item, Word: newWordResponse(item.Word)}
+	}
+	return views
+}
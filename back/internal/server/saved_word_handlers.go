@@ -0,0 +1,268 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/savedword"
+	"voconsteroid/internal/domain/tag"
+	"voconsteroid/internal/domain/word"
+)
+
+// ReadingContextRequest represents the reading context sent when saving a word
+type ReadingContextRequest struct {
+	Sentence   string `json:"sentence"`
+	BookTitle  string `json:"book_title"`
+	BookAuthor string `json:"book_author"`
+	Location   string `json:"location"`
+	Note       string `json:"note"`
+}
+
+// SaveWordRequest represents a request to save a word
+type SaveWordRequest struct {
+	WordID  string                `json:"word_id" binding:"required"`
+	Context ReadingContextRequest `json:"context"`
+}
+
+// SavedWordResponse represents the response for a single saved word
+type SavedWordResponse struct {
+	SavedWord *savedword.SavedWord `json:"saved_word"`
+}
+
+// SavedWordDetailResponse represents the response for a saved word with its word
+type SavedWordDetailResponse struct {
+	SavedWord *savedword.SavedWord `json:"saved_word"`
+	Word      *WordResponse        `json:"word"`
+}
+
+// SavedWordsRequest represents a request to list saved words
+type SavedWordsRequest struct {
+	Query  string `form:"q"`
+	Tags   string `form:"tags"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+// SavedWordsResponse represents the response for a list of saved words
+type SavedWordsResponse struct {
+	SavedWords []*savedword.SavedWord `json:"saved_words"`
+}
+
+// toReadingContext converts the request payload to the domain value
+func (r ReadingContextRequest) toReadingContext() savedword.ReadingContext {
+	return savedword.ReadingContext{
+		Sentence:   r.Sentence,
+		BookTitle:  r.BookTitle,
+		BookAuthor: r.BookAuthor,
+		Location:   r.Location,
+		Note:       r.Note,
+	}
+}
+
+// SaveWord handles requests to save a word
+// @Summary Save a word
+// @Description Save a word for the current user with the reading context it was met in
+// @Tags saved-words
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body SaveWordRequest true "Save word request"
+// @Success 201 {object} SavedWordResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/saved-words [post]
+func (s *Server) SaveWord(c *gin.Context) {
+	log := c.MustGet("logger").(zerolog.Logger)
+
+	var req SaveWordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Debug().Err(err).Msg("Invalid save word request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	savedWord, err := s.savedWordService.SaveWord(c.Request.Context(), currentUserID(c), req.WordID, req.Context.toReadingContext())
+	if err != nil {
+		s.respondSavedWordError(c, err, "Failed to save word")
+		return
+	}
+
+	c.JSON(http.StatusCreated, SavedWordResponse{
+		SavedWord: savedWord,
+	})
+}
+
+// ListSavedWords handles requests to list the current user's saved words
+// @Summary List saved words
+// @Description List the current user's saved words, optionally searching their reading context and filtering by tags
+// @Tags saved-words
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param q query string false "Text searched in the sentence, book, note and word"
+// @Param tags query string false "Tag expression, e.g. verbs AND (food OR travel) AND NOT \"to review\""
+// @Param limit query integer false "Maximum number of saved words to return" minimum(1) maximum(100) default(20)
+// @Param offset query integer false "Number of saved words to skip" minimum(0) default(0)
+// @Success 200 {object} SavedWordsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/saved-words [get]
+func (s *Server) ListSavedWords(c *gin.Context) {
+	log := c.MustGet("logger").(zerolog.Logger)
+
+	var req SavedWordsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Debug().Err(err).Msg("Invalid list saved words request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	filter := savedword.Filter{Query: req.Query}
+	if strings.TrimSpace(req.Tags) != "" {
+		expr, err := tag.ParseExpression(req.Tags)
+		if err != nil {
+			log.Debug().Err(err).Str("tags", req.Tags).Msg("Invalid tag expression")
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid tag expression",
+				Error:   err.Error(),
+			})
+			return
+		}
+		filter.Tags = expr
+	}
+
+	savedWords, err := s.savedWordService.ListSavedWords(c.Request.Context(), currentUserID(c), filter, req.Limit, req.Offset)
+	if err != nil {
+		s.respondSavedWordError(c, err, "Failed to list saved words")
+		return
+	}
+
+	c.JSON(http.StatusOK, SavedWordsResponse{
+		SavedWords: savedWords,
+	})
+}
+
+// GetSavedWord handles requests for a saved word detail
+// @Summary Get a saved word
+// @Description Get a saved word with its reading context, suggested definition and word data
+// @Tags saved-words
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param savedWordId path string true "Saved word ID"
+// @Success 200 {object} SavedWordDetailResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/saved-words/{savedWordId} [get]
+func (s *Server) GetSavedWord(c *gin.Context) {
+	detail, err := s.savedWordService.GetSavedWord(c.Request.Context(), currentUserID(c), c.Param("savedWordId"))
+	if err != nil {
+		s.respondSavedWordError(c, err, "Failed to get saved word")
+		return
+	}
+
+	c.JSON(http.StatusOK, SavedWordDetailResponse{
+		SavedWord: detail.SavedWord,
+		Word:      newWordResponse(detail.Word),
+	})
+}
+
+// UpdateSavedWordContext handles requests to replace a saved word's reading context
+// @Summary Update a saved word's reading context
+// @Description Replace the reading context of a saved word and recompute the suggested definition
+// @Tags saved-words
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param savedWordId path string true "Saved word ID"
+// @Param request body ReadingContextRequest true "Reading context"
+// @Success 200 {object} SavedWordResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/saved-words/{savedWordId}/context [put]
+func (s *Server) UpdateSavedWordContext(c *gin.Context) {
+	log := c.MustGet("logger").(zerolog.Logger)
+
+	var req ReadingContextRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Debug().Err(err).Msg("Invalid reading context request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	savedWord, err := s.savedWordService.UpdateContext(c.Request.Context(), currentUserID(c), c.Param("savedWordId"), req.toReadingContext())
+	if err != nil {
+		s.respondSavedWordError(c, err, "Failed to update saved word")
+		return
+	}
+
+	c.JSON(http.StatusOK, SavedWordResponse{
+		SavedWord: savedWord,
+	})
+}
+
+// DeleteSavedWord handles requests to delete a saved word
+// @Summary Delete a saved word
+// @Description Remove a word from the current user's saved words
+// @Tags saved-words
+// @Security BearerAuth
+// @Param savedWordId path string true "Saved word ID"
+// @Success 204
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/saved-words/{savedWordId} [delete]
+func (s *Server) DeleteSavedWord(c *gin.Context) {
+	if err := s.savedWordService.DeleteSavedWord(c.Request.Context(), currentUserID(c), c.Param("savedWordId")); err != nil {
+		s.respondSavedWordError(c, err, "Failed to delete saved word")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// respondSavedWordError maps saved word domain errors to HTTP responses
+func (s *Server) respondSavedWordError(c *gin.Context, err error, message string) {
+	log := c.MustGet("logger").(zerolog.Logger)
+
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, savedword.ErrSavedWordNotFound), errors.Is(err, word.ErrWordNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, savedword.ErrDuplicateSavedWord):
+		status = http.StatusConflict
+	case errors.Is(err, savedword.ErrInvalidSavedWord), errors.Is(err, savedword.ErrInvalidContext):
+		status = http.StatusBadRequest
+	}
+
+	log.Debug().Err(err).Int("status", status).Msg(message)
+	c.JSON(status, ErrorResponse{
+		Status:  status,
+		Message: message,
+		Error:   err.Error(),
+	})
+}
@@ -3,6 +3,7 @@ package server
 
 import (
 	"context"
+	"expvar"
 	"fmt"
 	"net"
 	"net/http"
@@ -13,9 +14,31 @@ import (
 	"github.com/rs/zerolog"
 
 	"voconsteroid/internal/config"
+	"voconsteroid/internal/domain/dailyword"
+	"voconsteroid/internal/domain/gamification"
+	"voconsteroid/internal/domain/quiz"
+	"voconsteroid/internal/domain/review"
+	"voconsteroid/internal/domain/savedword"
+	"voconsteroid/internal/domain/stats"
+	"voconsteroid/internal/domain/tag"
 	"voconsteroid/internal/domain/word"
+	"voconsteroid/internal/domain/wordlist"
+	"voconsteroid/internal/infrastructure/ratelimit"
 )
 
+// Services groups the domain services exposed by the HTTP server.
+type Services struct {
+	Word         word.Service
+	SavedWord    savedword.Service
+	WordList     wordlist.Service
+	Tag          tag.Service
+	Review       review.Service
+	Quiz         quiz.Service
+	DailyWord    dailyword.Service
+	Gamification gamification.Service
+	Stats        stats.Service
+}
+
 // Server represents the HTTP server with all its dependencies.
 type Server struct {
 	cfg    *config.Config
@@ -24,11 +47,23 @@ type Server struct {
 	srv    *http.Server
 
 	// Services
-	wordService word.Service
+	wordService         word.Service
+	savedWordService    savedword.Service
+	wordListService     wordlist.Service
+	tagService          tag.Service
+	reviewService       review.Service
+	quizService         quiz.Service
+	dailyWordService    dailyword.Service
+	gamificationService gamification.Service
+	statsService        stats.Service
+
+	// Rate limiting, disabled without a limiter
+	limiter    ratelimit.Limiter
+	rateLimits ratelimit.Rules
 }
 
-// NewServer creates a new server instance with the provided configuration and logger.
-func NewServer(cfg *config.Config, log zerolog.Logger, wordService word.Service) *Server {
+// NewServer creates a new server instance with the provided configuration, logger and services.
+func NewServer(cfg *config.Config, log zerolog.Logger, services Services) *Server {
 	// Set gin mode based on log level
 	if cfg.LogLevel == "debug" {
 		gin.SetMode(gin.DebugMode)
@@ -37,12 +72,28 @@ func NewServer(cfg *config.Config, log zerolog.Logger, wordService word.Service)
 	}
 
 	router := gin.New()
+	registerValidations()
+
+	// Client IPs key the anonymous rate limits, so forwarded headers are
+	// only believed from the configured proxies
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Error().Err(err).Strs("proxies", cfg.TrustedProxies).Msg("Invalid trusted proxies, trusting none")
+		_ = router.SetTrustedProxies(nil)
+	}
 
 	return &Server{
-		cfg:         cfg,
-		log:         log,
-		router:      router,
-		wordService: wordService,
+		cfg:                 cfg,
+		log:                 log,
+		router:              router,
+		wordService:         services.Word,
+		savedWordService:    services.SavedWord,
+		wordListService:     services.WordList,
+		tagService:          services.Tag,
+		reviewService:       services.Review,
+		quizService:         services.Quiz,
+		dailyWordService:    services.DailyWord,
+		gamificationService: services.Gamification,
+		statsService:        services.Stats,
 	}
 }
 
@@ -112,12 +163,15 @@ func (s *Server) setupRoutes() {
 	// Health check endpoint
 	s.router.GET("/health", s.healthCheck)
 
+	// Runtime counters, such as the dictionary fetches saved by coalescing
+	s.router.GET("/debug/vars", s.requireAdmin(), gin.WrapH(expvar.Handler()))
+
 	// Demo error endpoint
 	s.router.GET("/error", s.demoError)
 
 	// Setup Swagger documentation
 	s.setupSwagger()
-	
+
 	// Serve API documentation index
 	s.router.GET("/api/docs", func(c *gin.Context) {
 		c.File(filepath.Join("api", "index.html"))
@@ -126,12 +180,86 @@ func (s *Server) setupRoutes() {
 	// Word API routes
 	api := s.router.Group("/api/v1")
 	{
-		words := api.Group("/words")
+		words := api.Group("/words", s.rateLimit(rateLimitWords), s.fetchBudget())
 		{
-			words.POST("/search", s.SearchWord)
+			words.GET("", s.ListWords)
+			words.POST("/search", s.optionalUser(), s.SearchWord)
 			words.GET("/recent", s.GetRecentWords)
 			words.GET("/:wordId/related", s.GetRelatedWords)
 			words.GET("/autocomplete", s.AutoComplete)
+			words.GET("/daily", s.optionalUser(), s.GetDailyWord)
+			words.GET("/daily/history", s.optionalUser(), s.GetDailyWordHistory)
+		}
+
+		api.GET("/languages", s.rateLimit(rateLimitWords), s.ListLanguages)
+
+		savedWords := api.Group("/saved-words", s.rateLimit(rateLimitAccount), s.requireUser())
+		{
+			savedWords.POST("", s.SaveWord)
+			savedWords.GET("", s.ListSavedWords)
+			savedWords.GET("/:savedWordId", s.GetSavedWord)
+			savedWords.PUT("/:savedWordId/context", s.UpdateSavedWordContext)
+			savedWords.DELETE("/:savedWordId", s.DeleteSavedWord)
+		}
+
+		lists := api.Group("/lists", s.rateLimit(rateLimitAccount), s.requireUser())
+		{
+			lists.POST("", s.CreateWordList)
+			lists.GET("", s.ListWordLists)
+			lists.GET("/:listId", s.GetWordList)
+			lists.PUT("/:listId", s.UpdateWordList)
+			lists.DELETE("/:listId", s.DeleteWordList)
+			lists.POST("/:listId/words", s.AddWordListWords)
+			lists.DELETE("/:listId/words", s.RemoveWordListWords)
+			lists.PUT("/:listId/order", s.ReorderWordList)
+			lists.POST("/:listId/share", s.ShareWordList)
+			lists.DELETE("/:listId/share", s.UnshareWordList)
+		}
+
+		tags := api.Group("/tags", s.rateLimit(rateLimitAccount), s.requireUser())
+		{
+			tags.POST("", s.CreateTag)
+			tags.GET("", s.ListTags)
+			tags.GET("/autocomplete", s.TagAutoComplete)
+			tags.PUT("/:tagId", s.RenameTag)
+			tags.DELETE("/:tagId", s.DeleteTag)
+			tags.POST("/:tagId/merge", s.MergeTag)
+			tags.POST("/:tagId/assignments", s.AssignTag)
+			tags.DELETE("/:tagId/assignments", s.UnassignTag)
+		}
+
+		reviews := api.Group("/reviews", s.rateLimit(rateLimitAccount), s.requireUser())
+		{
+			reviews.GET("/due", s.GetDueReviews)
+			reviews.POST("/:savedWordId/grade", s.GradeReview)
+		}
+
+		quizzes := api.Group("/quizzes", s.rateLimit(rateLimitAccount), s.requireUser())
+		{
+			quizzes.POST("", s.StartQuiz)
+			quizzes.GET("/:quizId", s.GetQuiz)
+			quizzes.GET("/:quizId/question", s.GetQuizQuestion)
+			quizzes.POST("/:quizId/answers", s.AnswerQuiz)
+		}
+
+		me := api.Group("/me", s.rateLimit(rateLimitAccount), s.requireUser())
+		{
+			me.GET("/progress", s.GetProgress)
+			me.PATCH("/progress", s.UpdateProgress)
+			me.GET("/stats", s.GetStats)
+		}
+
+		// Maintainer views, such as the provenance of stored words
+		admin := api.Group("/admin", s.requireAdmin())
+		{
+			admin.GET("/words/:wordId", s.GetAdminWord)
+		}
+
+		// Shared lists are readable without an account
+		sharedLists := api.Group("/shared-lists", s.rateLimit(rateLimitShared))
+		{
+			sharedLists.GET("/:shareToken", s.GetSharedWordList)
+			sharedLists.POST("/:shareToken/duplicate", s.requireUser(), s.DuplicateSharedWordList)
 		}
 	}
 }
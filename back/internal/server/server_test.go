@@ -4,17 +4,29 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
 	"voconsteroid/internal/config"
+	"voconsteroid/internal/domain/dailyword"
+	"voconsteroid/internal/domain/gamification"
+	"voconsteroid/internal/domain/quiz"
+	"voconsteroid/internal/domain/review"
+	"voconsteroid/internal/domain/savedword"
+	"voconsteroid/internal/domain/stats"
+	"voconsteroid/internal/domain/tag"
 	"voconsteroid/internal/domain/word"
+	"voconsteroid/internal/domain/wordlist"
+	"voconsteroid/internal/infrastructure/ratelimit"
 )
 
 // MockEvent is a mock implementation of zerolog.Event
@@ -41,8 +53,16 @@ type MockWordService struct {
 	mock.Mock
 }
 
-func (m *MockWordService) Search(ctx context.Context, text, language string) (*word.Word, error) {
-	args := m.Called(ctx, text, language)
+func (m *MockWordService) Search(ctx context.Context, userID, text, language string) (*word.Word, error) {
+	args := m.Called(ctx, userID, text, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*word.Word), args.Error(1)
+}
+
+func (m *MockWordService) GetWord(ctx context.Context, wordID string) (*word.Word, error) {
+	args := m.Called(ctx, wordID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -73,6 +93,22 @@ func (m *MockWordService) AutoComplete(ctx context.Context, prefix, language str
 	return args.Get(0).([]string), args.Error(1)
 }
 
+func (m *MockWordService) GetSuggestions(ctx context.Context, prefix, language string) ([]string, error) {
+	args := m.Called(ctx, prefix, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockWordService) ListWords(ctx context.Context, options word.ListOptions) ([]*word.Word, error) {
+	args := m.Called(ctx, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*word.Word), args.Error(1)
+}
+
 func TestNewServer(t *testing.T) {
 	cfg := &config.Config{
 		AppName:  "Test App",
@@ -82,7 +118,7 @@ func TestNewServer(t *testing.T) {
 	logger := zerolog.New(zerolog.NewTestWriter(t))
 	wordService := new(MockWordService)
 
-	server := NewServer(cfg, logger, wordService)
+	server := NewServer(cfg, logger, Services{Word: wordService})
 
 	assert.NotNil(t, server)
 	assert.Equal(t, cfg, server.cfg)
@@ -91,6 +127,43 @@ func TestNewServer(t *testing.T) {
 	assert.NotNil(t, server.router)
 }
 
+func TestNewServer_TrustedProxies(t *testing.T) {
+	tests := []struct {
+		name       string
+		proxies    []string
+		remoteAddr string
+		expectedIP string
+	}{
+		{name: "no proxy ignores forwarded headers", proxies: nil, remoteAddr: "10.0.0.1", expectedIP: "10.0.0.1"},
+		{name: "invalid proxies are not trusted", proxies: []string{"not-an-ip"}, remoteAddr: "10.0.0.1", expectedIP: "10.0.0.1"},
+		{name: "untrusted client is not believed", proxies: []string{"10.0.0.0/8"}, remoteAddr: "192.168.1.1", expectedIP: "192.168.1.1"},
+		{name: "trusted proxy forwards the client IP", proxies: []string{"10.0.0.0/8"}, remoteAddr: "10.0.0.1", expectedIP: "203.0.113.7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			gin.SetMode(gin.TestMode)
+			logger := zerolog.New(zerolog.NewTestWriter(t))
+			server := NewServer(&config.Config{AppName: "Test App", TrustedProxies: tt.proxies}, logger, Services{})
+			server.router.GET("/ip", func(c *gin.Context) {
+				c.String(http.StatusOK, c.ClientIP())
+			})
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/ip", nil)
+			req.RemoteAddr = tt.remoteAddr + ":1234"
+			req.Header.Set("X-Forwarded-For", "203.0.113.7")
+
+			// Execute
+			server.router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedIP, w.Body.String())
+		})
+	}
+}
+
 func TestHealthCheck(t *testing.T) {
 	// Setup
 	gin.SetMode(gin.TestMode)
@@ -106,7 +179,7 @@ func TestHealthCheck(t *testing.T) {
 	wordService := new(MockWordService)
 
 	// Create server
-	server := NewServer(cfg, logger, wordService)
+	server := NewServer(cfg, logger, Services{Word: wordService})
 	server.setupRoutes()
 
 	// Create a test request
@@ -150,7 +223,7 @@ func TestLoggerMiddleware(t *testing.T) {
 	wordService := new(MockWordService)
 
 	// Create server
-	server := NewServer(cfg, logger, wordService)
+	server := NewServer(cfg, logger, Services{Word: wordService})
 
 	// Create a test request
 	w := httptest.NewRecorder()
@@ -191,7 +264,7 @@ func TestSearchWord(t *testing.T) {
 	wordService := new(MockWordService)
 
 	// Create server
-	server := NewServer(cfg, logger, wordService)
+	server := NewServer(cfg, logger, Services{Word: wordService})
 
 	// Mock word service response
 	testWord := &word.Word{
@@ -203,8 +276,9 @@ func TestSearchWord(t *testing.T) {
 				WordType: "noun",
 			},
 		},
+		Provenance: &word.Provenance{Source: "enwiktionary", ParserVersion: 1},
 	}
-	wordService.On("Search", mock.Anything, "test", "en").Return(testWord, nil)
+	wordService.On("Search", mock.Anything, "", "test", "en").Return(testWord, nil)
 
 	// Create request body
 	requestBody := WordSearchRequest{
@@ -240,11 +314,141 @@ func TestSearchWord(t *testing.T) {
 	assert.Equal(t, "en", response.Word.Language)
 	assert.Equal(t, "a procedure intended to establish quality", response.Word.Definitions[0].Text)
 	assert.Equal(t, "noun", response.Word.Definitions[0].WordType)
+	assert.NotContains(t, w.Body.String(), "provenance", "provenance is kept for maintainers")
 
 	// Verify mock was called
 	wordService.AssertExpectations(t)
 }
 
+func TestSearchWord_DictionaryUnavailable(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	wordService := new(MockWordService)
+	server := NewServer(&config.Config{AppName: "Test App"}, logger, Services{Word: wordService})
+	wordService.On("Search", mock.Anything, "", "maison", "fr").
+		Return(nil, fmt.Errorf("failed to fetch word: %w", word.ErrDictionaryUnavailable))
+
+	jsonBody, _ := json.Marshal(WordSearchRequest{Text: "maison", Language: "fr"})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1/words/search", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("logger", logger)
+		c.Next()
+	})
+	router.POST("/api/v1/words/search", server.SearchWord)
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	wordService.AssertExpectations(t)
+}
+
+func TestSearchWord_FetchLimited(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	wordService := new(MockWordService)
+	server := NewServer(&config.Config{AppName: "Test App"}, logger, Services{Word: wordService})
+	wordService.On("Search", mock.Anything, "", "maison", "fr").
+		Return(nil, fmt.Errorf("failed to fetch word: %w", word.ErrFetchLimited))
+
+	jsonBody, _ := json.Marshal(WordSearchRequest{Text: "maison", Language: "fr"})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1/words/search", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("logger", logger)
+		c.Next()
+	})
+	router.POST("/api/v1/words/search", server.SearchWord)
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	wordService.AssertExpectations(t)
+}
+
+func TestRateLimit(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	server := NewServer(&config.Config{AppName: "Test App", JWTSecret: "test-secret"}, logger, Services{})
+	server.SetRateLimiter(ratelimit.NewMemoryLimiter(nil), ratelimit.Rules{
+		rateLimitWords: {
+			Anonymous: ratelimit.Limit{Requests: 1, Window: time.Minute},
+			User:      ratelimit.Limit{Requests: 2, Window: time.Minute},
+		},
+	})
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("logger", logger)
+		c.Next()
+	})
+	router.GET("/words", server.rateLimit(rateLimitWords), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	router.GET("/unlimited", server.rateLimit(rateLimitAccount), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	send := func(path, ip, authorization string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		req.RemoteAddr = ip + ":1234"
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+	token := signTestToken(t, "test-secret", "user-1")
+
+	// Execute
+	first := send("/words", "10.0.0.1", "")
+	limited := send("/words", "10.0.0.1", "")
+	otherIP := send("/words", "10.0.0.2", "")
+	user := []*httptest.ResponseRecorder{
+		send("/words", "10.0.0.1", token),
+		send("/words", "10.0.0.1", token),
+		send("/words", "10.0.0.1", token),
+	}
+	unlimited := send("/unlimited", "10.0.0.1", "")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "1", first.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "0", first.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "60", first.Header().Get("RateLimit-Reset"))
+	assert.Equal(t, "1;w=60", first.Header().Get("RateLimit-Policy"))
+	assert.Empty(t, first.Header().Get("Retry-After"))
+
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Equal(t, "60", limited.Header().Get("Retry-After"))
+	var response ErrorResponse
+	assert.NoError(t, json.Unmarshal(limited.Body.Bytes(), &response))
+	assert.Equal(t, http.StatusTooManyRequests, response.Status)
+
+	assert.Equal(t, http.StatusOK, otherIP.Code)
+	assert.Equal(t, http.StatusOK, user[0].Code)
+	assert.Equal(t, "2", user[0].Header().Get("RateLimit-Limit"))
+	assert.Equal(t, http.StatusOK, user[1].Code)
+	assert.Equal(t, http.StatusTooManyRequests, user[2].Code)
+
+	assert.Equal(t, http.StatusOK, unlimited.Code)
+	assert.Empty(t, unlimited.Header().Get("RateLimit-Limit"))
+}
+
 func TestGetRecentWords(t *testing.T) {
 	// Setup
 	gin.SetMode(gin.TestMode)
@@ -258,7 +462,7 @@ func TestGetRecentWords(t *testing.T) {
 	wordService := new(MockWordService)
 
 	// Create server
-	server := NewServer(cfg, logger, wordService)
+	server := NewServer(cfg, logger, Services{Word: wordService})
 
 	// Mock word service response
 	testWords := []*word.Word{
@@ -328,14 +532,14 @@ func TestAutoComplete(t *testing.T) {
 	wordService := new(MockWordService)
 
 	// Create server
-	server := NewServer(cfg, logger, wordService)
+	server := NewServer(cfg, logger, Services{Word: wordService})
 
 	// Mock word service response
 	testWords := []string{
 		"test1",
 		"test2",
 	}
-	wordService.On("AutoComplete", mock.Anything, "test", "en").Return(testWords, nil)
+	wordService.On("GetSuggestions", mock.Anything, "test", "en").Return(testWords, nil)
 
 	// Create test request
 	w := httptest.NewRecorder()
@@ -380,7 +584,7 @@ func TestAutoComplete_InvalidRequest(t *testing.T) {
 	wordService := new(MockWordService)
 
 	// Create server
-	server := NewServer(cfg, logger, wordService)
+	server := NewServer(cfg, logger, Services{Word: wordService})
 
 	// Create test request with missing query parameter
 	w := httptest.NewRecorder()
@@ -407,5 +611,1213 @@ func TestAutoComplete_InvalidRequest(t *testing.T) {
 	assert.Contains(t, response["message"], "Invalid request")
 
 	// Verify mock was not called
-	wordService.AssertNotCalled(t, "AutoComplete")
+	wordService.AssertNotCalled(t, "GetSuggestions")
+}
+
+func TestAutoComplete_Language(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{AppName: "Test App"}
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	wordService := new(MockWordService)
+	server := NewServer(cfg, logger, Services{Word: wordService})
+	wordService.On("GetSuggestions", mock.Anything, "haus", "de").Return([]string{"Haus", "Hausaufgabe"}, nil)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("logger", logger)
+		c.Next()
+	})
+	router.GET("/api/v1/words/autocomplete", server.AutoComplete)
+
+	testCases := []struct {
+		name     string
+		query    string
+		expected int
+	}{
+		{"supported language", "?q=haus&lang=de", http.StatusOK},
+		{"unsupported language", "?q=haus&lang=xx", http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Execute
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/v1/words/autocomplete"+tc.query, nil)
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tc.expected, w.Code)
+		})
+	}
+	wordService.AssertNumberOfCalls(t, "GetSuggestions", 1)
+}
+
+func TestListLanguages(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	server := NewServer(&config.Config{AppName: "Test App"}, logger, Services{})
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("logger", logger)
+		c.Next()
+	})
+	router.GET("/api/v1/languages", server.ListLanguages)
+
+	// Execute
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/languages", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response LanguagesResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	if !assert.Len(t, response.Languages, 5) {
+		return
+	}
+	french := response.Languages[0]
+	assert.Equal(t, "fr", french.Code)
+	assert.True(t, french.Primary)
+	assert.True(t, french.Lemmatization)
+	assert.Contains(t, french.WordTypes, "nom")
+	assert.Equal(t, []string{"frwiktionary"}, french.Sources)
+	english := response.Languages[1]
+	assert.False(t, english.Primary)
+	assert.Empty(t, english.Genders)
+	assert.NotNil(t, english.Genders, "languages without gender list none")
+}
+
+func TestListWords(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{AppName: "Test App"}
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	wordService := new(MockWordService)
+	server := NewServer(cfg, logger, Services{Word: wordService})
+
+	easy := []*word.Word{{ID: "word-1", Text: "maison", Language: "fr", FrequencyRank: 120, Difficulty: word.DifficultyA1}}
+	wordService.On("ListWords", mock.Anything, word.ListOptions{
+		Language: "fr", MinDifficulty: word.DifficultyA1, MaxDifficulty: word.DifficultyA1, Sort: word.SortFrequency,
+	}).Return(easy, nil)
+	wordService.On("ListWords", mock.Anything, word.ListOptions{
+		MinDifficulty: word.DifficultyC1, MaxDifficulty: word.DifficultyA2,
+	}).Return(nil, word.ErrInvalidDifficulty)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("logger", logger)
+		c.Next()
+	})
+	router.GET("/api/v1/words", server.ListWords)
+
+	testCases := []struct {
+		name     string
+		query    string
+		expected int
+	}{
+		{"filter by band sorted by frequency", "?lang=fr&difficulty=A1&sort=frequency", http.StatusOK},
+		{"inverted range", "?min_difficulty=C1&max_difficulty=A2", http.StatusBadRequest},
+		{"unknown band", "?difficulty=D1", http.StatusBadRequest},
+		{"unknown sort", "?sort=alphabetical", http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/v1/words"+tc.query, nil)
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expected, w.Code)
+			if tc.expected == http.StatusOK {
+				assert.Contains(t, w.Body.String(), `"difficulty":"A1"`)
+				assert.Contains(t, w.Body.String(), `"frequency_rank":120`)
+			}
+		})
+	}
+
+	wordService.AssertNumberOfCalls(t, "ListWords", 2)
+}
+
+// MockSavedWordService is a mock implementation of savedword.Service
+type MockSavedWordService struct {
+	mock.Mock
+}
+
+func (m *MockSavedWordService) SaveWord(ctx context.Context, userID, wordID string, readingCtx savedword.ReadingContext) (*savedword.SavedWord, error) {
+	args := m.Called(ctx, userID, wordID, readingCtx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*savedword.SavedWord), args.Error(1)
+}
+
+func (m *MockSavedWordService) GetSavedWord(ctx context.Context, userID, id string) (*savedword.Detail, error) {
+	args := m.Called(ctx, userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*savedword.Detail), args.Error(1)
+}
+
+func (m *MockSavedWordService) ListSavedWords(ctx context.Context, userID string, filter savedword.Filter, limit, offset int) ([]*savedword.SavedWord, error) {
+	args := m.Called(ctx, userID, filter, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*savedword.SavedWord), args.Error(1)
+}
+
+func (m *MockSavedWordService) UpdateContext(ctx context.Context, userID, id string, readingCtx savedword.ReadingContext) (*savedword.SavedWord, error) {
+	args := m.Called(ctx, userID, id, readingCtx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*savedword.SavedWord), args.Error(1)
+}
+
+func (m *MockSavedWordService) DeleteSavedWord(ctx context.Context, userID, id string) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+// signTestToken creates a bearer token for the given user signed with secret
+func signTestToken(t *testing.T, secret, userID string) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Subject: userID})
+	signed, err := token.SignedString([]byte(secret))
+	assert.NoError(t, err)
+
+	return "Bearer " + signed
+}
+
+// newAuthenticatedRouter creates a router with the logger and requireUser middlewares
+func newAuthenticatedRouter(server *Server, logger zerolog.Logger) *gin.Engine {
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("logger", logger)
+		c.Next()
+	})
+	router.Use(server.requireUser())
+	return router
+}
+
+func TestSaveWord(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{AppName: "Test App", JWTSecret: "test-secret"}
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	savedWordService := new(MockSavedWordService)
+	server := NewServer(cfg, logger, Services{SavedWord: savedWordService})
+
+	readingCtx := savedword.ReadingContext{Sentence: "He sat on the bank", BookTitle: "Walden"}
+	saved := savedword.NewSavedWord("user-1", "word-1")
+	saved.Context = readingCtx
+	savedWordService.On("SaveWord", mock.Anything, "user-1", "word-1", readingCtx).Return(saved, nil)
+
+	jsonBody, _ := json.Marshal(SaveWordRequest{
+		WordID:  "word-1",
+		Context: ReadingContextRequest{Sentence: "He sat on the bank", BookTitle: "Walden"},
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1/saved-words", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", signTestToken(t, "test-secret", "user-1"))
+
+	router := newAuthenticatedRouter(server, logger)
+	router.POST("/api/v1/saved-words", server.SaveWord)
+
+	// Execute request
+	router.ServeHTTP(w, req)
+
+	// Assert response
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response SavedWordResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, saved.ID, response.SavedWord.ID)
+	assert.Equal(t, "Walden", response.SavedWord.Context.BookTitle)
+
+	savedWordService.AssertExpectations(t)
+}
+
+func TestSaveWord_Duplicate(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{AppName: "Test App", JWTSecret: "test-secret"}
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	savedWordService := new(MockSavedWordService)
+	server := NewServer(cfg, logger, Services{SavedWord: savedWordService})
+
+	savedWordService.On("SaveWord", mock.Anything, "user-1", "word-1", savedword.ReadingContext{}).
+		Return(nil, savedword.ErrDuplicateSavedWord)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1/saved-words", bytes.NewBufferString(`{"word_id":"word-1"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", signTestToken(t, "test-secret", "user-1"))
+
+	router := newAuthenticatedRouter(server, logger)
+	router.POST("/api/v1/saved-words", server.SaveWord)
+
+	// Execute request
+	router.ServeHTTP(w, req)
+
+	// Assert response
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestRequireUser_InvalidToken(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{AppName: "Test App", JWTSecret: "test-secret"}
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	savedWordService := new(MockSavedWordService)
+	server := NewServer(cfg, logger, Services{SavedWord: savedWordService})
+
+	router := newAuthenticatedRouter(server, logger)
+	router.GET("/api/v1/saved-words", server.ListSavedWords)
+
+	testCases := []struct {
+		name   string
+		header string
+	}{
+		{"missing header", ""},
+		{"wrong scheme", "Basic dXNlcjpwYXNz"},
+		{"wrong secret", signTestToken(t, "other-secret", "user-1")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/v1/saved-words", nil)
+			if tc.header != "" {
+				req.Header.Set("Authorization", tc.header)
+			}
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+		})
+	}
+
+	savedWordService.AssertNotCalled(t, "ListSavedWords")
+}
+
+func TestRequireAdmin(t *testing.T) {
+	testCases := []struct {
+		name       string
+		adminToken string
+		header     string
+		expected   int
+	}{
+		{"missing header", "admin-token", "", http.StatusUnauthorized},
+		{"wrong token", "admin-token", "Bearer other-token", http.StatusUnauthorized},
+		{"user token", "admin-token", signTestToken(t, "test-secret", "user-1"), http.StatusUnauthorized},
+		{"no admin token configured", "", "Bearer ", http.StatusUnauthorized},
+		{"admin token", "admin-token", "Bearer admin-token", http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			gin.SetMode(gin.TestMode)
+			cfg := &config.Config{AppName: "Test App", JWTSecret: "test-secret", AdminToken: tc.adminToken}
+			logger := zerolog.New(zerolog.NewTestWriter(t))
+			server := NewServer(cfg, logger, Services{})
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Set("logger", logger)
+				c.Next()
+			})
+			router.GET("/debug/vars", server.requireAdmin(), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/debug/vars", nil)
+			if tc.header != "" {
+				req.Header.Set("Authorization", tc.header)
+			}
+
+			// Execute
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tc.expected, w.Code)
+		})
+	}
+}
+
+func TestGetAdminWord(t *testing.T) {
+	stored := &word.Word{
+		ID:         "word-1",
+		Text:       "maison",
+		Language:   "fr",
+		Provenance: &word.Provenance{Source: "frwiktionary", RevisionID: 34803495, ParserVersion: 2},
+	}
+	testCases := []struct {
+		name     string
+		header   string
+		wordID   string
+		expected int
+	}{
+		{"missing admin token", "", "word-1", http.StatusUnauthorized},
+		{"user token", signTestToken(t, "test-secret", "user-1"), "word-1", http.StatusUnauthorized},
+		{"unknown word", "Bearer admin-token", "missing", http.StatusNotFound},
+		{"stored word", "Bearer admin-token", "word-1", http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			gin.SetMode(gin.TestMode)
+			cfg := &config.Config{AppName: "Test App", JWTSecret: "test-secret", AdminToken: "admin-token"}
+			logger := zerolog.New(zerolog.NewTestWriter(t))
+			wordService := new(MockWordService)
+			wordService.On("GetWord", mock.Anything, "word-1").Return(stored, nil).Maybe()
+			wordService.On("GetWord", mock.Anything, "missing").Return(nil, word.ErrWordNotFound).Maybe()
+			server := NewServer(cfg, logger, Services{Word: wordService})
+			server.setupMiddleware()
+			server.setupRoutes()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/v1/admin/words/"+tc.wordID, nil)
+			if tc.header != "" {
+				req.Header.Set("Authorization", tc.header)
+			}
+
+			// Execute
+			server.router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tc.expected, w.Code)
+			if tc.expected != http.StatusOK {
+				return
+			}
+			var response AdminWordResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			if assert.NotNil(t, response.Word) {
+				assert.Equal(t, stored.Provenance, response.Word.Provenance)
+			}
+		})
+	}
+}
+
+// MockWordListService is a mock implementation of wordlist.Service
+type MockWordListService struct {
+	mock.Mock
+}
+
+func (m *MockWordListService) CreateList(ctx context.Context, userID, name, description string) (*wordlist.WordList, error) {
+	args := m.Called(ctx, userID, name, description)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*wordlist.WordList), args.Error(1)
+}
+
+func (m *MockWordListService) GetList(ctx context.Context, userID, id string) (*wordlist.WordList, error) {
+	args := m.Called(ctx, userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*wordlist.WordList), args.Error(1)
+}
+
+func (m *MockWordListService) ListLists(ctx context.Context, userID string, limit, offset int) ([]*wordlist.WordList, error) {
+	args := m.Called(ctx, userID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*wordlist.WordList), args.Error(1)
+}
+
+func (m *MockWordListService) UpdateList(ctx context.Context, userID, id, name, description string) (*wordlist.WordList, error) {
+	args := m.Called(ctx, userID, id, name, description)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*wordlist.WordList), args.Error(1)
+}
+
+func (m *MockWordListService) DeleteList(ctx context.Context, userID, id string) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockWordListService) AddWords(ctx context.Context, userID, id string, savedWordIDs []string) (*wordlist.WordList, error) {
+	args := m.Called(ctx, userID, id, savedWordIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*wordlist.WordList), args.Error(1)
+}
+
+func (m *MockWordListService) RemoveWords(ctx context.Context, userID, id string, savedWordIDs []string) (*wordlist.WordList, error) {
+	args := m.Called(ctx, userID, id, savedWordIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*wordlist.WordList), args.Error(1)
+}
+
+func (m *MockWordListService) ReorderWords(ctx context.Context, userID, id string, savedWordIDs []string) (*wordlist.WordList, error) {
+	args := m.Called(ctx, userID, id, savedWordIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*wordlist.WordList), args.Error(1)
+}
+
+func (m *MockWordListService) ShareList(ctx context.Context, userID, id string) (*wordlist.WordList, error) {
+	args := m.Called(ctx, userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*wordlist.WordList), args.Error(1)
+}
+
+func (m *MockWordListService) UnshareList(ctx context.Context, userID, id string) (*wordlist.WordList, error) {
+	args := m.Called(ctx, userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*wordlist.WordList), args.Error(1)
+}
+
+func (m *MockWordListService) GetSharedList(ctx context.Context, token string) (*wordlist.SharedList, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*wordlist.SharedList), args.Error(1)
+}
+
+func (m *MockWordListService) DuplicateSharedList(ctx context.Context, userID, token string) (*wordlist.WordList, error) {
+	args := m.Called(ctx, userID, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*wordlist.WordList), args.Error(1)
+}
+
+func TestAddWordListWords(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{AppName: "Test App", JWTSecret: "test-secret"}
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	wordListService := new(MockWordListService)
+	server := NewServer(cfg, logger, Services{WordList: wordListService})
+
+	list := wordlist.NewWordList("user-1", "Verbs", "")
+	list.AddWords("sw-1", "sw-2")
+	wordListService.On("AddWords", mock.Anything, "user-1", list.ID, []string{"sw-1", "sw-2"}).Return(list, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1/lists/"+list.ID+"/words", bytes.NewBufferString(`{"saved_word_ids":["sw-1","sw-2"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", signTestToken(t, "test-secret", "user-1"))
+
+	router := newAuthenticatedRouter(server, logger)
+	router.POST("/api/v1/lists/:listId/words", server.AddWordListWords)
+
+	// Execute request
+	router.ServeHTTP(w, req)
+
+	// Assert response
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response WordListResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sw-1", "sw-2"}, response.List.SavedWordIDs)
+
+	wordListService.AssertExpectations(t)
+}
+
+func TestReorderWordList_InvalidOrder(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{AppName: "Test App", JWTSecret: "test-secret"}
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	wordListService := new(MockWordListService)
+	server := NewServer(cfg, logger, Services{WordList: wordListService})
+
+	wordListService.On("ReorderWords", mock.Anything, "user-1", "list-1", []string{"sw-9"}).
+		Return(nil, wordlist.ErrInvalidOrder)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/api/v1/lists/list-1/order", bytes.NewBufferString(`{"saved_word_ids":["sw-9"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", signTestToken(t, "test-secret", "user-1"))
+
+	router := newAuthenticatedRouter(server, logger)
+	router.PUT("/api/v1/lists/:listId/order", server.ReorderWordList)
+
+	// Execute request
+	router.ServeHTTP(w, req)
+
+	// Assert response
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetSharedWordList_NoAuthentication(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{AppName: "Test App", JWTSecret: "test-secret"}
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	wordListService := new(MockWordListService)
+	server := NewServer(cfg, logger, Services{WordList: wordListService})
+
+	shared := &wordlist.SharedList{Name: "Verbs", Words: []*word.Word{word.NewWord("manger", "fr")}}
+	wordListService.On("GetSharedList", mock.Anything, "token").Return(shared, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/shared-lists/token", nil)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("logger", logger)
+		c.Next()
+	})
+	router.GET("/api/v1/shared-lists/:shareToken", server.GetSharedWordList)
+
+	// Execute request
+	router.ServeHTTP(w, req)
+
+	// Assert response
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "user_id")
+
+	var response SharedListResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "Verbs", response.List.Name)
+	assert.Len(t, response.List.Words, 1)
+}
+
+// MockTagService is a mock implementation of tag.Service
+type MockTagService struct {
+	mock.Mock
+}
+
+func (m *MockTagService) CreateTag(ctx context.Context, userID, name string) (*tag.Tag, error) {
+	args := m.Called(ctx, userID, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tag.Tag), args.Error(1)
+}
+
+func (m *MockTagService) ListTags(ctx context.Context, userID string) ([]*tag.Tag, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*tag.Tag), args.Error(1)
+}
+
+func (m *MockTagService) ListTargetTags(ctx context.Context, userID string, targetType tag.TargetType, targetID string) ([]*tag.Tag, error) {
+	args := m.Called(ctx, userID, targetType, targetID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*tag.Tag), args.Error(1)
+}
+
+func (m *MockTagService) RenameTag(ctx context.Context, userID, id, name string) (*tag.Tag, error) {
+	args := m.Called(ctx, userID, id, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tag.Tag), args.Error(1)
+}
+
+func (m *MockTagService) MergeTags(ctx context.Context, userID, sourceID, targetID string) (*tag.Tag, error) {
+	args := m.Called(ctx, userID, sourceID, targetID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tag.Tag), args.Error(1)
+}
+
+func (m *MockTagService) GetSuggestions(ctx context.Context, userID, prefix string) ([]string, error) {
+	args := m.Called(ctx, userID, prefix)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockTagService) DeleteTag(ctx context.Context, userID, id string) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockTagService) AssignTag(ctx context.Context, userID, id string, targetType tag.TargetType, targetIDs []string) error {
+	args := m.Called(ctx, userID, id, targetType, targetIDs)
+	return args.Error(0)
+}
+
+func (m *MockTagService) UnassignTag(ctx context.Context, userID, id string, targetType tag.TargetType, targetIDs []string) error {
+	args := m.Called(ctx, userID, id, targetType, targetIDs)
+	return args.Error(0)
+}
+
+func TestListSavedWords_TagExpression(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{AppName: "Test App", JWTSecret: "test-secret"}
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	savedWordService := new(MockSavedWordService)
+	server := NewServer(cfg, logger, Services{SavedWord: savedWordService})
+
+	expected := []*savedword.SavedWord{savedword.NewSavedWord("user-1", "word-1")}
+	savedWordService.On("ListSavedWords", mock.Anything, "user-1", mock.MatchedBy(func(filter savedword.Filter) bool {
+		return filter.Tags != nil && filter.Tags.String() == "(verbs AND NOT done)"
+	}), 0, 0).Return(expected, nil)
+
+	router := newAuthenticatedRouter(server, logger)
+	router.GET("/api/v1/saved-words", server.ListSavedWords)
+
+	testCases := []struct {
+		name     string
+		tags     string
+		expected int
+	}{
+		{"valid expression", "verbs AND NOT done", http.StatusOK},
+		{"invalid expression", "verbs AND (done", http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/v1/saved-words", nil)
+			q := req.URL.Query()
+			q.Set("tags", tc.tags)
+			req.URL.RawQuery = q.Encode()
+			req.Header.Set("Authorization", signTestToken(t, "test-secret", "user-1"))
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expected, w.Code)
+		})
+	}
+
+	savedWordService.AssertNumberOfCalls(t, "ListSavedWords", 1)
+}
+
+func TestAssignTag(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{AppName: "Test App", JWTSecret: "test-secret"}
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	tagService := new(MockTagService)
+	server := NewServer(cfg, logger, Services{Tag: tagService})
+
+	tagService.On("AssignTag", mock.Anything, "user-1", "tag-1", tag.TargetWordList, []string{"list-1"}).Return(nil)
+
+	router := newAuthenticatedRouter(server, logger)
+	router.POST("/api/v1/tags/:tagId/assignments", server.AssignTag)
+
+	testCases := []struct {
+		name     string
+		body     string
+		expected int
+	}{
+		{"word list", `{"target_type":"word_list","target_ids":["list-1"]}`, http.StatusNoContent},
+		{"unknown target type", `{"target_type":"word","target_ids":["word-1"]}`, http.StatusBadRequest},
+		{"no targets", `{"target_type":"word_list","target_ids":[]}`, http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/v1/tags/tag-1/assignments", bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", signTestToken(t, "test-secret", "user-1"))
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expected, w.Code)
+		})
+	}
+
+	tagService.AssertNumberOfCalls(t, "AssignTag", 1)
+}
+
+// MockReviewService is a mock implementation of review.Service
+type MockReviewService struct {
+	mock.Mock
+}
+
+func (m *MockReviewService) GetDue(ctx context.Context, userID string, limit int) ([]*review.DueItem, error) {
+	args := m.Called(ctx, userID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*review.DueItem), args.Error(1)
+}
+
+func (m *MockReviewService) Grade(ctx context.Context, userID, savedWordID string, grade review.Grade, duration time.Duration, source review.Source) (*review.Card, error) {
+	args := m.Called(ctx, userID, savedWordID, grade, duration, source)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*review.Card), args.Error(1)
+}
+
+func TestGradeReview(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{AppName: "Test App", JWTSecret: "test-secret"}
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	reviewService := new(MockReviewService)
+	server := NewServer(cfg, logger, Services{Review: reviewService})
+
+	card := review.NewCard("sw-1", "user-1", time.Now())
+	card.IntervalDays = 4
+	reviewService.On("Grade", mock.Anything, "user-1", "sw-1", review.GradeGood, 2500*time.Millisecond, review.SourceQueue).Return(card, nil)
+	reviewService.On("Grade", mock.Anything, "user-1", "sw-9", review.GradeGood, time.Duration(0), review.SourceQueue).Return(nil, review.ErrCardNotFound)
+
+	router := newAuthenticatedRouter(server, logger)
+	router.POST("/api/v1/reviews/:savedWordId/grade", server.GradeReview)
+
+	testCases := []struct {
+		name        string
+		savedWordID string
+		body        string
+		expected    int
+	}{
+		{"good answer", "sw-1", `{"grade":3,"duration_ms":2500}`, http.StatusOK},
+		{"grade out of range", "sw-1", `{"grade":5}`, http.StatusBadRequest},
+		{"missing grade", "sw-1", `{}`, http.StatusBadRequest},
+		{"unknown saved word", "sw-9", `{"grade":3}`, http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/v1/reviews/"+tc.savedWordID+"/grade", bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", signTestToken(t, "test-secret", "user-1"))
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expected, w.Code)
+		})
+	}
+
+	reviewService.AssertNumberOfCalls(t, "Grade", 2)
+}
+
+// MockQuizService is a mock implementation of quiz.Service
+type MockQuizService struct {
+	mock.Mock
+}
+
+func (m *MockQuizService) GenerateQuestion(ctx context.Context, w *word.Word, questionType quiz.QuestionType) (*quiz.Question, error) {
+	args := m.Called(ctx, w, questionType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*quiz.Question), args.Error(1)
+}
+
+func (m *MockQuizService) GenerateQuiz(ctx context.Context, words []*word.Word, types []quiz.QuestionType, count int) ([]*quiz.Question, error) {
+	args := m.Called(ctx, words, types, count)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*quiz.Question), args.Error(1)
+}
+
+func (m *MockQuizService) StartSession(ctx context.Context, userID string, options quiz.SessionOptions) (*quiz.Session, error) {
+	args := m.Called(ctx, userID, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*quiz.Session), args.Error(1)
+}
+
+func (m *MockQuizService) GetSession(ctx context.Context, userID, id string) (*quiz.Session, error) {
+	args := m.Called(ctx, userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*quiz.Session), args.Error(1)
+}
+
+func (m *MockQuizService) CurrentQuestion(ctx context.Context, userID, sessionID string) (*quiz.SessionQuestion, error) {
+	args := m.Called(ctx, userID, sessionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*quiz.SessionQuestion), args.Error(1)
+}
+
+func (m *MockQuizService) Answer(ctx context.Context, userID, sessionID, questionID, answer string) (*quiz.AnswerResult, error) {
+	args := m.Called(ctx, userID, sessionID, questionID, answer)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*quiz.AnswerResult), args.Error(1)
+}
+
+func TestStartQuiz(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{AppName: "Test App", JWTSecret: "test-secret"}
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	quizService := new(MockQuizService)
+	server := NewServer(cfg, logger, Services{Quiz: quizService})
+
+	session := &quiz.Session{ID: "session-1", UserID: "user-1", Source: quiz.SourceList, Status: quiz.SessionInProgress, QuestionCount: 5}
+	question := &quiz.SessionQuestion{
+		Question:  &quiz.Question{ID: "q-1", Type: quiz.Gender, Prompt: "maison", Choices: []string{"masculin", "féminin"}, Answers: []string{"féminin"}},
+		SessionID: "session-1",
+	}
+	quizService.On("StartSession", mock.Anything, "user-1", quiz.SessionOptions{
+		Source:    quiz.SourceList,
+		SourceRef: "list-1",
+		Types:     []quiz.QuestionType{quiz.Gender},
+		Count:     5,
+	}).Return(session, nil)
+	quizService.On("StartSession", mock.Anything, "user-1", quiz.SessionOptions{Source: quiz.SourceDue}).Return(nil, quiz.ErrNoQuestions)
+	quizService.On("CurrentQuestion", mock.Anything, "user-1", "session-1").Return(question, nil)
+
+	router := newAuthenticatedRouter(server, logger)
+	router.POST("/api/v1/quizzes", server.StartQuiz)
+
+	testCases := []struct {
+		name     string
+		body     string
+		expected int
+	}{
+		{"word list", `{"source":"list","list_id":"list-1","types":["gender"],"count":5}`, http.StatusCreated},
+		{"missing list", `{"source":"list"}`, http.StatusBadRequest},
+		{"missing tags", `{"source":"tag"}`, http.StatusBadRequest},
+		{"unknown question type", `{"source":"due","types":["essay"]}`, http.StatusBadRequest},
+		{"nothing to ask", `{"source":"due"}`, http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/v1/quizzes", bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", signTestToken(t, "test-secret", "user-1"))
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expected, w.Code)
+			if tc.expected == http.StatusCreated {
+				assert.Contains(t, w.Body.String(), `"prompt":"maison"`)
+				assert.NotContains(t, w.Body.String(), "answers", "answer keys stay on the server")
+			}
+		})
+	}
+
+	quizService.AssertNumberOfCalls(t, "StartSession", 2)
+}
+
+func TestAnswerQuiz(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{AppName: "Test App", JWTSecret: "test-secret"}
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	quizService := new(MockQuizService)
+	server := NewServer(cfg, logger, Services{Quiz: quizService})
+
+	result := &quiz.AnswerResult{
+		Attempt:        &quiz.Attempt{ID: "attempt-1", QuestionID: "q-1", Answer: "feminin", Correct: true},
+		ExpectedAnswer: "féminin",
+		Session:        &quiz.Session{ID: "session-1", QuestionCount: 5, AnsweredCount: 2, CorrectCount: 1},
+	}
+	quizService.On("Answer", mock.Anything, "user-1", "session-1", "q-1", "feminin").Return(result, nil)
+	quizService.On("Answer", mock.Anything, "user-1", "session-1", "q-0", "feminin").Return(nil, quiz.ErrQuestionMismatch)
+	quizService.On("Answer", mock.Anything, "user-1", "session-9", "q-1", "feminin").Return(nil, quiz.ErrSessionNotFound)
+
+	router := newAuthenticatedRouter(server, logger)
+	router.POST("/api/v1/quizzes/:quizId/answers", server.AnswerQuiz)
+
+	testCases := []struct {
+		name      string
+		sessionID string
+		body      string
+		expected  int
+	}{
+		{"correct answer", "session-1", `{"question_id":"q-1","answer":"feminin"}`, http.StatusOK},
+		{"stale question", "session-1", `{"question_id":"q-0","answer":"feminin"}`, http.StatusConflict},
+		{"unknown session", "session-9", `{"question_id":"q-1","answer":"feminin"}`, http.StatusNotFound},
+		{"missing question", "session-1", `{"answer":"feminin"}`, http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/v1/quizzes/"+tc.sessionID+"/answers", bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", signTestToken(t, "test-secret", "user-1"))
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expected, w.Code)
+			if tc.expected == http.StatusOK {
+				assert.Contains(t, w.Body.String(), `"correct":true`)
+				assert.Contains(t, w.Body.String(), `"score":50`)
+			}
+		})
+	}
+
+	quizService.AssertNumberOfCalls(t, "Answer", 3)
+}
+
+// MockDailyWordService is a mock implementation of dailyword.Service
+type MockDailyWordService struct {
+	mock.Mock
+}
+
+func (m *MockDailyWordService) GetDailyWord(ctx context.Context, userID, language string, date time.Time) (*dailyword.DailyWord, error) {
+	args := m.Called(ctx, userID, language, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dailyword.DailyWord), args.Error(1)
+}
+
+func (m *MockDailyWordService) GetHistory(ctx context.Context, userID, language string, limit int) ([]*dailyword.DailyWord, error) {
+	args := m.Called(ctx, userID, language, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dailyword.DailyWord), args.Error(1)
+}
+
+func TestGetDailyWord(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{AppName: "Test App", JWTSecret: "test-secret"}
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	dailyWordService := new(MockDailyWordService)
+	server := NewServer(cfg, logger, Services{DailyWord: dailyWordService})
+
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	global := &dailyword.DailyWord{Date: "2024-03-01", Language: "fr", WordID: "word-1"}
+	personal := &dailyword.DailyWord{Date: "2024-03-01", Language: "fr", UserID: "user-1", WordID: "word-2"}
+	dailyWordService.On("GetDailyWord", mock.Anything, "", "fr", date).Return(global, nil)
+	dailyWordService.On("GetDailyWord", mock.Anything, "user-1", "", date).Return(personal, nil)
+	dailyWordService.On("GetDailyWord", mock.Anything, "", "en", date).Return(nil, dailyword.ErrNoCandidate)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("logger", logger)
+		c.Next()
+	})
+	router.GET("/api/v1/words/daily", server.optionalUser(), server.GetDailyWord)
+
+	testCases := []struct {
+		name     string
+		query    string
+		userID   string
+		expected int
+		wordID   string
+	}{
+		{"anonymous global word", "?lang=fr&date=2024-03-01", "", http.StatusOK, "word-1"},
+		{"authenticated personal word", "?date=2024-03-01", "user-1", http.StatusOK, "word-2"},
+		{"authenticated global word", "?lang=fr&date=2024-03-01&scope=global", "user-1", http.StatusOK, "word-1"},
+		{"personal scope requires authentication", "?scope=personal", "", http.StatusUnauthorized, ""},
+		{"no candidate", "?lang=en&date=2024-03-01", "", http.StatusNotFound, ""},
+		{"invalid date", "?date=03/01/2024", "", http.StatusBadRequest, ""},
+		{"unsupported language", "?lang=xx", "", http.StatusBadRequest, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/v1/words/daily"+tc.query, nil)
+			if tc.userID != "" {
+				req.Header.Set("Authorization", signTestToken(t, "test-secret", tc.userID))
+			}
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expected, w.Code)
+			if tc.wordID != "" {
+				assert.Contains(t, w.Body.String(), `"word_id":"`+tc.wordID+`"`)
+			}
+		})
+	}
+
+	t.Run("invalid token", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/words/daily?lang=fr&date=2024-03-01", nil)
+		req.Header.Set("Authorization", signTestToken(t, "wrong-secret", "user-1"))
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	dailyWordService.AssertNumberOfCalls(t, "GetDailyWord", 4)
+}
+
+// MockGamificationService is a mock implementation of gamification.Service
+type MockGamificationService struct {
+	mock.Mock
+}
+
+func (m *MockGamificationService) Record(ctx context.Context, event gamification.Event) (*gamification.Award, error) {
+	args := m.Called(ctx, event)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*gamification.Award), args.Error(1)
+}
+
+func (m *MockGamificationService) GetOverview(ctx context.Context, userID string) (*gamification.Overview, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*gamification.Overview), args.Error(1)
+}
+
+func (m *MockGamificationService) SetTimezone(ctx context.Context, userID, timezone string) (*gamification.Progress, error) {
+	args := m.Called(ctx, userID, timezone)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*gamification.Progress), args.Error(1)
+}
+
+func TestProgress(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{AppName: "Test App", JWTSecret: "test-secret"}
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	gamificationService := new(MockGamificationService)
+	server := NewServer(cfg, logger, Services{Gamification: gamificationService})
+
+	progress := gamification.NewProgress("user-1")
+	progress.XP, progress.Level, progress.CurrentStreak = 120, 2, 3
+	overview := &gamification.Overview{Progress: progress, Streak: 3, LevelXP: 50, NextLevelXP: 200}
+	gamificationService.On("GetOverview", mock.Anything, "user-1").Return(overview, nil)
+	gamificationService.On("SetTimezone", mock.Anything, "user-1", "Europe/Paris").Return(progress, nil)
+	gamificationService.On("SetTimezone", mock.Anything, "user-1", "Nowhere/City").Return(nil, gamification.ErrInvalidTimezone)
+
+	router := newAuthenticatedRouter(server, logger)
+	router.GET("/api/v1/me/progress", server.GetProgress)
+	router.PATCH("/api/v1/me/progress", server.UpdateProgress)
+
+	testCases := []struct {
+		name     string
+		method   string
+		body     string
+		expected int
+		contains string
+	}{
+		{"get progress", "GET", "", http.StatusOK, `"next_level_xp":200`},
+		{"set timezone", "PATCH", `{"timezone":"Europe/Paris"}`, http.StatusOK, `"xp":120`},
+		{"unknown timezone", "PATCH", `{"timezone":"Nowhere/City"}`, http.StatusBadRequest, "invalid timezone"},
+		{"missing timezone", "PATCH", `{}`, http.StatusBadRequest, "Invalid request"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tc.method, "/api/v1/me/progress", bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", signTestToken(t, "test-secret", "user-1"))
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expected, w.Code)
+			assert.Contains(t, w.Body.String(), tc.contains)
+		})
+	}
+}
+
+// MockStatsService is a mock implementation of stats.Service
+type MockStatsService struct {
+	mock.Mock
+}
+
+func (m *MockStatsService) GetStats(ctx context.Context, userID string, opts stats.Options) (*stats.Stats, error) {
+	args := m.Called(ctx, userID, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*stats.Stats), args.Error(1)
+}
+
+func TestGetStats(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{AppName: "Test App", JWTSecret: "test-secret"}
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	statsService := new(MockStatsService)
+	server := NewServer(cfg, logger, Services{Stats: statsService})
+
+	result := &stats.Stats{
+		Interval:   stats.IntervalWeek,
+		Totals:     stats.Totals{SavedWords: 12, RetentionRate: 0.8},
+		SavedWords: []stats.CumulativePoint{{Date: "2024-03-11", Added: 2, Total: 12}},
+	}
+	statsService.On("GetStats", mock.Anything, "user-1", stats.Options{Interval: stats.IntervalWeek, Timezone: "Europe/Paris"}).Return(result, nil)
+	statsService.On("GetStats", mock.Anything, "user-1", stats.Options{From: "2024-03-10", To: "2024-03-01"}).Return(nil, stats.ErrInvalidRange)
+
+	router := newAuthenticatedRouter(server, logger)
+	router.GET("/api/v1/me/stats", server.GetStats)
+
+	testCases := []struct {
+		name     string
+		query    string
+		expected int
+		contains string
+	}{
+		{"weekly stats", "?interval=week&tz=Europe/Paris", http.StatusOK, `"total":12`},
+		{"unknown interval", "?interval=year", http.StatusBadRequest, "Invalid request"},
+		{"invalid range", "?from=2024-03-10&to=2024-03-01", http.StatusBadRequest, "invalid date range"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/v1/me/stats"+tc.query, nil)
+			req.Header.Set("Authorization", signTestToken(t, "test-secret", "user-1"))
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expected, w.Code)
+			assert.Contains(t, w.Body.String(), tc.contains)
+		})
+	}
 }
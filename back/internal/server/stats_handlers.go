@@ -0,0 +1,77 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/stats"
+)
+
+// GetStatsRequest represents a request for the current user's statistics
+type GetStatsRequest struct {
+	Interval string `form:"interval" binding:"omitempty,oneof=day week month"`
+	From     string `form:"from"` // YYYY-MM-DD
+	To       string `form:"to"`   // YYYY-MM-DD
+	Timezone string `form:"tz"`   // IANA name, e.g. Europe/Paris
+}
+
+// StatsResponse represents the response for the current user's statistics
+type StatsResponse struct {
+	Stats *stats.Stats `json:"stats"`
+}
+
+// GetStats handles requests for the current user's learning statistics
+// @Summary Get learning statistics
+// @Description Get the words saved and mastered over time, retention rate, time spent, the review forecast for the next 30 days and breakdowns by review state, language and word type. Time series have one point per interval, including empty ones.
+// @Tags progress
+// @Produce json
+// @Security BearerAuth
+// @Param interval query string false "Bucket width (day, week or month)" default(day)
+// @Param from query string false "First day (YYYY-MM-DD), defaults to 30 days, 12 weeks or 12 months before the end"
+// @Param to query string false "Last day (YYYY-MM-DD), defaults to today"
+// @Param tz query string false "IANA timezone of day boundaries" default(UTC)
+// @Success 200 {object} StatsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/me/stats [get]
+func (s *Server) GetStats(c *gin.Context) {
+	log := c.MustGet("logger").(zerolog.Logger)
+
+	var req GetStatsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Debug().Err(err).Msg("Invalid stats request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	result, err := s.statsService.GetStats(c.Request.Context(), currentUserID(c), stats.Options{
+		Interval: stats.Interval(req.Interval),
+		From:     req.From,
+		To:       req.To,
+		Timezone: req.Timezone,
+	})
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, stats.ErrInvalidInterval) || errors.Is(err, stats.ErrInvalidRange) || errors.Is(err, stats.ErrInvalidTimezone) {
+			status = http.StatusBadRequest
+		}
+
+		log.Debug().Err(err).Int("status", status).Msg("Failed to get stats")
+		c.JSON(status, ErrorResponse{
+			Status:  status,
+			Message: "Failed to get stats",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, StatsResponse{Stats: result})
+}
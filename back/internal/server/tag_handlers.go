@@ -0,0 +1,336 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/tag"
+)
+
+// TagRequest represents a request to create or rename a tag
+type TagRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// MergeTagRequest represents a request to merge a tag into another one
+type MergeTagRequest struct {
+	TargetTagID string `json:"target_tag_id" binding:"required"`
+}
+
+// TagAssignmentRequest represents a request to assign or unassign a tag
+type TagAssignmentRequest struct {
+	TargetType string   `json:"target_type" binding:"required,oneof=saved_word word_list"`
+	TargetIDs  []string `json:"target_ids" binding:"required,min=1"`
+}
+
+// TagsRequest represents a request to list tags, optionally those of one object
+type TagsRequest struct {
+	TargetType string `form:"target_type" binding:"required_with=TargetID"`
+	TargetID   string `form:"target_id" binding:"required_with=TargetType"`
+}
+
+// TagAutoCompleteRequest represents a request for tag autocomplete suggestions
+type TagAutoCompleteRequest struct {
+	Prefix string `form:"q" binding:"required"`
+}
+
+// TagResponse represents the response for a single tag
+type TagResponse struct {
+	Tag *tag.Tag `json:"tag"`
+}
+
+// TagsResponse represents the response for a collection of tags
+type TagsResponse struct {
+	Tags []*tag.Tag `json:"tags"`
+}
+
+// CreateTag handles requests to create a tag
+// @Summary Create a tag
+// @Description Create a tag for the current user. Names are unique per user, ignoring case.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body TagRequest true "Tag"
+// @Success 201 {object} TagResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tags [post]
+func (s *Server) CreateTag(c *gin.Context) {
+	var req TagRequest
+	if !bindTagJSON(c, &req) {
+		return
+	}
+
+	t, err := s.tagService.CreateTag(c.Request.Context(), currentUserID(c), req.Name)
+	if err != nil {
+		s.respondTagError(c, err, "Failed to create tag")
+		return
+	}
+
+	c.JSON(http.StatusCreated, TagResponse{Tag: t})
+}
+
+// ListTags handles requests to list the current user's tags
+// @Summary List tags
+// @Description List the current user's tags, or only those assigned to one saved word or word list
+// @Tags tags
+// @Produce json
+// @Security BearerAuth
+// @Param target_type query string false "Type of the tagged object" Enums(saved_word, word_list)
+// @Param target_id query string false "ID of the tagged object"
+// @Success 200 {object} TagsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tags [get]
+func (s *Server) ListTags(c *gin.Context) {
+	log := c.MustGet("logger").(zerolog.Logger)
+
+	var req TagsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Debug().Err(err).Msg("Invalid list tags request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	var tags []*tag.Tag
+	var err error
+	if req.TargetID != "" {
+		tags, err = s.tagService.ListTargetTags(c.Request.Context(), currentUserID(c), tag.TargetType(req.TargetType), req.TargetID)
+	} else {
+		tags, err = s.tagService.ListTags(c.Request.Context(), currentUserID(c))
+	}
+	if err != nil {
+		s.respondTagError(c, err, "Failed to list tags")
+		return
+	}
+
+	c.JSON(http.StatusOK, TagsResponse{Tags: tags})
+}
+
+// TagAutoComplete handles tag autocomplete requests
+// @Summary Get tag autocomplete suggestions
+// @Description Get the names of the current user's tags starting with a prefix
+// @Tags tags
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "The prefix to search for"
+// @Success 200 {object} AutoCompleteResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tags/autocomplete [get]
+func (s *Server) TagAutoComplete(c *gin.Context) {
+	log := c.MustGet("logger").(zerolog.Logger)
+
+	var req TagAutoCompleteRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Debug().Err(err).Msg("Invalid tag autocomplete request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	suggestions, err := s.tagService.GetSuggestions(c.Request.Context(), currentUserID(c), req.Prefix)
+	if err != nil {
+		s.respondTagError(c, err, "Failed to get tag suggestions")
+		return
+	}
+
+	c.JSON(http.StatusOK, AutoCompleteResponse{Suggestions: suggestions})
+}
+
+// RenameTag handles requests to rename a tag
+// @Summary Rename a tag
+// @Description Change the name of a tag
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tagId path string true "Tag ID"
+// @Param request body TagRequest true "Tag"
+// @Success 200 {object} TagResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tags/{tagId} [put]
+func (s *Server) RenameTag(c *gin.Context) {
+	var req TagRequest
+	if !bindTagJSON(c, &req) {
+		return
+	}
+
+	t, err := s.tagService.RenameTag(c.Request.Context(), currentUserID(c), c.Param("tagId"), req.Name)
+	if err != nil {
+		s.respondTagError(c, err, "Failed to rename tag")
+		return
+	}
+
+	c.JSON(http.StatusOK, TagResponse{Tag: t})
+}
+
+// MergeTag handles requests to merge a tag into another one
+// @Summary Merge a tag
+// @Description Move every assignment of a tag to the target tag, then delete it
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tagId path string true "ID of the tag merged away"
+// @Param request body MergeTagRequest true "Tag kept after the merge"
+// @Success 200 {object} TagResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tags/{tagId}/merge [post]
+func (s *Server) MergeTag(c *gin.Context) {
+	var req MergeTagRequest
+	if !bindTagJSON(c, &req) {
+		return
+	}
+
+	t, err := s.tagService.MergeTags(c.Request.Context(), currentUserID(c), c.Param("tagId"), req.TargetTagID)
+	if err != nil {
+		s.respondTagError(c, err, "Failed to merge tags")
+		return
+	}
+
+	c.JSON(http.StatusOK, TagResponse{Tag: t})
+}
+
+// DeleteTag handles requests to delete a tag
+// @Summary Delete a tag
+// @Description Delete a tag and remove it from every object it was assigned to
+// @Tags tags
+// @Security BearerAuth
+// @Param tagId path string true "Tag ID"
+// @Success 204
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tags/{tagId} [delete]
+func (s *Server) DeleteTag(c *gin.Context) {
+	if err := s.tagService.DeleteTag(c.Request.Context(), currentUserID(c), c.Param("tagId")); err != nil {
+		s.respondTagError(c, err, "Failed to delete tag")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AssignTag handles requests to assign a tag to saved words or word lists
+// @Summary Assign a tag
+// @Description Assign a tag to saved words or word lists of the current user; existing assignments are kept
+// @Tags tags
+// @Accept json
+// @Security BearerAuth
+// @Param tagId path string true "Tag ID"
+// @Param request body TagAssignmentRequest true "Tagged objects"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tags/{tagId}/assignments [post]
+func (s *Server) AssignTag(c *gin.Context) {
+	var req TagAssignmentRequest
+	if !bindTagJSON(c, &req) {
+		return
+	}
+
+	err := s.tagService.AssignTag(c.Request.Context(), currentUserID(c), c.Param("tagId"), tag.TargetType(req.TargetType), req.TargetIDs)
+	if err != nil {
+		s.respondTagError(c, err, "Failed to assign tag")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UnassignTag handles requests to remove a tag from saved words or word lists
+// @Summary Unassign a tag
+// @Description Remove a tag from saved words or word lists
+// @Tags tags
+// @Accept json
+// @Security BearerAuth
+// @Param tagId path string true "Tag ID"
+// @Param request body TagAssignmentRequest true "Untagged objects"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tags/{tagId}/assignments [delete]
+func (s *Server) UnassignTag(c *gin.Context) {
+	var req TagAssignmentRequest
+	if !bindTagJSON(c, &req) {
+		return
+	}
+
+	err := s.tagService.UnassignTag(c.Request.Context(), currentUserID(c), c.Param("tagId"), tag.TargetType(req.TargetType), req.TargetIDs)
+	if err != nil {
+		s.respondTagError(c, err, "Failed to unassign tag")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// bindTagJSON binds a JSON body and answers 400 when it is invalid
+func bindTagJSON(c *gin.Context, req interface{}) bool {
+	log := c.MustGet("logger").(zerolog.Logger)
+
+	if err := c.ShouldBindJSON(req); err != nil {
+		log.Debug().Err(err).Msg("Invalid tag request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return false
+	}
+
+	return true
+}
+
+// respondTagError maps tag domain errors to HTTP responses
+func (s *Server) respondTagError(c *gin.Context, err error, message string) {
+	log := c.MustGet("logger").(zerolog.Logger)
+
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, tag.ErrTagNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, tag.ErrDuplicateTagName):
+		status = http.StatusConflict
+	case errors.Is(err, tag.ErrInvalidName),
+		errors.Is(err, tag.ErrInvalidTargetType),
+		errors.Is(err, tag.ErrInvalidTargets),
+		errors.Is(err, tag.ErrInvalidMerge),
+		errors.Is(err, tag.ErrInvalidExpression):
+		status = http.StatusBadRequest
+	}
+
+	log.Debug().Err(err).Int("status", status).Msg(message)
+	c.JSON(status, ErrorResponse{
+		Status:  status,
+		Message: message,
+		Error:   err.Error(),
+	})
+}
@@ -0,0 +1,26 @@
+package server
+
+import (
+	"sync"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"voconsteroid/internal/domain/language"
+)
+
+var registerValidationsOnce sync.Once
+
+// registerValidations adds the binding tags of the application to the
+// validator of gin: "language" accepts the codes of the registered languages
+func registerValidations() {
+	registerValidationsOnce.Do(func() {
+		v, ok := binding.Validator.Engine().(*validator.Validate)
+		if !ok {
+			return
+		}
+		_ = v.RegisterValidation("language", func(fl validator.FieldLevel) bool {
+			return language.Default.IsSupported(fl.Field().String())
+		})
+	})
+}
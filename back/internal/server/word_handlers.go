@@ -8,18 +8,43 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
 
+	languageDomain "voconsteroid/internal/domain/language"
 	"voconsteroid/internal/domain/word"
 )
 
 // WordSearchRequest represents a request to search for a word
 type WordSearchRequest struct {
 	Text     string `json:"text" binding:"required"`
-	Language string `json:"language" binding:"required"`
+	Language string `json:"language" binding:"required,language"`
+}
+
+// WordResponse is the public form of a word. It leaves out the provenance,
+// which only maintainers see through the admin word endpoint.
+type WordResponse struct {
+	*word.Word
+	Provenance *word.Provenance `json:"provenance,omitempty"` // Never set, hides the provenance of the word
+}
+
+// newWordResponse returns the public form of a word, nil for a nil word
+func newWordResponse(w *word.Word) *WordResponse {
+	if w == nil {
+		return nil
+	}
+	return &WordResponse{Word: w}
+}
+
+// newWordResponses returns the public form of words
+func newWordResponses(words []*word.Word) []*WordResponse {
+	responses := make([]*WordResponse, len(words))
+	for i, w := range words {
+		responses[i] = newWordResponse(w)
+	}
+	return responses
 }
 
 // WordSearchResponse represents the response for a word search
 type WordSearchResponse struct {
-	Word *word.Word `json:"word"`
+	Word *WordResponse `json:"word"`
 }
 
 // RecentWordsRequest represents a request for recent words
@@ -30,7 +55,23 @@ type RecentWordsRequest struct {
 
 // RecentWordsResponse represents the response for recent words
 type RecentWordsResponse struct {
-	Words []*word.Word `json:"words"`
+	Words []*WordResponse `json:"words"`
+}
+
+// ListWordsRequest represents a request to browse words by difficulty
+type ListWordsRequest struct {
+	Language      string `form:"lang" binding:"omitempty,language"`
+	Difficulty    string `form:"difficulty" binding:"omitempty,oneof=A1 A2 B1 B2 C1 C2"`
+	MinDifficulty string `form:"min_difficulty" binding:"omitempty,oneof=A1 A2 B1 B2 C1 C2"`
+	MaxDifficulty string `form:"max_difficulty" binding:"omitempty,oneof=A1 A2 B1 B2 C1 C2"`
+	Sort          string `form:"sort" binding:"omitempty,oneof=recent frequency difficulty"`
+	Limit         int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset        int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+// ListWordsResponse represents the response for a word listing
+type ListWordsResponse struct {
+	Words []*WordResponse `json:"words"`
 }
 
 // RelatedWordsRequest represents a request for related words
@@ -40,15 +81,15 @@ type RelatedWordsRequest struct {
 
 // RelatedWordsResponse represents the response for related words
 type RelatedWordsResponse struct {
-	SourceWord *word.Word   `json:"source_word"`
-	Synonyms   []*word.Word `json:"synonyms,omitempty"`
-	Antonyms   []*word.Word `json:"antonyms,omitempty"`
+	SourceWord *WordResponse   `json:"source_word"`
+	Synonyms   []*WordResponse `json:"synonyms,omitempty"`
+	Antonyms   []*WordResponse `json:"antonyms,omitempty"`
 }
 
 // AutoCompleteRequest represents a request for autocomplete suggestions
 type AutoCompleteRequest struct {
 	Prefix   string `form:"q" binding:"required,min=2"`
-	Language string `form:"lang" binding:"omitempty,oneof=fr en"`
+	Language string `form:"lang" binding:"omitempty,language"`
 }
 
 // AutoCompleteResponse represents the response for autocomplete suggestions
@@ -66,7 +107,9 @@ type AutoCompleteResponse struct {
 // @Success 200 {object} WordSearchResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
 // @Router /api/v1/words/search [post]
 func (s *Server) SearchWord(c *gin.Context) {
 	log := c.MustGet("logger").(zerolog.Logger)
@@ -84,7 +127,7 @@ func (s *Server) SearchWord(c *gin.Context) {
 
 	log.Debug().Str("text", req.Text).Str("language", req.Language).Msg("Searching for word")
 
-	foundWord, err := s.wordService.Search(c.Request.Context(), req.Text, req.Language)
+	foundWord, err := s.wordService.Search(c.Request.Context(), currentUserID(c), req.Text, req.Language)
 	if err != nil {
 		status := http.StatusInternalServerError
 		message := "Failed to search for word"
@@ -95,6 +138,12 @@ func (s *Server) SearchWord(c *gin.Context) {
 		} else if errors.Is(err, word.ErrInvalidWord) {
 			status = http.StatusBadRequest
 			message = "Invalid word"
+		} else if errors.Is(err, word.ErrDictionaryUnavailable) {
+			status = http.StatusServiceUnavailable
+			message = "Dictionary unavailable, try again later"
+		} else if errors.Is(err, word.ErrFetchLimited) {
+			status = http.StatusTooManyRequests
+			message = "Too many new words looked up, try again later"
 		}
 
 		log.Debug().Err(err).Str("word", req.Text).Str("language", req.Language).Msg(message)
@@ -107,7 +156,7 @@ func (s *Server) SearchWord(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, WordSearchResponse{
-		Word: foundWord,
+		Word: newWordResponse(foundWord),
 	})
 }
 
@@ -117,7 +166,7 @@ func (s *Server) SearchWord(c *gin.Context) {
 // @Tags words
 // @Accept json
 // @Produce json
-// @Param language query string false "Language code" Enums(fr, en) default(fr)
+// @Param language query string false "Language code" Enums(fr, en, es, de, it) default(fr)
 // @Param limit query integer false "Maximum number of words to return" minimum(1) maximum(100) default(10)
 // @Success 200 {object} RecentWordsResponse
 // @Failure 400 {object} ErrorResponse
@@ -129,7 +178,7 @@ func (s *Server) GetRecentWords(c *gin.Context) {
 	// Get parameters from query
 	language := c.Query("language")
 	if language == "" {
-		language = "fr" // Default to French
+		language = languageDomain.Default.Primary().Code
 	}
 	
 	limitStr := c.Query("limit")
@@ -162,7 +211,7 @@ func (s *Server) GetRecentWords(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, RecentWordsResponse{
-		Words: words,
+		Words: newWordResponses(words),
 	})
 }
 
@@ -173,10 +222,12 @@ func (s *Server) GetRecentWords(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param q query string true "The prefix to search for" minlength(2)
-// @Param lang query string false "Language code" Enums(fr, en)
+// @Param lang query string false "Language code" Enums(fr, en, es, de, it)
 // @Success 200 {object} AutoCompleteResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
 // @Router /api/v1/words/autocomplete [get]
 func (s *Server) AutoComplete(c *gin.Context) {
 	log := c.MustGet("logger").(zerolog.Logger)
@@ -192,19 +243,26 @@ func (s *Server) AutoComplete(c *gin.Context) {
 		return
 	}
 
-	// Default to French if no language specified
+	// Default to the primary language if no language specified
 	language := req.Language
 	if language == "" {
-		language = "fr"
+		language = languageDomain.Default.Primary().Code
 	}
 
 	log.Debug().Str("prefix", req.Prefix).Str("language", language).Msg("Getting autocomplete suggestions")
 
 	suggestions, err := s.wordService.GetSuggestions(c.Request.Context(), req.Prefix, language)
 	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, word.ErrDictionaryUnavailable) {
+			status = http.StatusServiceUnavailable
+		} else if errors.Is(err, word.ErrFetchLimited) {
+			status = http.StatusTooManyRequests
+		}
+
 		log.Debug().Err(err).Str("prefix", req.Prefix).Str("language", req.Language).Msg("Failed to get autocomplete suggestions")
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Status:  http.StatusInternalServerError,
+		c.JSON(status, ErrorResponse{
+			Status:  status,
 			Message: "Failed to get suggestions",
 			Error:   err.Error(),
 		})
@@ -261,8 +319,109 @@ func (s *Server) GetRelatedWords(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, RelatedWordsResponse{
-		SourceWord: relatedWords.SourceWord,
-		Synonyms:   relatedWords.Synonyms,
-		Antonyms:   relatedWords.Antonyms,
+		SourceWord: newWordResponse(relatedWords.SourceWord),
+		Synonyms:   newWordResponses(relatedWords.Synonyms),
+		Antonyms:   newWordResponses(relatedWords.Antonyms),
 	})
 }
+
+// AdminWordResponse represents the response for a word shown to maintainers
+type AdminWordResponse struct {
+	Word *word.Word `json:"word"`
+}
+
+// GetAdminWord handles maintainer requests for a stored word
+// @Summary Get a stored word with its provenance
+// @Description Get a stored word together with its provenance: the source dictionary, page URL and revision, parser version and fetch time. Requires the admin token.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param wordId path string true "Word ID"
+// @Success 200 {object} AdminWordResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/words/{wordId} [get]
+func (s *Server) GetAdminWord(c *gin.Context) {
+	log := c.MustGet("logger").(zerolog.Logger)
+
+	found, err := s.wordService.GetWord(c.Request.Context(), c.Param("wordId"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, word.ErrWordNotFound) {
+			status = http.StatusNotFound
+		}
+
+		log.Debug().Err(err).Msg("Error getting word")
+		c.JSON(status, ErrorResponse{
+			Status:  status,
+			Message: "Error getting word",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, AdminWordResponse{Word: found})
+}
+
+// ListWords handles requests to browse stored words
+// @Summary Browse words
+// @Description Browse stored words filtered by difficulty band and sorted by recency, frequency or difficulty. Bands are CEFR-like levels (A1 to C2) derived from the word's frequency rank.
+// @Tags words
+// @Produce json
+// @Param lang query string false "Language code (fr, en, es, de, it)"
+// @Param difficulty query string false "Exact band (A1, A2, B1, B2, C1, C2)"
+// @Param min_difficulty query string false "Lowest band, inclusive"
+// @Param max_difficulty query string false "Highest band, inclusive"
+// @Param sort query string false "recent (default), frequency or difficulty"
+// @Param limit query int false "Maximum number of words (1-100, default 20)"
+// @Param offset query int false "Number of words to skip"
+// @Success 200 {object} ListWordsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/words [get]
+func (s *Server) ListWords(c *gin.Context) {
+	log := c.MustGet("logger").(zerolog.Logger)
+
+	var req ListWordsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Debug().Err(err).Msg("Invalid list words request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	options := word.ListOptions{
+		Language:      req.Language,
+		MinDifficulty: word.Difficulty(req.MinDifficulty),
+		MaxDifficulty: word.Difficulty(req.MaxDifficulty),
+		Sort:          req.Sort,
+		Limit:         req.Limit,
+		Offset:        req.Offset,
+	}
+	if req.Difficulty != "" {
+		options.MinDifficulty = word.Difficulty(req.Difficulty)
+		options.MaxDifficulty = word.Difficulty(req.Difficulty)
+	}
+
+	words, err := s.wordService.ListWords(c.Request.Context(), options)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, word.ErrInvalidDifficulty) || errors.Is(err, word.ErrInvalidSort) {
+			status = http.StatusBadRequest
+		}
+
+		log.Debug().Err(err).Msg("Error listing words")
+		c.JSON(status, ErrorResponse{
+			Status:  status,
+			Message: "Error listing words",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, ListWordsResponse{Words: newWordResponses(words)})
+}
@@ -0,0 +1,407 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"voconsteroid/internal/domain/wordlist"
+)
+
+// WordListRequest represents a request to create or update a word list
+type WordListRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+// WordListItemsRequest represents a request carrying saved word IDs,
+// used for bulk add/remove and for setting the order of a word list
+type WordListItemsRequest struct {
+	SavedWordIDs []string `json:"saved_word_ids" binding:"required"`
+}
+
+// WordListsRequest represents a request to list word lists
+type WordListsRequest struct {
+	Limit  int `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+}
+
+// WordListResponse represents the response for a single word list
+type WordListResponse struct {
+	List *wordlist.WordList `json:"list"`
+}
+
+// WordListsResponse represents the response for a collection of word lists
+type WordListsResponse struct {
+	Lists []*wordlist.WordList `json:"lists"`
+}
+
+// SharedListResponse represents the response for a publicly shared word list
+type SharedListResponse struct {
+	List *SharedListView `json:"list"`
+}
+
+// SharedListView is a shared word list with the public form of its words
+type SharedListView struct {
+	*wordlist.SharedList
+	Words []*WordResponse `json:"words"`
+}
+
+// CreateWordList handles requests to create a word list
+// @Summary Create a word list
+// @Description Create a new, empty word list for the current user. Names are unique per user.
+// @Tags word-lists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body WordListRequest true "Word list"
+// @Success 201 {object} WordListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/lists [post]
+func (s *Server) CreateWordList(c *gin.Context) {
+	var req WordListRequest
+	if !bindWordListJSON(c, &req) {
+		return
+	}
+
+	list, err := s.wordListService.CreateList(c.Request.Context(), currentUserID(c), req.Name, req.Description)
+	if err != nil {
+		s.respondWordListError(c, err, "Failed to create word list")
+		return
+	}
+
+	c.JSON(http.StatusCreated, WordListResponse{List: list})
+}
+
+// ListWordLists handles requests to list the current user's word lists
+// @Summary List word lists
+// @Description List the current user's word lists ordered by name
+// @Tags word-lists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query integer false "Maximum number of lists to return" minimum(1) maximum(100) default(20)
+// @Param offset query integer false "Number of lists to skip" minimum(0) default(0)
+// @Success 200 {object} WordListsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/lists [get]
+func (s *Server) ListWordLists(c *gin.Context) {
+	log := c.MustGet("logger").(zerolog.Logger)
+
+	var req WordListsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Debug().Err(err).Msg("Invalid list word lists request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	lists, err := s.wordListService.ListLists(c.Request.Context(), currentUserID(c), req.Limit, req.Offset)
+	if err != nil {
+		s.respondWordListError(c, err, "Failed to list word lists")
+		return
+	}
+
+	c.JSON(http.StatusOK, WordListsResponse{Lists: lists})
+}
+
+// GetWordList handles requests for a single word list
+// @Summary Get a word list
+// @Description Get a word list of the current user with its saved words in order
+// @Tags word-lists
+// @Produce json
+// @Security BearerAuth
+// @Param listId path string true "Word list ID"
+// @Success 200 {object} WordListResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/lists/{listId} [get]
+func (s *Server) GetWordList(c *gin.Context) {
+	list, err := s.wordListService.GetList(c.Request.Context(), currentUserID(c), c.Param("listId"))
+	if err != nil {
+		s.respondWordListError(c, err, "Failed to get word list")
+		return
+	}
+
+	c.JSON(http.StatusOK, WordListResponse{List: list})
+}
+
+// UpdateWordList handles requests to rename a word list
+// @Summary Update a word list
+// @Description Change the name and description of a word list
+// @Tags word-lists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param listId path string true "Word list ID"
+// @Param request body WordListRequest true "Word list"
+// @Success 200 {object} WordListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/lists/{listId} [put]
+func (s *Server) UpdateWordList(c *gin.Context) {
+	var req WordListRequest
+	if !bindWordListJSON(c, &req) {
+		return
+	}
+
+	list, err := s.wordListService.UpdateList(c.Request.Context(), currentUserID(c), c.Param("listId"), req.Name, req.Description)
+	if err != nil {
+		s.respondWordListError(c, err, "Failed to update word list")
+		return
+	}
+
+	c.JSON(http.StatusOK, WordListResponse{List: list})
+}
+
+// DeleteWordList handles requests to delete a word list
+// @Summary Delete a word list
+// @Description Delete a word list. The saved words it contains are kept.
+// @Tags word-lists
+// @Security BearerAuth
+// @Param listId path string true "Word list ID"
+// @Success 204
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/lists/{listId} [delete]
+func (s *Server) DeleteWordList(c *gin.Context) {
+	if err := s.wordListService.DeleteList(c.Request.Context(), currentUserID(c), c.Param("listId")); err != nil {
+		s.respondWordListError(c, err, "Failed to delete word list")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AddWordListWords handles requests to add saved words to a word list
+// @Summary Add words to a word list
+// @Description Append saved words to the end of a word list; words already in the list are skipped
+// @Tags word-lists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param listId path string true "Word list ID"
+// @Param request body WordListItemsRequest true "Saved word IDs"
+// @Success 200 {object} WordListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/lists/{listId}/words [post]
+func (s *Server) AddWordListWords(c *gin.Context) {
+	var req WordListItemsRequest
+	if !bindWordListJSON(c, &req) {
+		return
+	}
+
+	list, err := s.wordListService.AddWords(c.Request.Context(), currentUserID(c), c.Param("listId"), req.SavedWordIDs)
+	if err != nil {
+		s.respondWordListError(c, err, "Failed to add words to word list")
+		return
+	}
+
+	c.JSON(http.StatusOK, WordListResponse{List: list})
+}
+
+// RemoveWordListWords handles requests to remove saved words from a word list
+// @Summary Remove words from a word list
+// @Description Remove saved words from a word list, keeping the order of the remaining words
+// @Tags word-lists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param listId path string true "Word list ID"
+// @Param request body WordListItemsRequest true "Saved word IDs"
+// @Success 200 {object} WordListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/lists/{listId}/words [delete]
+func (s *Server) RemoveWordListWords(c *gin.Context) {
+	var req WordListItemsRequest
+	if !bindWordListJSON(c, &req) {
+		return
+	}
+
+	list, err := s.wordListService.RemoveWords(c.Request.Context(), currentUserID(c), c.Param("listId"), req.SavedWordIDs)
+	if err != nil {
+		s.respondWordListError(c, err, "Failed to remove words from word list")
+		return
+	}
+
+	c.JSON(http.StatusOK, WordListResponse{List: list})
+}
+
+// ReorderWordList handles requests to set the order of a word list
+// @Summary Reorder a word list
+// @Description Set the manual order of a word list. The IDs must be exactly the saved words of the list.
+// @Tags word-lists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param listId path string true "Word list ID"
+// @Param request body WordListItemsRequest true "Saved word IDs in their new order"
+// @Success 200 {object} WordListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/lists/{listId}/order [put]
+func (s *Server) ReorderWordList(c *gin.Context) {
+	var req WordListItemsRequest
+	if !bindWordListJSON(c, &req) {
+		return
+	}
+
+	list, err := s.wordListService.ReorderWords(c.Request.Context(), currentUserID(c), c.Param("listId"), req.SavedWordIDs)
+	if err != nil {
+		s.respondWordListError(c, err, "Failed to reorder word list")
+		return
+	}
+
+	c.JSON(http.StatusOK, WordListResponse{List: list})
+}
+
+// ShareWordList handles requests to share a word list
+// @Summary Share a word list
+// @Description Create a read-only public link for a word list. Sharing twice keeps the same link.
+// @Tags word-lists
+// @Produce json
+// @Security BearerAuth
+// @Param listId path string true "Word list ID"
+// @Success 200 {object} WordListResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/lists/{listId}/share [post]
+func (s *Server) ShareWordList(c *gin.Context) {
+	list, err := s.wordListService.ShareList(c.Request.Context(), currentUserID(c), c.Param("listId"))
+	if err != nil {
+		s.respondWordListError(c, err, "Failed to share word list")
+		return
+	}
+
+	c.JSON(http.StatusOK, WordListResponse{List: list})
+}
+
+// UnshareWordList handles requests to revoke the public link of a word list
+// @Summary Stop sharing a word list
+// @Description Revoke the public link of a word list
+// @Tags word-lists
+// @Produce json
+// @Security BearerAuth
+// @Param listId path string true "Word list ID"
+// @Success 200 {object} WordListResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/lists/{listId}/share [delete]
+func (s *Server) UnshareWordList(c *gin.Context) {
+	list, err := s.wordListService.UnshareList(c.Request.Context(), currentUserID(c), c.Param("listId"))
+	if err != nil {
+		s.respondWordListError(c, err, "Failed to unshare word list")
+		return
+	}
+
+	c.JSON(http.StatusOK, WordListResponse{List: list})
+}
+
+// GetSharedWordList handles requests for a publicly shared word list
+// @Summary Get a shared word list
+// @Description Get the read-only view of a shared word list. No authentication is required.
+// @Tags word-lists
+// @Produce json
+// @Param shareToken path string true "Share token"
+// @Success 200 {object} SharedListResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/shared-lists/{shareToken} [get]
+func (s *Server) GetSharedWordList(c *gin.Context) {
+	list, err := s.wordListService.GetSharedList(c.Request.Context(), c.Param("shareToken"))
+	if err != nil {
+		s.respondWordListError(c, err, "Failed to get shared word list")
+		return
+	}
+
+	c.JSON(http.StatusOK, SharedListResponse{List: &SharedListView{SharedList: list, Words: newWordResponses(list.Words)}})
+}
+
+// DuplicateSharedWordList handles requests to copy a shared word list
+// @Summary Duplicate a shared word list
+// @Description Copy a shared word list into the current user's lists, saving the words they have not saved yet
+// @Tags word-lists
+// @Produce json
+// @Security BearerAuth
+// @Param shareToken path string true "Share token"
+// @Success 201 {object} WordListResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/shared-lists/{shareToken}/duplicate [post]
+func (s *Server) DuplicateSharedWordList(c *gin.Context) {
+	list, err := s.wordListService.DuplicateSharedList(c.Request.Context(), currentUserID(c), c.Param("shareToken"))
+	if err != nil {
+		s.respondWordListError(c, err, "Failed to duplicate word list")
+		return
+	}
+
+	c.JSON(http.StatusCreated, WordListResponse{List: list})
+}
+
+// bindWordListJSON binds a JSON body and answers 400 when it is invalid
+func bindWordListJSON(c *gin.Context, req interface{}) bool {
+	log := c.MustGet("logger").(zerolog.Logger)
+
+	if err := c.ShouldBindJSON(req); err != nil {
+		log.Debug().Err(err).Msg("Invalid word list request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return false
+	}
+
+	return true
+}
+
+// respondWordListError maps word list domain errors to HTTP responses
+func (s *Server) respondWordListError(c *gin.Context, err error, message string) {
+	log := c.MustGet("logger").(zerolog.Logger)
+
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, wordlist.ErrListNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, wordlist.ErrDuplicateListName), errors.Is(err, wordlist.ErrVersionConflict):
+		status = http.StatusConflict
+	case errors.Is(err, wordlist.ErrInvalidName),
+		errors.Is(err, wordlist.ErrInvalidDescription),
+		errors.Is(err, wordlist.ErrInvalidSavedWords),
+		errors.Is(err, wordlist.ErrInvalidOrder):
+		status = http.StatusBadRequest
+	}
+
+	log.Debug().Err(err).Int("status", status).Msg(message)
+	c.JSON(status, ErrorResponse{
+		Status:  status,
+		Message: message,
+		Error:   err.Error(),
+	})
+}